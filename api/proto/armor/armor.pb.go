@@ -269,7 +269,9 @@ type Armor struct {
 	MaxDurability int32 `protobuf:"varint,13,opt,name=max_durability,json=maxDurability,proto3" json:"max_durability,omitempty"` // maximum durability
 	IsBroken      bool  `protobuf:"varint,14,opt,name=is_broken,json=isBroken,proto3" json:"is_broken,omitempty"`                // derived from durability == 0
 	// Generalized ownership (supports warrior/enemy/dragon)
-	Owners        []*OwnerRef `protobuf:"bytes,15,rep,name=owners,proto3" json:"owners,omitempty"` // when set, preferred over owned_by
+	Owners []*OwnerRef `protobuf:"bytes,15,rep,name=owners,proto3" json:"owners,omitempty"` // when set, preferred over owned_by
+	// Elemental resistances, one entry per resisted element
+	Resistances   []*ElementResistance `protobuf:"bytes,16,rep,name=resistances,proto3" json:"resistances,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -409,6 +411,13 @@ func (x *Armor) GetOwners() []*OwnerRef {
 	return nil
 }
 
+func (x *Armor) GetResistances() []*ElementResistance {
+	if x != nil {
+		return x.Resistances
+	}
+	return nil
+}
+
 // Owner reference to support multiple entity types
 type OwnerRef struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -462,6 +471,58 @@ func (x *OwnerRef) GetOwnerId() string {
 	return ""
 }
 
+type ElementResistance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Element       string                 `protobuf:"bytes,1,opt,name=element,proto3" json:"element,omitempty"`  // "fire" | "ice" | "lightning" | "shadow"
+	Percent       int32                  `protobuf:"varint,2,opt,name=percent,proto3" json:"percent,omitempty"` // share of that element's damage absorbed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ElementResistance) Reset() {
+	*x = ElementResistance{}
+	mi := &file_api_proto_armor_armor_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ElementResistance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ElementResistance) ProtoMessage() {}
+
+func (x *ElementResistance) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_armor_armor_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ElementResistance.ProtoReflect.Descriptor instead.
+func (*ElementResistance) Descriptor() ([]byte, []int) {
+	return file_api_proto_armor_armor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ElementResistance) GetElement() string {
+	if x != nil {
+		return x.Element
+	}
+	return ""
+}
+
+func (x *ElementResistance) GetPercent() int32 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
 // Request to list armors by owner
 type ListOwnerArmorsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -473,7 +534,7 @@ type ListOwnerArmorsRequest struct {
 
 func (x *ListOwnerArmorsRequest) Reset() {
 	*x = ListOwnerArmorsRequest{}
-	mi := &file_api_proto_armor_armor_proto_msgTypes[6]
+	mi := &file_api_proto_armor_armor_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -485,7 +546,7 @@ func (x *ListOwnerArmorsRequest) String() string {
 func (*ListOwnerArmorsRequest) ProtoMessage() {}
 
 func (x *ListOwnerArmorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_armor_armor_proto_msgTypes[6]
+	mi := &file_api_proto_armor_armor_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -498,7 +559,7 @@ func (x *ListOwnerArmorsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOwnerArmorsRequest.ProtoReflect.Descriptor instead.
 func (*ListOwnerArmorsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_armor_armor_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_armor_armor_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListOwnerArmorsRequest) GetOwnerType() string {
@@ -525,7 +586,7 @@ type ListOwnerArmorsResponse struct {
 
 func (x *ListOwnerArmorsResponse) Reset() {
 	*x = ListOwnerArmorsResponse{}
-	mi := &file_api_proto_armor_armor_proto_msgTypes[7]
+	mi := &file_api_proto_armor_armor_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -537,7 +598,7 @@ func (x *ListOwnerArmorsResponse) String() string {
 func (*ListOwnerArmorsResponse) ProtoMessage() {}
 
 func (x *ListOwnerArmorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_armor_armor_proto_msgTypes[7]
+	mi := &file_api_proto_armor_armor_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -550,7 +611,7 @@ func (x *ListOwnerArmorsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOwnerArmorsResponse.ProtoReflect.Descriptor instead.
 func (*ListOwnerArmorsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_armor_armor_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_armor_armor_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListOwnerArmorsResponse) GetArmors() []*Armor {
@@ -564,14 +625,14 @@ func (x *ListOwnerArmorsResponse) GetArmors() []*Armor {
 type ApplyWearRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ArmorId       string                 `protobuf:"bytes,1,opt,name=armor_id,json=armorId,proto3" json:"armor_id,omitempty"`
-	Wear          int32                  `protobuf:"varint,2,opt,name=wear,proto3" json:"wear,omitempty"` // how much durability to reduce
+	Wear          int32                  `protobuf:"varint,2,opt,name=wear,proto3" json:"wear,omitempty"` // how much durability to reduce; negative restores durability, up to the maximum
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ApplyWearRequest) Reset() {
 	*x = ApplyWearRequest{}
-	mi := &file_api_proto_armor_armor_proto_msgTypes[8]
+	mi := &file_api_proto_armor_armor_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -583,7 +644,7 @@ func (x *ApplyWearRequest) String() string {
 func (*ApplyWearRequest) ProtoMessage() {}
 
 func (x *ApplyWearRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_armor_armor_proto_msgTypes[8]
+	mi := &file_api_proto_armor_armor_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -596,7 +657,7 @@ func (x *ApplyWearRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApplyWearRequest.ProtoReflect.Descriptor instead.
 func (*ApplyWearRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_armor_armor_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_armor_armor_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ApplyWearRequest) GetArmorId() string {
@@ -625,7 +686,7 @@ type ApplyWearResponse struct {
 
 func (x *ApplyWearResponse) Reset() {
 	*x = ApplyWearResponse{}
-	mi := &file_api_proto_armor_armor_proto_msgTypes[9]
+	mi := &file_api_proto_armor_armor_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -637,7 +698,7 @@ func (x *ApplyWearResponse) String() string {
 func (*ApplyWearResponse) ProtoMessage() {}
 
 func (x *ApplyWearResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_armor_armor_proto_msgTypes[9]
+	mi := &file_api_proto_armor_armor_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -650,7 +711,7 @@ func (x *ApplyWearResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApplyWearResponse.ProtoReflect.Descriptor instead.
 func (*ApplyWearResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_armor_armor_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_armor_armor_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ApplyWearResponse) GetArmorId() string {
@@ -696,7 +757,7 @@ const file_api_proto_armor_armor_proto_rawDesc = "" +
 	"armorBonus\x12#\n" +
 	"\rtotal_defense\x18\x05 \x01(\x05R\ftotalDefense\x12\x1f\n" +
 	"\varmor_count\x18\x06 \x01(\x05R\n" +
-	"armorCount\"\xe9\x03\n" +
+	"armorCount\"\xa5\x04\n" +
 	"\x05Armor\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -718,11 +779,15 @@ const file_api_proto_armor_armor_proto_rawDesc = "" +
 	"durability\x12%\n" +
 	"\x0emax_durability\x18\r \x01(\x05R\rmaxDurability\x12\x1b\n" +
 	"\tis_broken\x18\x0e \x01(\bR\bisBroken\x12'\n" +
-	"\x06owners\x18\x0f \x03(\v2\x0f.armor.OwnerRefR\x06owners\"D\n" +
+	"\x06owners\x18\x0f \x03(\v2\x0f.armor.OwnerRefR\x06owners\x12:\n" +
+	"\vresistances\x18\x10 \x03(\v2\x18.armor.ElementResistanceR\vresistances\"D\n" +
 	"\bOwnerRef\x12\x1d\n" +
 	"\n" +
 	"owner_type\x18\x01 \x01(\tR\townerType\x12\x19\n" +
-	"\bowner_id\x18\x02 \x01(\tR\aownerId\"R\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\"G\n" +
+	"\x11ElementResistance\x12\x18\n" +
+	"\aelement\x18\x01 \x01(\tR\aelement\x12\x18\n" +
+	"\apercent\x18\x02 \x01(\x05R\apercent\"R\n" +
 	"\x16ListOwnerArmorsRequest\x12\x1d\n" +
 	"\n" +
 	"owner_type\x18\x01 \x01(\tR\townerType\x12\x19\n" +
@@ -756,7 +821,7 @@ func file_api_proto_armor_armor_proto_rawDescGZIP() []byte {
 	return file_api_proto_armor_armor_proto_rawDescData
 }
 
-var file_api_proto_armor_armor_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_proto_armor_armor_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_api_proto_armor_armor_proto_goTypes = []any{
 	(*GetArmorRequest)(nil),          // 0: armor.GetArmorRequest
 	(*GetArmorResponse)(nil),         // 1: armor.GetArmorResponse
@@ -764,31 +829,33 @@ var file_api_proto_armor_armor_proto_goTypes = []any{
 	(*CalculateDefenseResponse)(nil), // 3: armor.CalculateDefenseResponse
 	(*Armor)(nil),                    // 4: armor.Armor
 	(*OwnerRef)(nil),                 // 5: armor.OwnerRef
-	(*ListOwnerArmorsRequest)(nil),   // 6: armor.ListOwnerArmorsRequest
-	(*ListOwnerArmorsResponse)(nil),  // 7: armor.ListOwnerArmorsResponse
-	(*ApplyWearRequest)(nil),         // 8: armor.ApplyWearRequest
-	(*ApplyWearResponse)(nil),        // 9: armor.ApplyWearResponse
-	(*timestamppb.Timestamp)(nil),    // 10: google.protobuf.Timestamp
+	(*ElementResistance)(nil),        // 6: armor.ElementResistance
+	(*ListOwnerArmorsRequest)(nil),   // 7: armor.ListOwnerArmorsRequest
+	(*ListOwnerArmorsResponse)(nil),  // 8: armor.ListOwnerArmorsResponse
+	(*ApplyWearRequest)(nil),         // 9: armor.ApplyWearRequest
+	(*ApplyWearResponse)(nil),        // 10: armor.ApplyWearResponse
+	(*timestamppb.Timestamp)(nil),    // 11: google.protobuf.Timestamp
 }
 var file_api_proto_armor_armor_proto_depIdxs = []int32{
 	4,  // 0: armor.GetArmorResponse.armor:type_name -> armor.Armor
-	10, // 1: armor.Armor.created_at:type_name -> google.protobuf.Timestamp
-	10, // 2: armor.Armor.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 1: armor.Armor.created_at:type_name -> google.protobuf.Timestamp
+	11, // 2: armor.Armor.updated_at:type_name -> google.protobuf.Timestamp
 	5,  // 3: armor.Armor.owners:type_name -> armor.OwnerRef
-	4,  // 4: armor.ListOwnerArmorsResponse.armors:type_name -> armor.Armor
-	0,  // 5: armor.ArmorService.GetArmor:input_type -> armor.GetArmorRequest
-	2,  // 6: armor.ArmorService.CalculateDefense:input_type -> armor.CalculateDefenseRequest
-	6,  // 7: armor.ArmorService.ListOwnerArmors:input_type -> armor.ListOwnerArmorsRequest
-	8,  // 8: armor.ArmorService.ApplyWear:input_type -> armor.ApplyWearRequest
-	1,  // 9: armor.ArmorService.GetArmor:output_type -> armor.GetArmorResponse
-	3,  // 10: armor.ArmorService.CalculateDefense:output_type -> armor.CalculateDefenseResponse
-	7,  // 11: armor.ArmorService.ListOwnerArmors:output_type -> armor.ListOwnerArmorsResponse
-	9,  // 12: armor.ArmorService.ApplyWear:output_type -> armor.ApplyWearResponse
-	9,  // [9:13] is the sub-list for method output_type
-	5,  // [5:9] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	6,  // 4: armor.Armor.resistances:type_name -> armor.ElementResistance
+	4,  // 5: armor.ListOwnerArmorsResponse.armors:type_name -> armor.Armor
+	0,  // 6: armor.ArmorService.GetArmor:input_type -> armor.GetArmorRequest
+	2,  // 7: armor.ArmorService.CalculateDefense:input_type -> armor.CalculateDefenseRequest
+	7,  // 8: armor.ArmorService.ListOwnerArmors:input_type -> armor.ListOwnerArmorsRequest
+	9,  // 9: armor.ArmorService.ApplyWear:input_type -> armor.ApplyWearRequest
+	1,  // 10: armor.ArmorService.GetArmor:output_type -> armor.GetArmorResponse
+	3,  // 11: armor.ArmorService.CalculateDefense:output_type -> armor.CalculateDefenseResponse
+	8,  // 12: armor.ArmorService.ListOwnerArmors:output_type -> armor.ListOwnerArmorsResponse
+	10, // 13: armor.ArmorService.ApplyWear:output_type -> armor.ApplyWearResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_armor_armor_proto_init() }
@@ -802,7 +869,7 @@ func file_api_proto_armor_armor_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_armor_armor_proto_rawDesc), len(file_api_proto_armor_armor_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
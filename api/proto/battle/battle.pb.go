@@ -302,16 +302,17 @@ func (x *GetBattleParticipantsResponse) GetParticipants() []*BattleParticipant {
 
 // Request to update participant stats
 type UpdateParticipantStatsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
-	ParticipantId string                 `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
-	Hp            int32                  `protobuf:"varint,3,opt,name=hp,proto3" json:"hp,omitempty"`
-	MaxHp         int32                  `protobuf:"varint,4,opt,name=max_hp,json=maxHp,proto3" json:"max_hp,omitempty"`
-	AttackPower   int32                  `protobuf:"varint,5,opt,name=attack_power,json=attackPower,proto3" json:"attack_power,omitempty"`
-	Defense       int32                  `protobuf:"varint,6,opt,name=defense,proto3" json:"defense,omitempty"`
-	IsAlive       bool                   `protobuf:"varint,7,opt,name=is_alive,json=isAlive,proto3" json:"is_alive,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BattleId        string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	ParticipantId   string                 `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	Hp              int32                  `protobuf:"varint,3,opt,name=hp,proto3" json:"hp,omitempty"`
+	MaxHp           int32                  `protobuf:"varint,4,opt,name=max_hp,json=maxHp,proto3" json:"max_hp,omitempty"`
+	AttackPower     int32                  `protobuf:"varint,5,opt,name=attack_power,json=attackPower,proto3" json:"attack_power,omitempty"`
+	Defense         int32                  `protobuf:"varint,6,opt,name=defense,proto3" json:"defense,omitempty"`
+	IsAlive         bool                   `protobuf:"varint,7,opt,name=is_alive,json=isAlive,proto3" json:"is_alive,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,8,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"` // Participant version the stats were computed from (0 = unconditional)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateParticipantStatsRequest) Reset() {
@@ -393,6 +394,13 @@ func (x *UpdateParticipantStatsRequest) GetIsAlive() bool {
 	return false
 }
 
+func (x *UpdateParticipantStatsRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
 // Response after updating stats
 type UpdateParticipantStatsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -592,6 +600,176 @@ func (x *CastSpellResponse) GetMessage() string {
 	return ""
 }
 
+// Request to apply a status effect
+type ApplyStatusEffectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	ParticipantId string                 `protobuf:"bytes,2,opt,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`            // poison, burn, regen, stun, attack_modifier, defense_modifier
+	Magnitude     int32                  `protobuf:"varint,4,opt,name=magnitude,proto3" json:"magnitude,omitempty"` // HP per tick, or percent for modifiers (negative to weaken)
+	DurationTurns int32                  `protobuf:"varint,5,opt,name=duration_turns,json=durationTurns,proto3" json:"duration_turns,omitempty"`
+	SourceId      string                 `protobuf:"bytes,6,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	Tick          string                 `protobuf:"bytes,7,opt,name=tick,proto3" json:"tick,omitempty"`                             // turn_start or turn_end (optional, effect default)
+	Stacking      string                 `protobuf:"bytes,8,opt,name=stacking,proto3" json:"stacking,omitempty"`                     // refresh, stack or ignore (optional, effect default)
+	MaxStacks     int32                  `protobuf:"varint,9,opt,name=max_stacks,json=maxStacks,proto3" json:"max_stacks,omitempty"` // optional, effect default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyStatusEffectRequest) Reset() {
+	*x = ApplyStatusEffectRequest{}
+	mi := &file_api_proto_battle_battle_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyStatusEffectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyStatusEffectRequest) ProtoMessage() {}
+
+func (x *ApplyStatusEffectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_battle_battle_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyStatusEffectRequest.ProtoReflect.Descriptor instead.
+func (*ApplyStatusEffectRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_battle_battle_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ApplyStatusEffectRequest) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+func (x *ApplyStatusEffectRequest) GetParticipantId() string {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return ""
+}
+
+func (x *ApplyStatusEffectRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ApplyStatusEffectRequest) GetMagnitude() int32 {
+	if x != nil {
+		return x.Magnitude
+	}
+	return 0
+}
+
+func (x *ApplyStatusEffectRequest) GetDurationTurns() int32 {
+	if x != nil {
+		return x.DurationTurns
+	}
+	return 0
+}
+
+func (x *ApplyStatusEffectRequest) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *ApplyStatusEffectRequest) GetTick() string {
+	if x != nil {
+		return x.Tick
+	}
+	return ""
+}
+
+func (x *ApplyStatusEffectRequest) GetStacking() string {
+	if x != nil {
+		return x.Stacking
+	}
+	return ""
+}
+
+func (x *ApplyStatusEffectRequest) GetMaxStacks() int32 {
+	if x != nil {
+		return x.MaxStacks
+	}
+	return 0
+}
+
+// Response after applying a status effect
+type ApplyStatusEffectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Participant   *BattleParticipant     `protobuf:"bytes,3,opt,name=participant,proto3" json:"participant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyStatusEffectResponse) Reset() {
+	*x = ApplyStatusEffectResponse{}
+	mi := &file_api_proto_battle_battle_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyStatusEffectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyStatusEffectResponse) ProtoMessage() {}
+
+func (x *ApplyStatusEffectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_battle_battle_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyStatusEffectResponse.ProtoReflect.Descriptor instead.
+func (*ApplyStatusEffectResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_battle_battle_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ApplyStatusEffectResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ApplyStatusEffectResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApplyStatusEffectResponse) GetParticipant() *BattleParticipant {
+	if x != nil {
+		return x.Participant
+	}
+	return nil
+}
+
 // Battle model
 type Battle struct {
 	state                   protoimpl.MessageState `protogen:"open.v1"`
@@ -610,13 +788,14 @@ type Battle struct {
 	CompletedAt             *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
 	CreatedAt               *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt               *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Seed                    int64                  `protobuf:"varint,16,opt,name=seed,proto3" json:"seed,omitempty"`
 	unknownFields           protoimpl.UnknownFields
 	sizeCache               protoimpl.SizeCache
 }
 
 func (x *Battle) Reset() {
 	*x = Battle{}
-	mi := &file_api_proto_battle_battle_proto_msgTypes[10]
+	mi := &file_api_proto_battle_battle_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -628,7 +807,7 @@ func (x *Battle) String() string {
 func (*Battle) ProtoMessage() {}
 
 func (x *Battle) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_battle_battle_proto_msgTypes[10]
+	mi := &file_api_proto_battle_battle_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -641,7 +820,7 @@ func (x *Battle) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Battle.ProtoReflect.Descriptor instead.
 func (*Battle) Descriptor() ([]byte, []int) {
-	return file_api_proto_battle_battle_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_battle_battle_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Battle) GetId() string {
@@ -749,6 +928,13 @@ func (x *Battle) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Battle) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
 // BattleParticipant model
 type BattleParticipant struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -767,13 +953,15 @@ type BattleParticipant struct {
 	DefeatedAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=defeated_at,json=defeatedAt,proto3" json:"defeated_at,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int32                  `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"` // Optimistic concurrency version (SQL store)
+	StatusEffects []*StatusEffect        `protobuf:"bytes,17,rep,name=status_effects,json=statusEffects,proto3" json:"status_effects,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BattleParticipant) Reset() {
 	*x = BattleParticipant{}
-	mi := &file_api_proto_battle_battle_proto_msgTypes[11]
+	mi := &file_api_proto_battle_battle_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -785,7 +973,7 @@ func (x *BattleParticipant) String() string {
 func (*BattleParticipant) ProtoMessage() {}
 
 func (x *BattleParticipant) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_battle_battle_proto_msgTypes[11]
+	mi := &file_api_proto_battle_battle_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,7 +986,7 @@ func (x *BattleParticipant) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BattleParticipant.ProtoReflect.Descriptor instead.
 func (*BattleParticipant) Descriptor() ([]byte, []int) {
-	return file_api_proto_battle_battle_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_battle_battle_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BattleParticipant) GetId() string {
@@ -906,6 +1094,121 @@ func (x *BattleParticipant) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *BattleParticipant) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BattleParticipant) GetStatusEffects() []*StatusEffect {
+	if x != nil {
+		return x.StatusEffects
+	}
+	return nil
+}
+
+// StatusEffect active on a participant
+type StatusEffect struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	SourceId       string                 `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	Magnitude      int32                  `protobuf:"varint,3,opt,name=magnitude,proto3" json:"magnitude,omitempty"`
+	RemainingTurns int32                  `protobuf:"varint,4,opt,name=remaining_turns,json=remainingTurns,proto3" json:"remaining_turns,omitempty"`
+	Stacks         int32                  `protobuf:"varint,5,opt,name=stacks,proto3" json:"stacks,omitempty"`
+	MaxStacks      int32                  `protobuf:"varint,6,opt,name=max_stacks,json=maxStacks,proto3" json:"max_stacks,omitempty"`
+	Stacking       string                 `protobuf:"bytes,7,opt,name=stacking,proto3" json:"stacking,omitempty"`
+	Tick           string                 `protobuf:"bytes,8,opt,name=tick,proto3" json:"tick,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StatusEffect) Reset() {
+	*x = StatusEffect{}
+	mi := &file_api_proto_battle_battle_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusEffect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusEffect) ProtoMessage() {}
+
+func (x *StatusEffect) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_battle_battle_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusEffect.ProtoReflect.Descriptor instead.
+func (*StatusEffect) Descriptor() ([]byte, []int) {
+	return file_api_proto_battle_battle_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StatusEffect) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StatusEffect) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *StatusEffect) GetMagnitude() int32 {
+	if x != nil {
+		return x.Magnitude
+	}
+	return 0
+}
+
+func (x *StatusEffect) GetRemainingTurns() int32 {
+	if x != nil {
+		return x.RemainingTurns
+	}
+	return 0
+}
+
+func (x *StatusEffect) GetStacks() int32 {
+	if x != nil {
+		return x.Stacks
+	}
+	return 0
+}
+
+func (x *StatusEffect) GetMaxStacks() int32 {
+	if x != nil {
+		return x.MaxStacks
+	}
+	return 0
+}
+
+func (x *StatusEffect) GetStacking() string {
+	if x != nil {
+		return x.Stacking
+	}
+	return ""
+}
+
+func (x *StatusEffect) GetTick() string {
+	if x != nil {
+		return x.Tick
+	}
+	return ""
+}
+
 var File_api_proto_battle_battle_proto protoreflect.FileDescriptor
 
 const file_api_proto_battle_battle_proto_rawDesc = "" +
@@ -923,7 +1226,7 @@ const file_api_proto_battle_battle_proto_rawDesc = "" +
 	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\x12\x12\n" +
 	"\x04side\x18\x02 \x01(\tR\x04side\"^\n" +
 	"\x1dGetBattleParticipantsResponse\x12=\n" +
-	"\fparticipants\x18\x01 \x03(\v2\x19.battle.BattleParticipantR\fparticipants\"\xe2\x01\n" +
+	"\fparticipants\x18\x01 \x03(\v2\x19.battle.BattleParticipantR\fparticipants\"\x8d\x02\n" +
 	"\x1dUpdateParticipantStatsRequest\x12\x1b\n" +
 	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\x12%\n" +
 	"\x0eparticipant_id\x18\x02 \x01(\tR\rparticipantId\x12\x0e\n" +
@@ -931,7 +1234,8 @@ const file_api_proto_battle_battle_proto_rawDesc = "" +
 	"\x06max_hp\x18\x04 \x01(\x05R\x05maxHp\x12!\n" +
 	"\fattack_power\x18\x05 \x01(\x05R\vattackPower\x12\x18\n" +
 	"\adefense\x18\x06 \x01(\x05R\adefense\x12\x19\n" +
-	"\bis_alive\x18\a \x01(\bR\aisAlive\"T\n" +
+	"\bis_alive\x18\a \x01(\bR\aisAlive\x12)\n" +
+	"\x10expected_version\x18\b \x01(\x05R\x0fexpectedVersion\"T\n" +
 	"\x1eUpdateParticipantStatsResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"\x9d\x02\n" +
@@ -947,7 +1251,22 @@ const file_api_proto_battle_battle_proto_rawDesc = "" +
 	"\x16target_dark_emperor_id\x18\a \x01(\tR\x13targetDarkEmperorId\"G\n" +
 	"\x11CastSpellResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xe3\x04\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xa3\x02\n" +
+	"\x18ApplyStatusEffectRequest\x12\x1b\n" +
+	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\x12%\n" +
+	"\x0eparticipant_id\x18\x02 \x01(\tR\rparticipantId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1c\n" +
+	"\tmagnitude\x18\x04 \x01(\x05R\tmagnitude\x12%\n" +
+	"\x0eduration_turns\x18\x05 \x01(\x05R\rdurationTurns\x12\x1b\n" +
+	"\tsource_id\x18\x06 \x01(\tR\bsourceId\x12\x12\n" +
+	"\x04tick\x18\a \x01(\tR\x04tick\x12\x1a\n" +
+	"\bstacking\x18\b \x01(\tR\bstacking\x12\x1d\n" +
+	"\n" +
+	"max_stacks\x18\t \x01(\x05R\tmaxStacks\"\x8c\x01\n" +
+	"\x19ApplyStatusEffectResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\vparticipant\x18\x03 \x01(\v2\x19.battle.BattleParticipantR\vparticipant\"\xf7\x04\n" +
 	"\x06Battle\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vbattle_type\x18\x02 \x01(\tR\n" +
@@ -970,7 +1289,8 @@ const file_api_proto_battle_battle_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf6\x03\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04seed\x18\x10 \x01(\x03R\x04seed\"\xcd\x04\n" +
 	"\x11BattleParticipant\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tbattle_id\x18\x02 \x01(\tR\bbattleId\x12%\n" +
@@ -991,13 +1311,26 @@ const file_api_proto_battle_battle_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2\xc5\x03\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\x05R\aversion\x12;\n" +
+	"\x0estatus_effects\x18\x11 \x03(\v2\x14.battle.StatusEffectR\rstatusEffects\"\xed\x01\n" +
+	"\fStatusEffect\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1b\n" +
+	"\tsource_id\x18\x02 \x01(\tR\bsourceId\x12\x1c\n" +
+	"\tmagnitude\x18\x03 \x01(\x05R\tmagnitude\x12'\n" +
+	"\x0fremaining_turns\x18\x04 \x01(\x05R\x0eremainingTurns\x12\x16\n" +
+	"\x06stacks\x18\x05 \x01(\x05R\x06stacks\x12\x1d\n" +
+	"\n" +
+	"max_stacks\x18\x06 \x01(\x05R\tmaxStacks\x12\x1a\n" +
+	"\bstacking\x18\a \x01(\tR\bstacking\x12\x12\n" +
+	"\x04tick\x18\b \x01(\tR\x04tick2\x9f\x04\n" +
 	"\rBattleService\x12L\n" +
 	"\rGetBattleByID\x12\x1c.battle.GetBattleByIDRequest\x1a\x1d.battle.GetBattleByIDResponse\x12U\n" +
 	"\x10GetActiveBattles\x12\x1f.battle.GetActiveBattlesRequest\x1a .battle.GetActiveBattlesResponse\x12d\n" +
 	"\x15GetBattleParticipants\x12$.battle.GetBattleParticipantsRequest\x1a%.battle.GetBattleParticipantsResponse\x12g\n" +
 	"\x16UpdateParticipantStats\x12%.battle.UpdateParticipantStatsRequest\x1a&.battle.UpdateParticipantStatsResponse\x12@\n" +
-	"\tCastSpell\x12\x18.battle.CastSpellRequest\x1a\x19.battle.CastSpellResponseB$Z\"network-sec-micro/api/proto/battleb\x06proto3"
+	"\tCastSpell\x12\x18.battle.CastSpellRequest\x1a\x19.battle.CastSpellResponse\x12X\n" +
+	"\x11ApplyStatusEffect\x12 .battle.ApplyStatusEffectRequest\x1a!.battle.ApplyStatusEffectResponseB$Z\"network-sec-micro/api/proto/battleb\x06proto3"
 
 var (
 	file_api_proto_battle_battle_proto_rawDescOnce sync.Once
@@ -1011,7 +1344,7 @@ func file_api_proto_battle_battle_proto_rawDescGZIP() []byte {
 	return file_api_proto_battle_battle_proto_rawDescData
 }
 
-var file_api_proto_battle_battle_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_proto_battle_battle_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_api_proto_battle_battle_proto_goTypes = []any{
 	(*GetBattleByIDRequest)(nil),           // 0: battle.GetBattleByIDRequest
 	(*GetBattleByIDResponse)(nil),          // 1: battle.GetBattleByIDResponse
@@ -1023,36 +1356,43 @@ var file_api_proto_battle_battle_proto_goTypes = []any{
 	(*UpdateParticipantStatsResponse)(nil), // 7: battle.UpdateParticipantStatsResponse
 	(*CastSpellRequest)(nil),               // 8: battle.CastSpellRequest
 	(*CastSpellResponse)(nil),              // 9: battle.CastSpellResponse
-	(*Battle)(nil),                         // 10: battle.Battle
-	(*BattleParticipant)(nil),              // 11: battle.BattleParticipant
-	(*timestamppb.Timestamp)(nil),          // 12: google.protobuf.Timestamp
+	(*ApplyStatusEffectRequest)(nil),       // 10: battle.ApplyStatusEffectRequest
+	(*ApplyStatusEffectResponse)(nil),      // 11: battle.ApplyStatusEffectResponse
+	(*Battle)(nil),                         // 12: battle.Battle
+	(*BattleParticipant)(nil),              // 13: battle.BattleParticipant
+	(*StatusEffect)(nil),                   // 14: battle.StatusEffect
+	(*timestamppb.Timestamp)(nil),          // 15: google.protobuf.Timestamp
 }
 var file_api_proto_battle_battle_proto_depIdxs = []int32{
-	10, // 0: battle.GetBattleByIDResponse.battle:type_name -> battle.Battle
-	10, // 1: battle.GetActiveBattlesResponse.battles:type_name -> battle.Battle
-	11, // 2: battle.GetBattleParticipantsResponse.participants:type_name -> battle.BattleParticipant
-	12, // 3: battle.Battle.started_at:type_name -> google.protobuf.Timestamp
-	12, // 4: battle.Battle.completed_at:type_name -> google.protobuf.Timestamp
-	12, // 5: battle.Battle.created_at:type_name -> google.protobuf.Timestamp
-	12, // 6: battle.Battle.updated_at:type_name -> google.protobuf.Timestamp
-	12, // 7: battle.BattleParticipant.defeated_at:type_name -> google.protobuf.Timestamp
-	12, // 8: battle.BattleParticipant.created_at:type_name -> google.protobuf.Timestamp
-	12, // 9: battle.BattleParticipant.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 10: battle.BattleService.GetBattleByID:input_type -> battle.GetBattleByIDRequest
-	2,  // 11: battle.BattleService.GetActiveBattles:input_type -> battle.GetActiveBattlesRequest
-	4,  // 12: battle.BattleService.GetBattleParticipants:input_type -> battle.GetBattleParticipantsRequest
-	6,  // 13: battle.BattleService.UpdateParticipantStats:input_type -> battle.UpdateParticipantStatsRequest
-	8,  // 14: battle.BattleService.CastSpell:input_type -> battle.CastSpellRequest
-	1,  // 15: battle.BattleService.GetBattleByID:output_type -> battle.GetBattleByIDResponse
-	3,  // 16: battle.BattleService.GetActiveBattles:output_type -> battle.GetActiveBattlesResponse
-	5,  // 17: battle.BattleService.GetBattleParticipants:output_type -> battle.GetBattleParticipantsResponse
-	7,  // 18: battle.BattleService.UpdateParticipantStats:output_type -> battle.UpdateParticipantStatsResponse
-	9,  // 19: battle.BattleService.CastSpell:output_type -> battle.CastSpellResponse
-	15, // [15:20] is the sub-list for method output_type
-	10, // [10:15] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	12, // 0: battle.GetBattleByIDResponse.battle:type_name -> battle.Battle
+	12, // 1: battle.GetActiveBattlesResponse.battles:type_name -> battle.Battle
+	13, // 2: battle.GetBattleParticipantsResponse.participants:type_name -> battle.BattleParticipant
+	13, // 3: battle.ApplyStatusEffectResponse.participant:type_name -> battle.BattleParticipant
+	15, // 4: battle.Battle.started_at:type_name -> google.protobuf.Timestamp
+	15, // 5: battle.Battle.completed_at:type_name -> google.protobuf.Timestamp
+	15, // 6: battle.Battle.created_at:type_name -> google.protobuf.Timestamp
+	15, // 7: battle.Battle.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 8: battle.BattleParticipant.defeated_at:type_name -> google.protobuf.Timestamp
+	15, // 9: battle.BattleParticipant.created_at:type_name -> google.protobuf.Timestamp
+	15, // 10: battle.BattleParticipant.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 11: battle.BattleParticipant.status_effects:type_name -> battle.StatusEffect
+	0,  // 12: battle.BattleService.GetBattleByID:input_type -> battle.GetBattleByIDRequest
+	2,  // 13: battle.BattleService.GetActiveBattles:input_type -> battle.GetActiveBattlesRequest
+	4,  // 14: battle.BattleService.GetBattleParticipants:input_type -> battle.GetBattleParticipantsRequest
+	6,  // 15: battle.BattleService.UpdateParticipantStats:input_type -> battle.UpdateParticipantStatsRequest
+	8,  // 16: battle.BattleService.CastSpell:input_type -> battle.CastSpellRequest
+	10, // 17: battle.BattleService.ApplyStatusEffect:input_type -> battle.ApplyStatusEffectRequest
+	1,  // 18: battle.BattleService.GetBattleByID:output_type -> battle.GetBattleByIDResponse
+	3,  // 19: battle.BattleService.GetActiveBattles:output_type -> battle.GetActiveBattlesResponse
+	5,  // 20: battle.BattleService.GetBattleParticipants:output_type -> battle.GetBattleParticipantsResponse
+	7,  // 21: battle.BattleService.UpdateParticipantStats:output_type -> battle.UpdateParticipantStatsResponse
+	9,  // 22: battle.BattleService.CastSpell:output_type -> battle.CastSpellResponse
+	11, // 23: battle.BattleService.ApplyStatusEffect:output_type -> battle.ApplyStatusEffectResponse
+	18, // [18:24] is the sub-list for method output_type
+	12, // [12:18] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_battle_battle_proto_init() }
@@ -1066,7 +1406,7 @@ func file_api_proto_battle_battle_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_battle_battle_proto_rawDesc), len(file_api_proto_battle_battle_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,7 @@ const (
 	BattleService_GetBattleParticipants_FullMethodName  = "/battle.BattleService/GetBattleParticipants"
 	BattleService_UpdateParticipantStats_FullMethodName = "/battle.BattleService/UpdateParticipantStats"
 	BattleService_CastSpell_FullMethodName              = "/battle.BattleService/CastSpell"
+	BattleService_ApplyStatusEffect_FullMethodName      = "/battle.BattleService/ApplyStatusEffect"
 )
 
 // BattleServiceClient is the client API for BattleService service.
@@ -42,6 +43,8 @@ type BattleServiceClient interface {
 	UpdateParticipantStats(ctx context.Context, in *UpdateParticipantStatsRequest, opts ...grpc.CallOption) (*UpdateParticipantStatsResponse, error)
 	// Cast spell (called by other services if needed)
 	CastSpell(ctx context.Context, in *CastSpellRequest, opts ...grpc.CallOption) (*CastSpellResponse, error)
+	// Put a status effect (poison, burn, regen, stun, stat modifier) on a participant
+	ApplyStatusEffect(ctx context.Context, in *ApplyStatusEffectRequest, opts ...grpc.CallOption) (*ApplyStatusEffectResponse, error)
 }
 
 type battleServiceClient struct {
@@ -102,6 +105,16 @@ func (c *battleServiceClient) CastSpell(ctx context.Context, in *CastSpellReques
 	return out, nil
 }
 
+func (c *battleServiceClient) ApplyStatusEffect(ctx context.Context, in *ApplyStatusEffectRequest, opts ...grpc.CallOption) (*ApplyStatusEffectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyStatusEffectResponse)
+	err := c.cc.Invoke(ctx, BattleService_ApplyStatusEffect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BattleServiceServer is the server API for BattleService service.
 // All implementations must embed UnimplementedBattleServiceServer
 // for forward compatibility.
@@ -118,6 +131,8 @@ type BattleServiceServer interface {
 	UpdateParticipantStats(context.Context, *UpdateParticipantStatsRequest) (*UpdateParticipantStatsResponse, error)
 	// Cast spell (called by other services if needed)
 	CastSpell(context.Context, *CastSpellRequest) (*CastSpellResponse, error)
+	// Put a status effect (poison, burn, regen, stun, stat modifier) on a participant
+	ApplyStatusEffect(context.Context, *ApplyStatusEffectRequest) (*ApplyStatusEffectResponse, error)
 	mustEmbedUnimplementedBattleServiceServer()
 }
 
@@ -143,6 +158,9 @@ func (UnimplementedBattleServiceServer) UpdateParticipantStats(context.Context,
 func (UnimplementedBattleServiceServer) CastSpell(context.Context, *CastSpellRequest) (*CastSpellResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CastSpell not implemented")
 }
+func (UnimplementedBattleServiceServer) ApplyStatusEffect(context.Context, *ApplyStatusEffectRequest) (*ApplyStatusEffectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyStatusEffect not implemented")
+}
 func (UnimplementedBattleServiceServer) mustEmbedUnimplementedBattleServiceServer() {}
 func (UnimplementedBattleServiceServer) testEmbeddedByValue()                       {}
 
@@ -254,6 +272,24 @@ func _BattleService_CastSpell_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BattleService_ApplyStatusEffect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyStatusEffectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleServiceServer).ApplyStatusEffect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleService_ApplyStatusEffect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleServiceServer).ApplyStatusEffect(ctx, req.(*ApplyStatusEffectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BattleService_ServiceDesc is the grpc.ServiceDesc for BattleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +317,10 @@ var BattleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CastSpell",
 			Handler:    _BattleService_CastSpell_Handler,
 		},
+		{
+			MethodName: "ApplyStatusEffect",
+			Handler:    _BattleService_ApplyStatusEffect_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/battle/battle.proto",
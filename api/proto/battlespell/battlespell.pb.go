@@ -266,6 +266,186 @@ func (x *GetActiveSpellsResponse) GetSpells() []*Spell {
 	return nil
 }
 
+// Request to list all spells of a battle
+type ListBattleSpellsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBattleSpellsRequest) Reset() {
+	*x = ListBattleSpellsRequest{}
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBattleSpellsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBattleSpellsRequest) ProtoMessage() {}
+
+func (x *ListBattleSpellsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBattleSpellsRequest.ProtoReflect.Descriptor instead.
+func (*ListBattleSpellsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_battlespell_battlespell_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListBattleSpellsRequest) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+// Response with all spells ordered by cast time
+type ListBattleSpellsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spells        []*Spell               `protobuf:"bytes,1,rep,name=spells,proto3" json:"spells,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBattleSpellsResponse) Reset() {
+	*x = ListBattleSpellsResponse{}
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBattleSpellsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBattleSpellsResponse) ProtoMessage() {}
+
+func (x *ListBattleSpellsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBattleSpellsResponse.ProtoReflect.Descriptor instead.
+func (*ListBattleSpellsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_battlespell_battlespell_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListBattleSpellsResponse) GetSpells() []*Spell {
+	if x != nil {
+		return x.Spells
+	}
+	return nil
+}
+
+// Request to clear the spell state of a battle
+type ClearBattleSpellsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearBattleSpellsRequest) Reset() {
+	*x = ClearBattleSpellsRequest{}
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearBattleSpellsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearBattleSpellsRequest) ProtoMessage() {}
+
+func (x *ClearBattleSpellsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearBattleSpellsRequest.ProtoReflect.Descriptor instead.
+func (*ClearBattleSpellsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_battlespell_battlespell_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ClearBattleSpellsRequest) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+// Response after clearing spells
+type ClearBattleSpellsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClearedCount  int32                  `protobuf:"varint,1,opt,name=cleared_count,json=clearedCount,proto3" json:"cleared_count,omitempty"` // Number of spells deactivated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearBattleSpellsResponse) Reset() {
+	*x = ClearBattleSpellsResponse{}
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearBattleSpellsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearBattleSpellsResponse) ProtoMessage() {}
+
+func (x *ClearBattleSpellsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearBattleSpellsResponse.ProtoReflect.Descriptor instead.
+func (*ClearBattleSpellsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_battlespell_battlespell_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ClearBattleSpellsResponse) GetClearedCount() int32 {
+	if x != nil {
+		return x.ClearedCount
+	}
+	return 0
+}
+
 // Request to trigger wraith of dragon
 type TriggerWraithOfDragonRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -276,7 +456,7 @@ type TriggerWraithOfDragonRequest struct {
 
 func (x *TriggerWraithOfDragonRequest) Reset() {
 	*x = TriggerWraithOfDragonRequest{}
-	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[4]
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -288,7 +468,7 @@ func (x *TriggerWraithOfDragonRequest) String() string {
 func (*TriggerWraithOfDragonRequest) ProtoMessage() {}
 
 func (x *TriggerWraithOfDragonRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[4]
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +481,7 @@ func (x *TriggerWraithOfDragonRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerWraithOfDragonRequest.ProtoReflect.Descriptor instead.
 func (*TriggerWraithOfDragonRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_battlespell_battlespell_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_battlespell_battlespell_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TriggerWraithOfDragonRequest) GetBattleId() string {
@@ -324,7 +504,7 @@ type TriggerWraithOfDragonResponse struct {
 
 func (x *TriggerWraithOfDragonResponse) Reset() {
 	*x = TriggerWraithOfDragonResponse{}
-	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[5]
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -336,7 +516,7 @@ func (x *TriggerWraithOfDragonResponse) String() string {
 func (*TriggerWraithOfDragonResponse) ProtoMessage() {}
 
 func (x *TriggerWraithOfDragonResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[5]
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,7 +529,7 @@ func (x *TriggerWraithOfDragonResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerWraithOfDragonResponse.ProtoReflect.Descriptor instead.
 func (*TriggerWraithOfDragonResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_battlespell_battlespell_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_battlespell_battlespell_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *TriggerWraithOfDragonResponse) GetTriggered() bool {
@@ -404,7 +584,7 @@ type Spell struct {
 
 func (x *Spell) Reset() {
 	*x = Spell{}
-	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[6]
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -416,7 +596,7 @@ func (x *Spell) String() string {
 func (*Spell) ProtoMessage() {}
 
 func (x *Spell) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[6]
+	mi := &file_api_proto_battlespell_battlespell_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,7 +609,7 @@ func (x *Spell) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Spell.ProtoReflect.Descriptor instead.
 func (*Spell) Descriptor() ([]byte, []int) {
-	return file_api_proto_battlespell_battlespell_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_battlespell_battlespell_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Spell) GetId() string {
@@ -559,7 +739,15 @@ const file_api_proto_battlespell_battlespell_proto_rawDesc = "" +
 	"\x16GetActiveSpellsRequest\x12\x1b\n" +
 	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\"E\n" +
 	"\x17GetActiveSpellsResponse\x12*\n" +
-	"\x06spells\x18\x01 \x03(\v2\x12.battlespell.SpellR\x06spells\";\n" +
+	"\x06spells\x18\x01 \x03(\v2\x12.battlespell.SpellR\x06spells\"6\n" +
+	"\x17ListBattleSpellsRequest\x12\x1b\n" +
+	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\"F\n" +
+	"\x18ListBattleSpellsResponse\x12*\n" +
+	"\x06spells\x18\x01 \x03(\v2\x12.battlespell.SpellR\x06spells\"7\n" +
+	"\x18ClearBattleSpellsRequest\x12\x1b\n" +
+	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\"@\n" +
+	"\x19ClearBattleSpellsResponse\x12#\n" +
+	"\rcleared_count\x18\x01 \x01(\x05R\fclearedCount\";\n" +
 	"\x1cTriggerWraithOfDragonRequest\x12\x1b\n" +
 	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\"\xac\x01\n" +
 	"\x1dTriggerWraithOfDragonResponse\x12\x1c\n" +
@@ -588,11 +776,13 @@ const file_api_proto_battlespell_battlespell_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2\xae\x02\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2\xf3\x03\n" +
 	"\x12BattleSpellService\x12J\n" +
 	"\tCastSpell\x12\x1d.battlespell.CastSpellRequest\x1a\x1e.battlespell.CastSpellResponse\x12\\\n" +
 	"\x0fGetActiveSpells\x12#.battlespell.GetActiveSpellsRequest\x1a$.battlespell.GetActiveSpellsResponse\x12n\n" +
-	"\x15TriggerWraithOfDragon\x12).battlespell.TriggerWraithOfDragonRequest\x1a*.battlespell.TriggerWraithOfDragonResponseB)Z'network-sec-micro/api/proto/battlespellb\x06proto3"
+	"\x15TriggerWraithOfDragon\x12).battlespell.TriggerWraithOfDragonRequest\x1a*.battlespell.TriggerWraithOfDragonResponse\x12_\n" +
+	"\x10ListBattleSpells\x12$.battlespell.ListBattleSpellsRequest\x1a%.battlespell.ListBattleSpellsResponse\x12b\n" +
+	"\x11ClearBattleSpells\x12%.battlespell.ClearBattleSpellsRequest\x1a&.battlespell.ClearBattleSpellsResponseB)Z'network-sec-micro/api/proto/battlespellb\x06proto3"
 
 var (
 	file_api_proto_battlespell_battlespell_proto_rawDescOnce sync.Once
@@ -606,33 +796,42 @@ func file_api_proto_battlespell_battlespell_proto_rawDescGZIP() []byte {
 	return file_api_proto_battlespell_battlespell_proto_rawDescData
 }
 
-var file_api_proto_battlespell_battlespell_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_proto_battlespell_battlespell_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_api_proto_battlespell_battlespell_proto_goTypes = []any{
 	(*CastSpellRequest)(nil),              // 0: battlespell.CastSpellRequest
 	(*CastSpellResponse)(nil),             // 1: battlespell.CastSpellResponse
 	(*GetActiveSpellsRequest)(nil),        // 2: battlespell.GetActiveSpellsRequest
 	(*GetActiveSpellsResponse)(nil),       // 3: battlespell.GetActiveSpellsResponse
-	(*TriggerWraithOfDragonRequest)(nil),  // 4: battlespell.TriggerWraithOfDragonRequest
-	(*TriggerWraithOfDragonResponse)(nil), // 5: battlespell.TriggerWraithOfDragonResponse
-	(*Spell)(nil),                         // 6: battlespell.Spell
-	(*timestamppb.Timestamp)(nil),         // 7: google.protobuf.Timestamp
+	(*ListBattleSpellsRequest)(nil),       // 4: battlespell.ListBattleSpellsRequest
+	(*ListBattleSpellsResponse)(nil),      // 5: battlespell.ListBattleSpellsResponse
+	(*ClearBattleSpellsRequest)(nil),      // 6: battlespell.ClearBattleSpellsRequest
+	(*ClearBattleSpellsResponse)(nil),     // 7: battlespell.ClearBattleSpellsResponse
+	(*TriggerWraithOfDragonRequest)(nil),  // 8: battlespell.TriggerWraithOfDragonRequest
+	(*TriggerWraithOfDragonResponse)(nil), // 9: battlespell.TriggerWraithOfDragonResponse
+	(*Spell)(nil),                         // 10: battlespell.Spell
+	(*timestamppb.Timestamp)(nil),         // 11: google.protobuf.Timestamp
 }
 var file_api_proto_battlespell_battlespell_proto_depIdxs = []int32{
-	6, // 0: battlespell.GetActiveSpellsResponse.spells:type_name -> battlespell.Spell
-	7, // 1: battlespell.Spell.cast_at:type_name -> google.protobuf.Timestamp
-	7, // 2: battlespell.Spell.created_at:type_name -> google.protobuf.Timestamp
-	7, // 3: battlespell.Spell.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 4: battlespell.BattleSpellService.CastSpell:input_type -> battlespell.CastSpellRequest
-	2, // 5: battlespell.BattleSpellService.GetActiveSpells:input_type -> battlespell.GetActiveSpellsRequest
-	4, // 6: battlespell.BattleSpellService.TriggerWraithOfDragon:input_type -> battlespell.TriggerWraithOfDragonRequest
-	1, // 7: battlespell.BattleSpellService.CastSpell:output_type -> battlespell.CastSpellResponse
-	3, // 8: battlespell.BattleSpellService.GetActiveSpells:output_type -> battlespell.GetActiveSpellsResponse
-	5, // 9: battlespell.BattleSpellService.TriggerWraithOfDragon:output_type -> battlespell.TriggerWraithOfDragonResponse
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	10, // 0: battlespell.GetActiveSpellsResponse.spells:type_name -> battlespell.Spell
+	10, // 1: battlespell.ListBattleSpellsResponse.spells:type_name -> battlespell.Spell
+	11, // 2: battlespell.Spell.cast_at:type_name -> google.protobuf.Timestamp
+	11, // 3: battlespell.Spell.created_at:type_name -> google.protobuf.Timestamp
+	11, // 4: battlespell.Spell.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: battlespell.BattleSpellService.CastSpell:input_type -> battlespell.CastSpellRequest
+	2,  // 6: battlespell.BattleSpellService.GetActiveSpells:input_type -> battlespell.GetActiveSpellsRequest
+	8,  // 7: battlespell.BattleSpellService.TriggerWraithOfDragon:input_type -> battlespell.TriggerWraithOfDragonRequest
+	4,  // 8: battlespell.BattleSpellService.ListBattleSpells:input_type -> battlespell.ListBattleSpellsRequest
+	6,  // 9: battlespell.BattleSpellService.ClearBattleSpells:input_type -> battlespell.ClearBattleSpellsRequest
+	1,  // 10: battlespell.BattleSpellService.CastSpell:output_type -> battlespell.CastSpellResponse
+	3,  // 11: battlespell.BattleSpellService.GetActiveSpells:output_type -> battlespell.GetActiveSpellsResponse
+	9,  // 12: battlespell.BattleSpellService.TriggerWraithOfDragon:output_type -> battlespell.TriggerWraithOfDragonResponse
+	5,  // 13: battlespell.BattleSpellService.ListBattleSpells:output_type -> battlespell.ListBattleSpellsResponse
+	7,  // 14: battlespell.BattleSpellService.ClearBattleSpells:output_type -> battlespell.ClearBattleSpellsResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_battlespell_battlespell_proto_init() }
@@ -646,7 +845,7 @@ func file_api_proto_battlespell_battlespell_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_battlespell_battlespell_proto_rawDesc), len(file_api_proto_battlespell_battlespell_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
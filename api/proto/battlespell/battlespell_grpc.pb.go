@@ -22,6 +22,8 @@ const (
 	BattleSpellService_CastSpell_FullMethodName             = "/battlespell.BattleSpellService/CastSpell"
 	BattleSpellService_GetActiveSpells_FullMethodName       = "/battlespell.BattleSpellService/GetActiveSpells"
 	BattleSpellService_TriggerWraithOfDragon_FullMethodName = "/battlespell.BattleSpellService/TriggerWraithOfDragon"
+	BattleSpellService_ListBattleSpells_FullMethodName      = "/battlespell.BattleSpellService/ListBattleSpells"
+	BattleSpellService_ClearBattleSpells_FullMethodName     = "/battlespell.BattleSpellService/ClearBattleSpells"
 )
 
 // BattleSpellServiceClient is the client API for BattleSpellService service.
@@ -36,6 +38,10 @@ type BattleSpellServiceClient interface {
 	GetActiveSpells(ctx context.Context, in *GetActiveSpellsRequest, opts ...grpc.CallOption) (*GetActiveSpellsResponse, error)
 	// Trigger Wraith of Dragon effect (called by battle service when dragon kills warrior)
 	TriggerWraithOfDragon(ctx context.Context, in *TriggerWraithOfDragonRequest, opts ...grpc.CallOption) (*TriggerWraithOfDragonResponse, error)
+	// List every spell cast in a battle, including expired and instant ones (used for replays)
+	ListBattleSpells(ctx context.Context, in *ListBattleSpellsRequest, opts ...grpc.CallOption) (*ListBattleSpellsResponse, error)
+	// Deactivate every active spell of a battle (called by battle service when a battle is surrendered or cancelled)
+	ClearBattleSpells(ctx context.Context, in *ClearBattleSpellsRequest, opts ...grpc.CallOption) (*ClearBattleSpellsResponse, error)
 }
 
 type battleSpellServiceClient struct {
@@ -76,6 +82,26 @@ func (c *battleSpellServiceClient) TriggerWraithOfDragon(ctx context.Context, in
 	return out, nil
 }
 
+func (c *battleSpellServiceClient) ListBattleSpells(ctx context.Context, in *ListBattleSpellsRequest, opts ...grpc.CallOption) (*ListBattleSpellsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBattleSpellsResponse)
+	err := c.cc.Invoke(ctx, BattleSpellService_ListBattleSpells_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *battleSpellServiceClient) ClearBattleSpells(ctx context.Context, in *ClearBattleSpellsRequest, opts ...grpc.CallOption) (*ClearBattleSpellsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearBattleSpellsResponse)
+	err := c.cc.Invoke(ctx, BattleSpellService_ClearBattleSpells_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BattleSpellServiceServer is the server API for BattleSpellService service.
 // All implementations must embed UnimplementedBattleSpellServiceServer
 // for forward compatibility.
@@ -88,6 +114,10 @@ type BattleSpellServiceServer interface {
 	GetActiveSpells(context.Context, *GetActiveSpellsRequest) (*GetActiveSpellsResponse, error)
 	// Trigger Wraith of Dragon effect (called by battle service when dragon kills warrior)
 	TriggerWraithOfDragon(context.Context, *TriggerWraithOfDragonRequest) (*TriggerWraithOfDragonResponse, error)
+	// List every spell cast in a battle, including expired and instant ones (used for replays)
+	ListBattleSpells(context.Context, *ListBattleSpellsRequest) (*ListBattleSpellsResponse, error)
+	// Deactivate every active spell of a battle (called by battle service when a battle is surrendered or cancelled)
+	ClearBattleSpells(context.Context, *ClearBattleSpellsRequest) (*ClearBattleSpellsResponse, error)
 	mustEmbedUnimplementedBattleSpellServiceServer()
 }
 
@@ -107,6 +137,12 @@ func (UnimplementedBattleSpellServiceServer) GetActiveSpells(context.Context, *G
 func (UnimplementedBattleSpellServiceServer) TriggerWraithOfDragon(context.Context, *TriggerWraithOfDragonRequest) (*TriggerWraithOfDragonResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TriggerWraithOfDragon not implemented")
 }
+func (UnimplementedBattleSpellServiceServer) ListBattleSpells(context.Context, *ListBattleSpellsRequest) (*ListBattleSpellsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBattleSpells not implemented")
+}
+func (UnimplementedBattleSpellServiceServer) ClearBattleSpells(context.Context, *ClearBattleSpellsRequest) (*ClearBattleSpellsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearBattleSpells not implemented")
+}
 func (UnimplementedBattleSpellServiceServer) mustEmbedUnimplementedBattleSpellServiceServer() {}
 func (UnimplementedBattleSpellServiceServer) testEmbeddedByValue()                            {}
 
@@ -182,6 +218,42 @@ func _BattleSpellService_TriggerWraithOfDragon_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BattleSpellService_ListBattleSpells_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBattleSpellsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleSpellServiceServer).ListBattleSpells(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleSpellService_ListBattleSpells_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleSpellServiceServer).ListBattleSpells(ctx, req.(*ListBattleSpellsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BattleSpellService_ClearBattleSpells_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearBattleSpellsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BattleSpellServiceServer).ClearBattleSpells(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BattleSpellService_ClearBattleSpells_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BattleSpellServiceServer).ClearBattleSpells(ctx, req.(*ClearBattleSpellsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BattleSpellService_ServiceDesc is the grpc.ServiceDesc for BattleSpellService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -201,6 +273,14 @@ var BattleSpellService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TriggerWraithOfDragon",
 			Handler:    _BattleSpellService_TriggerWraithOfDragon_Handler,
 		},
+		{
+			MethodName: "ListBattleSpells",
+			Handler:    _BattleSpellService_ListBattleSpells_Handler,
+		},
+		{
+			MethodName: "ClearBattleSpells",
+			Handler:    _BattleSpellService_ClearBattleSpells_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/battlespell/battlespell.proto",
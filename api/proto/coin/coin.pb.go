@@ -71,7 +71,9 @@ func (x *GetBalanceRequest) GetWarriorId() uint32 {
 type GetBalanceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WarriorId     uint32                 `protobuf:"varint,1,opt,name=warrior_id,json=warriorId,proto3" json:"warrior_id,omitempty"`
-	Balance       int64                  `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Balance       int64                  `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`     // every coin the warrior has, held or not
+	Held          int64                  `protobuf:"varint,3,opt,name=held,proto3" json:"held,omitempty"`           // reserved by holds
+	Available     int64                  `protobuf:"varint,4,opt,name=available,proto3" json:"available,omitempty"` // balance minus held
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -120,14 +122,30 @@ func (x *GetBalanceResponse) GetBalance() int64 {
 	return 0
 }
 
+func (x *GetBalanceResponse) GetHeld() int64 {
+	if x != nil {
+		return x.Held
+	}
+	return 0
+}
+
+func (x *GetBalanceResponse) GetAvailable() int64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
 // Request to deduct coins
 type DeductCoinsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WarriorId     uint32                 `protobuf:"varint,1,opt,name=warrior_id,json=warriorId,proto3" json:"warrior_id,omitempty"`
-	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // e.g., "weapon_purchase", "item_buy", etc.
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WarriorId      uint32                 `protobuf:"varint,1,opt,name=warrior_id,json=warriorId,proto3" json:"warrior_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                       // e.g., "weapon_purchase", "item_buy", etc.
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // optional; a replay with the same key returns the first result
+	Account        string                 `protobuf:"bytes,5,opt,name=account,proto3" json:"account,omitempty"`                                     // ledger account the coins go to, e.g. "shop_revenue"; defaults to "treasury"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *DeductCoinsRequest) Reset() {
@@ -181,6 +199,20 @@ func (x *DeductCoinsRequest) GetReason() string {
 	return ""
 }
 
+func (x *DeductCoinsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *DeductCoinsRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
 // Response after deduction
 type DeductCoinsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -189,6 +221,7 @@ type DeductCoinsResponse struct {
 	BalanceBefore int64                  `protobuf:"varint,3,opt,name=balance_before,json=balanceBefore,proto3" json:"balance_before,omitempty"`
 	BalanceAfter  int64                  `protobuf:"varint,4,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`
 	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Replayed      bool                   `protobuf:"varint,6,opt,name=replayed,proto3" json:"replayed,omitempty"` // an earlier request with the same idempotency key made this change
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -258,14 +291,23 @@ func (x *DeductCoinsResponse) GetMessage() string {
 	return ""
 }
 
+func (x *DeductCoinsResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
 // Request to add coins
 type AddCoinsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WarriorId     uint32                 `protobuf:"varint,1,opt,name=warrior_id,json=warriorId,proto3" json:"warrior_id,omitempty"`
-	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // e.g., "quest_reward", "login_bonus", etc.
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WarriorId      uint32                 `protobuf:"varint,1,opt,name=warrior_id,json=warriorId,proto3" json:"warrior_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                       // e.g., "quest_reward", "login_bonus", etc.
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // optional; a replay with the same key returns the first result
+	Account        string                 `protobuf:"bytes,5,opt,name=account,proto3" json:"account,omitempty"`                                     // ledger account the coins come from; defaults to "treasury"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *AddCoinsRequest) Reset() {
@@ -319,6 +361,20 @@ func (x *AddCoinsRequest) GetReason() string {
 	return ""
 }
 
+func (x *AddCoinsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AddCoinsRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
 // Response after adding coins
 type AddCoinsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -327,6 +383,7 @@ type AddCoinsResponse struct {
 	BalanceBefore int64                  `protobuf:"varint,3,opt,name=balance_before,json=balanceBefore,proto3" json:"balance_before,omitempty"`
 	BalanceAfter  int64                  `protobuf:"varint,4,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`
 	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Replayed      bool                   `protobuf:"varint,6,opt,name=replayed,proto3" json:"replayed,omitempty"` // an earlier request with the same idempotency key made this change
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -396,15 +453,23 @@ func (x *AddCoinsResponse) GetMessage() string {
 	return ""
 }
 
+func (x *AddCoinsResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
 // Request to transfer coins
 type TransferCoinsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FromWarriorId uint32                 `protobuf:"varint,1,opt,name=from_warrior_id,json=fromWarriorId,proto3" json:"from_warrior_id,omitempty"`
-	ToWarriorId   uint32                 `protobuf:"varint,2,opt,name=to_warrior_id,json=toWarriorId,proto3" json:"to_warrior_id,omitempty"`
-	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FromWarriorId  uint32                 `protobuf:"varint,1,opt,name=from_warrior_id,json=fromWarriorId,proto3" json:"from_warrior_id,omitempty"`
+	ToWarriorId    uint32                 `protobuf:"varint,2,opt,name=to_warrior_id,json=toWarriorId,proto3" json:"to_warrior_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason         string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // optional; a replay with the same key returns the first result
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *TransferCoinsRequest) Reset() {
@@ -465,6 +530,13 @@ func (x *TransferCoinsRequest) GetReason() string {
 	return ""
 }
 
+func (x *TransferCoinsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 // Response after transfer
 type TransferCoinsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -473,6 +545,7 @@ type TransferCoinsResponse struct {
 	ToWarriorId   uint32                 `protobuf:"varint,3,opt,name=to_warrior_id,json=toWarriorId,proto3" json:"to_warrior_id,omitempty"`
 	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
 	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Replayed      bool                   `protobuf:"varint,6,opt,name=replayed,proto3" json:"replayed,omitempty"` // an earlier request with the same idempotency key made this transfer
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -542,6 +615,13 @@ func (x *TransferCoinsResponse) GetMessage() string {
 	return ""
 }
 
+func (x *TransferCoinsResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
 // Request to get transaction history
 type GetTransactionHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -741,77 +821,1067 @@ func (x *Transaction) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-var File_api_proto_coin_coin_proto protoreflect.FileDescriptor
+// Request to lock a wager stake in escrow
+type LockWagerStakeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BattleId      string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	WarriorId     uint32                 `protobuf:"varint,2,opt,name=warrior_id,json=warriorId,proto3" json:"warrior_id,omitempty"` // The emperor putting up the stake
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_proto_coin_coin_proto_rawDesc = "" +
-	"\n" +
-	"\x19api/proto/coin/coin.proto\x12\x04coin\x1a\x1fgoogle/protobuf/timestamp.proto\"2\n" +
-	"\x11GetBalanceRequest\x12\x1d\n" +
-	"\n" +
-	"warrior_id\x18\x01 \x01(\rR\twarriorId\"M\n" +
-	"\x12GetBalanceResponse\x12\x1d\n" +
-	"\n" +
-	"warrior_id\x18\x01 \x01(\rR\twarriorId\x12\x18\n" +
-	"\abalance\x18\x02 \x01(\x03R\abalance\"c\n" +
-	"\x12DeductCoinsRequest\x12\x1d\n" +
-	"\n" +
-	"warrior_id\x18\x01 \x01(\rR\twarriorId\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xb4\x01\n" +
-	"\x13DeductCoinsResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
-	"\n" +
-	"warrior_id\x18\x02 \x01(\rR\twarriorId\x12%\n" +
-	"\x0ebalance_before\x18\x03 \x01(\x03R\rbalanceBefore\x12#\n" +
-	"\rbalance_after\x18\x04 \x01(\x03R\fbalanceAfter\x12\x18\n" +
-	"\amessage\x18\x05 \x01(\tR\amessage\"`\n" +
-	"\x0fAddCoinsRequest\x12\x1d\n" +
-	"\n" +
-	"warrior_id\x18\x01 \x01(\rR\twarriorId\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xb1\x01\n" +
-	"\x10AddCoinsResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
-	"\n" +
-	"warrior_id\x18\x02 \x01(\rR\twarriorId\x12%\n" +
-	"\x0ebalance_before\x18\x03 \x01(\x03R\rbalanceBefore\x12#\n" +
-	"\rbalance_after\x18\x04 \x01(\x03R\fbalanceAfter\x12\x18\n" +
-	"\amessage\x18\x05 \x01(\tR\amessage\"\x92\x01\n" +
-	"\x14TransferCoinsRequest\x12&\n" +
-	"\x0ffrom_warrior_id\x18\x01 \x01(\rR\rfromWarriorId\x12\"\n" +
-	"\rto_warrior_id\x18\x02 \x01(\rR\vtoWarriorId\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x16\n" +
-	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xaf\x01\n" +
-	"\x15TransferCoinsResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12&\n" +
-	"\x0ffrom_warrior_id\x18\x02 \x01(\rR\rfromWarriorId\x12\"\n" +
-	"\rto_warrior_id\x18\x03 \x01(\rR\vtoWarriorId\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x18\n" +
-	"\amessage\x18\x05 \x01(\tR\amessage\"k\n" +
-	"\x1cGetTransactionHistoryRequest\x12\x1d\n" +
-	"\n" +
-	"warrior_id\x18\x01 \x01(\rR\twarriorId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"l\n" +
-	"\x1dGetTransactionHistoryResponse\x125\n" +
-	"\ftransactions\x18\x01 \x03(\v2\x11.coin.TransactionR\ftransactions\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\xd2\x01\n" +
-	"\vTransaction\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1d\n" +
-	"\n" +
-	"warrior_id\x18\x02 \x01(\rR\twarriorId\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12)\n" +
-	"\x10transaction_type\x18\x04 \x01(\tR\x0ftransactionType\x12\x16\n" +
-	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt2\xf9\x02\n" +
+func (x *LockWagerStakeRequest) Reset() {
+	*x = LockWagerStakeRequest{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockWagerStakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockWagerStakeRequest) ProtoMessage() {}
+
+func (x *LockWagerStakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockWagerStakeRequest.ProtoReflect.Descriptor instead.
+func (*LockWagerStakeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LockWagerStakeRequest) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+func (x *LockWagerStakeRequest) GetWarriorId() uint32 {
+	if x != nil {
+		return x.WarriorId
+	}
+	return 0
+}
+
+func (x *LockWagerStakeRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Response after locking a wager stake
+type LockWagerStakeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	BattleId      string                 `protobuf:"bytes,2,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	WarriorId     uint32                 `protobuf:"varint,3,opt,name=warrior_id,json=warriorId,proto3" json:"warrior_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	BalanceAfter  int64                  `protobuf:"varint,5,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`
+	AlreadyLocked bool                   `protobuf:"varint,6,opt,name=already_locked,json=alreadyLocked,proto3" json:"already_locked,omitempty"` // The stake was locked by an earlier call
+	Message       string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockWagerStakeResponse) Reset() {
+	*x = LockWagerStakeResponse{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockWagerStakeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockWagerStakeResponse) ProtoMessage() {}
+
+func (x *LockWagerStakeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockWagerStakeResponse.ProtoReflect.Descriptor instead.
+func (*LockWagerStakeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LockWagerStakeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LockWagerStakeResponse) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+func (x *LockWagerStakeResponse) GetWarriorId() uint32 {
+	if x != nil {
+		return x.WarriorId
+	}
+	return 0
+}
+
+func (x *LockWagerStakeResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *LockWagerStakeResponse) GetBalanceAfter() int64 {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return 0
+}
+
+func (x *LockWagerStakeResponse) GetAlreadyLocked() bool {
+	if x != nil {
+		return x.AlreadyLocked
+	}
+	return false
+}
+
+func (x *LockWagerStakeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request to resolve a battle's wager
+type ResolveWagerRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BattleId        string                 `protobuf:"bytes,1,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	WinnerWarriorId uint32                 `protobuf:"varint,2,opt,name=winner_warrior_id,json=winnerWarriorId,proto3" json:"winner_warrior_id,omitempty"` // Emperor who takes the pot; 0 refunds every stake (draw or cancel)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ResolveWagerRequest) Reset() {
+	*x = ResolveWagerRequest{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveWagerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveWagerRequest) ProtoMessage() {}
+
+func (x *ResolveWagerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveWagerRequest.ProtoReflect.Descriptor instead.
+func (*ResolveWagerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ResolveWagerRequest) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+func (x *ResolveWagerRequest) GetWinnerWarriorId() uint32 {
+	if x != nil {
+		return x.WinnerWarriorId
+	}
+	return 0
+}
+
+// Response after resolving a wager
+type ResolveWagerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	BattleId      string                 `protobuf:"bytes,2,opt,name=battle_id,json=battleId,proto3" json:"battle_id,omitempty"`
+	PaidOut       int64                  `protobuf:"varint,3,opt,name=paid_out,json=paidOut,proto3" json:"paid_out,omitempty"` // Pot paid to the winner
+	Refunded      int64                  `protobuf:"varint,4,opt,name=refunded,proto3" json:"refunded,omitempty"`              // Stakes given back
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveWagerResponse) Reset() {
+	*x = ResolveWagerResponse{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveWagerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveWagerResponse) ProtoMessage() {}
+
+func (x *ResolveWagerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveWagerResponse.ProtoReflect.Descriptor instead.
+func (*ResolveWagerResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ResolveWagerResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResolveWagerResponse) GetBattleId() string {
+	if x != nil {
+		return x.BattleId
+	}
+	return ""
+}
+
+func (x *ResolveWagerResponse) GetPaidOut() int64 {
+	if x != nil {
+		return x.PaidOut
+	}
+	return 0
+}
+
+func (x *ResolveWagerResponse) GetRefunded() int64 {
+	if x != nil {
+		return x.Refunded
+	}
+	return 0
+}
+
+func (x *ResolveWagerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Coins reserved on a warrior's balance
+type Hold struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reference      string                 `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	WarriorId      uint32                 `protobuf:"varint,3,opt,name=warrior_id,json=warriorId,proto3" json:"warrior_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Account        string                 `protobuf:"bytes,5,opt,name=account,proto3" json:"account,omitempty"` // ledger account the coins go to on capture
+	Reason         string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status         string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // "held", "captured", "released" or "expired"
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CapturedAmount int64                  `protobuf:"varint,9,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"` // taken on capture; the rest was given back
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Hold) Reset() {
+	*x = Hold{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hold) ProtoMessage() {}
+
+func (x *Hold) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hold.ProtoReflect.Descriptor instead.
+func (*Hold) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Hold) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Hold) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Hold) GetWarriorId() uint32 {
+	if x != nil {
+		return x.WarriorId
+	}
+	return 0
+}
+
+func (x *Hold) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Hold) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *Hold) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Hold) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Hold) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Hold) GetCapturedAmount() int64 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *Hold) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Request to place a hold
+type PlaceHoldRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WarriorId        uint32                 `protobuf:"varint,1,opt,name=warrior_id,json=warriorId,proto3" json:"warrior_id,omitempty"`
+	Amount           int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason           string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Reference        string                 `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`                                          // required; placing a hold again with the same reference returns the first hold
+	Account          string                 `protobuf:"bytes,5,opt,name=account,proto3" json:"account,omitempty"`                                              // ledger account the coins go to on capture; defaults to "treasury"
+	ExpiresInSeconds int64                  `protobuf:"varint,6,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"` // optional; defaults to 15 minutes, at most 24 hours
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PlaceHoldRequest) Reset() {
+	*x = PlaceHoldRequest{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceHoldRequest) ProtoMessage() {}
+
+func (x *PlaceHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceHoldRequest.ProtoReflect.Descriptor instead.
+func (*PlaceHoldRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PlaceHoldRequest) GetWarriorId() uint32 {
+	if x != nil {
+		return x.WarriorId
+	}
+	return 0
+}
+
+func (x *PlaceHoldRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PlaceHoldRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PlaceHoldRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *PlaceHoldRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *PlaceHoldRequest) GetExpiresInSeconds() int64 {
+	if x != nil {
+		return x.ExpiresInSeconds
+	}
+	return 0
+}
+
+// Response after placing a hold
+type PlaceHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Hold          *Hold                  `protobuf:"bytes,2,opt,name=hold,proto3" json:"hold,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceHoldResponse) Reset() {
+	*x = PlaceHoldResponse{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceHoldResponse) ProtoMessage() {}
+
+func (x *PlaceHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceHoldResponse.ProtoReflect.Descriptor instead.
+func (*PlaceHoldResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PlaceHoldResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PlaceHoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+func (x *PlaceHoldResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request to capture a hold
+type CaptureHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        uint32                 `protobuf:"varint,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"` // optional; takes part of the hold and gives back the rest, 0 takes all of it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureHoldRequest) Reset() {
+	*x = CaptureHoldRequest{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHoldRequest) ProtoMessage() {}
+
+func (x *CaptureHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHoldRequest.ProtoReflect.Descriptor instead.
+func (*CaptureHoldRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CaptureHoldRequest) GetHoldId() uint32 {
+	if x != nil {
+		return x.HoldId
+	}
+	return 0
+}
+
+func (x *CaptureHoldRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Response after capturing a hold
+type CaptureHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Hold          *Hold                  `protobuf:"bytes,2,opt,name=hold,proto3" json:"hold,omitempty"` // on failure, the hold as it stands, e.g. already released
+	BalanceAfter  int64                  `protobuf:"varint,3,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureHoldResponse) Reset() {
+	*x = CaptureHoldResponse{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHoldResponse) ProtoMessage() {}
+
+func (x *CaptureHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHoldResponse.ProtoReflect.Descriptor instead.
+func (*CaptureHoldResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CaptureHoldResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CaptureHoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+func (x *CaptureHoldResponse) GetBalanceAfter() int64 {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return 0
+}
+
+func (x *CaptureHoldResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request to release a hold
+type ReleaseHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        uint32                 `protobuf:"varint,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseHoldRequest) Reset() {
+	*x = ReleaseHoldRequest{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseHoldRequest) ProtoMessage() {}
+
+func (x *ReleaseHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseHoldRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseHoldRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReleaseHoldRequest) GetHoldId() uint32 {
+	if x != nil {
+		return x.HoldId
+	}
+	return 0
+}
+
+// Response after releasing a hold
+type ReleaseHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Hold          *Hold                  `protobuf:"bytes,2,opt,name=hold,proto3" json:"hold,omitempty"` // on failure, the hold as it stands, e.g. already captured
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseHoldResponse) Reset() {
+	*x = ReleaseHoldResponse{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseHoldResponse) ProtoMessage() {}
+
+func (x *ReleaseHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseHoldResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseHoldResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReleaseHoldResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReleaseHoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+func (x *ReleaseHoldResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request to list a warrior's holds
+type ListHoldsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarriorId     uint32                 `protobuf:"varint,1,opt,name=warrior_id,json=warriorId,proto3" json:"warrior_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`  // optional, e.g. "held"
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`   // optional, default 50
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"` // optional, default 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldsRequest) Reset() {
+	*x = ListHoldsRequest{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldsRequest) ProtoMessage() {}
+
+func (x *ListHoldsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldsRequest.ProtoReflect.Descriptor instead.
+func (*ListHoldsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListHoldsRequest) GetWarriorId() uint32 {
+	if x != nil {
+		return x.WarriorId
+	}
+	return 0
+}
+
+func (x *ListHoldsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListHoldsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListHoldsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Response with a warrior's holds
+type ListHoldsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holds         []*Hold                `protobuf:"bytes,1,rep,name=holds,proto3" json:"holds,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldsResponse) Reset() {
+	*x = ListHoldsResponse{}
+	mi := &file_api_proto_coin_coin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldsResponse) ProtoMessage() {}
+
+func (x *ListHoldsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_coin_coin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldsResponse.ProtoReflect.Descriptor instead.
+func (*ListHoldsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_coin_coin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListHoldsResponse) GetHolds() []*Hold {
+	if x != nil {
+		return x.Holds
+	}
+	return nil
+}
+
+func (x *ListHoldsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_api_proto_coin_coin_proto protoreflect.FileDescriptor
+
+const file_api_proto_coin_coin_proto_rawDesc = "" +
+	"\n" +
+	"\x19api/proto/coin/coin.proto\x12\x04coin\x1a\x1fgoogle/protobuf/timestamp.proto\"2\n" +
+	"\x11GetBalanceRequest\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x01 \x01(\rR\twarriorId\"\x7f\n" +
+	"\x12GetBalanceResponse\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x01 \x01(\rR\twarriorId\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x03R\abalance\x12\x12\n" +
+	"\x04held\x18\x03 \x01(\x03R\x04held\x12\x1c\n" +
+	"\tavailable\x18\x04 \x01(\x03R\tavailable\"\xa6\x01\n" +
+	"\x12DeductCoinsRequest\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x01 \x01(\rR\twarriorId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12\x18\n" +
+	"\aaccount\x18\x05 \x01(\tR\aaccount\"\xd0\x01\n" +
+	"\x13DeductCoinsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x02 \x01(\rR\twarriorId\x12%\n" +
+	"\x0ebalance_before\x18\x03 \x01(\x03R\rbalanceBefore\x12#\n" +
+	"\rbalance_after\x18\x04 \x01(\x03R\fbalanceAfter\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x1a\n" +
+	"\breplayed\x18\x06 \x01(\bR\breplayed\"\xa3\x01\n" +
+	"\x0fAddCoinsRequest\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x01 \x01(\rR\twarriorId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12\x18\n" +
+	"\aaccount\x18\x05 \x01(\tR\aaccount\"\xcd\x01\n" +
+	"\x10AddCoinsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x02 \x01(\rR\twarriorId\x12%\n" +
+	"\x0ebalance_before\x18\x03 \x01(\x03R\rbalanceBefore\x12#\n" +
+	"\rbalance_after\x18\x04 \x01(\x03R\fbalanceAfter\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x1a\n" +
+	"\breplayed\x18\x06 \x01(\bR\breplayed\"\xbb\x01\n" +
+	"\x14TransferCoinsRequest\x12&\n" +
+	"\x0ffrom_warrior_id\x18\x01 \x01(\rR\rfromWarriorId\x12\"\n" +
+	"\rto_warrior_id\x18\x02 \x01(\rR\vtoWarriorId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"\xcb\x01\n" +
+	"\x15TransferCoinsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12&\n" +
+	"\x0ffrom_warrior_id\x18\x02 \x01(\rR\rfromWarriorId\x12\"\n" +
+	"\rto_warrior_id\x18\x03 \x01(\rR\vtoWarriorId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x1a\n" +
+	"\breplayed\x18\x06 \x01(\bR\breplayed\"k\n" +
+	"\x1cGetTransactionHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x01 \x01(\rR\twarriorId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"l\n" +
+	"\x1dGetTransactionHistoryResponse\x125\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x11.coin.TransactionR\ftransactions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xd2\x01\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x02 \x01(\rR\twarriorId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12)\n" +
+	"\x10transaction_type\x18\x04 \x01(\tR\x0ftransactionType\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"k\n" +
+	"\x15LockWagerStakeRequest\x12\x1b\n" +
+	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x02 \x01(\rR\twarriorId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"\xec\x01\n" +
+	"\x16LockWagerStakeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1b\n" +
+	"\tbattle_id\x18\x02 \x01(\tR\bbattleId\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x03 \x01(\rR\twarriorId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12#\n" +
+	"\rbalance_after\x18\x05 \x01(\x03R\fbalanceAfter\x12%\n" +
+	"\x0ealready_locked\x18\x06 \x01(\bR\ralreadyLocked\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\"^\n" +
+	"\x13ResolveWagerRequest\x12\x1b\n" +
+	"\tbattle_id\x18\x01 \x01(\tR\bbattleId\x12*\n" +
+	"\x11winner_warrior_id\x18\x02 \x01(\rR\x0fwinnerWarriorId\"\x9e\x01\n" +
+	"\x14ResolveWagerResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1b\n" +
+	"\tbattle_id\x18\x02 \x01(\tR\bbattleId\x12\x19\n" +
+	"\bpaid_out\x18\x03 \x01(\x03R\apaidOut\x12\x1a\n" +
+	"\brefunded\x18\x04 \x01(\x03R\brefunded\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\xd4\x02\n" +
+	"\x04Hold\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1c\n" +
+	"\treference\x18\x02 \x01(\tR\treference\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x03 \x01(\rR\twarriorId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x18\n" +
+	"\aaccount\x18\x05 \x01(\tR\aaccount\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12'\n" +
+	"\x0fcaptured_amount\x18\t \x01(\x03R\x0ecapturedAmount\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xc7\x01\n" +
+	"\x10PlaceHoldRequest\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x01 \x01(\rR\twarriorId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12\x18\n" +
+	"\aaccount\x18\x05 \x01(\tR\aaccount\x12,\n" +
+	"\x12expires_in_seconds\x18\x06 \x01(\x03R\x10expiresInSeconds\"g\n" +
+	"\x11PlaceHoldResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1e\n" +
+	"\x04hold\x18\x02 \x01(\v2\n" +
+	".coin.HoldR\x04hold\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"E\n" +
+	"\x12CaptureHoldRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\rR\x06holdId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"\x8e\x01\n" +
+	"\x13CaptureHoldResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1e\n" +
+	"\x04hold\x18\x02 \x01(\v2\n" +
+	".coin.HoldR\x04hold\x12#\n" +
+	"\rbalance_after\x18\x03 \x01(\x03R\fbalanceAfter\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"-\n" +
+	"\x12ReleaseHoldRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\rR\x06holdId\"i\n" +
+	"\x13ReleaseHoldResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1e\n" +
+	"\x04hold\x18\x02 \x01(\v2\n" +
+	".coin.HoldR\x04hold\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"w\n" +
+	"\x10ListHoldsRequest\x12\x1d\n" +
+	"\n" +
+	"warrior_id\x18\x01 \x01(\rR\twarriorId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"K\n" +
+	"\x11ListHoldsResponse\x12 \n" +
+	"\x05holds\x18\x01 \x03(\v2\n" +
+	".coin.HoldR\x05holds\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total2\x91\x06\n" +
 	"\vCoinService\x12?\n" +
 	"\n" +
 	"GetBalance\x12\x17.coin.GetBalanceRequest\x1a\x18.coin.GetBalanceResponse\x12B\n" +
 	"\vDeductCoins\x12\x18.coin.DeductCoinsRequest\x1a\x19.coin.DeductCoinsResponse\x129\n" +
 	"\bAddCoins\x12\x15.coin.AddCoinsRequest\x1a\x16.coin.AddCoinsResponse\x12H\n" +
 	"\rTransferCoins\x12\x1a.coin.TransferCoinsRequest\x1a\x1b.coin.TransferCoinsResponse\x12`\n" +
-	"\x15GetTransactionHistory\x12\".coin.GetTransactionHistoryRequest\x1a#.coin.GetTransactionHistoryResponseB\"Z network-sec-micro/api/proto/coinb\x06proto3"
+	"\x15GetTransactionHistory\x12\".coin.GetTransactionHistoryRequest\x1a#.coin.GetTransactionHistoryResponse\x12K\n" +
+	"\x0eLockWagerStake\x12\x1b.coin.LockWagerStakeRequest\x1a\x1c.coin.LockWagerStakeResponse\x12E\n" +
+	"\fResolveWager\x12\x19.coin.ResolveWagerRequest\x1a\x1a.coin.ResolveWagerResponse\x12<\n" +
+	"\tPlaceHold\x12\x16.coin.PlaceHoldRequest\x1a\x17.coin.PlaceHoldResponse\x12B\n" +
+	"\vCaptureHold\x12\x18.coin.CaptureHoldRequest\x1a\x19.coin.CaptureHoldResponse\x12B\n" +
+	"\vReleaseHold\x12\x18.coin.ReleaseHoldRequest\x1a\x19.coin.ReleaseHoldResponse\x12<\n" +
+	"\tListHolds\x12\x16.coin.ListHoldsRequest\x1a\x17.coin.ListHoldsResponseB\"Z network-sec-micro/api/proto/coinb\x06proto3"
 
 var (
 	file_api_proto_coin_coin_proto_rawDescOnce sync.Once
@@ -825,7 +1895,7 @@ func file_api_proto_coin_coin_proto_rawDescGZIP() []byte {
 	return file_api_proto_coin_coin_proto_rawDescData
 }
 
-var file_api_proto_coin_coin_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_api_proto_coin_coin_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_api_proto_coin_coin_proto_goTypes = []any{
 	(*GetBalanceRequest)(nil),             // 0: coin.GetBalanceRequest
 	(*GetBalanceResponse)(nil),            // 1: coin.GetBalanceResponse
@@ -838,26 +1908,57 @@ var file_api_proto_coin_coin_proto_goTypes = []any{
 	(*GetTransactionHistoryRequest)(nil),  // 8: coin.GetTransactionHistoryRequest
 	(*GetTransactionHistoryResponse)(nil), // 9: coin.GetTransactionHistoryResponse
 	(*Transaction)(nil),                   // 10: coin.Transaction
-	(*timestamppb.Timestamp)(nil),         // 11: google.protobuf.Timestamp
+	(*LockWagerStakeRequest)(nil),         // 11: coin.LockWagerStakeRequest
+	(*LockWagerStakeResponse)(nil),        // 12: coin.LockWagerStakeResponse
+	(*ResolveWagerRequest)(nil),           // 13: coin.ResolveWagerRequest
+	(*ResolveWagerResponse)(nil),          // 14: coin.ResolveWagerResponse
+	(*Hold)(nil),                          // 15: coin.Hold
+	(*PlaceHoldRequest)(nil),              // 16: coin.PlaceHoldRequest
+	(*PlaceHoldResponse)(nil),             // 17: coin.PlaceHoldResponse
+	(*CaptureHoldRequest)(nil),            // 18: coin.CaptureHoldRequest
+	(*CaptureHoldResponse)(nil),           // 19: coin.CaptureHoldResponse
+	(*ReleaseHoldRequest)(nil),            // 20: coin.ReleaseHoldRequest
+	(*ReleaseHoldResponse)(nil),           // 21: coin.ReleaseHoldResponse
+	(*ListHoldsRequest)(nil),              // 22: coin.ListHoldsRequest
+	(*ListHoldsResponse)(nil),             // 23: coin.ListHoldsResponse
+	(*timestamppb.Timestamp)(nil),         // 24: google.protobuf.Timestamp
 }
 var file_api_proto_coin_coin_proto_depIdxs = []int32{
 	10, // 0: coin.GetTransactionHistoryResponse.transactions:type_name -> coin.Transaction
-	11, // 1: coin.Transaction.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: coin.CoinService.GetBalance:input_type -> coin.GetBalanceRequest
-	2,  // 3: coin.CoinService.DeductCoins:input_type -> coin.DeductCoinsRequest
-	4,  // 4: coin.CoinService.AddCoins:input_type -> coin.AddCoinsRequest
-	6,  // 5: coin.CoinService.TransferCoins:input_type -> coin.TransferCoinsRequest
-	8,  // 6: coin.CoinService.GetTransactionHistory:input_type -> coin.GetTransactionHistoryRequest
-	1,  // 7: coin.CoinService.GetBalance:output_type -> coin.GetBalanceResponse
-	3,  // 8: coin.CoinService.DeductCoins:output_type -> coin.DeductCoinsResponse
-	5,  // 9: coin.CoinService.AddCoins:output_type -> coin.AddCoinsResponse
-	7,  // 10: coin.CoinService.TransferCoins:output_type -> coin.TransferCoinsResponse
-	9,  // 11: coin.CoinService.GetTransactionHistory:output_type -> coin.GetTransactionHistoryResponse
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	24, // 1: coin.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	24, // 2: coin.Hold.created_at:type_name -> google.protobuf.Timestamp
+	24, // 3: coin.Hold.expires_at:type_name -> google.protobuf.Timestamp
+	15, // 4: coin.PlaceHoldResponse.hold:type_name -> coin.Hold
+	15, // 5: coin.CaptureHoldResponse.hold:type_name -> coin.Hold
+	15, // 6: coin.ReleaseHoldResponse.hold:type_name -> coin.Hold
+	15, // 7: coin.ListHoldsResponse.holds:type_name -> coin.Hold
+	0,  // 8: coin.CoinService.GetBalance:input_type -> coin.GetBalanceRequest
+	2,  // 9: coin.CoinService.DeductCoins:input_type -> coin.DeductCoinsRequest
+	4,  // 10: coin.CoinService.AddCoins:input_type -> coin.AddCoinsRequest
+	6,  // 11: coin.CoinService.TransferCoins:input_type -> coin.TransferCoinsRequest
+	8,  // 12: coin.CoinService.GetTransactionHistory:input_type -> coin.GetTransactionHistoryRequest
+	11, // 13: coin.CoinService.LockWagerStake:input_type -> coin.LockWagerStakeRequest
+	13, // 14: coin.CoinService.ResolveWager:input_type -> coin.ResolveWagerRequest
+	16, // 15: coin.CoinService.PlaceHold:input_type -> coin.PlaceHoldRequest
+	18, // 16: coin.CoinService.CaptureHold:input_type -> coin.CaptureHoldRequest
+	20, // 17: coin.CoinService.ReleaseHold:input_type -> coin.ReleaseHoldRequest
+	22, // 18: coin.CoinService.ListHolds:input_type -> coin.ListHoldsRequest
+	1,  // 19: coin.CoinService.GetBalance:output_type -> coin.GetBalanceResponse
+	3,  // 20: coin.CoinService.DeductCoins:output_type -> coin.DeductCoinsResponse
+	5,  // 21: coin.CoinService.AddCoins:output_type -> coin.AddCoinsResponse
+	7,  // 22: coin.CoinService.TransferCoins:output_type -> coin.TransferCoinsResponse
+	9,  // 23: coin.CoinService.GetTransactionHistory:output_type -> coin.GetTransactionHistoryResponse
+	12, // 24: coin.CoinService.LockWagerStake:output_type -> coin.LockWagerStakeResponse
+	14, // 25: coin.CoinService.ResolveWager:output_type -> coin.ResolveWagerResponse
+	17, // 26: coin.CoinService.PlaceHold:output_type -> coin.PlaceHoldResponse
+	19, // 27: coin.CoinService.CaptureHold:output_type -> coin.CaptureHoldResponse
+	21, // 28: coin.CoinService.ReleaseHold:output_type -> coin.ReleaseHoldResponse
+	23, // 29: coin.CoinService.ListHolds:output_type -> coin.ListHoldsResponse
+	19, // [19:30] is the sub-list for method output_type
+	8,  // [8:19] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_coin_coin_proto_init() }
@@ -871,7 +1972,7 @@ func file_api_proto_coin_coin_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_coin_coin_proto_rawDesc), len(file_api_proto_coin_coin_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
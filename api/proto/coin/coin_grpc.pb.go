@@ -24,6 +24,12 @@ const (
 	CoinService_AddCoins_FullMethodName              = "/coin.CoinService/AddCoins"
 	CoinService_TransferCoins_FullMethodName         = "/coin.CoinService/TransferCoins"
 	CoinService_GetTransactionHistory_FullMethodName = "/coin.CoinService/GetTransactionHistory"
+	CoinService_LockWagerStake_FullMethodName        = "/coin.CoinService/LockWagerStake"
+	CoinService_ResolveWager_FullMethodName          = "/coin.CoinService/ResolveWager"
+	CoinService_PlaceHold_FullMethodName             = "/coin.CoinService/PlaceHold"
+	CoinService_CaptureHold_FullMethodName           = "/coin.CoinService/CaptureHold"
+	CoinService_ReleaseHold_FullMethodName           = "/coin.CoinService/ReleaseHold"
+	CoinService_ListHolds_FullMethodName             = "/coin.CoinService/ListHolds"
 )
 
 // CoinServiceClient is the client API for CoinService service.
@@ -32,7 +38,7 @@ const (
 //
 // Coin Service
 type CoinServiceClient interface {
-	// Get warrior's coin balance
+	// Get warrior's coin balance, with the coins held and available
 	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
 	// Deduct coins from warrior's balance
 	DeductCoins(ctx context.Context, in *DeductCoinsRequest, opts ...grpc.CallOption) (*DeductCoinsResponse, error)
@@ -42,6 +48,18 @@ type CoinServiceClient interface {
 	TransferCoins(ctx context.Context, in *TransferCoinsRequest, opts ...grpc.CallOption) (*TransferCoinsResponse, error)
 	// Get transaction history
 	GetTransactionHistory(ctx context.Context, in *GetTransactionHistoryRequest, opts ...grpc.CallOption) (*GetTransactionHistoryResponse, error)
+	// Lock an emperor's wager stake on a battle in escrow (idempotent per battle and warrior)
+	LockWagerStake(ctx context.Context, in *LockWagerStakeRequest, opts ...grpc.CallOption) (*LockWagerStakeResponse, error)
+	// Pay a battle's escrowed pot to the winner, or refund every stake (idempotent per battle)
+	ResolveWager(ctx context.Context, in *ResolveWagerRequest, opts ...grpc.CallOption) (*ResolveWagerResponse, error)
+	// Reserve coins on a warrior's balance (idempotent per reference)
+	PlaceHold(ctx context.Context, in *PlaceHoldRequest, opts ...grpc.CallOption) (*PlaceHoldResponse, error)
+	// Take the coins a hold reserved, or part of them, giving back the rest (idempotent)
+	CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*CaptureHoldResponse, error)
+	// Give the coins a hold reserved back (idempotent)
+	ReleaseHold(ctx context.Context, in *ReleaseHoldRequest, opts ...grpc.CallOption) (*ReleaseHoldResponse, error)
+	// List a warrior's holds, newest first
+	ListHolds(ctx context.Context, in *ListHoldsRequest, opts ...grpc.CallOption) (*ListHoldsResponse, error)
 }
 
 type coinServiceClient struct {
@@ -102,13 +120,73 @@ func (c *coinServiceClient) GetTransactionHistory(ctx context.Context, in *GetTr
 	return out, nil
 }
 
+func (c *coinServiceClient) LockWagerStake(ctx context.Context, in *LockWagerStakeRequest, opts ...grpc.CallOption) (*LockWagerStakeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LockWagerStakeResponse)
+	err := c.cc.Invoke(ctx, CoinService_LockWagerStake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coinServiceClient) ResolveWager(ctx context.Context, in *ResolveWagerRequest, opts ...grpc.CallOption) (*ResolveWagerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveWagerResponse)
+	err := c.cc.Invoke(ctx, CoinService_ResolveWager_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coinServiceClient) PlaceHold(ctx context.Context, in *PlaceHoldRequest, opts ...grpc.CallOption) (*PlaceHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaceHoldResponse)
+	err := c.cc.Invoke(ctx, CoinService_PlaceHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coinServiceClient) CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*CaptureHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaptureHoldResponse)
+	err := c.cc.Invoke(ctx, CoinService_CaptureHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coinServiceClient) ReleaseHold(ctx context.Context, in *ReleaseHoldRequest, opts ...grpc.CallOption) (*ReleaseHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseHoldResponse)
+	err := c.cc.Invoke(ctx, CoinService_ReleaseHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coinServiceClient) ListHolds(ctx context.Context, in *ListHoldsRequest, opts ...grpc.CallOption) (*ListHoldsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHoldsResponse)
+	err := c.cc.Invoke(ctx, CoinService_ListHolds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CoinServiceServer is the server API for CoinService service.
 // All implementations must embed UnimplementedCoinServiceServer
 // for forward compatibility.
 //
 // Coin Service
 type CoinServiceServer interface {
-	// Get warrior's coin balance
+	// Get warrior's coin balance, with the coins held and available
 	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
 	// Deduct coins from warrior's balance
 	DeductCoins(context.Context, *DeductCoinsRequest) (*DeductCoinsResponse, error)
@@ -118,6 +196,18 @@ type CoinServiceServer interface {
 	TransferCoins(context.Context, *TransferCoinsRequest) (*TransferCoinsResponse, error)
 	// Get transaction history
 	GetTransactionHistory(context.Context, *GetTransactionHistoryRequest) (*GetTransactionHistoryResponse, error)
+	// Lock an emperor's wager stake on a battle in escrow (idempotent per battle and warrior)
+	LockWagerStake(context.Context, *LockWagerStakeRequest) (*LockWagerStakeResponse, error)
+	// Pay a battle's escrowed pot to the winner, or refund every stake (idempotent per battle)
+	ResolveWager(context.Context, *ResolveWagerRequest) (*ResolveWagerResponse, error)
+	// Reserve coins on a warrior's balance (idempotent per reference)
+	PlaceHold(context.Context, *PlaceHoldRequest) (*PlaceHoldResponse, error)
+	// Take the coins a hold reserved, or part of them, giving back the rest (idempotent)
+	CaptureHold(context.Context, *CaptureHoldRequest) (*CaptureHoldResponse, error)
+	// Give the coins a hold reserved back (idempotent)
+	ReleaseHold(context.Context, *ReleaseHoldRequest) (*ReleaseHoldResponse, error)
+	// List a warrior's holds, newest first
+	ListHolds(context.Context, *ListHoldsRequest) (*ListHoldsResponse, error)
 	mustEmbedUnimplementedCoinServiceServer()
 }
 
@@ -143,6 +233,24 @@ func (UnimplementedCoinServiceServer) TransferCoins(context.Context, *TransferCo
 func (UnimplementedCoinServiceServer) GetTransactionHistory(context.Context, *GetTransactionHistoryRequest) (*GetTransactionHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionHistory not implemented")
 }
+func (UnimplementedCoinServiceServer) LockWagerStake(context.Context, *LockWagerStakeRequest) (*LockWagerStakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockWagerStake not implemented")
+}
+func (UnimplementedCoinServiceServer) ResolveWager(context.Context, *ResolveWagerRequest) (*ResolveWagerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveWager not implemented")
+}
+func (UnimplementedCoinServiceServer) PlaceHold(context.Context, *PlaceHoldRequest) (*PlaceHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceHold not implemented")
+}
+func (UnimplementedCoinServiceServer) CaptureHold(context.Context, *CaptureHoldRequest) (*CaptureHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureHold not implemented")
+}
+func (UnimplementedCoinServiceServer) ReleaseHold(context.Context, *ReleaseHoldRequest) (*ReleaseHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseHold not implemented")
+}
+func (UnimplementedCoinServiceServer) ListHolds(context.Context, *ListHoldsRequest) (*ListHoldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHolds not implemented")
+}
 func (UnimplementedCoinServiceServer) mustEmbedUnimplementedCoinServiceServer() {}
 func (UnimplementedCoinServiceServer) testEmbeddedByValue()                     {}
 
@@ -254,6 +362,114 @@ func _CoinService_GetTransactionHistory_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CoinService_LockWagerStake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockWagerStakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoinServiceServer).LockWagerStake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoinService_LockWagerStake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoinServiceServer).LockWagerStake(ctx, req.(*LockWagerStakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoinService_ResolveWager_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveWagerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoinServiceServer).ResolveWager(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoinService_ResolveWager_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoinServiceServer).ResolveWager(ctx, req.(*ResolveWagerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoinService_PlaceHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoinServiceServer).PlaceHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoinService_PlaceHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoinServiceServer).PlaceHold(ctx, req.(*PlaceHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoinService_CaptureHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoinServiceServer).CaptureHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoinService_CaptureHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoinServiceServer).CaptureHold(ctx, req.(*CaptureHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoinService_ReleaseHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoinServiceServer).ReleaseHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoinService_ReleaseHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoinServiceServer).ReleaseHold(ctx, req.(*ReleaseHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CoinService_ListHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHoldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoinServiceServer).ListHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CoinService_ListHolds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoinServiceServer).ListHolds(ctx, req.(*ListHoldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CoinService_ServiceDesc is the grpc.ServiceDesc for CoinService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +497,30 @@ var CoinService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactionHistory",
 			Handler:    _CoinService_GetTransactionHistory_Handler,
 		},
+		{
+			MethodName: "LockWagerStake",
+			Handler:    _CoinService_LockWagerStake_Handler,
+		},
+		{
+			MethodName: "ResolveWager",
+			Handler:    _CoinService_ResolveWager_Handler,
+		},
+		{
+			MethodName: "PlaceHold",
+			Handler:    _CoinService_PlaceHold_Handler,
+		},
+		{
+			MethodName: "CaptureHold",
+			Handler:    _CoinService_CaptureHold_Handler,
+		},
+		{
+			MethodName: "ReleaseHold",
+			Handler:    _CoinService_ReleaseHold_Handler,
+		},
+		{
+			MethodName: "ListHolds",
+			Handler:    _CoinService_ListHolds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/coin/coin.proto",
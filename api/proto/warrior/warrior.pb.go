@@ -554,22 +554,25 @@ func (x *UpdateWarriorHealingStateResponse) GetMessage() string {
 
 // Warrior model
 type Warrior struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	Id                  uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Username            string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Email               string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	Role                string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
-	CoinBalance         int32                  `protobuf:"varint,5,opt,name=coin_balance,json=coinBalance,proto3" json:"coin_balance,omitempty"`
-	TotalPower          int32                  `protobuf:"varint,6,opt,name=total_power,json=totalPower,proto3" json:"total_power,omitempty"`
-	WeaponCount         int32                  `protobuf:"varint,7,opt,name=weapon_count,json=weaponCount,proto3" json:"weapon_count,omitempty"`
-	CurrentHp           int32                  `protobuf:"varint,8,opt,name=current_hp,json=currentHp,proto3" json:"current_hp,omitempty"`                                  // Current HP (for healing)
-	MaxHp               int32                  `protobuf:"varint,9,opt,name=max_hp,json=maxHp,proto3" json:"max_hp,omitempty"`                                              // Maximum HP (calculated from total_power)
-	IsHealing           bool                   `protobuf:"varint,10,opt,name=is_healing,json=isHealing,proto3" json:"is_healing,omitempty"`                                 // Is currently healing
-	HealingUntilSeconds int64                  `protobuf:"varint,11,opt,name=healing_until_seconds,json=healingUntilSeconds,proto3" json:"healing_until_seconds,omitempty"` // Unix timestamp when healing completes (0 if not healing)
-	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username              string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email                 string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role                  string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	CoinBalance           int32                  `protobuf:"varint,5,opt,name=coin_balance,json=coinBalance,proto3" json:"coin_balance,omitempty"`
+	TotalPower            int32                  `protobuf:"varint,6,opt,name=total_power,json=totalPower,proto3" json:"total_power,omitempty"`
+	WeaponCount           int32                  `protobuf:"varint,7,opt,name=weapon_count,json=weaponCount,proto3" json:"weapon_count,omitempty"`
+	CurrentHp             int32                  `protobuf:"varint,8,opt,name=current_hp,json=currentHp,proto3" json:"current_hp,omitempty"`                                  // Current HP (for healing)
+	MaxHp                 int32                  `protobuf:"varint,9,opt,name=max_hp,json=maxHp,proto3" json:"max_hp,omitempty"`                                              // Maximum HP (calculated from total_power)
+	IsHealing             bool                   `protobuf:"varint,10,opt,name=is_healing,json=isHealing,proto3" json:"is_healing,omitempty"`                                 // Is currently healing
+	HealingUntilSeconds   int64                  `protobuf:"varint,11,opt,name=healing_until_seconds,json=healingUntilSeconds,proto3" json:"healing_until_seconds,omitempty"` // Unix timestamp when healing completes (0 if not healing)
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Experience            int32                  `protobuf:"varint,14,opt,name=experience,proto3" json:"experience,omitempty"` // Total experience earned
+	Level                 int32                  `protobuf:"varint,15,opt,name=level,proto3" json:"level,omitempty"`
+	ExperienceToNextLevel int32                  `protobuf:"varint,16,opt,name=experience_to_next_level,json=experienceToNextLevel,proto3" json:"experience_to_next_level,omitempty"` // 0 at the level cap
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *Warrior) Reset() {
@@ -693,6 +696,27 @@ func (x *Warrior) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Warrior) GetExperience() int32 {
+	if x != nil {
+		return x.Experience
+	}
+	return 0
+}
+
+func (x *Warrior) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Warrior) GetExperienceToNextLevel() int32 {
+	if x != nil {
+		return x.ExperienceToNextLevel
+	}
+	return 0
+}
+
 var File_api_proto_warrior_warrior_proto protoreflect.FileDescriptor
 
 const file_api_proto_warrior_warrior_proto_rawDesc = "" +
@@ -733,7 +757,7 @@ const file_api_proto_warrior_warrior_proto_rawDesc = "" +
 	"\x15healing_until_seconds\x18\x03 \x01(\x03R\x13healingUntilSeconds\"W\n" +
 	"!UpdateWarriorHealingStateResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xc5\x03\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb4\x04\n" +
 	"\aWarrior\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
@@ -753,7 +777,12 @@ const file_api_proto_warrior_warrior_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2\xf1\x03\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1e\n" +
+	"\n" +
+	"experience\x18\x0e \x01(\x05R\n" +
+	"experience\x12\x14\n" +
+	"\x05level\x18\x0f \x01(\x05R\x05level\x127\n" +
+	"\x18experience_to_next_level\x18\x10 \x01(\x05R\x15experienceToNextLevel2\xf1\x03\n" +
 	"\x0eWarriorService\x12c\n" +
 	"\x14GetWarriorByUsername\x12$.warrior.GetWarriorByUsernameRequest\x1a%.warrior.GetWarriorByUsernameResponse\x12Q\n" +
 	"\x0eGetWarriorByID\x12\x1e.warrior.GetWarriorByIDRequest\x1a\x1f.warrior.GetWarriorByIDResponse\x12]\n" +
@@ -252,7 +252,9 @@ type Weapon struct {
 	MaxDurability int32 `protobuf:"varint,12,opt,name=max_durability,json=maxDurability,proto3" json:"max_durability,omitempty"` // maximum durability
 	IsBroken      bool  `protobuf:"varint,13,opt,name=is_broken,json=isBroken,proto3" json:"is_broken,omitempty"`                // derived from durability == 0
 	// Generalized ownership (supports warrior/enemy/dragon) - optional for now
-	Owners        []*OwnerRef `protobuf:"bytes,14,rep,name=owners,proto3" json:"owners,omitempty"` // when set, preferred over owned_by
+	Owners []*OwnerRef `protobuf:"bytes,14,rep,name=owners,proto3" json:"owners,omitempty"` // when set, preferred over owned_by
+	// Elemental affinity: hits with this weapon deal this element's damage (empty = physical)
+	Element       string `protobuf:"bytes,15,opt,name=element,proto3" json:"element,omitempty"` // "fire", "ice", "lightning", "shadow"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -385,6 +387,13 @@ func (x *Weapon) GetOwners() []*OwnerRef {
 	return nil
 }
 
+func (x *Weapon) GetElement() string {
+	if x != nil {
+		return x.Element
+	}
+	return ""
+}
+
 // Owner reference to support multiple entity types
 type OwnerRef struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -540,7 +549,7 @@ func (x *ListOwnerWeaponsResponse) GetWeapons() []*Weapon {
 type ApplyWearRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WeaponId      string                 `protobuf:"bytes,1,opt,name=weapon_id,json=weaponId,proto3" json:"weapon_id,omitempty"`
-	Wear          int32                  `protobuf:"varint,2,opt,name=wear,proto3" json:"wear,omitempty"` // how much durability to reduce
+	Wear          int32                  `protobuf:"varint,2,opt,name=wear,proto3" json:"wear,omitempty"` // how much durability to reduce; negative restores durability, up to the maximum
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -668,7 +677,7 @@ const file_api_proto_weapon_weapon_proto_rawDesc = "" +
 	"\fweapon_bonus\x18\x03 \x01(\x05R\vweaponBonus\x12\x1f\n" +
 	"\vtotal_power\x18\x04 \x01(\x05R\n" +
 	"totalPower\x12!\n" +
-	"\fweapon_count\x18\x05 \x01(\x05R\vweaponCount\"\xce\x03\n" +
+	"\fweapon_count\x18\x05 \x01(\x05R\vweaponCount\"\xe8\x03\n" +
 	"\x06Weapon\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -689,7 +698,8 @@ const file_api_proto_weapon_weapon_proto_rawDesc = "" +
 	"durability\x12%\n" +
 	"\x0emax_durability\x18\f \x01(\x05R\rmaxDurability\x12\x1b\n" +
 	"\tis_broken\x18\r \x01(\bR\bisBroken\x12(\n" +
-	"\x06owners\x18\x0e \x03(\v2\x10.weapon.OwnerRefR\x06owners\"D\n" +
+	"\x06owners\x18\x0e \x03(\v2\x10.weapon.OwnerRefR\x06owners\x12\x18\n" +
+	"\aelement\x18\x0f \x01(\tR\aelement\"D\n" +
 	"\bOwnerRef\x12\x1d\n" +
 	"\n" +
 	"owner_type\x18\x01 \x01(\tR\townerType\x12\x19\n" +
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	pb "network-sec-micro/api/proto/arena"
 	"network-sec-micro/internal/arena"
@@ -21,8 +22,10 @@ import (
 func main() {
     _ = docs.SwaggerInfo // ensure docs package is linked
     // Initialize Redis client (for Redis-backed repo)
+    redisReady := true
     if err := arena.InitRedisClient(); err != nil {
         log.Printf("Warning: Arena Redis init failed: %v", err)
+        redisReady = false
     }
 
     // Optionally initialize PostgreSQL (gradual migration)
@@ -67,6 +70,11 @@ func main() {
         log.Printf("Warning: Failed to connect to Armor gRPC: %v", err)
     }
 
+    // Initialize Coin gRPC client (season rewards)
+    if err := arena.InitCoinClient(os.Getenv("COIN_GRPC_ADDR")); err != nil {
+        log.Printf("Warning: Failed to connect to Coin gRPC: %v", err)
+    }
+
 	// Set Gin to release mode
 	if os.Getenv("GIN_MODE") == "release" {
 		gin.SetMode(gin.ReleaseMode)
@@ -78,6 +86,18 @@ func main() {
 		log.Fatalf("Failed to initialize app with Wire: %v", err)
 	}
 
+	// Ranked seasons and tournaments need PostgreSQL for ratings, standings and brackets
+	if arena.SQLDB.Enabled {
+		arena.SetSeasonConfig(arena.SeasonConfigFromEnv())
+		service.StartSeasonScheduler(context.Background(), time.Minute)
+		service.StartTournamentScheduler(context.Background(), 30*time.Second)
+	}
+
+	// The matchmaking queue lives in Redis
+	if redisReady {
+		service.StartMatchmaker(context.Background(), 2*time.Second)
+	}
+
 	// Initialize Kafka consumer
 	if err := arena.InitKafkaConsumer(); err != nil {
 		log.Printf("Warning: Failed to initialize Kafka consumer: %v", err)
@@ -104,6 +124,9 @@ func main() {
         arena.CloseArenaSpellClient()
         arena.CloseWeaponClient()
         arena.CloseArmorClient()
+        arena.CloseCoinClient()
+		arena.CloseSpectatorHub()
+		arena.CloseRedisClient()
 	}()
 
 	// Create Gin router
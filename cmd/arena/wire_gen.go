@@ -0,0 +1,16 @@
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"network-sec-micro/internal/arena"
+)
+
+// InitializeApp is a manual initializer; wire cannot generate an injector with four return values
+func InitializeApp() (*arena.Service, *arena.Handler, *arena.ArenaServiceServer, error) {
+	service := arena.NewService()
+	handler := arena.NewHandler(service)
+	grpcServer := arena.NewArenaServiceServer(service)
+	return service, handler, grpcServer, nil
+}
@@ -1,6 +1,6 @@
 // @title Armor Service API
 // @version 1.0
-// @description Armor service handles armor creation, purchase, and management. Purchases trigger Kafka events for coin deduction.
+// @description Armor service handles armor creation, purchase, and management. Warriors pay for armor through coin holds captured once the armor is theirs.
 // @host localhost:8089
 // @BasePath /api
 // @schemes http https
@@ -11,10 +11,13 @@
 package main
 
 import (
+    "context"
     "log"
     "net"
     "os"
+    "strconv"
     "sync"
+    "time"
 
     "network-sec-micro/internal/armor"
     pbArmor "network-sec-micro/api/proto/armor"
@@ -26,10 +29,22 @@ import (
 func main() {
     if err := armor.InitDatabase(); err != nil { log.Fatalf("Failed to init db: %v", err) }
 
+    if err := armor.InitCoinClient(os.Getenv("COIN_GRPC_ADDR")); err != nil { log.Fatalf("Failed to connect to Coin gRPC: %v", err) }
+
     service := armor.NewService()
     handler := armor.NewHandler(service)
 
-    defer func(){ _ = armor.CloseKafkaPublisher() }()
+    // Finish or roll back purchases left unfinished, e.g. by a crash
+    recoveryInterval := time.Minute
+    if v, err := strconv.Atoi(os.Getenv("PURCHASE_RECOVERY_INTERVAL_SECONDS")); err == nil && v > 0 { recoveryInterval = time.Duration(v) * time.Second }
+    recoveryCtx, stopRecovery := context.WithCancel(context.Background())
+    service.StartPurchaseRecovery(recoveryCtx, recoveryInterval)
+
+    defer func(){
+        stopRecovery()
+        _ = armor.CloseKafkaPublisher()
+        armor.CloseCoinClient()
+    }()
 
     if os.Getenv("GIN_MODE") == "release" { gin.SetMode(gin.ReleaseMode) }
     r := gin.Default()
@@ -22,11 +22,14 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	pb "network-sec-micro/api/proto/battle"
 	"network-sec-micro/internal/battle"
@@ -111,6 +114,25 @@ func main() {
 		log.Printf("Warning: Failed to connect to Heal gRPC: %v", err)
 	}
 
+	// Initialize Dragon and Enemy gRPC clients (optional, for initiative in speed-ordered battles)
+	dragonAddr := os.Getenv("DRAGON_GRPC_ADDR")
+	if dragonAddr == "" {
+		dragonAddr = "localhost:50059"
+	}
+	if err := battle.InitDragonClient(dragonAddr); err != nil {
+		log.Printf("Warning: Failed to connect to Dragon gRPC: %v", err)
+	}
+	enemyAddr := os.Getenv("ENEMY_GRPC_ADDR")
+	if enemyAddr == "" {
+		enemyAddr = "localhost:50060"
+	}
+	if err := battle.InitEnemyClient(enemyAddr); err != nil {
+		log.Printf("Warning: Failed to connect to Enemy gRPC: %v", err)
+	}
+
+	// Strategies for server-controlled participants (enemy, dragon, dark_emperor)
+	battle.LoadStrategiesFromEnv()
+
 	// Set Gin to release mode
 	if os.Getenv("GIN_MODE") == "release" {
 		gin.SetMode(gin.ReleaseMode)
@@ -134,10 +156,13 @@ func main() {
 		battle.CloseWarriorClient()
 		battle.CloseCoinClient()
 		battle.CloseBattlespellClient()
+		battle.CloseSpectatorHub()
 		battle.CloseRedisClient()
 		battle.CloseWeaponClient()
 		battle.CloseArmorClient()
 		battle.CloseHealClient()
+		battle.CloseDragonClient()
+		battle.CloseEnemyClient()
 	}()
 
 	// Start gRPC server in a goroutine
@@ -161,6 +186,27 @@ func main() {
 		}
 	}()
 
+	// Start the turn deadline sweeper (safe to run on every replica)
+	sweepCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+	if battle.SQLDB.Enabled {
+		interval := 5 * time.Second
+		if v, err := strconv.Atoi(os.Getenv("BATTLE_SWEEP_INTERVAL_SECONDS")); err == nil && v > 0 {
+			interval = time.Duration(v) * time.Second
+		}
+		go service.RunTurnSweeper(sweepCtx, interval)
+		log.Printf("Battle turn sweeper running every %s", interval)
+	}
+
+	// Feed the analytics read model from battle events
+	if battle.SQLDB.Enabled {
+		go func() {
+			if err := battle.StartAnalyticsConsumer(); err != nil {
+				log.Printf("Warning: Failed to start battle analytics consumer: %v", err)
+			}
+		}()
+	}
+
 	// Create Gin router
 	r := gin.Default()
 
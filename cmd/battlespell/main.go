@@ -34,13 +34,18 @@ func main() {
 		log.Fatalf("Failed to connect to Battle gRPC: %v", err)
 	}
 
+	// Redis carries spell casts to battle spectators; casting works without it
+	if err := battlespell.InitRedisClient(); err != nil {
+		log.Printf("Warning: Failed to connect to Redis (spectators will not see spell casts): %v", err)
+	}
+
 	// Set Gin to release mode
 	if os.Getenv("GIN_MODE") == "release" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	// Initialize service, handler, and gRPC server using Wire
-	service, handler, grpcServer, err := InitializeApp()
+	_, handler, grpcServer, err := InitializeApp()
 	if err != nil {
 		log.Fatalf("Failed to initialize app with Wire: %v", err)
 	}
@@ -52,6 +57,8 @@ func main() {
 	defer func() {
 		log.Println("Shutting down...")
 		battlespell.CloseBattleClient()
+		battlespell.CloseKafkaPublisher()
+		battlespell.CloseRedisClient()
 	}()
 
 	// Start gRPC server in a goroutine
@@ -0,0 +1,16 @@
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"network-sec-micro/internal/battlespell"
+)
+
+// InitializeApp is a manual initializer; wire cannot generate an injector with four return values
+func InitializeApp() (*battlespell.Service, *battlespell.Handler, *battlespell.BattleSpellServiceServer, error) {
+	service := battlespell.NewService()
+	handler := battlespell.NewHandler(service)
+	grpcServer := battlespell.NewBattleSpellServiceServer(service)
+	return service, handler, grpcServer, nil
+}
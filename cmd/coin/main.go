@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	pb "network-sec-micro/api/proto/coin"
 	"network-sec-micro/internal/coin"
@@ -29,7 +32,7 @@ func main() {
 	consumer, err := kafkaLib.NewConsumer(
 		kafkaBrokers,
 		"coin-service-group",
-		[]string{kafkaLib.TopicWeaponPurchase, kafkaLib.TopicArenaMatchCompleted, kafkaLib.TopicBattleWagerResolved},
+		[]string{kafkaLib.TopicArenaMatchCompleted, kafkaLib.TopicBattleWagerResolved},
 		coin.ProcessKafkaMessage,
 	)
 	// Init Warrior gRPC client for event-driven coin awards
@@ -50,6 +53,22 @@ func main() {
 	service := coin.NewService()
 	grpcServer := coin.NewCoinServiceServer(service)
 
+	// Check the ledger against the stored balances in the background
+	reconcileInterval := 10 * time.Minute
+	if v, err := strconv.Atoi(secrets.GetOrDefault("LEDGER_RECONCILE_INTERVAL_SECONDS", "")); err == nil && v > 0 {
+		reconcileInterval = time.Duration(v) * time.Second
+	}
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	service.StartLedgerReconciler(jobsCtx, reconcileInterval)
+
+	// Mark coin holds that were neither captured nor released in time as expired
+	holdExpiryInterval := time.Minute
+	if v, err := strconv.Atoi(secrets.GetOrDefault("HOLD_EXPIRY_INTERVAL_SECONDS", "")); err == nil && v > 0 {
+		holdExpiryInterval = time.Duration(v) * time.Second
+	}
+	service.StartHoldExpirer(jobsCtx, holdExpiryInterval)
+
 	// TODO: Wire integration when wire issue is resolved
 	// service, grpcServer, err := InitializeCoinApp()
 	// if err != nil {
@@ -48,6 +48,11 @@ func main() {
 		log.Fatalf("Failed to connect to Warrior gRPC: %v", err)
 	}
 
+	// Initialize Weapon gRPC client (weapon elements); attacks fall back to physical damage without it
+	if err := dragon.InitWeaponClient(""); err != nil {
+		log.Printf("Warning: Failed to connect to Weapon gRPC: %v", err)
+	}
+
 	// Set Gin to release mode
 	if os.Getenv("GIN_MODE") == "release" {
 		gin.SetMode(gin.ReleaseMode)
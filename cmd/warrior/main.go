@@ -24,6 +24,7 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"network-sec-micro/internal/warrior"
     kafkaLib "network-sec-micro/pkg/kafka"
@@ -39,12 +40,24 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 
-    // Initialize Kafka consumer for achievements
+    warrior.SetLevelCurve(warrior.LevelCurveFromEnv())
+    defer warrior.CloseKafkaPublisher()
+
+    // Achievement rules file, re-read on change so rules can be edited without a deploy
+    if path := os.Getenv("WARRIOR_ACHIEVEMENTS_FILE"); path != "" {
+        stopWatching := warrior.WatchAchievementRules(path, 30*time.Second)
+        defer stopWatching()
+    }
+
+    // Initialize Kafka consumer for achievements and experience
     brokers := getEnvSlice("KAFKA_BROKERS", "localhost:9092")
     consumer, err := kafkaLib.NewConsumer(
         brokers,
         "warrior-service-group",
-        []string{kafkaLib.TopicDragonDeath, kafkaLib.TopicEnemyDestroyed},
+        []string{
+            kafkaLib.TopicDragonDeath, kafkaLib.TopicEnemyDestroyed, kafkaLib.TopicBattleReward, kafkaLib.TopicBattleCompleted,
+            kafkaLib.TopicArenaMatchCompleted, kafkaLib.TopicWeaponPurchase, kafkaLib.TopicArmorPurchase,
+        },
         warrior.ProcessKafkaMessage,
     )
     if err != nil {
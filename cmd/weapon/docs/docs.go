@@ -41,7 +41,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api",
 	Schemes:          []string{"http", "https"},
 	Title:            "Weapon Service API",
-	Description:      "Weapon service handles weapon creation, purchase, and management. Warriors can buy weapons which trigger Kafka events for coin deduction.",
+	Description:      "Weapon service handles weapon creation, purchase, and management. Warriors pay for weapons through coin holds captured once the weapon is theirs.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -1,6 +1,6 @@
 // @title Weapon Service API
 // @version 1.0
-// @description Weapon service handles weapon creation, purchase, and management. Warriors can buy weapons which trigger Kafka events for coin deduction.
+// @description Weapon service handles weapon creation, purchase, and management. Warriors pay for weapons through coin holds captured once the weapon is theirs.
 // @termsOfService http://swagger.io/terms/
 
 // @contact.name API Support
@@ -22,8 +22,11 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"strconv"
+	"time"
     "net"
     "sync"
 
@@ -42,14 +45,29 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 
+	// Initialize Coin gRPC client
+	if err := weapon.InitCoinClient(os.Getenv("COIN_GRPC_ADDR")); err != nil {
+		log.Fatalf("Failed to connect to Coin gRPC: %v", err)
+	}
+
 	// Initialize service and handler
 	service := weapon.NewService()
 	handler := weapon.NewHandler(service)
 
+	// Finish or roll back purchases left unfinished, e.g. by a crash
+	recoveryInterval := time.Minute
+	if v, err := strconv.Atoi(os.Getenv("PURCHASE_RECOVERY_INTERVAL_SECONDS")); err == nil && v > 0 {
+		recoveryInterval = time.Duration(v) * time.Second
+	}
+	recoveryCtx, stopRecovery := context.WithCancel(context.Background())
+	service.StartPurchaseRecovery(recoveryCtx, recoveryInterval)
+
 	// Setup graceful shutdown
 	defer func() {
 		log.Println("Shutting down...")
+		stopRecovery()
 		weapon.CloseKafkaPublisher()
+		weapon.CloseCoinClient()
 	}()
 
 	// Set Gin to release mode
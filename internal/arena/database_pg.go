@@ -23,7 +23,7 @@ func InitPostgres() error {
         return err
     }
     // AutoMigrate relational models
-    if err := pkgdb.AutoMigrate(db, &ArenaInvitationSQL{}, &ArenaMatchSQL{}); err != nil {
+    if err := pkgdb.AutoMigrate(db, &ArenaInvitationSQL{}, &ArenaMatchSQL{}, &ArenaSeasonSQL{}, &ArenaRatingSQL{}, &ArenaRatingChangeSQL{}, &ArenaSeasonRewardSQL{}, &ArenaTournamentSQL{}, &ArenaTournamentEntrySQL{}, &ArenaTournamentMatchSQL{}); err != nil {
         return err
     }
     SQLDB.Enabled = true
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // SendInvitationCommand represents a command to send an arena invitation
 type SendInvitationCommand struct {
 	ChallengerID   uint   `json:"challenger_id"`
@@ -26,9 +28,35 @@ type CancelInvitationCommand struct {
 	ChallengerID uint   `json:"challenger_id"`
 }
 
+// JoinQueueCommand represents a command to enter the matchmaking queue
+type JoinQueueCommand struct {
+	WarriorID   uint   `json:"warrior_id"`
+	WarriorName string `json:"warrior_name"`
+}
+
 // AttackInArenaCommand represents a command to perform an attack in an arena match
 type AttackInArenaCommand struct {
 	MatchID     string `json:"match_id"`
 	AttackerID  uint   `json:"attacker_id"`
 }
 
+// CreateTournamentCommand represents a command to organise an arena tournament
+type CreateTournamentCommand struct {
+	OrganizerID         uint      `json:"organizer_id"`
+	OrganizerName       string    `json:"organizer_name"`
+	Name                string    `json:"name"`
+	Format              string    `json:"format"`
+	EntryFee            int       `json:"entry_fee"`
+	MinPlayers          int       `json:"min_players"`
+	MaxPlayers          int       `json:"max_players"`
+	StartsAt            time.Time `json:"starts_at"`
+	SwissRounds         int       `json:"swiss_rounds"`
+	ForfeitAfterMinutes int       `json:"forfeit_after_minutes"`
+}
+
+// TournamentRegistrationCommand represents a command to enter or withdraw from a tournament
+type TournamentRegistrationCommand struct {
+	TournamentID string `json:"tournament_id"`
+	WarriorID    uint   `json:"warrior_id"`
+	WarriorName  string `json:"warrior_name"`
+}
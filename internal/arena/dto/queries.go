@@ -17,3 +17,24 @@ type GetMyMatchesQuery struct {
 	Status string `json:"status,omitempty"` // pending, in_progress, completed, cancelled
 }
 
+
+// GetLeaderboardQuery represents a query for a page of the ranked ladder
+type GetLeaderboardQuery struct {
+	Season int `json:"season,omitempty"` // 0 for the current season
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// GetRatingHistoryQuery represents a query for a warrior's rating changes
+type GetRatingHistoryQuery struct {
+	WarriorID uint `json:"warrior_id"`
+	Limit     int  `json:"limit"`
+	Offset    int  `json:"offset"`
+}
+
+// ListTournamentsQuery represents a query for a page of tournaments
+type ListTournamentsQuery struct {
+	Status string `json:"status,omitempty"` // registration, in_progress, completed, cancelled
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
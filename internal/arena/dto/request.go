@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // SendInvitationRequest represents a request to send an arena invitation
 type SendInvitationRequest struct {
 	OpponentName string `json:"opponent_name" binding:"required"`
@@ -31,3 +33,15 @@ type ApplyArenaSpellRequest struct {
     CasterID  uint   `json:"caster_id" binding:"required"`
     SpellType string `json:"spell_type" binding:"required"`
 }
+
+// CreateTournamentRequest represents a request to organise an arena tournament
+type CreateTournamentRequest struct {
+	Name                string    `json:"name" binding:"required"`
+	Format              string    `json:"format" binding:"required"` // single_elimination, double_elimination or swiss
+	EntryFee            int       `json:"entry_fee"`
+	MinPlayers          int       `json:"min_players"`
+	MaxPlayers          int       `json:"max_players"`
+	StartsAt            time.Time `json:"starts_at" binding:"required"`
+	SwissRounds         int       `json:"swiss_rounds,omitempty"`          // Defaults to enough rounds for a clear winner
+	ForfeitAfterMinutes int       `json:"forfeit_after_minutes,omitempty"` // Defaults to 15
+}
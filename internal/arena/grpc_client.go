@@ -2,6 +2,7 @@ package arena
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -10,6 +11,7 @@ import (
     pbArenaSpell "network-sec-micro/api/proto/arenaspell"
     pbWeapon "network-sec-micro/api/proto/weapon"
     pbArmor "network-sec-micro/api/proto/armor"
+    pbCoin "network-sec-micro/api/proto/coin"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -23,6 +25,8 @@ var weaponGrpcClient pbWeapon.WeaponServiceClient
 var weaponGrpcConn *grpc.ClientConn
 var armorGrpcClient pbArmor.ArmorServiceClient
 var armorGrpcConn *grpc.ClientConn
+var coinGrpcClient pbCoin.CoinServiceClient
+var coinGrpcConn *grpc.ClientConn
 
 // InitWarriorClient initializes the gRPC client connection to warrior service
 func InitWarriorClient(addr string) error {
@@ -119,7 +123,7 @@ func CastArenaSpellViaGRPC(ctx context.Context, matchID string, spellType string
         return 0, err
     }
     if !resp.Success {
-        return 0, fmt.Errorf(resp.Message)
+        return 0, errors.New(resp.Message)
     }
     return resp.AffectedCount, nil
 }
@@ -234,3 +238,66 @@ func GetWarriorByID(ctx context.Context, warriorID uint) (*pbWarrior.Warrior, er
 	return resp.Warrior, nil
 }
 
+// InitCoinClient initializes the gRPC client connection to coin service (season rewards, tournament fees and prizes)
+func InitCoinClient(addr string) error {
+    if addr == "" {
+        addr = os.Getenv("COIN_GRPC_ADDR")
+        if addr == "" {
+            addr = "localhost:50051"
+        }
+    }
+
+    conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+    if err != nil {
+        return fmt.Errorf("failed to connect to coin gRPC: %w", err)
+    }
+
+    coinGrpcClient = pbCoin.NewCoinServiceClient(conn)
+    coinGrpcConn = conn
+    log.Printf("Connected to Coin gRPC service at %s", addr)
+    return nil
+}
+
+func CloseCoinClient() {
+    if coinGrpcConn != nil { coinGrpcConn.Close() }
+}
+
+// DeductCoins deducts coins from warrior's balance via gRPC. Retrying with the same idempotency key never charges twice.
+func DeductCoins(ctx context.Context, warriorID uint, amount int64, reason, idempotencyKey string) error {
+    if coinGrpcClient == nil {
+        return fmt.Errorf("coin gRPC client not initialized")
+    }
+    resp, err := coinGrpcClient.DeductCoins(ctx, &pbCoin.DeductCoinsRequest{
+        WarriorId:      uint32(warriorID),
+        Amount:         amount,
+        Reason:         reason,
+        IdempotencyKey: idempotencyKey,
+    })
+    if err != nil {
+        return fmt.Errorf("failed to deduct coins: %w", err)
+    }
+    if !resp.Success {
+        return fmt.Errorf("failed to deduct coins: %s", resp.Message)
+    }
+    return nil
+}
+
+// AddCoins adds coins to warrior's balance via gRPC. Retrying with the same idempotency key never pays twice.
+func AddCoins(ctx context.Context, warriorID uint, amount int64, reason, idempotencyKey string) error {
+    if coinGrpcClient == nil {
+        return fmt.Errorf("coin gRPC client not initialized")
+    }
+    resp, err := coinGrpcClient.AddCoins(ctx, &pbCoin.AddCoinsRequest{
+        WarriorId:      uint32(warriorID),
+        Amount:         amount,
+        Reason:         reason,
+        IdempotencyKey: idempotencyKey,
+    })
+    if err != nil {
+        return fmt.Errorf("failed to add coins: %w", err)
+    }
+    if !resp.Success {
+        return fmt.Errorf("failed to add coins: %s", resp.Message)
+    }
+    return nil
+}
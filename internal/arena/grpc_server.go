@@ -52,8 +52,7 @@ func toProtoWarrior(warrior *pbWarrior.Warrior) *pb.Warrior {
 		Email:       warrior.Email,
 		Role:        warrior.Role,
 		TotalPower:  warrior.TotalPower,
-		AttackPower: warrior.AttackPower,
-		Defense:     warrior.Defense,
+		AttackPower: warrior.TotalPower,
 		CoinBalance: warrior.CoinBalance,
 		WeaponCount: warrior.WeaponCount,
 	}
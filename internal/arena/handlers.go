@@ -1,14 +1,17 @@
 package arena
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"network-sec-micro/internal/arena/dto"
+	"network-sec-micro/pkg/spectate"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // Handler handles HTTP requests for arena service
@@ -226,7 +229,7 @@ func (h *Handler) GetInvitation(c *gin.Context) {
 		return
 	}
 
-	if _, err := primitive.ObjectIDFromHex(invitationID); err != nil {
+	if _, err := strconv.ParseUint(invitationID, 10, 64); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error:   "validation_error",
 			Message: "invalid invitation ID format",
@@ -352,8 +355,7 @@ func (h *Handler) AttackInArena(c *gin.Context) {
 		return
 	}
 
-	matchID, err := primitive.ObjectIDFromHex(req.MatchID)
-	if err != nil {
+	if _, err := strconv.ParseUint(req.MatchID, 10, 64); err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error:   "validation_error",
 			Message: "invalid match ID format",
@@ -367,7 +369,7 @@ func (h *Handler) AttackInArena(c *gin.Context) {
 		AttackerID: 1, // TODO: Get from auth
 	}
 
-	match, err := h.Service.PerformAttack(c.Request.Context(), matchID, cmd.AttackerID)
+	match, err := h.Service.PerformAttack(c.Request.Context(), cmd.MatchID, cmd.AttackerID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error:   "attack_failed",
@@ -489,3 +491,651 @@ func (h *Handler) GetMatch(c *gin.Context) {
     })
 }
 
+// WatchMatch godoc
+// @Summary Watch an arena match live
+// @Description Streams a match as it happens: turns, spell casts and completion. Upgrades to WebSocket when asked to, otherwise sends server-sent events. The first event is a snapshot of the match and the stream ends with a completed event. Players watch their own matches, emperors any match and kings matches of their faction.
+// @Tags arena
+// @Produce json
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path string true "Match ID"
+// @Success 200 {object} map[string]interface{} "stream of events: type, timestamp, data"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /api/v1/arena/matches/{id}/watch [get]
+func (h *Handler) WatchMatch(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Message: err.Error()})
+		return
+	}
+
+	match, err := GetRepository().GetMatchByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "not_found", Message: "Match not found"})
+		return
+	}
+	if !canWatchMatch(c.Request.Context(), user, match) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "forbidden", Message: "You do not have permission to watch this match"})
+		return
+	}
+
+	hub := GetSpectatorHub()
+	if hub == nil {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "unavailable", Message: "live spectating is unavailable"})
+		return
+	}
+	sub, err := hub.Subscribe(spectate.ArenaMatchChannel(match.ID))
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "unavailable", Message: err.Error()})
+		return
+	}
+	defer sub.Close()
+
+	// Take the snapshot only once subscribed, so nothing happening in between is missed
+	if current, err := GetRepository().GetMatchByID(c.Request.Context(), match.ID); err == nil {
+		match = current
+	}
+	var initial []spectate.Event
+	if snapshot, err := spectate.NewEvent(spectate.EventSnapshot, match); err == nil {
+		initial = append(initial, snapshot)
+	}
+	if match.Status == MatchStatusCompleted || match.Status == MatchStatusCancelled {
+		if done, err := spectate.NewEvent(spectate.EventCompleted, match); err == nil {
+			initial = append(initial, done)
+		}
+	}
+
+	spectate.Serve(c.Writer, c.Request, sub.C, initial...)
+}
+
+// GetLeaderboard godoc
+// @Summary Get the ranked arena ladder
+// @Description Gets one page of a season's leaderboard, highest rating first. Defaults to the current season.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param season query int false "Season number (default: current)"
+// @Param limit query int false "Page size (default 50, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} map[string]interface{} "season: int, entries: []ArenaRating, total: int64"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/arena/leaderboard [get]
+func (h *Handler) GetLeaderboard(c *gin.Context) {
+	season, _ := strconv.Atoi(c.DefaultQuery("season", "0"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if season < 0 || limit <= 0 || limit > 100 || offset < 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: "season and offset must be non-negative and limit between 1 and 100",
+		})
+		return
+	}
+
+	entries, total, season, err := h.Service.GetLeaderboard(c.Request.Context(), dto.GetLeaderboardQuery{
+		Season: season,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":  season,
+		"entries": entries,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// GetCurrentSeason godoc
+// @Summary Get the current arena season
+// @Description Gets the ranked season in progress and when it ends.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "season: ArenaSeason"
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/arena/seasons/current [get]
+func (h *Handler) GetCurrentSeason(c *gin.Context) {
+	season, err := h.Service.CurrentSeason(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"season": season})
+}
+
+// GetMyRating godoc
+// @Summary Get my arena rating
+// @Description Gets the current user's rating and record in the current season.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "rating: ArenaRating"
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/arena/ratings/me [get]
+func (h *Handler) GetMyRating(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Message: err.Error()})
+		return
+	}
+
+	r, err := h.Service.GetMyRating(c.Request.Context(), user.UserID, user.Username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rating": r})
+}
+
+// GetRatingHistory godoc
+// @Summary Get a warrior's rating history
+// @Description Gets the rating change of every rated arena match of a warrior, newest first.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param warrior_id path int true "Warrior ID"
+// @Param limit query int false "Page size (default 50, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} map[string]interface{} "history: []ArenaRatingChange, total: int64"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/arena/ratings/{warrior_id}/history [get]
+func (h *Handler) GetRatingHistory(c *gin.Context) {
+	warriorID, err := strconv.ParseUint(c.Param("warrior_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: "invalid warrior ID",
+		})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 || offset < 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: "offset must be non-negative and limit between 1 and 100",
+		})
+		return
+	}
+
+	history, total, err := h.Service.GetRatingHistory(c.Request.Context(), dto.GetRatingHistoryQuery{
+		WarriorID: uint(warriorID),
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"history": history,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// JoinQueue godoc
+// @Summary Join the matchmaking queue
+// @Description Queues the current user for a ranked match against a warrior of similar rating and power. The allowed gap widens the longer the warrior waits. Poll GET /api/v1/arena/queue for the match.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} map[string]interface{} "ticket: QueueTicket"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/arena/queue [post]
+func (h *Handler) JoinQueue(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Message: err.Error()})
+		return
+	}
+
+	ticket, err := h.Service.JoinQueue(c.Request.Context(), dto.JoinQueueCommand{
+		WarriorID:   user.UserID,
+		WarriorName: user.Username,
+	})
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrAlreadyQueued) || errors.Is(err, ErrAlreadyInMatch) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error:   "queue_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"ticket":  ticket,
+		"message": "Searching for an opponent",
+	})
+}
+
+// LeaveQueue godoc
+// @Summary Leave the matchmaking queue
+// @Description Takes the current user out of the matchmaking queue. Fails once a match has been found.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/arena/queue [delete]
+func (h *Handler) LeaveQueue(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Message: err.Error()})
+		return
+	}
+
+	if err := h.Service.LeaveQueue(c.Request.Context(), user.UserID); err != nil {
+		status := http.StatusConflict
+		if errors.Is(err, ErrNotQueued) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error:   "leave_queue_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Left the matchmaking queue"})
+}
+
+// GetQueueStatus godoc
+// @Summary Get my matchmaking status
+// @Description Gets the current user's queue ticket: still searching with the current windows, or matched with the match ID.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "ticket: QueueTicket"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/arena/queue [get]
+func (h *Handler) GetQueueStatus(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Message: err.Error()})
+		return
+	}
+
+	ticket, err := h.Service.GetQueueStatus(c.Request.Context(), user.UserID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrNotQueued) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error:   "queue_status_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	resp := gin.H{"ticket": ticket}
+	if ticket.Status == QueueStatusSearching {
+		ratingWindow, powerWindow := matchmakingConfig.Windows(ticket, time.Now())
+		resp["wait_seconds"] = int(time.Since(ticket.JoinedAt).Seconds())
+		resp["rating_window"] = ratingWindow
+		resp["power_window_percent"] = powerWindow
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateTournament godoc
+// @Summary Create an arena tournament
+// @Description Opens a tournament for registration. Formats: single_elimination, double_elimination, swiss. Entry fees form the prize pool. Emperors and kings only.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateTournamentRequest true "Tournament data"
+// @Success 201 {object} map[string]interface{} "tournament: Tournament"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/arena/tournaments [post]
+func (h *Handler) CreateTournament(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Message: err.Error()})
+		return
+	}
+	if !CanOrganizeTournaments(user.Role) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "forbidden",
+			Message: "only emperors and kings can organise tournaments",
+		})
+		return
+	}
+
+	var req dto.CreateTournamentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	t, err := h.Service.CreateTournament(c.Request.Context(), dto.CreateTournamentCommand{
+		OrganizerID:         user.UserID,
+		OrganizerName:       user.Username,
+		Name:                req.Name,
+		Format:              req.Format,
+		EntryFee:            req.EntryFee,
+		MinPlayers:          req.MinPlayers,
+		MaxPlayers:          req.MaxPlayers,
+		StartsAt:            req.StartsAt,
+		SwissRounds:         req.SwissRounds,
+		ForfeitAfterMinutes: req.ForfeitAfterMinutes,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "tournament_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"tournament": t})
+}
+
+// ListTournaments godoc
+// @Summary List arena tournaments
+// @Description Lists tournaments, soonest starting first, optionally filtered by status.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "registration, in_progress, completed or cancelled"
+// @Param limit query int false "Page size (default 50, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} map[string]interface{} "tournaments: []Tournament, total: int64"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/arena/tournaments [get]
+func (h *Handler) ListTournaments(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 || offset < 0 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: "offset must be non-negative and limit between 1 and 100",
+		})
+		return
+	}
+
+	tournaments, total, err := h.Service.ListTournaments(c.Request.Context(), dto.ListTournamentsQuery{
+		Status: c.Query("status"),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tournaments": tournaments,
+		"total":       total,
+		"limit":       limit,
+		"offset":      offset,
+	})
+}
+
+// GetTournament godoc
+// @Summary Get an arena tournament
+// @Description Gets a tournament with its entries, their seeds, records and, once finished, places and prizes.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tournament ID"
+// @Success 200 {object} map[string]interface{} "tournament: Tournament, entries: []TournamentEntry"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/arena/tournaments/{id} [get]
+func (h *Handler) GetTournament(c *gin.Context) {
+	t, entries, err := h.Service.GetTournament(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tournament": t,
+		"entries":    entries,
+	})
+}
+
+// GetTournamentBracket godoc
+// @Summary Get a tournament bracket
+// @Description Gets every bracket slot of a tournament grouped by round, with the arena match played for it and its result.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tournament ID"
+// @Success 200 {object} map[string]interface{} "tournament: Tournament, rounds: []TournamentRound"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/arena/tournaments/{id}/bracket [get]
+func (h *Handler) GetTournamentBracket(c *gin.Context) {
+	t, _, err := h.Service.GetTournament(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	rounds, err := h.Service.GetTournamentBracket(c.Request.Context(), t.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tournament": t,
+		"rounds":     rounds,
+	})
+}
+
+// RegisterForTournament godoc
+// @Summary Register for an arena tournament
+// @Description Enters the current user into a tournament open for registration, paying its entry fee.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tournament ID"
+// @Success 201 {object} map[string]interface{} "entry: TournamentEntry"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/arena/tournaments/{id}/register [post]
+func (h *Handler) RegisterForTournament(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Message: err.Error()})
+		return
+	}
+
+	entry, err := h.Service.RegisterForTournament(c.Request.Context(), dto.TournamentRegistrationCommand{
+		TournamentID: c.Param("id"),
+		WarriorID:    user.UserID,
+		WarriorName:  user.Username,
+	})
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrAlreadyRegistered) || errors.Is(err, ErrTournamentFull) || errors.Is(err, ErrRegistrationClosed) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error:   "registration_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"entry": entry})
+}
+
+// WithdrawFromTournament godoc
+// @Summary Withdraw from an arena tournament
+// @Description Removes the current user from a tournament that has not started yet and refunds the entry fee.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tournament ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/arena/tournaments/{id}/register [delete]
+func (h *Handler) WithdrawFromTournament(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Message: err.Error()})
+		return
+	}
+
+	err = h.Service.WithdrawFromTournament(c.Request.Context(), dto.TournamentRegistrationCommand{
+		TournamentID: c.Param("id"),
+		WarriorID:    user.UserID,
+		WarriorName:  user.Username,
+	})
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrNotRegistered) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, dto.ErrorResponse{
+			Error:   "withdraw_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Withdrawn from the tournament"})
+}
+
+// StartTournament godoc
+// @Summary Start an arena tournament early
+// @Description Closes registration and creates the first round now instead of at the scheduled start. Organiser only.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tournament ID"
+// @Success 200 {object} map[string]interface{} "tournament: Tournament"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/arena/tournaments/{id}/start [post]
+func (h *Handler) StartTournament(c *gin.Context) {
+	h.organizerAction(c, h.Service.StartTournament)
+}
+
+// CancelTournament godoc
+// @Summary Cancel an arena tournament
+// @Description Calls off a tournament that has not started and refunds every entry fee. Organiser only.
+// @Tags arena
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tournament ID"
+// @Success 200 {object} map[string]interface{} "tournament: Tournament"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/arena/tournaments/{id}/cancel [post]
+func (h *Handler) CancelTournament(c *gin.Context) {
+	h.organizerAction(c, h.Service.CancelTournament)
+}
+
+// organizerAction runs a tournament action after checking the current user organised the tournament
+func (h *Handler) organizerAction(c *gin.Context, action func(ctx context.Context, id string) (*Tournament, error)) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Message: err.Error()})
+		return
+	}
+	t, _, err := h.Service.GetTournament(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: err.Error(),
+		})
+		return
+	}
+	if t.CreatedByID != user.UserID {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "forbidden",
+			Message: "only the organiser can do this",
+		})
+		return
+	}
+
+	t, err = action(c.Request.Context(), t.ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "tournament_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tournament": t})
+}
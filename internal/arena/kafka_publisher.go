@@ -172,6 +172,40 @@ func PublishMatchCompleted(matchID string, player1ID uint, player1Name string, p
 	return nil
 }
 
+// PublishMatchFound publishes arena match found event for a matchmaking pairing
+func PublishMatchFound(matchID string, player1ID uint, player1Name string, player1Rating int, player2ID uint, player2Name string, player2Rating int, waitSeconds int) error {
+	publisher := GetKafkaPublisher()
+	if publisher == nil {
+		return fmt.Errorf("kafka publisher not initialized")
+	}
+
+	event := kafka.NewArenaMatchFoundEvent(matchID, player1ID, player1Name, player1Rating, player2ID, player2Name, player2Rating, waitSeconds)
+	topic := kafka.TopicArenaMatchFound
+	if err := publisher.Publish(topic, event); err != nil {
+		return fmt.Errorf("failed to publish match found event: %w", err)
+	}
+
+	log.Printf("Published arena match found event: %s (%d) vs %s (%d)", player1Name, player1Rating, player2Name, player2Rating)
+	return nil
+}
+
+// PublishTournamentStage publishes an arena tournament stage change
+func PublishTournamentStage(t *Tournament, stage string) error {
+	publisher := GetKafkaPublisher()
+	if publisher == nil {
+		return fmt.Errorf("kafka publisher not initialized")
+	}
+
+	event := kafka.NewArenaTournamentStageEvent(t.ID, t.Name, t.Format, stage, t.CurrentRound, t.Players, t.PrizePool, t.WinnerID, t.WinnerName)
+	topic := kafka.TopicArenaTournamentStage
+	if err := publisher.Publish(topic, event); err != nil {
+		return fmt.Errorf("failed to publish tournament stage event: %w", err)
+	}
+
+	log.Printf("Published arena tournament event: %s %s (round %d)", t.Name, stage, t.CurrentRound)
+	return nil
+}
+
 // PublishSpellWindowOpened publishes event when a player's HP first falls to <=50%
 func PublishSpellWindowOpened(matchID string, playerID uint, playerName string, hpPercent float64) error {
     publisher := GetKafkaPublisher()
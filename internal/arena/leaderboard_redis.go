@@ -0,0 +1,62 @@
+package arena
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strconv"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// The leaderboard of a season is a sorted set of warrior IDs scored by rating,
+// with a hash alongside holding the warriors' names.
+func leaderboardKey(season int) string      { return fmt.Sprintf("arena:leaderboard:%d", season) }
+func leaderboardNamesKey(season int) string { return fmt.Sprintf("arena:leaderboard:%d:names", season) }
+
+// updateLeaderboard sets the warriors' scores on the season leaderboard
+func updateLeaderboard(ctx context.Context, ratings []*ArenaRating) error {
+    rc := getRedis()
+    if rc == nil { return errors.New("redis not initialized") }
+    pipe := rc.TxPipeline()
+    for _, r := range ratings {
+        member := strconv.FormatUint(uint64(r.WarriorID), 10)
+        pipe.ZAdd(ctx, leaderboardKey(r.Season), redis.Z{Score: float64(r.Rating), Member: member})
+        pipe.HSet(ctx, leaderboardNamesKey(r.Season), member, r.WarriorName)
+    }
+    _, err := pipe.Exec(ctx)
+    return err
+}
+
+// readLeaderboard returns one page of the season leaderboard, highest rating first, and its size.
+// Only rank, warrior, name and rating are filled in.
+func readLeaderboard(ctx context.Context, season int, limit, offset int) ([]*ArenaRating, int64, error) {
+    rc := getRedis()
+    if rc == nil { return nil, 0, errors.New("redis not initialized") }
+    total, err := rc.ZCard(ctx, leaderboardKey(season)).Result()
+    if err != nil { return nil, 0, err }
+    entries, err := rc.ZRevRangeWithScores(ctx, leaderboardKey(season), int64(offset), int64(offset+limit-1)).Result()
+    if err != nil { return nil, 0, err }
+    if len(entries) == 0 { return []*ArenaRating{}, total, nil }
+
+    members := make([]string, len(entries))
+    for i, e := range entries { members[i], _ = e.Member.(string) }
+    names, err := rc.HMGet(ctx, leaderboardNamesKey(season), members...).Result()
+    if err != nil { return nil, 0, err }
+
+    out := make([]*ArenaRating, len(entries))
+    for i, e := range entries {
+        id, _ := strconv.ParseUint(members[i], 10, 32)
+        name, _ := names[i].(string)
+        out[i] = &ArenaRating{Season: season, WarriorID: uint(id), WarriorName: name, Rating: int(e.Score), Rank: offset + i + 1}
+    }
+    return out, total, nil
+}
+
+// rebuildLeaderboard repopulates a season's sorted set from SQL, e.g. after Redis lost it
+func rebuildLeaderboard(ctx context.Context, season int) error {
+    ratings, _, err := GetRepository().ListRatings(ctx, season, 0, 0)
+    if err != nil { return err }
+    if len(ratings) == 0 { return nil }
+    return updateLeaderboard(ctx, ratings)
+}
@@ -0,0 +1,333 @@
+package arena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"network-sec-micro/internal/arena/dto"
+	"network-sec-micro/pkg/rating"
+)
+
+// MatchmakingConfig controls how far apart two queued warriors may be to be paired.
+// Both windows start narrow and widen every WidenEvery a warrior spends waiting, up to their caps.
+type MatchmakingConfig struct {
+	RatingWindow       int // Initial allowed rating difference
+	RatingWindowGrowth int
+	MaxRatingWindow    int
+	PowerWindow        int // Initial allowed power difference, percent of the stronger warrior's power
+	PowerWindowGrowth  int
+	MaxPowerWindow     int
+	WidenEvery         time.Duration
+}
+
+// DefaultMatchmakingConfig is used unless overridden with SetMatchmakingConfig
+var DefaultMatchmakingConfig = MatchmakingConfig{
+	RatingWindow:       100,
+	RatingWindowGrowth: 50,
+	MaxRatingWindow:    600,
+	PowerWindow:        10,
+	PowerWindowGrowth:  5,
+	MaxPowerWindow:     100,
+	WidenEvery:         10 * time.Second,
+}
+
+var matchmakingConfig = DefaultMatchmakingConfig
+
+// SetMatchmakingConfig replaces the active matchmaking configuration
+func SetMatchmakingConfig(c MatchmakingConfig) {
+	if c.WidenEvery <= 0 {
+		c.WidenEvery = DefaultMatchmakingConfig.WidenEvery
+	}
+	matchmakingConfig = c
+}
+
+// Windows returns the rating and power windows of a ticket that has waited since JoinedAt
+func (c MatchmakingConfig) Windows(t *QueueTicket, now time.Time) (ratingWindow, powerWindow int) {
+	steps := int(now.Sub(t.JoinedAt) / c.WidenEvery)
+	if steps < 0 {
+		steps = 0
+	}
+	ratingWindow = min(c.RatingWindow+steps*c.RatingWindowGrowth, c.MaxRatingWindow)
+	powerWindow = min(c.PowerWindow+steps*c.PowerWindowGrowth, c.MaxPowerWindow)
+	return ratingWindow, powerWindow
+}
+
+// Compatible checks if two tickets fall within each other's windows
+func (c MatchmakingConfig) Compatible(a, b *QueueTicket, now time.Time) bool {
+	ratingA, powerA := c.Windows(a, now)
+	ratingB, powerB := c.Windows(b, now)
+
+	if abs(a.Rating-b.Rating) > min(ratingA, ratingB) {
+		return false
+	}
+	stronger := max(a.Power, b.Power, 1)
+	return abs(a.Power-b.Power)*100 <= min(powerA, powerB)*stronger
+}
+
+// PairTickets pairs waiting tickets, longest waiting first, each with the closest rated compatible ticket.
+// Tickets still healing at now are left out, and a warrior listed twice is never paired with themselves.
+func (c MatchmakingConfig) PairTickets(tickets []*QueueTicket, now time.Time) [][2]*QueueTicket {
+	var pairs [][2]*QueueTicket
+	taken := make([]bool, len(tickets))
+	for i, a := range tickets {
+		if taken[i] || a.isHealing(now) {
+			continue
+		}
+		best := -1
+		for j := i + 1; j < len(tickets); j++ {
+			b := tickets[j]
+			if taken[j] || b.WarriorID == a.WarriorID || b.isHealing(now) || !c.Compatible(a, b, now) {
+				continue
+			}
+			if best < 0 || abs(a.Rating-b.Rating) < abs(a.Rating-tickets[best].Rating) {
+				best = j
+			}
+		}
+		if best >= 0 {
+			taken[i], taken[best] = true, true
+			pairs = append(pairs, [2]*QueueTicket{a, tickets[best]})
+		}
+	}
+	return pairs
+}
+
+func (t *QueueTicket) isHealing(now time.Time) bool {
+	return t.HealingUntil != nil && now.Before(*t.HealingUntil)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// ==================== QUEUE ====================
+
+// JoinQueue puts a warrior in the matchmaking queue with their current rating and power
+func (s *Service) JoinQueue(ctx context.Context, cmd dto.JoinQueueCommand) (*QueueTicket, error) {
+	if getRedis() == nil {
+		return nil, errors.New("matchmaking is unavailable")
+	}
+
+	warrior, err := GetWarriorByID(ctx, cmd.WarriorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warrior: %w", err)
+	}
+	if remaining := healingRemaining(warrior); remaining > 0 {
+		return nil, fmt.Errorf("warrior is currently healing. Cannot join the queue. Remaining time: %.0f seconds", remaining.Seconds())
+	}
+
+	// Matches and ratings live in SQL; without it there are no matches and everyone queues at the starting rating
+	warriorRating := rating.DefaultRating
+	if SQLDB.Enabled {
+		busy, err := GetRepository().HasOpenMatch(ctx, cmd.WarriorID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check open matches: %w", err)
+		}
+		if busy {
+			return nil, ErrAlreadyInMatch
+		}
+
+		r, err := s.GetMyRating(ctx, cmd.WarriorID, cmd.WarriorName)
+		if err != nil {
+			return nil, err
+		}
+		warriorRating = r.Rating
+	}
+
+	ticket := &QueueTicket{
+		WarriorID:   cmd.WarriorID,
+		WarriorName: cmd.WarriorName,
+		Rating:      warriorRating,
+		Power:       int(warrior.TotalPower),
+		Status:      QueueStatusSearching,
+		JoinedAt:    time.Now(),
+	}
+	if err := enqueueTicket(ctx, ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// LeaveQueue takes a warrior out of the matchmaking queue
+func (s *Service) LeaveQueue(ctx context.Context, warriorID uint) error {
+	if getRedis() == nil {
+		return errors.New("matchmaking is unavailable")
+	}
+
+	left, err := dequeueTicket(ctx, warriorID)
+	if err != nil {
+		return err
+	}
+	if left {
+		return nil
+	}
+
+	// Not waiting: either never queued, already matched, or claimed by a matching pass right now
+	ticket, err := loadTicket(ctx, warriorID)
+	if err != nil {
+		return err
+	}
+	if ticket.Status == QueueStatusMatched {
+		return fmt.Errorf("warrior was already matched into match %s", ticket.MatchID)
+	}
+	return ErrQueueMatching
+}
+
+// GetQueueStatus returns the warrior's ticket; a matched ticket stays readable for a while so the match can be picked up
+func (s *Service) GetQueueStatus(ctx context.Context, warriorID uint) (*QueueTicket, error) {
+	if getRedis() == nil {
+		return nil, errors.New("matchmaking is unavailable")
+	}
+	return loadTicket(ctx, warriorID)
+}
+
+// leaveQueueForMatch takes warriors just put into an invitation or tournament match out of the queue.
+// A ticket claimed by a matching pass at the same moment is dropped by that pass's open match check.
+func leaveQueueForMatch(ctx context.Context, warriorIDs ...uint) {
+	if getRedis() == nil {
+		return
+	}
+	for _, id := range warriorIDs {
+		if _, err := dequeueTicket(ctx, id); err != nil {
+			log.Printf("Failed to take warrior %d out of the matchmaking queue: %v", id, err)
+		}
+	}
+}
+
+// ==================== MATCHER ====================
+
+// StartMatchmaker runs a matching pass every interval until ctx is done.
+// A Redis lock keeps passes on several arena instances from pairing the same warriors.
+func (s *Service) StartMatchmaker(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.runMatchmaking(ctx, interval)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *Service) runMatchmaking(ctx context.Context, interval time.Duration) {
+	token, err := acquireMatcherLock(ctx, 2*interval+5*time.Second)
+	if err != nil || token == "" {
+		return
+	}
+	defer releaseMatcherLock(ctx, token)
+
+	tickets, err := listQueuedTickets(ctx)
+	if err != nil {
+		log.Printf("Failed to read matchmaking queue: %v", err)
+		return
+	}
+	now := time.Now()
+	for _, pair := range matchmakingConfig.PairTickets(tickets, now) {
+		if err := s.startQueuedMatch(ctx, pair[0], pair[1], now); err != nil {
+			log.Printf("Failed to start queued match %s vs %s: %v", pair[0].WarriorName, pair[1].WarriorName, err)
+		}
+	}
+}
+
+// startQueuedMatch creates the match for a pair, the longer waiting warrior moving first.
+// Warriors found healing are put back in the queue and skipped until they have recovered.
+// A warrior who entered an invitation or tournament match while queued loses their ticket
+// and the opponent goes back to the queue.
+func (s *Service) startQueuedMatch(ctx context.Context, a, b *QueueTicket, now time.Time) error {
+	claimed, err := claimTickets(ctx, a, b)
+	if err != nil || !claimed {
+		return err
+	}
+
+	requeue := func() {
+		_ = requeueTicket(ctx, a)
+		_ = requeueTicket(ctx, b)
+	}
+
+	if SQLDB.Enabled {
+		var busy [2]bool
+		for i, t := range []*QueueTicket{a, b} {
+			if busy[i], err = GetRepository().HasOpenMatch(ctx, t.WarriorID); err != nil {
+				requeue()
+				return fmt.Errorf("failed to check open matches: %w", err)
+			}
+		}
+		if busy[0] || busy[1] {
+			for i, t := range []*QueueTicket{a, b} {
+				if busy[i] {
+					_ = deleteTicket(ctx, t.WarriorID)
+				} else {
+					_ = requeueTicket(ctx, t)
+				}
+			}
+			return nil
+		}
+	}
+
+	player1, err := GetWarriorByID(ctx, a.WarriorID)
+	if err != nil {
+		requeue()
+		return fmt.Errorf("failed to get warrior: %w", err)
+	}
+	player2, err := GetWarriorByID(ctx, b.WarriorID)
+	if err != nil {
+		requeue()
+		return fmt.Errorf("failed to get warrior: %w", err)
+	}
+
+	healing := false
+	for _, p := range []struct {
+		ticket    *QueueTicket
+		remaining time.Duration
+	}{{a, healingRemaining(player1)}, {b, healingRemaining(player2)}} {
+		if p.remaining > 0 {
+			until := now.Add(p.remaining)
+			p.ticket.HealingUntil = &until
+			healing = true
+		}
+	}
+	if healing {
+		requeue()
+		return nil
+	}
+
+	match := newArenaMatch(ctx, player1, player2, now)
+	matchID, err := GetRepository().CreateMatch(ctx, match)
+	if err != nil {
+		requeue()
+		return fmt.Errorf("failed to create match: %w", err)
+	}
+	match.ID = matchID
+
+	for _, m := range []struct{ ticket, opponent *QueueTicket }{{a, b}, {b, a}} {
+		m.ticket.Status = QueueStatusMatched
+		m.ticket.MatchID = matchID
+		m.ticket.OpponentID = m.opponent.WarriorID
+		m.ticket.OpponentName = m.opponent.WarriorName
+		m.ticket.MatchedAt = &now
+		m.ticket.HealingUntil = nil
+		if err := saveTicket(ctx, m.ticket, matchedTicketTTL); err != nil {
+			log.Printf("Failed to save matched ticket of %s: %v", m.ticket.WarriorName, err)
+		}
+	}
+
+	waited := int(now.Sub(a.JoinedAt).Seconds())
+	if err := PublishMatchFound(matchID, a.WarriorID, a.WarriorName, a.Rating, b.WarriorID, b.WarriorName, b.Rating, waited); err != nil {
+		log.Printf("Failed to publish match found event: %v", err)
+	}
+	if err := PublishMatchStarted(matchID, match.Player1ID, match.Player1Name, match.Player2ID, match.Player2Name, matchID); err != nil {
+		log.Printf("Failed to publish match started event: %v", err)
+	}
+
+	log.Printf("Arena queue match found: %s (%d) vs %s (%d), match: %s", a.WarriorName, a.Rating, b.WarriorName, b.Rating, matchID)
+	return nil
+}
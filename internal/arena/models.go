@@ -110,3 +110,197 @@ type ArenaMatch struct {
 // legacy: kept for reference; no longer used with Postgres
 func (ArenaMatch) CollectionName() string { return "arena_matches" }
 
+
+// ArenaSeason is a ranked period; ratings are kept per season and soft reset when a new one starts
+type ArenaSeason struct {
+    Number    int        `json:"number"`
+    StartedAt time.Time  `json:"started_at"`
+    EndsAt    time.Time  `json:"ends_at"`
+    EndedAt   *time.Time `json:"ended_at,omitempty"` // Set once the season is closed and rewards recorded
+}
+
+// IsOver checks if the season's scheduled end has passed
+func (s *ArenaSeason) IsOver() bool {
+    return time.Now().After(s.EndsAt)
+}
+
+// ArenaRating is a warrior's rating and record in one season
+type ArenaRating struct {
+    Season      int       `json:"season"`
+    WarriorID   uint      `json:"warrior_id"`
+    WarriorName string    `json:"warrior_name"`
+    Rating      int       `json:"rating"`
+    PeakRating  int       `json:"peak_rating"`
+    Games       int       `json:"games"`
+    Wins        int       `json:"wins"`
+    Losses      int       `json:"losses"`
+    Draws       int       `json:"draws"`
+    Rank        int       `json:"rank,omitempty"` // Leaderboard position, filled on reads
+    UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ArenaRatingChange records how one match moved a warrior's rating
+type ArenaRatingChange struct {
+    Season       int       `json:"season"`
+    MatchID      string    `json:"match_id"`
+    WarriorID    uint      `json:"warrior_id"`
+    OpponentID   uint      `json:"opponent_id"`
+    OpponentName string    `json:"opponent_name"`
+    Result       string    `json:"result"` // win, loss or draw
+    RatingBefore int       `json:"rating_before"`
+    RatingAfter  int       `json:"rating_after"`
+    Delta        int       `json:"delta"`
+    CreatedAt    time.Time `json:"created_at"`
+}
+
+// ArenaSeasonReward is the coin reward of a warrior's final standing in a season
+type ArenaSeasonReward struct {
+    Season      int       `json:"season"`
+    WarriorID   uint      `json:"warrior_id"`
+    WarriorName string    `json:"warrior_name"`
+    Rank        int       `json:"rank"`
+    Rating      int       `json:"rating"`
+    Coins       int       `json:"coins"`
+    Paid        bool      `json:"paid"` // Coins credited through the coin service
+    CreatedAt   time.Time `json:"created_at"`
+}
+
+// QueueTicketStatus represents where a warrior stands in the matchmaking queue
+type QueueTicketStatus string
+
+const (
+	QueueStatusSearching QueueTicketStatus = "searching" // Waiting for an opponent
+	QueueStatusMatched   QueueTicketStatus = "matched"   // Paired; MatchID is set
+)
+
+// QueueTicket is a warrior's place in the matchmaking queue
+type QueueTicket struct {
+    WarriorID    uint              `json:"warrior_id"`
+    WarriorName  string            `json:"warrior_name"`
+    Rating       int               `json:"rating"`
+    Power        int               `json:"power"`
+    Status       QueueTicketStatus `json:"status"`
+    HealingUntil *time.Time        `json:"healing_until,omitempty"` // Not paired before this
+    JoinedAt     time.Time         `json:"joined_at"`
+
+	// Set once paired
+    MatchID      string     `json:"match_id,omitempty"`
+    OpponentID   uint       `json:"opponent_id,omitempty"`
+    OpponentName string     `json:"opponent_name,omitempty"`
+    MatchedAt    *time.Time `json:"matched_at,omitempty"`
+}
+
+// TournamentStatus represents the lifecycle of an arena tournament
+type TournamentStatus string
+
+const (
+	TournamentStatusRegistration TournamentStatus = "registration" // Open for entries until it starts
+	TournamentStatusInProgress   TournamentStatus = "in_progress"  // Rounds being played
+	TournamentStatusCompleted    TournamentStatus = "completed"    // Winner decided, prizes recorded
+	TournamentStatusCancelled    TournamentStatus = "cancelled"    // Called off; entry fees refunded
+)
+
+// Tournament is an organised arena event played out as a bracket of matches
+type Tournament struct {
+    ID            string           `json:"id"`
+    Name          string           `json:"name"`
+    Format        string           `json:"format"` // single_elimination, double_elimination or swiss
+    Status        TournamentStatus `json:"status"`
+    CreatedByID   uint             `json:"created_by_id"`
+    CreatedByName string           `json:"created_by_name"`
+
+	// Entry
+    EntryFee   int `json:"entry_fee"`
+    PrizePool  int `json:"prize_pool"` // Sum of the entry fees paid
+    MinPlayers int `json:"min_players"`
+    MaxPlayers int `json:"max_players"`
+    Players    int `json:"players"`
+
+	// Rounds
+    Rounds              int `json:"rounds,omitempty"` // Planned Swiss rounds
+    CurrentRound        int `json:"current_round"`
+    ForfeitAfterMinutes int `json:"forfeit_after_minutes"` // A match still running this long after it started is forfeited
+
+	// Result
+    WinnerID   *uint  `json:"winner_id,omitempty"`
+    WinnerName string `json:"winner_name,omitempty"`
+
+	// Timestamps
+    StartsAt    time.Time  `json:"starts_at"`
+    StartedAt   *time.Time `json:"started_at,omitempty"`
+    CompletedAt *time.Time `json:"completed_at,omitempty"`
+    CreatedAt   time.Time  `json:"created_at"`
+    UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TournamentEntryStatus represents where a registered warrior stands in a tournament
+type TournamentEntryStatus string
+
+const (
+	TournamentEntryActive     TournamentEntryStatus = "active"
+	TournamentEntryEliminated TournamentEntryStatus = "eliminated"
+	TournamentEntryWinner     TournamentEntryStatus = "winner"
+	TournamentEntryWithdrawn  TournamentEntryStatus = "withdrawn" // Left before the start; kept to number registrations
+)
+
+// TournamentEntry is a warrior registered for a tournament, with their record in it
+type TournamentEntry struct {
+    TournamentID    string                `json:"tournament_id"`
+    WarriorID       uint                  `json:"warrior_id"`
+    WarriorName     string                `json:"warrior_name"`
+    Rating          int                   `json:"rating"` // Arena rating at registration, used for seeding
+    Seed            int                   `json:"seed,omitempty"`
+    Status          TournamentEntryStatus `json:"status"`
+    Wins            int                   `json:"wins"`
+    Losses          int                   `json:"losses"`
+    Draws           int                   `json:"draws"`
+    Points          int                   `json:"points"` // Swiss score
+    EliminatedRound int                   `json:"eliminated_round,omitempty"`
+    Place           int                   `json:"place,omitempty"`
+    Prize           int                   `json:"prize,omitempty"`
+    PrizePaid       bool                  `json:"prize_paid"`
+    Registration    int                   `json:"registration"` // How many times the warrior has registered, counting withdrawals
+    RegisteredAt    time.Time             `json:"registered_at"`
+}
+
+// TournamentMatchStatus represents the state of one game of a tournament round
+type TournamentMatchStatus string
+
+const (
+	TournamentMatchPending    TournamentMatchStatus = "pending"     // Arena match not created yet
+	TournamentMatchInProgress TournamentMatchStatus = "in_progress" // Arena match being played
+	TournamentMatchCompleted  TournamentMatchStatus = "completed"
+	TournamentMatchForfeit    TournamentMatchStatus = "forfeit" // A player did not show up in time
+	TournamentMatchBye        TournamentMatchStatus = "bye"     // No opponent; the player advances
+)
+
+// TournamentMatch is one slot of a tournament bracket and the arena match played for it
+type TournamentMatch struct {
+    ID           string                `json:"id"`
+    TournamentID string                `json:"tournament_id"`
+    Round        int                   `json:"round"`
+    Pool         string                `json:"pool"` // winners, losers, grand_final or swiss
+    Slot         int                   `json:"slot"`
+    Player1ID    uint                  `json:"player1_id"`
+    Player1Name  string                `json:"player1_name"`
+    Player2ID    uint                  `json:"player2_id,omitempty"` // Zero for a bye
+    Player2Name  string                `json:"player2_name,omitempty"`
+    MatchID      string                `json:"match_id,omitempty"` // Arena match
+    Status       TournamentMatchStatus `json:"status"`
+    WinnerID     *uint                 `json:"winner_id,omitempty"` // Nil for a Swiss draw
+    WinnerName   string                `json:"winner_name,omitempty"`
+    Deadline     *time.Time            `json:"deadline,omitempty"`
+    CreatedAt    time.Time             `json:"created_at"`
+    CompletedAt  *time.Time            `json:"completed_at,omitempty"`
+}
+
+// IsFinished checks if the slot has a result
+func (m *TournamentMatch) IsFinished() bool {
+    return m.Status == TournamentMatchCompleted || m.Status == TournamentMatchForfeit || m.Status == TournamentMatchBye
+}
+
+// TournamentRound groups the matches of one round of a bracket
+type TournamentRound struct {
+    Round   int                `json:"round"`
+    Matches []*TournamentMatch `json:"matches"`
+}
@@ -55,3 +55,134 @@ type ArenaMatchSQL struct {
 func (ArenaMatchSQL) TableName() string { return "arena_matches" }
 
 
+// ArenaSeasonSQL mirrors ArenaSeason
+type ArenaSeasonSQL struct {
+    Number    int `gorm:"primaryKey;autoIncrement:false"`
+    StartedAt time.Time
+    EndsAt    time.Time
+    EndedAt   *time.Time
+}
+
+func (ArenaSeasonSQL) TableName() string { return "arena_seasons" }
+
+// ArenaRatingSQL mirrors ArenaRating; Games doubles as the row version for optimistic updates
+type ArenaRatingSQL struct {
+    ID          uint   `gorm:"primaryKey;autoIncrement"`
+    Season      int    `gorm:"not null;uniqueIndex:idx_arena_rating_season_warrior;index:idx_arena_rating_season_rating,priority:1"`
+    WarriorID   uint   `gorm:"not null;uniqueIndex:idx_arena_rating_season_warrior"`
+    WarriorName string `gorm:"size:255"`
+    Rating      int    `gorm:"not null;index:idx_arena_rating_season_rating,priority:2"`
+    PeakRating  int
+    Games       int `gorm:"not null;default:0"`
+    Wins        int `gorm:"not null;default:0"`
+    Losses      int `gorm:"not null;default:0"`
+    Draws       int `gorm:"not null;default:0"`
+    UpdatedAt   time.Time
+}
+
+func (ArenaRatingSQL) TableName() string { return "arena_ratings" }
+
+// ArenaRatingChangeSQL mirrors ArenaRatingChange; one row per match and warrior
+type ArenaRatingChangeSQL struct {
+    ID           uint   `gorm:"primaryKey;autoIncrement"`
+    Season       int    `gorm:"not null;index"`
+    MatchID      string `gorm:"size:64;not null;uniqueIndex:idx_arena_rating_change_match"`
+    WarriorID    uint   `gorm:"not null;uniqueIndex:idx_arena_rating_change_match;index"`
+    OpponentID   uint
+    OpponentName string `gorm:"size:255"`
+    Result       string `gorm:"size:16"`
+    RatingBefore int
+    RatingAfter  int
+    Delta        int
+    CreatedAt    time.Time `gorm:"index"`
+}
+
+func (ArenaRatingChangeSQL) TableName() string { return "arena_rating_changes" }
+
+// ArenaSeasonRewardSQL mirrors ArenaSeasonReward; one row per season and warrior
+type ArenaSeasonRewardSQL struct {
+    ID          uint `gorm:"primaryKey;autoIncrement"`
+    Season      int  `gorm:"not null;uniqueIndex:idx_arena_season_reward"`
+    WarriorID   uint `gorm:"not null;uniqueIndex:idx_arena_season_reward"`
+    WarriorName string `gorm:"size:255"`
+    Rank        int
+    Rating      int
+    Coins       int
+    Paid        bool `gorm:"not null;default:false"`
+    CreatedAt   time.Time
+}
+
+func (ArenaSeasonRewardSQL) TableName() string { return "arena_season_rewards" }
+
+
+// ArenaTournamentSQL mirrors Tournament
+type ArenaTournamentSQL struct {
+    ID            uint   `gorm:"primaryKey;autoIncrement"`
+    Name          string `gorm:"size:255;not null"`
+    Format        string `gorm:"size:32;not null"`
+    Status        string `gorm:"size:32;not null;index"`
+    CreatedByID   uint   `gorm:"not null"`
+    CreatedByName string `gorm:"size:255"`
+    EntryFee      int    `gorm:"not null;default:0"`
+    PrizePool     int    `gorm:"not null;default:0"`
+    MinPlayers    int
+    MaxPlayers    int
+    Players       int `gorm:"not null;default:0"`
+    Rounds        int
+    CurrentRound  int `gorm:"not null;default:0"`
+    ForfeitAfterMinutes int
+    WinnerID      *uint
+    WinnerName    string `gorm:"size:255"`
+    StartsAt      time.Time `gorm:"index"`
+    StartedAt     *time.Time
+    CompletedAt   *time.Time
+    CreatedAt     time.Time
+    UpdatedAt     time.Time
+}
+
+func (ArenaTournamentSQL) TableName() string { return "arena_tournaments" }
+
+// ArenaTournamentEntrySQL mirrors TournamentEntry; one row per tournament and warrior
+type ArenaTournamentEntrySQL struct {
+    ID              uint   `gorm:"primaryKey;autoIncrement"`
+    TournamentID    uint   `gorm:"not null;uniqueIndex:idx_arena_tournament_entry"`
+    WarriorID       uint   `gorm:"not null;uniqueIndex:idx_arena_tournament_entry;index"`
+    WarriorName     string `gorm:"size:255"`
+    Rating          int
+    Seed            int
+    Status          string `gorm:"size:32;not null"`
+    Wins            int `gorm:"not null;default:0"`
+    Losses          int `gorm:"not null;default:0"`
+    Draws           int `gorm:"not null;default:0"`
+    Points          int `gorm:"not null;default:0"`
+    EliminatedRound int
+    Place           int
+    Prize           int `gorm:"not null;default:0"`
+    PrizePaid       bool `gorm:"not null;default:false"`
+    Registration    int  `gorm:"not null;default:1"`
+    RegisteredAt    time.Time
+}
+
+func (ArenaTournamentEntrySQL) TableName() string { return "arena_tournament_entries" }
+
+// ArenaTournamentMatchSQL mirrors TournamentMatch; one row per bracket slot
+type ArenaTournamentMatchSQL struct {
+    ID           uint   `gorm:"primaryKey;autoIncrement"`
+    TournamentID uint   `gorm:"not null;uniqueIndex:idx_arena_tournament_slot"`
+    Round        int    `gorm:"not null;uniqueIndex:idx_arena_tournament_slot"`
+    Pool         string `gorm:"size:32;not null;uniqueIndex:idx_arena_tournament_slot"`
+    Slot         int    `gorm:"not null;uniqueIndex:idx_arena_tournament_slot"`
+    Player1ID    uint
+    Player1Name  string `gorm:"size:255"`
+    Player2ID    uint
+    Player2Name  string `gorm:"size:255"`
+    MatchID      string `gorm:"size:64;index"`
+    Status       string `gorm:"size:32;not null;index"`
+    WinnerID     *uint
+    WinnerName   string `gorm:"size:255"`
+    Deadline     *time.Time
+    CreatedAt    time.Time
+    CompletedAt  *time.Time
+}
+
+func (ArenaTournamentMatchSQL) TableName() string { return "arena_tournament_matches" }
@@ -0,0 +1,166 @@
+package arena
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strconv"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// The matchmaking queue is a sorted set of warrior IDs scored by join time, oldest first.
+// Each warrior's ticket is kept as JSON next to it and outlives the queue entry once matched,
+// so the warrior can still poll for the match it was put into.
+const (
+    queueKey         = "arena:queue"
+    queueLockKey     = "arena:queue:matcher"
+    matchedTicketTTL = 10 * time.Minute
+)
+
+func queueTicketKey(warriorID uint) string { return fmt.Sprintf("arena:queue:ticket:%d", warriorID) }
+
+var (
+    ErrAlreadyQueued  = errors.New("warrior is already in the matchmaking queue")
+    ErrNotQueued      = errors.New("warrior is not in the matchmaking queue")
+    ErrQueueMatching  = errors.New("warrior is being matched")
+    ErrAlreadyInMatch = errors.New("warrior is already in an arena or tournament match")
+)
+
+// enqueueTicket adds a searching ticket, failing with ErrAlreadyQueued if the warrior is waiting already
+func enqueueTicket(ctx context.Context, t *QueueTicket) error {
+    rc := getRedis()
+    if rc == nil { return errors.New("redis not initialized") }
+    data, err := json.Marshal(t)
+    if err != nil { return err }
+    member := strconv.FormatUint(uint64(t.WarriorID), 10)
+    added, err := rc.ZAddNX(ctx, queueKey, redis.Z{Score: float64(t.JoinedAt.UnixMilli()), Member: member}).Result()
+    if err != nil { return err }
+    if added == 0 { return ErrAlreadyQueued }
+    if err := rc.Set(ctx, queueTicketKey(t.WarriorID), data, 0).Err(); err != nil {
+        _ = rc.ZRem(ctx, queueKey, member).Err()
+        return err
+    }
+    return nil
+}
+
+// saveTicket overwrites a ticket; ttl 0 keeps it until removed
+func saveTicket(ctx context.Context, t *QueueTicket, ttl time.Duration) error {
+    rc := getRedis()
+    if rc == nil { return errors.New("redis not initialized") }
+    data, err := json.Marshal(t)
+    if err != nil { return err }
+    return rc.Set(ctx, queueTicketKey(t.WarriorID), data, ttl).Err()
+}
+
+// loadTicket returns the warrior's ticket, or ErrNotQueued if there is none
+func loadTicket(ctx context.Context, warriorID uint) (*QueueTicket, error) {
+    rc := getRedis()
+    if rc == nil { return nil, errors.New("redis not initialized") }
+    data, err := rc.Get(ctx, queueTicketKey(warriorID)).Bytes()
+    if err == redis.Nil { return nil, ErrNotQueued }
+    if err != nil { return nil, err }
+    var t QueueTicket
+    if err := json.Unmarshal(data, &t); err != nil { return nil, err }
+    return &t, nil
+}
+
+// dequeueTicket takes a searching warrior out of the queue; false if they were not waiting
+func dequeueTicket(ctx context.Context, warriorID uint) (bool, error) {
+    rc := getRedis()
+    if rc == nil { return false, errors.New("redis not initialized") }
+    removed, err := rc.ZRem(ctx, queueKey, strconv.FormatUint(uint64(warriorID), 10)).Result()
+    if err != nil || removed == 0 { return false, err }
+    return true, rc.Del(ctx, queueTicketKey(warriorID)).Err()
+}
+
+// deleteTicket removes the ticket of a warrior no longer in the queue
+func deleteTicket(ctx context.Context, warriorID uint) error {
+    rc := getRedis()
+    if rc == nil { return errors.New("redis not initialized") }
+    return rc.Del(ctx, queueTicketKey(warriorID)).Err()
+}
+
+// listQueuedTickets returns the searching tickets, oldest first
+func listQueuedTickets(ctx context.Context) ([]*QueueTicket, error) {
+    rc := getRedis()
+    if rc == nil { return nil, errors.New("redis not initialized") }
+    members, err := rc.ZRange(ctx, queueKey, 0, -1).Result()
+    if err != nil { return nil, err }
+    if len(members) == 0 { return nil, nil }
+
+    keys := make([]string, len(members))
+    for i, m := range members {
+        id, _ := strconv.ParseUint(m, 10, 32)
+        keys[i] = queueTicketKey(uint(id))
+    }
+    values, err := rc.MGet(ctx, keys...).Result()
+    if err != nil { return nil, err }
+
+    tickets := make([]*QueueTicket, 0, len(values))
+    for _, v := range values {
+        s, ok := v.(string)
+        if !ok { continue }
+        var t QueueTicket
+        if err := json.Unmarshal([]byte(s), &t); err != nil { continue }
+        if t.Status == QueueStatusSearching { tickets = append(tickets, &t) }
+    }
+    return tickets, nil
+}
+
+// claimTickets removes both warriors from the queue so nobody else can pair them.
+// If either had already left, the other is put back at its original place and false is returned.
+func claimTickets(ctx context.Context, a, b *QueueTicket) (bool, error) {
+    rc := getRedis()
+    if rc == nil { return false, errors.New("redis not initialized") }
+    var removed [2]int64
+    for i, t := range []*QueueTicket{a, b} {
+        n, err := rc.ZRem(ctx, queueKey, strconv.FormatUint(uint64(t.WarriorID), 10)).Result()
+        if err != nil { return false, err }
+        removed[i] = n
+    }
+    if removed[0] == 1 && removed[1] == 1 { return true, nil }
+    for i, t := range []*QueueTicket{a, b} {
+        if removed[i] == 1 { _ = requeueTicket(ctx, t) }
+    }
+    return false, nil
+}
+
+// requeueTicket puts a claimed ticket back in the queue keeping its join time
+func requeueTicket(ctx context.Context, t *QueueTicket) error {
+    rc := getRedis()
+    if rc == nil { return errors.New("redis not initialized") }
+    if err := saveTicket(ctx, t, 0); err != nil { return err }
+    member := strconv.FormatUint(uint64(t.WarriorID), 10)
+    return rc.ZAdd(ctx, queueKey, redis.Z{Score: float64(t.JoinedAt.UnixMilli()), Member: member}).Err()
+}
+
+// releaseMatcherLockScript deletes the lock only while it still holds the caller's token, so a pass
+// that outlived its TTL cannot release the lock another instance has taken since
+var releaseMatcherLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// acquireMatcherLock makes sure only one arena instance runs a matching pass at a time.
+// It returns the token to release the lock with, or "" if another instance holds it.
+func acquireMatcherLock(ctx context.Context, ttl time.Duration) (string, error) {
+    rc := getRedis()
+    if rc == nil { return "", errors.New("redis not initialized") }
+    buf := make([]byte, 16)
+    if _, err := rand.Read(buf); err != nil { return "", err }
+    token := hex.EncodeToString(buf)
+    locked, err := rc.SetNX(ctx, queueLockKey, token, ttl).Result()
+    if err != nil || !locked { return "", err }
+    return token, nil
+}
+
+func releaseMatcherLock(ctx context.Context, token string) {
+    if rc := getRedis(); rc != nil { _ = releaseMatcherLockScript.Run(ctx, rc, []string{queueLockKey}, token).Err() }
+}
@@ -0,0 +1,424 @@
+package arena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"network-sec-micro/internal/arena/dto"
+	"network-sec-micro/pkg/rating"
+
+	"gorm.io/gorm"
+)
+
+// Match results as recorded in rating history
+const (
+	RatingResultWin  = "win"
+	RatingResultLoss = "loss"
+	RatingResultDraw = "draw"
+)
+
+// ratingUpdateAttempts bounds retries when a player's rating changed under a concurrent match
+const ratingUpdateAttempts = 3
+
+// unratedMatchBatch bounds how many unrated matches one scheduler run retries
+const unratedMatchBatch = 100
+
+// SeasonConfig controls ranked season length, the soft reset between seasons and reward eligibility
+type SeasonConfig struct {
+	Length        time.Duration
+	SoftResetKeep float64 // Fraction of the distance from the default rating kept into the next season
+	MinGames      int     // Games needed in a season to earn its rewards
+}
+
+// DefaultSeasonConfig is used unless overridden with SetSeasonConfig
+var DefaultSeasonConfig = SeasonConfig{Length: 30 * 24 * time.Hour, SoftResetKeep: 0.5, MinGames: 10}
+
+var seasonConfig = DefaultSeasonConfig
+
+// SetSeasonConfig replaces the active season configuration
+func SetSeasonConfig(c SeasonConfig) {
+	if c.Length <= 0 {
+		c.Length = DefaultSeasonConfig.Length
+	}
+	if c.SoftResetKeep < 0 || c.SoftResetKeep > 1 {
+		c.SoftResetKeep = DefaultSeasonConfig.SoftResetKeep
+	}
+	if c.MinGames < 0 {
+		c.MinGames = DefaultSeasonConfig.MinGames
+	}
+	seasonConfig = c
+}
+
+// SeasonConfigFromEnv reads ARENA_SEASON_DAYS, ARENA_SEASON_SOFT_RESET and ARENA_SEASON_MIN_GAMES over the defaults
+func SeasonConfigFromEnv() SeasonConfig {
+	c := DefaultSeasonConfig
+	if v, err := strconv.Atoi(os.Getenv("ARENA_SEASON_DAYS")); err == nil {
+		c.Length = time.Duration(v) * 24 * time.Hour
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("ARENA_SEASON_SOFT_RESET"), 64); err == nil {
+		c.SoftResetKeep = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("ARENA_SEASON_MIN_GAMES")); err == nil {
+		c.MinGames = v
+	}
+	return c
+}
+
+// seasonRewardTiers pay coins by final rank; ranks past the last tier earn nothing
+var seasonRewardTiers = []struct {
+	MaxRank int
+	Coins   int
+}{
+	{1, 5000},
+	{2, 3000},
+	{3, 2000},
+	{10, 1000},
+	{50, 500},
+	{100, 250},
+}
+
+// SeasonRewardForRank returns the coins paid for finishing a season at rank
+func SeasonRewardForRank(rank int) int {
+	for _, tier := range seasonRewardTiers {
+		if rank <= tier.MaxRank {
+			return tier.Coins
+		}
+	}
+	return 0
+}
+
+// ==================== RATINGS ====================
+
+// RecordMatchRatings updates both players' ratings in the current season from a completed match.
+// A match is only ever rated once.
+func (s *Service) RecordMatchRatings(ctx context.Context, match *ArenaMatch) error {
+	if match.Status != MatchStatusCompleted {
+		return nil
+	}
+	season, err := s.CurrentSeason(ctx)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; attempt < ratingUpdateAttempts; attempt++ {
+		err = s.applyMatchRatings(ctx, season.Number, match)
+		if !errors.Is(err, ErrRatingConflict) {
+			break
+		}
+	}
+	if errors.Is(err, ErrMatchAlreadyRated) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record ratings for match %s: %w", match.ID, err)
+	}
+	return nil
+}
+
+// RateUnratedMatches retries the ratings of matches completed in the current season whose rating failed
+// when they finished, and returns how many were rated
+func (s *Service) RateUnratedMatches(ctx context.Context) (int, error) {
+	season, err := s.CurrentSeason(ctx)
+	if err != nil {
+		return 0, err
+	}
+	matches, err := GetRepository().ListUnratedMatches(ctx, season.StartedAt, unratedMatchBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list unrated matches: %w", err)
+	}
+	rated := 0
+	for i := range matches {
+		if err := s.RecordMatchRatings(ctx, &matches[i]); err != nil {
+			log.Printf("Failed to update arena ratings: %v", err)
+			continue
+		}
+		rated++
+	}
+	return rated, nil
+}
+
+func (s *Service) applyMatchRatings(ctx context.Context, season int, match *ArenaMatch) error {
+	p1, err := s.seasonRating(ctx, season, match.Player1ID, match.Player1Name)
+	if err != nil {
+		return err
+	}
+	p2, err := s.seasonRating(ctx, season, match.Player2ID, match.Player2Name)
+	if err != nil {
+		return err
+	}
+
+	outcome, result1, result2 := rating.Draw, RatingResultDraw, RatingResultDraw
+	if match.WinnerID != nil && *match.WinnerID == match.Player1ID {
+		outcome, result1, result2 = rating.Win, RatingResultWin, RatingResultLoss
+	} else if match.WinnerID != nil && *match.WinnerID == match.Player2ID {
+		outcome, result1, result2 = rating.Loss, RatingResultLoss, RatingResultWin
+	}
+	new1, new2 := rating.Update(rating.Player{Rating: p1.Rating, Games: p1.Games}, rating.Player{Rating: p2.Rating, Games: p2.Games}, outcome)
+
+	now := time.Now()
+	changes := []*ArenaRatingChange{
+		{Season: season, MatchID: match.ID, WarriorID: p1.WarriorID, OpponentID: p2.WarriorID, OpponentName: p2.WarriorName, Result: result1, RatingBefore: p1.Rating, RatingAfter: new1, Delta: new1 - p1.Rating, CreatedAt: now},
+		{Season: season, MatchID: match.ID, WarriorID: p2.WarriorID, OpponentID: p1.WarriorID, OpponentName: p1.WarriorName, Result: result2, RatingBefore: p2.Rating, RatingAfter: new2, Delta: new2 - p2.Rating, CreatedAt: now},
+	}
+	p1.record(new1, result1, now)
+	p2.record(new2, result2, now)
+
+	ratings := []*ArenaRating{p1, p2}
+	if err := GetRepository().ApplyMatchRatings(ctx, changes, ratings); err != nil {
+		return err
+	}
+	if err := updateLeaderboard(ctx, ratings); err != nil {
+		log.Printf("Failed to update arena leaderboard: %v", err)
+	}
+	log.Printf("Arena ratings: %s %d -> %d, %s %d -> %d", p1.WarriorName, changes[0].RatingBefore, new1, p2.WarriorName, changes[1].RatingBefore, new2)
+	return nil
+}
+
+// record applies a match result to the rating in memory
+func (r *ArenaRating) record(newRating int, result string, at time.Time) {
+	r.Rating = newRating
+	r.PeakRating = max(r.PeakRating, newRating)
+	r.Games++
+	switch result {
+	case RatingResultWin:
+		r.Wins++
+	case RatingResultLoss:
+		r.Losses++
+	default:
+		r.Draws++
+	}
+	r.UpdatedAt = at
+}
+
+// seasonRating returns a warrior's rating in season. A warrior new to the season starts from a soft reset
+// of their latest earlier rating, or from the default rating.
+func (s *Service) seasonRating(ctx context.Context, season int, warriorID uint, warriorName string) (*ArenaRating, error) {
+	repo := GetRepository()
+	r, err := repo.GetRating(ctx, season, warriorID)
+	if err == nil {
+		r.WarriorName = warriorName
+		return r, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	start := rating.DefaultRating
+	prev, err := repo.GetLatestRating(ctx, warriorID, season)
+	if err == nil {
+		start = rating.SoftReset(prev.Rating, seasonConfig.SoftResetKeep)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return &ArenaRating{Season: season, WarriorID: warriorID, WarriorName: warriorName, Rating: start, PeakRating: start}, nil
+}
+
+// ==================== SEASONS ====================
+
+// CurrentSeason returns the open season, starting the next one if the last was closed (or none exists yet)
+func (s *Service) CurrentSeason(ctx context.Context) (*ArenaSeason, error) {
+	repo := GetRepository()
+	latest, err := repo.GetLatestSeason(ctx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get season: %w", err)
+	}
+	if err == nil && latest.EndedAt == nil {
+		return latest, nil
+	}
+
+	number := 1
+	if latest != nil {
+		number = latest.Number + 1
+	}
+	now := time.Now()
+	season := &ArenaSeason{Number: number, StartedAt: now, EndsAt: now.Add(seasonConfig.Length)}
+	if err := repo.CreateSeason(ctx, season); err != nil {
+		// Another instance may have started it first
+		if existing, getErr := repo.GetLatestSeason(ctx); getErr == nil && existing.Number == number {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("failed to start season %d: %w", number, err)
+	}
+	log.Printf("Arena season %d started, ends %s", number, season.EndsAt.Format(time.RFC3339))
+	return season, nil
+}
+
+// EndSeason closes the current season: final standings are recorded as rewards and paid through the
+// coin service, and the next season starts. Ratings carry over with a soft reset as players return.
+func (s *Service) EndSeason(ctx context.Context) ([]*ArenaSeasonReward, error) {
+	season, err := s.CurrentSeason(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repo := GetRepository()
+
+	standings, _, err := repo.ListRatings(ctx, season.Number, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load standings: %w", err)
+	}
+	now := time.Now()
+	var rewards []*ArenaSeasonReward
+	rank := 0
+	for _, r := range standings {
+		if r.Games < seasonConfig.MinGames {
+			continue
+		}
+		rank++
+		coins := SeasonRewardForRank(rank)
+		if coins == 0 {
+			break
+		}
+		rewards = append(rewards, &ArenaSeasonReward{
+			Season: season.Number, WarriorID: r.WarriorID, WarriorName: r.WarriorName,
+			Rank: rank, Rating: r.Rating, Coins: coins, CreatedAt: now,
+		})
+	}
+	if err := repo.InsertSeasonRewards(ctx, rewards); err != nil {
+		return nil, fmt.Errorf("failed to record season rewards: %w", err)
+	}
+
+	closed, err := repo.CloseSeason(ctx, season.Number, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close season %d: %w", season.Number, err)
+	}
+	if closed {
+		log.Printf("Arena season %d ended with %d rewarded players", season.Number, len(rewards))
+		if _, err := s.CurrentSeason(ctx); err != nil {
+			log.Printf("Failed to start next arena season: %v", err)
+		}
+	}
+
+	recorded, err := repo.ListSeasonRewards(ctx, season.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load season rewards: %w", err)
+	}
+	s.paySeasonRewards(ctx, recorded)
+	return recorded, nil
+}
+
+// paySeasonRewards credits each unpaid season reward once. The paid flag is claimed before paying and
+// released again if the coin service fails, leaving the reward for the next scheduler run.
+func (s *Service) paySeasonRewards(ctx context.Context, rewards []*ArenaSeasonReward) {
+	repo := GetRepository()
+	for _, reward := range rewards {
+		if reward.Paid {
+			continue
+		}
+		claimed, err := repo.SetSeasonRewardPaid(ctx, reward.Season, reward.WarriorID, true)
+		if err != nil {
+			log.Printf("Failed to claim season %d reward for warrior %d: %v", reward.Season, reward.WarriorID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		reason := fmt.Sprintf("arena_season_%d_rank_%d", reward.Season, reward.Rank)
+		if err := AddCoins(ctx, reward.WarriorID, int64(reward.Coins), reason, fmt.Sprintf("arena_season_reward:%d:%d", reward.Season, reward.WarriorID)); err != nil {
+			log.Printf("Failed to pay season %d reward to warrior %d: %v", reward.Season, reward.WarriorID, err)
+			if _, err := repo.SetSeasonRewardPaid(ctx, reward.Season, reward.WarriorID, false); err != nil {
+				log.Printf("Failed to release season reward claim for warrior %d: %v", reward.WarriorID, err)
+			}
+			continue
+		}
+		reward.Paid = true
+	}
+}
+
+// StartSeasonScheduler ends seasons once they are due and retries unrated matches of the current season
+// and unpaid rewards of the last one, checking every interval until ctx is done
+func (s *Service) StartSeasonScheduler(ctx context.Context, interval time.Duration) {
+	check := func() {
+		season, err := s.CurrentSeason(ctx)
+		if err != nil {
+			log.Printf("Arena season check failed: %v", err)
+			return
+		}
+		if season.IsOver() {
+			if _, err := s.EndSeason(ctx); err != nil {
+				log.Printf("Failed to end arena season %d: %v", season.Number, err)
+			}
+			return
+		}
+		if rated, err := s.RateUnratedMatches(ctx); err != nil {
+			log.Printf("Arena rating retry failed: %v", err)
+		} else if rated > 0 {
+			log.Printf("Arena rating retry rated %d match(es)", rated)
+		}
+		if season.Number > 1 {
+			if rewards, err := GetRepository().ListSeasonRewards(ctx, season.Number-1); err == nil {
+				s.paySeasonRewards(ctx, rewards)
+			}
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		check()
+		for {
+			select {
+			case <-ticker.C:
+				check()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// ==================== QUERIES ====================
+
+// GetLeaderboard returns one page of a season's ladder (current season when none is given) and its size.
+// The ladder is read from the Redis sorted set, rebuilt from SQL when missing; without Redis SQL is used directly.
+func (s *Service) GetLeaderboard(ctx context.Context, query dto.GetLeaderboardQuery) ([]*ArenaRating, int64, int, error) {
+	season := query.Season
+	if season == 0 {
+		current, err := s.CurrentSeason(ctx)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		season = current.Number
+	}
+
+	if getRedis() != nil {
+		entries, total, err := readLeaderboard(ctx, season, query.Limit, query.Offset)
+		if err == nil && total == 0 {
+			if err = rebuildLeaderboard(ctx, season); err == nil {
+				entries, total, err = readLeaderboard(ctx, season, query.Limit, query.Offset)
+			}
+		}
+		if err == nil {
+			return entries, total, season, nil
+		}
+		log.Printf("Arena leaderboard unavailable in Redis, reading from SQL: %v", err)
+	}
+
+	entries, total, err := GetRepository().ListRatings(ctx, season, query.Limit, query.Offset)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to load leaderboard: %w", err)
+	}
+	return entries, total, season, nil
+}
+
+// GetMyRating returns the warrior's rating in the current season; warriors yet to play get their starting rating
+func (s *Service) GetMyRating(ctx context.Context, warriorID uint, warriorName string) (*ArenaRating, error) {
+	season, err := s.CurrentSeason(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.seasonRating(ctx, season.Number, warriorID, warriorName)
+}
+
+// GetRatingHistory returns a warrior's rating changes, newest first
+func (s *Service) GetRatingHistory(ctx context.Context, query dto.GetRatingHistoryQuery) ([]*ArenaRatingChange, int64, error) {
+	changes, total, err := GetRepository().ListRatingChanges(ctx, query.WarriorID, query.Limit, query.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load rating history: %w", err)
+	}
+	return changes, total, nil
+}
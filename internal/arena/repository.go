@@ -2,7 +2,9 @@ package arena
 
 import (
     "context"
+    "errors"
     "os"
+    "time"
 )
 
 // Repository abstracts persistence for Arena domain (Mongo or SQL)
@@ -15,32 +17,62 @@ type Repository interface {
     GetInvitationByID(ctx context.Context, id string) (*ArenaInvitation, error)
     UpdateInvitationFields(ctx context.Context, id string, fields map[string]interface{}) error
     FindPendingInvitationBetween(ctx context.Context, challengerID, opponentID uint) (*ArenaInvitation, error)
+    ListInvitations(ctx context.Context, userID uint, status string) ([]ArenaInvitation, error)
+    ListMatches(ctx context.Context, userID uint, status string) ([]ArenaMatch, error)
+    HasOpenMatch(ctx context.Context, warriorID uint) (bool, error)
+
+    // Seasons and ratings
+    GetLatestSeason(ctx context.Context) (*ArenaSeason, error)
+    CreateSeason(ctx context.Context, season *ArenaSeason) error
+    CloseSeason(ctx context.Context, number int, endedAt time.Time) (bool, error)
+    GetRating(ctx context.Context, season int, warriorID uint) (*ArenaRating, error)
+    GetLatestRating(ctx context.Context, warriorID uint, beforeSeason int) (*ArenaRating, error)
+    ApplyMatchRatings(ctx context.Context, changes []*ArenaRatingChange, ratings []*ArenaRating) error
+    ListRatings(ctx context.Context, season int, limit, offset int) ([]*ArenaRating, int64, error)
+    ListRatingChanges(ctx context.Context, warriorID uint, limit, offset int) ([]*ArenaRatingChange, int64, error)
+    ListUnratedMatches(ctx context.Context, since time.Time, limit int) ([]ArenaMatch, error)
+    InsertSeasonRewards(ctx context.Context, rewards []*ArenaSeasonReward) error
+    ListSeasonRewards(ctx context.Context, season int) ([]*ArenaSeasonReward, error)
+    SetSeasonRewardPaid(ctx context.Context, season int, warriorID uint, paid bool) (bool, error)
+
+    // Tournaments
+    CreateTournament(ctx context.Context, t *Tournament) (string, error)
+    GetTournament(ctx context.Context, id string) (*Tournament, error)
+    ListTournaments(ctx context.Context, status string, limit, offset int) ([]*Tournament, int64, error)
+    TransitionTournament(ctx context.Context, id string, from TournamentStatus, round int, fields map[string]interface{}) (bool, error)
+    AddTournamentEntry(ctx context.Context, e *TournamentEntry, fee int) error
+    RemoveTournamentEntry(ctx context.Context, tournamentID string, warriorID uint, fee int) (int, error)
+    GetTournamentEntry(ctx context.Context, tournamentID string, warriorID uint) (*TournamentEntry, error)
+    ListTournamentEntries(ctx context.Context, tournamentID string) ([]*TournamentEntry, error)
+    UpdateTournamentEntries(ctx context.Context, entries []*TournamentEntry) error
+    SetTournamentPrizePaid(ctx context.Context, tournamentID string, warriorID uint, paid bool) (bool, error)
+    ListUnpaidTournamentPrizes(ctx context.Context) ([]*TournamentEntry, error)
+    InsertTournamentMatches(ctx context.Context, matches []*TournamentMatch) error
+    ListTournamentMatches(ctx context.Context, tournamentID string, round int) ([]*TournamentMatch, error)
+    GetTournamentMatchByArenaMatch(ctx context.Context, matchID string) (*TournamentMatch, error)
+    UpdateTournamentMatch(ctx context.Context, id string, from TournamentMatchStatus, fields map[string]interface{}) (bool, error)
 }
 
+// ErrRatingConflict is returned when a rating changed between being read and written
+var ErrRatingConflict = errors.New("arena rating changed concurrently")
+
+// ErrMatchAlreadyRated is returned when a match's rating changes were already recorded
+var ErrMatchAlreadyRated = errors.New("arena match already rated")
+
+// Tournament registration errors
+var (
+    ErrRegistrationClosed = errors.New("tournament registration is closed")
+    ErrTournamentFull     = errors.New("tournament is full")
+    ErrAlreadyRegistered  = errors.New("warrior is already registered for this tournament")
+    ErrNotRegistered      = errors.New("warrior is not registered for this tournament")
+)
+
 var defaultRepo Repository
 
-// GetRepository returns a singleton repo based on env (ARENA_STORE=sql|mongo)
+// GetRepository returns a singleton repo; arena state is kept in PostgreSQL (ARENA_STORE is ignored)
 func GetRepository() Repository {
     if defaultRepo != nil { return defaultRepo }
-    store := os.Getenv("ARENA_STORE")
-    switch store {
-    case "redis":
-        if getRedis() != nil {
-            defaultRepo = &redisRepo{}
-            return defaultRepo
-        }
-        // fallback to sql if redis not ready and sql enabled
-        if SQLDB.Enabled { defaultRepo = &sqlRepo{} } else { defaultRepo = &redisRepo{} }
-    case "sql":
-        if SQLDB.Enabled {
-            defaultRepo = &sqlRepo{}
-            return defaultRepo
-        }
-        defaultRepo = &redisRepo{}
-    default:
-        if SQLDB.Enabled { defaultRepo = &sqlRepo{} } else if getRedis() != nil { defaultRepo = &redisRepo{} } else { defaultRepo = &sqlRepo{} }
-    }
+    _ = os.Getenv("ARENA_STORE")
+    defaultRepo = &sqlRepo{}
     return defaultRepo
 }
-
-
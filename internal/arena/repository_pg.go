@@ -3,8 +3,12 @@ package arena
 import (
     "context"
     "errors"
+    "fmt"
+    "strconv"
+    "time"
 
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
 )
 
 type sqlRepo struct{}
@@ -20,21 +24,7 @@ func (r *sqlRepo) GetMatchByID(ctx context.Context, id string) (*ArenaMatch, err
     db, err := getGorm(); if err != nil { return nil, err }
     var m ArenaMatchSQL
     if tx := db.WithContext(ctx).First(&m, "id = ?", id); tx.Error != nil { return nil, tx.Error }
-    // map to ArenaMatch
-    am := &ArenaMatch{
-        // ID isn’t available as ObjectID; leave zero, fields carry state
-        Player1ID: m.Player1ID, Player1Name: m.Player1Name,
-        Player1HP: m.Player1HP, Player1MaxHP: m.Player1MaxHP,
-        Player1Attack: m.Player1Attack, Player1Defense: m.Player1Defense,
-        Player2ID: m.Player2ID, Player2Name: m.Player2Name,
-        Player2HP: m.Player2HP, Player2MaxHP: m.Player2MaxHP,
-        Player2Attack: m.Player2Attack, Player2Defense: m.Player2Defense,
-        CurrentTurn: m.CurrentTurn, MaxTurns: m.MaxTurns, CurrentAttacker: m.CurrentAttacker,
-        Status: ArenaMatchStatus(m.Status), WinnerID: m.WinnerID, WinnerName: m.WinnerName,
-        P1Below50Announced: m.P1Below50Announced, P2Below50Announced: m.P2Below50Announced,
-        P1Below10Announced: m.P1Below10Announced, P2Below10Announced: m.P2Below10Announced,
-    }
-    return am, nil
+    return toArenaMatch(&m), nil
 }
 
 func (r *sqlRepo) UpdateMatchFields(ctx context.Context, id string, fields map[string]interface{}) error {
@@ -66,19 +56,7 @@ func (r *sqlRepo) GetInvitationByID(ctx context.Context, id string) (*ArenaInvit
     db, err := getGorm(); if err != nil { return nil, err }
     var row ArenaInvitationSQL
     if tx := db.WithContext(ctx).First(&row, "id = ?", id); tx.Error != nil { return nil, tx.Error }
-    inv := &ArenaInvitation{
-        ChallengerID:  row.ChallengerID,
-        ChallengerName: row.ChallengerName,
-        OpponentID:    row.OpponentID,
-        OpponentName:  row.OpponentName,
-        Status:        ArenaInvitationStatus(row.Status),
-        ExpiresAt:     row.ExpiresAt,
-        RespondedAt:   row.RespondedAt,
-        BattleID:      row.BattleID,
-        CreatedAt:     row.CreatedAt,
-        UpdatedAt:     row.UpdatedAt,
-    }
-    return inv, nil
+    return toArenaInvitation(&row), nil
 }
 
 func (r *sqlRepo) UpdateInvitationFields(ctx context.Context, id string, fields map[string]interface{}) error {
@@ -91,19 +69,47 @@ func (r *sqlRepo) FindPendingInvitationBetween(ctx context.Context, challengerID
     var row ArenaInvitationSQL
     tx := db.WithContext(ctx).Where("challenger_id = ? AND opponent_id = ? AND status = ?", challengerID, opponentID, string(InvitationStatusPending)).First(&row)
     if tx.Error != nil { return nil, tx.Error }
-    inv := &ArenaInvitation{
-        ChallengerID:  row.ChallengerID,
-        ChallengerName: row.ChallengerName,
-        OpponentID:    row.OpponentID,
-        OpponentName:  row.OpponentName,
-        Status:        ArenaInvitationStatus(row.Status),
-        ExpiresAt:     row.ExpiresAt,
-        RespondedAt:   row.RespondedAt,
-        BattleID:      row.BattleID,
-        CreatedAt:     row.CreatedAt,
-        UpdatedAt:     row.UpdatedAt,
-    }
-    return inv, nil
+    return toArenaInvitation(&row), nil
+}
+
+// ListInvitations returns the invitations a user sent or received, optionally of one status, newest first
+func (r *sqlRepo) ListInvitations(ctx context.Context, userID uint, status string) ([]ArenaInvitation, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    q := db.WithContext(ctx).Where("challenger_id = ? OR opponent_id = ?", userID, userID)
+    if status != "" { q = q.Where("status = ?", status) }
+    var rows []ArenaInvitationSQL
+    if tx := q.Order("created_at DESC, id DESC").Find(&rows); tx.Error != nil { return nil, tx.Error }
+    out := make([]ArenaInvitation, len(rows))
+    for i := range rows { out[i] = *toArenaInvitation(&rows[i]) }
+    return out, nil
+}
+
+// ListMatches returns the matches a user played in, optionally of one status, newest first
+func (r *sqlRepo) ListMatches(ctx context.Context, userID uint, status string) ([]ArenaMatch, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    q := db.WithContext(ctx).Where("player1_id = ? OR player2_id = ?", userID, userID)
+    if status != "" { q = q.Where("status = ?", status) }
+    var rows []ArenaMatchSQL
+    if tx := q.Order("created_at DESC, id DESC").Find(&rows); tx.Error != nil { return nil, tx.Error }
+    out := make([]ArenaMatch, len(rows))
+    for i := range rows { out[i] = *toArenaMatch(&rows[i]) }
+    return out, nil
+}
+
+// HasOpenMatch checks if a warrior has an arena match pending or in progress, or a tournament slot not played yet
+func (r *sqlRepo) HasOpenMatch(ctx context.Context, warriorID uint) (bool, error) {
+    db, err := getGorm(); if err != nil { return false, err }
+    var n int64
+    tx := db.WithContext(ctx).Model(&ArenaMatchSQL{}).
+        Where("(player1_id = ? OR player2_id = ?) AND status IN ?", warriorID, warriorID, []string{string(MatchStatusPending), string(MatchStatusInProgress)}).
+        Count(&n)
+    if tx.Error != nil { return false, tx.Error }
+    if n > 0 { return true, nil }
+    tx = db.WithContext(ctx).Model(&ArenaTournamentMatchSQL{}).
+        Where("(player1_id = ? OR player2_id = ?) AND status IN ?", warriorID, warriorID, []string{string(TournamentMatchPending), string(TournamentMatchInProgress)}).
+        Count(&n)
+    if tx.Error != nil { return false, tx.Error }
+    return n > 0, nil
 }
 
 // CreateMatch inserts a new match row and returns an identifier string (row id)
@@ -128,3 +134,472 @@ func (r *sqlRepo) CreateMatch(ctx context.Context, m *ArenaMatch) (string, error
 }
 
 
+
+// ===== Seasons and ratings (SQL) =====
+
+// GetLatestSeason returns the most recent season, closed or not, or gorm.ErrRecordNotFound
+func (r *sqlRepo) GetLatestSeason(ctx context.Context) (*ArenaSeason, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    var row ArenaSeasonSQL
+    if tx := db.WithContext(ctx).Order("number DESC").First(&row); tx.Error != nil { return nil, tx.Error }
+    return &ArenaSeason{Number: row.Number, StartedAt: row.StartedAt, EndsAt: row.EndsAt, EndedAt: row.EndedAt}, nil
+}
+
+func (r *sqlRepo) CreateSeason(ctx context.Context, season *ArenaSeason) error {
+    db, err := getGorm(); if err != nil { return err }
+    row := &ArenaSeasonSQL{Number: season.Number, StartedAt: season.StartedAt, EndsAt: season.EndsAt, EndedAt: season.EndedAt}
+    return db.WithContext(ctx).Create(row).Error
+}
+
+// CloseSeason marks a season ended; it reports false if it already was
+func (r *sqlRepo) CloseSeason(ctx context.Context, number int, endedAt time.Time) (bool, error) {
+    db, err := getGorm(); if err != nil { return false, err }
+    tx := db.WithContext(ctx).Model(&ArenaSeasonSQL{}).Where("number = ? AND ended_at IS NULL", number).Update("ended_at", endedAt)
+    if tx.Error != nil { return false, tx.Error }
+    return tx.RowsAffected == 1, nil
+}
+
+func (r *sqlRepo) GetRating(ctx context.Context, season int, warriorID uint) (*ArenaRating, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    var row ArenaRatingSQL
+    if tx := db.WithContext(ctx).Where("season = ? AND warrior_id = ?", season, warriorID).First(&row); tx.Error != nil { return nil, tx.Error }
+    return toArenaRating(&row), nil
+}
+
+// GetLatestRating returns the warrior's rating from the most recent season before beforeSeason
+func (r *sqlRepo) GetLatestRating(ctx context.Context, warriorID uint, beforeSeason int) (*ArenaRating, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    var row ArenaRatingSQL
+    tx := db.WithContext(ctx).Where("warrior_id = ? AND season < ?", warriorID, beforeSeason).Order("season DESC").First(&row)
+    if tx.Error != nil { return nil, tx.Error }
+    return toArenaRating(&row), nil
+}
+
+// ApplyMatchRatings records a match's rating changes and the resulting ratings in one transaction.
+// Ratings are written only if their game count is still one behind (ErrRatingConflict otherwise),
+// and a match can only be recorded once (ErrMatchAlreadyRated).
+func (r *sqlRepo) ApplyMatchRatings(ctx context.Context, changes []*ArenaRatingChange, ratings []*ArenaRating) error {
+    db, err := getGorm(); if err != nil { return err }
+    return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        for _, c := range changes {
+            row := &ArenaRatingChangeSQL{
+                Season: c.Season, MatchID: c.MatchID, WarriorID: c.WarriorID,
+                OpponentID: c.OpponentID, OpponentName: c.OpponentName, Result: c.Result,
+                RatingBefore: c.RatingBefore, RatingAfter: c.RatingAfter, Delta: c.Delta,
+                CreatedAt: c.CreatedAt,
+            }
+            res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+            if res.Error != nil { return res.Error }
+            if res.RowsAffected == 0 { return ErrMatchAlreadyRated }
+        }
+        for _, rt := range ratings {
+            row := &ArenaRatingSQL{
+                Season: rt.Season, WarriorID: rt.WarriorID, WarriorName: rt.WarriorName,
+                Rating: rt.Rating, PeakRating: rt.PeakRating, Games: rt.Games,
+                Wins: rt.Wins, Losses: rt.Losses, Draws: rt.Draws, UpdatedAt: rt.UpdatedAt,
+            }
+            if rt.Games == 1 {
+                res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+                if res.Error != nil { return res.Error }
+                if res.RowsAffected == 0 { return ErrRatingConflict }
+                continue
+            }
+            res := tx.Model(&ArenaRatingSQL{}).
+                Where("season = ? AND warrior_id = ? AND games = ?", rt.Season, rt.WarriorID, rt.Games-1).
+                Updates(map[string]interface{}{
+                    "warrior_name": rt.WarriorName, "rating": rt.Rating, "peak_rating": rt.PeakRating,
+                    "games": rt.Games, "wins": rt.Wins, "losses": rt.Losses, "draws": rt.Draws,
+                    "updated_at": rt.UpdatedAt,
+                })
+            if res.Error != nil { return res.Error }
+            if res.RowsAffected == 0 { return ErrRatingConflict }
+        }
+        return nil
+    })
+}
+
+// ListRatings returns a season's ratings, highest first, with their rank
+func (r *sqlRepo) ListRatings(ctx context.Context, season int, limit, offset int) ([]*ArenaRating, int64, error) {
+    db, err := getGorm(); if err != nil { return nil, 0, err }
+    var total int64
+    q := db.WithContext(ctx).Model(&ArenaRatingSQL{}).Where("season = ?", season)
+    if tx := q.Count(&total); tx.Error != nil { return nil, 0, tx.Error }
+    var rows []ArenaRatingSQL
+    if limit > 0 { q = q.Limit(limit) }
+    if offset > 0 { q = q.Offset(offset) }
+    if tx := q.Order("rating DESC, warrior_id ASC").Find(&rows); tx.Error != nil { return nil, 0, tx.Error }
+    out := make([]*ArenaRating, len(rows))
+    for i := range rows {
+        out[i] = toArenaRating(&rows[i])
+        out[i].Rank = offset + i + 1
+    }
+    return out, total, nil
+}
+
+// ListRatingChanges returns a warrior's rating history, newest first
+func (r *sqlRepo) ListRatingChanges(ctx context.Context, warriorID uint, limit, offset int) ([]*ArenaRatingChange, int64, error) {
+    db, err := getGorm(); if err != nil { return nil, 0, err }
+    var total int64
+    q := db.WithContext(ctx).Model(&ArenaRatingChangeSQL{}).Where("warrior_id = ?", warriorID)
+    if tx := q.Count(&total); tx.Error != nil { return nil, 0, tx.Error }
+    var rows []ArenaRatingChangeSQL
+    if limit > 0 { q = q.Limit(limit) }
+    if offset > 0 { q = q.Offset(offset) }
+    if tx := q.Order("created_at DESC, id DESC").Find(&rows); tx.Error != nil { return nil, 0, tx.Error }
+    out := make([]*ArenaRatingChange, len(rows))
+    for i, row := range rows {
+        out[i] = &ArenaRatingChange{
+            Season: row.Season, MatchID: row.MatchID, WarriorID: row.WarriorID,
+            OpponentID: row.OpponentID, OpponentName: row.OpponentName, Result: row.Result,
+            RatingBefore: row.RatingBefore, RatingAfter: row.RatingAfter, Delta: row.Delta,
+            CreatedAt: row.CreatedAt,
+        }
+    }
+    return out, total, nil
+}
+
+// ListUnratedMatches returns matches completed since the given time that have no rating changes yet, oldest first
+func (r *sqlRepo) ListUnratedMatches(ctx context.Context, since time.Time, limit int) ([]ArenaMatch, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    var rows []ArenaMatchSQL
+    tx := db.WithContext(ctx).
+        Where("status = ? AND completed_at >= ?", string(MatchStatusCompleted), since).
+        Where("NOT EXISTS (SELECT 1 FROM arena_rating_changes c WHERE c.match_id = CAST(arena_matches.id AS VARCHAR(64)))").
+        Order("completed_at ASC, id ASC").Limit(limit).Find(&rows)
+    if tx.Error != nil { return nil, tx.Error }
+    out := make([]ArenaMatch, len(rows))
+    for i := range rows { out[i] = *toArenaMatch(&rows[i]) }
+    return out, nil
+}
+
+// InsertSeasonRewards records season rewards; rewards already recorded are left untouched
+func (r *sqlRepo) InsertSeasonRewards(ctx context.Context, rewards []*ArenaSeasonReward) error {
+    if len(rewards) == 0 { return nil }
+    db, err := getGorm(); if err != nil { return err }
+    rows := make([]*ArenaSeasonRewardSQL, len(rewards))
+    for i, rw := range rewards {
+        rows[i] = &ArenaSeasonRewardSQL{
+            Season: rw.Season, WarriorID: rw.WarriorID, WarriorName: rw.WarriorName,
+            Rank: rw.Rank, Rating: rw.Rating, Coins: rw.Coins, Paid: rw.Paid, CreatedAt: rw.CreatedAt,
+        }
+    }
+    return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+func (r *sqlRepo) ListSeasonRewards(ctx context.Context, season int) ([]*ArenaSeasonReward, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    var rows []ArenaSeasonRewardSQL
+    if tx := db.WithContext(ctx).Where("season = ?", season).Order("rank ASC").Find(&rows); tx.Error != nil { return nil, tx.Error }
+    out := make([]*ArenaSeasonReward, len(rows))
+    for i, row := range rows {
+        out[i] = &ArenaSeasonReward{
+            Season: row.Season, WarriorID: row.WarriorID, WarriorName: row.WarriorName,
+            Rank: row.Rank, Rating: row.Rating, Coins: row.Coins, Paid: row.Paid, CreatedAt: row.CreatedAt,
+        }
+    }
+    return out, nil
+}
+
+// SetSeasonRewardPaid flips the paid flag; it reports false if the flag already had that value
+func (r *sqlRepo) SetSeasonRewardPaid(ctx context.Context, season int, warriorID uint, paid bool) (bool, error) {
+    db, err := getGorm(); if err != nil { return false, err }
+    tx := db.WithContext(ctx).Model(&ArenaSeasonRewardSQL{}).
+        Where("season = ? AND warrior_id = ? AND paid = ?", season, warriorID, !paid).
+        Update("paid", paid)
+    if tx.Error != nil { return false, tx.Error }
+    return tx.RowsAffected == 1, nil
+}
+
+func toArenaInvitation(row *ArenaInvitationSQL) *ArenaInvitation {
+    return &ArenaInvitation{
+        ID: fmt.Sprintf("%d", row.ID),
+        ChallengerID: row.ChallengerID, ChallengerName: row.ChallengerName,
+        OpponentID: row.OpponentID, OpponentName: row.OpponentName,
+        Status: ArenaInvitationStatus(row.Status), ExpiresAt: row.ExpiresAt, RespondedAt: row.RespondedAt,
+        BattleID: row.BattleID, CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt,
+    }
+}
+
+func toArenaMatch(m *ArenaMatchSQL) *ArenaMatch {
+    return &ArenaMatch{
+        ID: fmt.Sprintf("%d", m.ID),
+        Player1ID: m.Player1ID, Player1Name: m.Player1Name,
+        Player1HP: m.Player1HP, Player1MaxHP: m.Player1MaxHP,
+        Player1Attack: m.Player1Attack, Player1Defense: m.Player1Defense,
+        Player2ID: m.Player2ID, Player2Name: m.Player2Name,
+        Player2HP: m.Player2HP, Player2MaxHP: m.Player2MaxHP,
+        Player2Attack: m.Player2Attack, Player2Defense: m.Player2Defense,
+        CurrentTurn: m.CurrentTurn, MaxTurns: m.MaxTurns, CurrentAttacker: m.CurrentAttacker,
+        Status: ArenaMatchStatus(m.Status), WinnerID: m.WinnerID, WinnerName: m.WinnerName,
+        StartedAt: m.StartedAt, CompletedAt: m.CompletedAt,
+        P1Below50Announced: m.P1Below50Announced, P2Below50Announced: m.P2Below50Announced,
+        P1Below10Announced: m.P1Below10Announced, P2Below10Announced: m.P2Below10Announced,
+        CreatedAt: m.CreatedAt, UpdatedAt: m.UpdatedAt,
+    }
+}
+
+func toArenaRating(row *ArenaRatingSQL) *ArenaRating {
+    return &ArenaRating{
+        Season: row.Season, WarriorID: row.WarriorID, WarriorName: row.WarriorName,
+        Rating: row.Rating, PeakRating: row.PeakRating, Games: row.Games,
+        Wins: row.Wins, Losses: row.Losses, Draws: row.Draws, UpdatedAt: row.UpdatedAt,
+    }
+}
+
+// ===== Tournaments (SQL) =====
+
+func (r *sqlRepo) CreateTournament(ctx context.Context, t *Tournament) (string, error) {
+    db, err := getGorm(); if err != nil { return "", err }
+    row := &ArenaTournamentSQL{
+        Name: t.Name, Format: t.Format, Status: string(t.Status),
+        CreatedByID: t.CreatedByID, CreatedByName: t.CreatedByName,
+        EntryFee: t.EntryFee, PrizePool: t.PrizePool, MinPlayers: t.MinPlayers, MaxPlayers: t.MaxPlayers,
+        Rounds: t.Rounds, ForfeitAfterMinutes: t.ForfeitAfterMinutes,
+        StartsAt: t.StartsAt, CreatedAt: t.CreatedAt, UpdatedAt: t.UpdatedAt,
+    }
+    if tx := db.WithContext(ctx).Create(row); tx.Error != nil { return "", tx.Error }
+    return fmt.Sprintf("%d", row.ID), nil
+}
+
+func (r *sqlRepo) GetTournament(ctx context.Context, id string) (*Tournament, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    var row ArenaTournamentSQL
+    if tx := db.WithContext(ctx).First(&row, "id = ?", id); tx.Error != nil { return nil, tx.Error }
+    return toTournament(&row), nil
+}
+
+// ListTournaments returns tournaments, optionally of one status, soonest starting first
+func (r *sqlRepo) ListTournaments(ctx context.Context, status string, limit, offset int) ([]*Tournament, int64, error) {
+    db, err := getGorm(); if err != nil { return nil, 0, err }
+    q := db.WithContext(ctx).Model(&ArenaTournamentSQL{})
+    if status != "" { q = q.Where("status = ?", status) }
+    var total int64
+    if tx := q.Count(&total); tx.Error != nil { return nil, 0, tx.Error }
+    var rows []ArenaTournamentSQL
+    if limit > 0 { q = q.Limit(limit) }
+    if offset > 0 { q = q.Offset(offset) }
+    if tx := q.Order("starts_at ASC, id ASC").Find(&rows); tx.Error != nil { return nil, 0, tx.Error }
+    out := make([]*Tournament, len(rows))
+    for i := range rows { out[i] = toTournament(&rows[i]) }
+    return out, total, nil
+}
+
+// TransitionTournament updates a tournament only if it still has the given status and round,
+// reporting false if another caller moved it on first
+func (r *sqlRepo) TransitionTournament(ctx context.Context, id string, from TournamentStatus, round int, fields map[string]interface{}) (bool, error) {
+    db, err := getGorm(); if err != nil { return false, err }
+    tx := db.WithContext(ctx).Model(&ArenaTournamentSQL{}).
+        Where("id = ? AND status = ? AND current_round = ?", id, string(from), round).
+        Updates(fields)
+    if tx.Error != nil { return false, tx.Error }
+    return tx.RowsAffected == 1, nil
+}
+
+// AddTournamentEntry registers a warrior and adds the fee to the prize pool while registration is open and seats remain.
+// A registration after a withdrawal reuses the withdrawn row, which must hold the previous registration.
+func (r *sqlRepo) AddTournamentEntry(ctx context.Context, e *TournamentEntry, fee int) error {
+    db, err := getGorm(); if err != nil { return err }
+    tournamentID, err := parseRowID(e.TournamentID); if err != nil { return err }
+    return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        res := tx.Model(&ArenaTournamentSQL{}).
+            Where("id = ? AND status = ? AND players < max_players", tournamentID, string(TournamentStatusRegistration)).
+            Updates(map[string]interface{}{
+                "players": gorm.Expr("players + 1"), "prize_pool": gorm.Expr("prize_pool + ?", fee), "updated_at": time.Now(),
+            })
+        if res.Error != nil { return res.Error }
+        if res.RowsAffected == 0 {
+            var t ArenaTournamentSQL
+            if err := tx.First(&t, "id = ?", tournamentID).Error; err != nil { return err }
+            if t.Status != string(TournamentStatusRegistration) { return ErrRegistrationClosed }
+            return ErrTournamentFull
+        }
+        if e.Registration > 1 {
+            res = tx.Model(&ArenaTournamentEntrySQL{}).
+                Where("tournament_id = ? AND warrior_id = ? AND status = ? AND registration = ?",
+                    tournamentID, e.WarriorID, string(TournamentEntryWithdrawn), e.Registration-1).
+                Updates(map[string]interface{}{
+                    "warrior_name": e.WarriorName, "rating": e.Rating, "status": string(e.Status),
+                    "registration": e.Registration, "registered_at": e.RegisteredAt,
+                })
+        } else {
+            row := &ArenaTournamentEntrySQL{
+                TournamentID: tournamentID, WarriorID: e.WarriorID, WarriorName: e.WarriorName,
+                Rating: e.Rating, Status: string(e.Status), Registration: 1, RegisteredAt: e.RegisteredAt,
+            }
+            res = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+        }
+        if res.Error != nil { return res.Error }
+        if res.RowsAffected == 0 { return ErrAlreadyRegistered }
+        return nil
+    })
+}
+
+// RemoveTournamentEntry withdraws a warrior and takes their fee back out of the prize pool while registration is open.
+// It returns the number of the registration withdrawn.
+func (r *sqlRepo) RemoveTournamentEntry(ctx context.Context, tournamentID string, warriorID uint, fee int) (int, error) {
+    db, err := getGorm(); if err != nil { return 0, err }
+    id, err := parseRowID(tournamentID); if err != nil { return 0, err }
+    var registration int
+    err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        var row ArenaTournamentEntrySQL
+        res := tx.Where("tournament_id = ? AND warrior_id = ? AND status = ?", id, warriorID, string(TournamentEntryActive)).First(&row)
+        if errors.Is(res.Error, gorm.ErrRecordNotFound) { return ErrNotRegistered }
+        if res.Error != nil { return res.Error }
+        res = tx.Model(&ArenaTournamentEntrySQL{}).
+            Where("id = ? AND status = ? AND registration = ?", row.ID, string(TournamentEntryActive), row.Registration).
+            Update("status", string(TournamentEntryWithdrawn))
+        if res.Error != nil { return res.Error }
+        if res.RowsAffected == 0 { return ErrNotRegistered }
+        registration = row.Registration
+        res = tx.Model(&ArenaTournamentSQL{}).
+            Where("id = ? AND status = ?", id, string(TournamentStatusRegistration)).
+            Updates(map[string]interface{}{
+                "players": gorm.Expr("players - 1"), "prize_pool": gorm.Expr("prize_pool - ?", fee), "updated_at": time.Now(),
+            })
+        if res.Error != nil { return res.Error }
+        if res.RowsAffected == 0 { return ErrRegistrationClosed }
+        return nil
+    })
+    return registration, err
+}
+
+// GetTournamentEntry returns a warrior's entry, withdrawn or not, or gorm.ErrRecordNotFound
+func (r *sqlRepo) GetTournamentEntry(ctx context.Context, tournamentID string, warriorID uint) (*TournamentEntry, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    var row ArenaTournamentEntrySQL
+    if tx := db.WithContext(ctx).Where("tournament_id = ? AND warrior_id = ?", tournamentID, warriorID).First(&row); tx.Error != nil { return nil, tx.Error }
+    return toTournamentEntry(&row), nil
+}
+
+// ListTournamentEntries returns a tournament's entries, without withdrawn ones, by seed, then registration order
+func (r *sqlRepo) ListTournamentEntries(ctx context.Context, tournamentID string) ([]*TournamentEntry, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    var rows []ArenaTournamentEntrySQL
+    tx := db.WithContext(ctx).Where("tournament_id = ? AND status <> ?", tournamentID, string(TournamentEntryWithdrawn)).Order("seed ASC, registered_at ASC, id ASC").Find(&rows)
+    if tx.Error != nil { return nil, tx.Error }
+    out := make([]*TournamentEntry, len(rows))
+    for i := range rows { out[i] = toTournamentEntry(&rows[i]) }
+    return out, nil
+}
+
+// UpdateTournamentEntries saves the seeding, record and placing of entries; the prize paid flag is left alone
+func (r *sqlRepo) UpdateTournamentEntries(ctx context.Context, entries []*TournamentEntry) error {
+    db, err := getGorm(); if err != nil { return err }
+    return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        for _, e := range entries {
+            res := tx.Model(&ArenaTournamentEntrySQL{}).
+                Where("tournament_id = ? AND warrior_id = ?", e.TournamentID, e.WarriorID).
+                Updates(map[string]interface{}{
+                    "seed": e.Seed, "status": string(e.Status),
+                    "wins": e.Wins, "losses": e.Losses, "draws": e.Draws, "points": e.Points,
+                    "eliminated_round": e.EliminatedRound, "place": e.Place, "prize": e.Prize,
+                })
+            if res.Error != nil { return res.Error }
+        }
+        return nil
+    })
+}
+
+// SetTournamentPrizePaid flips the prize paid flag; it reports false if the flag already had that value
+func (r *sqlRepo) SetTournamentPrizePaid(ctx context.Context, tournamentID string, warriorID uint, paid bool) (bool, error) {
+    db, err := getGorm(); if err != nil { return false, err }
+    tx := db.WithContext(ctx).Model(&ArenaTournamentEntrySQL{}).
+        Where("tournament_id = ? AND warrior_id = ? AND prize_paid = ?", tournamentID, warriorID, !paid).
+        Update("prize_paid", paid)
+    if tx.Error != nil { return false, tx.Error }
+    return tx.RowsAffected == 1, nil
+}
+
+// ListUnpaidTournamentPrizes returns entries awarded a prize that has not been paid yet
+func (r *sqlRepo) ListUnpaidTournamentPrizes(ctx context.Context) ([]*TournamentEntry, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    var rows []ArenaTournamentEntrySQL
+    if tx := db.WithContext(ctx).Where("prize > 0 AND prize_paid = ?", false).Find(&rows); tx.Error != nil { return nil, tx.Error }
+    out := make([]*TournamentEntry, len(rows))
+    for i := range rows { out[i] = toTournamentEntry(&rows[i]) }
+    return out, nil
+}
+
+// InsertTournamentMatches records the slots of a round and sets their IDs
+func (r *sqlRepo) InsertTournamentMatches(ctx context.Context, matches []*TournamentMatch) error {
+    if len(matches) == 0 { return nil }
+    db, err := getGorm(); if err != nil { return err }
+    rows := make([]*ArenaTournamentMatchSQL, len(matches))
+    for i, m := range matches {
+        tournamentID, err := parseRowID(m.TournamentID); if err != nil { return err }
+        rows[i] = &ArenaTournamentMatchSQL{
+            TournamentID: tournamentID, Round: m.Round, Pool: m.Pool, Slot: m.Slot,
+            Player1ID: m.Player1ID, Player1Name: m.Player1Name, Player2ID: m.Player2ID, Player2Name: m.Player2Name,
+            MatchID: m.MatchID, Status: string(m.Status), WinnerID: m.WinnerID, WinnerName: m.WinnerName,
+            Deadline: m.Deadline, CreatedAt: m.CreatedAt, CompletedAt: m.CompletedAt,
+        }
+    }
+    if tx := db.WithContext(ctx).Create(&rows); tx.Error != nil { return tx.Error }
+    for i, row := range rows { matches[i].ID = fmt.Sprintf("%d", row.ID) }
+    return nil
+}
+
+// ListTournamentMatches returns the slots of one round, or of every round when round is 0, in bracket order
+func (r *sqlRepo) ListTournamentMatches(ctx context.Context, tournamentID string, round int) ([]*TournamentMatch, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    q := db.WithContext(ctx).Where("tournament_id = ?", tournamentID)
+    if round > 0 { q = q.Where("round = ?", round) }
+    var rows []ArenaTournamentMatchSQL
+    if tx := q.Order("round ASC, pool DESC, slot ASC").Find(&rows); tx.Error != nil { return nil, tx.Error }
+    out := make([]*TournamentMatch, len(rows))
+    for i := range rows { out[i] = toTournamentMatch(&rows[i]) }
+    return out, nil
+}
+
+func (r *sqlRepo) GetTournamentMatchByArenaMatch(ctx context.Context, matchID string) (*TournamentMatch, error) {
+    db, err := getGorm(); if err != nil { return nil, err }
+    var row ArenaTournamentMatchSQL
+    if tx := db.WithContext(ctx).Where("match_id = ?", matchID).First(&row); tx.Error != nil { return nil, tx.Error }
+    return toTournamentMatch(&row), nil
+}
+
+// UpdateTournamentMatch updates a slot only if it still has the given status; it reports false otherwise
+func (r *sqlRepo) UpdateTournamentMatch(ctx context.Context, id string, from TournamentMatchStatus, fields map[string]interface{}) (bool, error) {
+    db, err := getGorm(); if err != nil { return false, err }
+    tx := db.WithContext(ctx).Model(&ArenaTournamentMatchSQL{}).Where("id = ? AND status = ?", id, string(from)).Updates(fields)
+    if tx.Error != nil { return false, tx.Error }
+    return tx.RowsAffected == 1, nil
+}
+
+func parseRowID(id string) (uint, error) {
+    v, err := strconv.ParseUint(id, 10, 64)
+    if err != nil { return 0, fmt.Errorf("invalid id %q", id) }
+    return uint(v), nil
+}
+
+func toTournament(row *ArenaTournamentSQL) *Tournament {
+    return &Tournament{
+        ID: fmt.Sprintf("%d", row.ID), Name: row.Name, Format: row.Format, Status: TournamentStatus(row.Status),
+        CreatedByID: row.CreatedByID, CreatedByName: row.CreatedByName,
+        EntryFee: row.EntryFee, PrizePool: row.PrizePool, MinPlayers: row.MinPlayers, MaxPlayers: row.MaxPlayers,
+        Players: row.Players, Rounds: row.Rounds, CurrentRound: row.CurrentRound, ForfeitAfterMinutes: row.ForfeitAfterMinutes,
+        WinnerID: row.WinnerID, WinnerName: row.WinnerName,
+        StartsAt: row.StartsAt, StartedAt: row.StartedAt, CompletedAt: row.CompletedAt,
+        CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt,
+    }
+}
+
+func toTournamentEntry(row *ArenaTournamentEntrySQL) *TournamentEntry {
+    return &TournamentEntry{
+        TournamentID: fmt.Sprintf("%d", row.TournamentID), WarriorID: row.WarriorID, WarriorName: row.WarriorName,
+        Rating: row.Rating, Seed: row.Seed, Status: TournamentEntryStatus(row.Status),
+        Wins: row.Wins, Losses: row.Losses, Draws: row.Draws, Points: row.Points,
+        EliminatedRound: row.EliminatedRound, Place: row.Place, Prize: row.Prize, PrizePaid: row.PrizePaid,
+        Registration: row.Registration, RegisteredAt: row.RegisteredAt,
+    }
+}
+
+func toTournamentMatch(row *ArenaTournamentMatchSQL) *TournamentMatch {
+    return &TournamentMatch{
+        ID: fmt.Sprintf("%d", row.ID), TournamentID: fmt.Sprintf("%d", row.TournamentID),
+        Round: row.Round, Pool: row.Pool, Slot: row.Slot,
+        Player1ID: row.Player1ID, Player1Name: row.Player1Name, Player2ID: row.Player2ID, Player2Name: row.Player2Name,
+        MatchID: row.MatchID, Status: TournamentMatchStatus(row.Status), WinnerID: row.WinnerID, WinnerName: row.WinnerName,
+        Deadline: row.Deadline, CreatedAt: row.CreatedAt, CompletedAt: row.CompletedAt,
+    }
+}
@@ -40,4 +40,4 @@ func (r *redisRepo) UpdateMatchFields(ctx context.Context, id string, fields map
     return rc.Set(ctx, key, enc, 0).Err()
 }
 
-
+// The rest of repository methods are handled by SQL for persistence; Redis remains a match snapshot cache.
@@ -71,9 +71,31 @@ func SetupRoutes(r *gin.Engine, handler *Handler) {
 		// Match operations
 		api.GET("/matches/my", handler.GetMyMatches)
 		api.GET("/matches/:id", handler.GetMatch)
+		api.GET("/matches/:id/watch", handler.WatchMatch)
 		api.POST("/matches/attack", handler.AttackInArena)
 		// Arenaspell application
 		api.POST("/spells/apply", handler.ApplyArenaSpell)
+
+		// Ranked ladder and seasons
+		api.GET("/leaderboard", handler.GetLeaderboard)
+		api.GET("/seasons/current", handler.GetCurrentSeason)
+		api.GET("/ratings/me", handler.GetMyRating)
+		api.GET("/ratings/:warrior_id/history", handler.GetRatingHistory)
+
+		// Matchmaking queue
+		api.POST("/queue", handler.JoinQueue)
+		api.DELETE("/queue", handler.LeaveQueue)
+		api.GET("/queue", handler.GetQueueStatus)
+
+		// Tournaments
+		api.POST("/tournaments", handler.CreateTournament)
+		api.GET("/tournaments", handler.ListTournaments)
+		api.GET("/tournaments/:id", handler.GetTournament)
+		api.GET("/tournaments/:id/bracket", handler.GetTournamentBracket)
+		api.POST("/tournaments/:id/register", handler.RegisterForTournament)
+		api.DELETE("/tournaments/:id/register", handler.WithdrawFromTournament)
+		api.POST("/tournaments/:id/start", handler.StartTournament)
+		api.POST("/tournaments/:id/cancel", handler.CancelTournament)
 	}
 }
 
@@ -5,14 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	pbWarrior "network-sec-micro/api/proto/warrior"
 	"network-sec-micro/internal/arena/dto"
 	"network-sec-micro/pkg/secrets"
-
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"network-sec-micro/pkg/spectate"
 )
 
 // Service handles arena business logic with CQRS pattern
@@ -107,7 +106,7 @@ func (s *Service) AcceptInvitation(ctx context.Context, cmd dto.AcceptInvitation
 	// Check if can be accepted
 	if !invitation.CanBeAccepted() {
 		if invitation.IsExpired() {
-			s.markInvitationAsExpired(ctx, invitationID)
+			s.markInvitationAsExpired(ctx, invitation.ID)
 			return nil, errors.New("invitation has expired")
 		}
 		return nil, fmt.Errorf("invitation cannot be accepted (status: %s)", invitation.Status)
@@ -125,73 +124,23 @@ func (s *Service) AcceptInvitation(ctx context.Context, cmd dto.AcceptInvitation
 	}
 
 	// Check if either warrior is currently healing
-	if challenger.IsHealing && challenger.HealingUntilSeconds > 0 {
-		healingUntil := time.Unix(challenger.HealingUntilSeconds, 0)
-		if time.Now().Before(healingUntil) {
-			remaining := time.Until(healingUntil).Seconds()
-			return nil, fmt.Errorf("challenger is currently healing. Cannot start match. Remaining time: %.0f seconds", remaining)
-		}
+	if remaining := healingRemaining(challenger); remaining > 0 {
+		return nil, fmt.Errorf("challenger is currently healing. Cannot start match. Remaining time: %.0f seconds", remaining.Seconds())
 	}
-	if opponent.IsHealing && opponent.HealingUntilSeconds > 0 {
-		healingUntil := time.Unix(opponent.HealingUntilSeconds, 0)
-		if time.Now().Before(healingUntil) {
-			remaining := time.Until(healingUntil).Seconds()
-			return nil, fmt.Errorf("opponent is currently healing. Cannot start match. Remaining time: %.0f seconds", remaining)
-		}
-	}
-
-	// Optionally recalc power via weapon service
-	if secrets.GetOrDefault("ARENA_USE_WEAPON_POWER", "") != "" {
-		if tp, wc, err := CalculateWarriorPowerViaWeapon(ctx, challenger.Username); err == nil {
-			challenger.TotalPower = tp
-			challenger.WeaponCount = wc
-		}
-		if tp, wc, err := CalculateWarriorPowerViaWeapon(ctx, opponent.Username); err == nil {
-			opponent.TotalPower = tp
-			opponent.WeaponCount = wc
-		}
-	}
-
-	// Calculate HP based on total power
-	challengerMaxHP := int(challenger.TotalPower) * 10
-	if challengerMaxHP < 100 {
-		challengerMaxHP = 100
-	}
-
-	opponentMaxHP := int(opponent.TotalPower) * 10
-	if opponentMaxHP < 100 {
-		opponentMaxHP = 100
+	if remaining := healingRemaining(opponent); remaining > 0 {
+		return nil, fmt.Errorf("opponent is currently healing. Cannot start match. Remaining time: %.0f seconds", remaining.Seconds())
 	}
 
 	// Create arena match directly (no battle service dependency)
 	now := time.Now()
-	match := &ArenaMatch{
-		Player1ID:       invitation.ChallengerID,
-		Player1Name:     invitation.ChallengerName,
-		Player1HP:       challengerMaxHP,
-		Player1MaxHP:    challengerMaxHP,
-		Player1Attack:   int(challenger.AttackPower),
-		Player1Defense:  int(challenger.Defense),
-		Player2ID:       invitation.OpponentID,
-		Player2Name:     invitation.OpponentName,
-		Player2HP:       opponentMaxHP,
-		Player2MaxHP:    opponentMaxHP,
-		Player2Attack:   int(opponent.AttackPower),
-		Player2Defense:  int(opponent.Defense),
-		CurrentTurn:     0,
-		MaxTurns:        50, // Default for arena battles
-		CurrentAttacker: 1,  // Player1 starts first
-		Status:          MatchStatusInProgress,
-		StartedAt:       &now,
-		CreatedAt:       now,
-		UpdatedAt:       now,
-	}
+	match := newArenaMatch(ctx, challenger, opponent, now)
 
 	matchID, err := GetRepository().CreateMatch(ctx, match)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create match: %w", err)
 	}
 	match.ID = matchID
+	leaveQueueForMatch(ctx, match.Player1ID, match.Player2ID)
 
 	// Update invitation
 	updateData := map[string]interface{}{
@@ -219,20 +168,71 @@ func (s *Service) AcceptInvitation(ctx context.Context, cmd dto.AcceptInvitation
 	return match, nil
 }
 
+// healingRemaining returns how long the warrior is still healing; zero if not healing
+func healingRemaining(w *pbWarrior.Warrior) time.Duration {
+	if !w.IsHealing || w.HealingUntilSeconds <= 0 {
+		return 0
+	}
+	remaining := time.Until(time.Unix(w.HealingUntilSeconds, 0))
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// newArenaMatch builds an in-progress match between two warriors, player1 moving first
+func newArenaMatch(ctx context.Context, player1, player2 *pbWarrior.Warrior, now time.Time) *ArenaMatch {
+	// Optionally recalc power via weapon service
+	if secrets.GetOrDefault("ARENA_USE_WEAPON_POWER", "") != "" {
+		if tp, wc, err := CalculateWarriorPowerViaWeapon(ctx, player1.Username); err == nil {
+			player1.TotalPower = tp
+			player1.WeaponCount = wc
+		}
+		if tp, wc, err := CalculateWarriorPowerViaWeapon(ctx, player2.Username); err == nil {
+			player2.TotalPower = tp
+			player2.WeaponCount = wc
+		}
+	}
+
+	// Calculate HP based on total power
+	player1MaxHP := int(player1.TotalPower) * 10
+	if player1MaxHP < 100 {
+		player1MaxHP = 100
+	}
+
+	player2MaxHP := int(player2.TotalPower) * 10
+	if player2MaxHP < 100 {
+		player2MaxHP = 100
+	}
+
+	return &ArenaMatch{
+		Player1ID:       uint(player1.Id),
+		Player1Name:     player1.Username,
+		Player1HP:       player1MaxHP,
+		Player1MaxHP:    player1MaxHP,
+		Player1Attack:   int(player1.TotalPower),
+		Player2ID:       uint(player2.Id),
+		Player2Name:     player2.Username,
+		Player2HP:       player2MaxHP,
+		Player2MaxHP:    player2MaxHP,
+		Player2Attack:   int(player2.TotalPower),
+		CurrentTurn:     0,
+		MaxTurns:        50, // Default for arena battles
+		CurrentAttacker: 1,  // Player1 starts first
+		Status:          MatchStatusInProgress,
+		StartedAt:       &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
 // RejectInvitation rejects an arena invitation
 func (s *Service) RejectInvitation(ctx context.Context, cmd dto.RejectInvitationCommand) error {
-	invitationID, err := primitive.ObjectIDFromHex(cmd.InvitationID)
-	if err != nil {
+	if cmd.InvitationID == "" {
 		return errors.New("invalid invitation ID")
 	}
-
-	// Get invitation
-	var invitation ArenaInvitation
-	err = InvitationColl.FindOne(ctx, bson.M{"_id": invitationID}).Decode(&invitation)
+	invitation, err := GetRepository().GetInvitationByID(ctx, cmd.InvitationID)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return errors.New("invitation not found")
-		}
 		return fmt.Errorf("failed to get invitation: %w", err)
 	}
 
@@ -247,14 +247,13 @@ func (s *Service) RejectInvitation(ctx context.Context, cmd dto.RejectInvitation
 
 	// Update invitation
 	now := time.Now()
-	updateData := bson.M{
+	updateData := map[string]interface{}{
 		"status":       InvitationStatusRejected,
 		"responded_at": now,
 		"updated_at":   now,
 	}
 
-	_, err = InvitationColl.UpdateOne(ctx, bson.M{"_id": invitationID}, bson.M{"$set": updateData})
-	if err != nil {
+	if err := GetRepository().UpdateInvitationFields(ctx, cmd.InvitationID, updateData); err != nil {
 		return fmt.Errorf("failed to update invitation: %w", err)
 	}
 
@@ -271,18 +270,11 @@ func (s *Service) RejectInvitation(ctx context.Context, cmd dto.RejectInvitation
 
 // CancelInvitation cancels an invitation (only by challenger)
 func (s *Service) CancelInvitation(ctx context.Context, cmd dto.CancelInvitationCommand) error {
-	invitationID, err := primitive.ObjectIDFromHex(cmd.InvitationID)
-	if err != nil {
+	if cmd.InvitationID == "" {
 		return errors.New("invalid invitation ID")
 	}
-
-	// Get invitation
-	var invitation ArenaInvitation
-	err = InvitationColl.FindOne(ctx, bson.M{"_id": invitationID}).Decode(&invitation)
+	invitation, err := GetRepository().GetInvitationByID(ctx, cmd.InvitationID)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return errors.New("invitation not found")
-		}
 		return fmt.Errorf("failed to get invitation: %w", err)
 	}
 
@@ -297,13 +289,12 @@ func (s *Service) CancelInvitation(ctx context.Context, cmd dto.CancelInvitation
 
 	// Update invitation
 	now := time.Now()
-	updateData := bson.M{
+	updateData := map[string]interface{}{
 		"status":     InvitationStatusCancelled,
 		"updated_at": now,
 	}
 
-	_, err = InvitationColl.UpdateOne(ctx, bson.M{"_id": invitationID}, bson.M{"$set": updateData})
-	if err != nil {
+	if err := GetRepository().UpdateInvitationFields(ctx, cmd.InvitationID, updateData); err != nil {
 		return fmt.Errorf("failed to update invitation: %w", err)
 	}
 
@@ -312,7 +303,7 @@ func (s *Service) CancelInvitation(ctx context.Context, cmd dto.CancelInvitation
 }
 
 // PerformAttack performs an attack in an arena match
-func (s *Service) PerformAttack(ctx context.Context, matchID primitive.ObjectID, attackerID uint) (*ArenaMatch, error) {
+func (s *Service) PerformAttack(ctx context.Context, matchID string, attackerID uint) (*ArenaMatch, error) {
 	var match ArenaMatch
 	repo := GetRepository()
 	m, err := repo.GetMatchByID(ctx, matchID)
@@ -326,7 +317,7 @@ func (s *Service) PerformAttack(ctx context.Context, matchID primitive.ObjectID,
 	}
 
 	// Validate attacker
-	var attackerHP, attackerAttack *int
+	var attackerAttack *int
 	var defenderHP, defenderDefense *int
 	var defenderID uint
 	var attackerName, defenderName string
@@ -336,7 +327,6 @@ func (s *Service) PerformAttack(ctx context.Context, matchID primitive.ObjectID,
 		if match.CurrentAttacker != 1 && match.CurrentAttacker != 0 {
 			return nil, errors.New("not your turn")
 		}
-		attackerHP = &match.Player1HP
 		attackerAttack = &match.Player1Attack
 		defenderHP = &match.Player2HP
 		defenderDefense = &match.Player2Defense
@@ -348,7 +338,6 @@ func (s *Service) PerformAttack(ctx context.Context, matchID primitive.ObjectID,
 		if match.CurrentAttacker != 2 {
 			return nil, errors.New("not your turn")
 		}
-		attackerHP = &match.Player2HP
 		attackerAttack = &match.Player2Attack
 		defenderHP = &match.Player1HP
 		defenderDefense = &match.Player1Defense
@@ -384,7 +373,6 @@ func (s *Service) PerformAttack(ctx context.Context, matchID primitive.ObjectID,
 
 	// Include armor bonus defense and HP for defender
 	armorDefenseBonus := 0
-	armorHPBonus := 0
 	var defenderUsername string
 	if defenderID == match.Player1ID {
 		defenderUsername = match.Player1Name
@@ -394,7 +382,6 @@ func (s *Service) PerformAttack(ctx context.Context, matchID primitive.ObjectID,
 	if defenderUsername != "" {
 		if armors, err := ListArmorsByOwner(ctx, "warrior", defenderUsername); err == nil {
 			maxDef := 0
-			totalHPBonus := 0
 			var usedArmorID string
 			for _, a := range armors {
 				if a.IsBroken {
@@ -402,12 +389,10 @@ func (s *Service) PerformAttack(ctx context.Context, matchID primitive.ObjectID,
 				}
 				if int(a.Defense) > maxDef {
 					maxDef = int(a.Defense)
-					totalHPBonus = int(a.HpBonus)
 					usedArmorID = a.Id
 				}
 			}
 			armorDefenseBonus = maxDef
-			armorHPBonus = totalHPBonus
 			if usedArmorID != "" {
 				_, _ = ApplyArmorWear(ctx, usedArmorID, 1)
 			}
@@ -481,22 +466,6 @@ func (s *Service) PerformAttack(ctx context.Context, matchID primitive.ObjectID,
 			match.WinnerID = &winnerID
 			match.WinnerName = match.Player2Name
 		}
-
-		// Publish match completed event
-		go func() {
-			if err := PublishMatchCompleted(
-				match.ID,
-				match.Player1ID,
-				match.Player1Name,
-				match.Player2ID,
-				match.Player2Name,
-				match.WinnerID,
-				match.WinnerName,
-				match.ID,
-			); err != nil {
-				log.Printf("Failed to publish match completed event: %v", err)
-			}
-		}()
 	} else if match.CurrentTurn >= match.MaxTurns {
 		// Match timeout - draw (or determine winner by HP)
 		if match.Player1HP > match.Player2HP {
@@ -513,21 +482,6 @@ func (s *Service) PerformAttack(ctx context.Context, matchID primitive.ObjectID,
 		match.Status = MatchStatusCompleted
 		now := time.Now()
 		match.CompletedAt = &now
-
-		go func() {
-			if err := PublishMatchCompleted(
-				match.ID,
-				match.Player1ID,
-				match.Player1Name,
-				match.Player2ID,
-				match.Player2Name,
-				match.WinnerID,
-				match.WinnerName,
-				match.ID,
-			); err != nil {
-				log.Printf("Failed to publish match completed event: %v", err)
-			}
-		}()
 	}
 
 	match.UpdatedAt = time.Now()
@@ -554,12 +508,61 @@ func (s *Service) PerformAttack(ctx context.Context, matchID primitive.ObjectID,
 		return nil, fmt.Errorf("failed to update match: %w", err)
 	}
 
+	publishSpectatorEvent(match.ID, spectate.EventTurn, ArenaTurn{
+		MatchID:      match.ID,
+		TurnNumber:   match.CurrentTurn,
+		AttackerID:   attackerID,
+		AttackerName: attackerName,
+		DefenderID:   defenderID,
+		DefenderName: defenderName,
+		Damage:       damage,
+		DefenderHP:   *defenderHP,
+		Player1HP:    match.Player1HP,
+		Player2HP:    match.Player2HP,
+	})
+	// Feed the result into the ranked ladder and any tournament the match belongs to
+	if match.Status == MatchStatusCompleted {
+		completed := match
+		go func() {
+			s.finishMatch(context.Background(), &completed)
+			if err := s.RecordTournamentResult(context.Background(), &completed); err != nil {
+				log.Printf("Failed to record tournament result: %v", err)
+			}
+		}()
+	}
+
 	log.Printf("Arena attack: %s dealt %d damage to %s (HP: %d)", attackerName, damage, defenderName, *defenderHP)
 	return &match, nil
 }
 
+// finishMatch announces a match that has just been completed, by a final turn or a forfeit, and rates it.
+// Rating is recorded once per match, so a match finished twice is not rated twice; a failed rating is
+// retried by the season scheduler.
+func (s *Service) finishMatch(ctx context.Context, match *ArenaMatch) {
+	completed := *match
+	go func() {
+		if err := PublishMatchCompleted(
+			completed.ID,
+			completed.Player1ID,
+			completed.Player1Name,
+			completed.Player2ID,
+			completed.Player2Name,
+			completed.WinnerID,
+			completed.WinnerName,
+			completed.ID,
+		); err != nil {
+			log.Printf("Failed to publish match completed event: %v", err)
+		}
+	}()
+	publishSpectatorEvent(match.ID, spectate.EventCompleted, completed)
+
+	if err := s.RecordMatchRatings(ctx, match); err != nil {
+		log.Printf("Failed to update arena ratings: %v", err)
+	}
+}
+
 // ApplySpellEffect applies a 1v1 arenaspell's immediate effect to the match
-func (s *Service) ApplySpellEffect(ctx context.Context, matchID primitive.ObjectID, casterID uint, spellType string) (*ArenaMatch, error) {
+func (s *Service) ApplySpellEffect(ctx context.Context, matchID string, casterID uint, spellType string) (*ArenaMatch, error) {
 	var match ArenaMatch
 	repo := GetRepository()
 	m, err := repo.GetMatchByID(ctx, matchID)
@@ -574,7 +577,7 @@ func (s *Service) ApplySpellEffect(ctx context.Context, matchID primitive.Object
 
 	// Resolve caster/opponent pointers
 	var casterAttack, casterDefense, casterHP, casterMaxHP *int
-	var opponentAttack, opponentDefense *int
+	var opponentAttack, opponentDefense, opponentHP, opponentMaxHP *int
 	var casterName, opponentName string
 
 	if casterID == match.Player1ID {
@@ -584,6 +587,8 @@ func (s *Service) ApplySpellEffect(ctx context.Context, matchID primitive.Object
 		casterMaxHP = &match.Player1MaxHP
 		opponentAttack = &match.Player2Attack
 		opponentDefense = &match.Player2Defense
+		opponentHP = &match.Player2HP
+		opponentMaxHP = &match.Player2MaxHP
 		casterName = match.Player1Name
 		opponentName = match.Player2Name
 	} else if casterID == match.Player2ID {
@@ -593,6 +598,8 @@ func (s *Service) ApplySpellEffect(ctx context.Context, matchID primitive.Object
 		casterMaxHP = &match.Player2MaxHP
 		opponentAttack = &match.Player1Attack
 		opponentDefense = &match.Player1Defense
+		opponentHP = &match.Player1HP
+		opponentMaxHP = &match.Player1MaxHP
 		casterName = match.Player2Name
 		opponentName = match.Player1Name
 	} else {
@@ -660,29 +667,15 @@ func (s *Service) ApplySpellEffect(ctx context.Context, matchID primitive.Object
 		}
 		*opponentAttack = halve(*opponentAttack)
 		*opponentDefense = halve(*opponentDefense)
-		if *casterIDPtr := &casterID; casterIDPtr != nil { /* placeholder to avoid unused var warnings in some editors */
-		}
-		if *opponentDefense >= 0 && *opponentAttack >= 0 { /* noop to keep linters calm */
-		}
-		if *casterMaxHP > 0 { /* no-op */
-		}
-		if *defenderHP != 0 { /* no-op */
-		}
 		// Apply HP damage to opponent
-		if opponentMax := func() int {
-			if casterID == match.Player1ID {
-				return match.Player2MaxHP
-			} else {
-				return match.Player1MaxHP
-			}
-		}(); opponentMax > 0 {
-			dmg := opponentMax / 5
+		if *opponentMaxHP > 0 {
+			dmg := (*opponentMaxHP) / 5
 			if dmg < 1 {
 				dmg = 1
 			}
-			*defenderHP -= dmg
-			if *defenderHP < 0 {
-				*defenderHP = 0
+			*opponentHP -= dmg
+			if *opponentHP < 0 {
+				*opponentHP = 0
 			}
 		}
 	default:
@@ -704,25 +697,32 @@ func (s *Service) ApplySpellEffect(ctx context.Context, matchID primitive.Object
 	if err != nil {
 		return nil, fmt.Errorf("failed to update match: %w", err)
 	}
+	publishSpectatorEvent(match.ID, spectate.EventSpellCast, spectate.SpellCast{
+		SpellType:  spellType,
+		CasterID:   strconv.FormatUint(uint64(casterID), 10),
+		CasterName: casterName,
+	})
 
 	log.Printf("Arena spell applied: %s by %s on %s", spellType, casterName, opponentName)
 	return &match, nil
 }
 
 // markInvitationAsExpired marks an invitation as expired
-func (s *Service) markInvitationAsExpired(ctx context.Context, invitationID primitive.ObjectID) {
-	var invitation ArenaInvitation
-	err := InvitationColl.FindOne(ctx, bson.M{"_id": invitationID}).Decode(&invitation)
+func (s *Service) markInvitationAsExpired(ctx context.Context, invitationID string) {
+	invitation, err := GetRepository().GetInvitationByID(ctx, invitationID)
 	if err != nil {
 		return
 	}
 
 	if invitation.Status == InvitationStatusPending {
-		updateData := bson.M{
+		updateData := map[string]interface{}{
 			"status":     InvitationStatusExpired,
 			"updated_at": time.Now(),
 		}
-		InvitationColl.UpdateOne(ctx, bson.M{"_id": invitationID}, bson.M{"$set": updateData})
+		if err := GetRepository().UpdateInvitationFields(ctx, invitationID, updateData); err != nil {
+			log.Printf("Failed to expire invitation %s: %v", invitationID, err)
+			return
+		}
 
 		// Publish event
 		go func() {
@@ -737,52 +737,30 @@ func (s *Service) markInvitationAsExpired(ctx context.Context, invitationID prim
 
 // GetInvitation gets an invitation by ID
 func (s *Service) GetInvitation(ctx context.Context, query dto.GetInvitationQuery) (*ArenaInvitation, error) {
-	invitationID, err := primitive.ObjectIDFromHex(query.InvitationID)
-	if err != nil {
+	if query.InvitationID == "" {
 		return nil, errors.New("invalid invitation ID")
 	}
 
-	var invitation ArenaInvitation
-	err = InvitationColl.FindOne(ctx, bson.M{"_id": invitationID}).Decode(&invitation)
+	invitation, err := GetRepository().GetInvitationByID(ctx, query.InvitationID)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("invitation not found")
-		}
 		return nil, fmt.Errorf("failed to get invitation: %w", err)
 	}
 
 	// Check if expired
 	if invitation.IsExpired() && invitation.Status == InvitationStatusPending {
-		s.markInvitationAsExpired(ctx, invitationID)
+		s.markInvitationAsExpired(ctx, invitation.ID)
 		invitation.Status = InvitationStatusExpired
 	}
 
-	return &invitation, nil
+	return invitation, nil
 }
 
 // GetMyInvitations gets user's invitations (sent or received)
 func (s *Service) GetMyInvitations(ctx context.Context, query dto.GetMyInvitationsQuery) ([]ArenaInvitation, error) {
-	filter := bson.M{
-		"$or": []bson.M{
-			{"challenger_id": query.UserID},
-			{"opponent_id": query.UserID},
-		},
-	}
-
-	if query.Status != "" {
-		filter["status"] = ArenaInvitationStatus(query.Status)
-	}
-
-	cursor, err := InvitationColl.Find(ctx, filter)
+	invitations, err := GetRepository().ListInvitations(ctx, query.UserID, query.Status)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find invitations: %w", err)
 	}
-	defer cursor.Close(ctx)
-
-	var invitations []ArenaInvitation
-	if err := cursor.All(ctx, &invitations); err != nil {
-		return nil, fmt.Errorf("failed to decode invitations: %w", err)
-	}
 
 	// Check for expired invitations
 	for i := range invitations {
@@ -797,27 +775,10 @@ func (s *Service) GetMyInvitations(ctx context.Context, query dto.GetMyInvitatio
 
 // GetMyMatches gets user's arena matches
 func (s *Service) GetMyMatches(ctx context.Context, query dto.GetMyMatchesQuery) ([]ArenaMatch, error) {
-	filter := bson.M{
-		"$or": []bson.M{
-			{"player1_id": query.UserID},
-			{"player2_id": query.UserID},
-		},
-	}
-
-	if query.Status != "" {
-		filter["status"] = ArenaMatchStatus(query.Status)
-	}
-
-	cursor, err := MatchColl.Find(ctx, filter)
+	matches, err := GetRepository().ListMatches(ctx, query.UserID, query.Status)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find matches: %w", err)
 	}
-	defer cursor.Close(ctx)
-
-	var matches []ArenaMatch
-	if err := cursor.All(ctx, &matches); err != nil {
-		return nil, fmt.Errorf("failed to decode matches: %w", err)
-	}
 
 	return matches, nil
 }
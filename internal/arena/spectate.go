@@ -0,0 +1,106 @@
+package arena
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"network-sec-micro/pkg/spectate"
+)
+
+// ArenaTurn is the data of a turn event sent to spectators of an arena match
+type ArenaTurn struct {
+	MatchID      string `json:"match_id"`
+	TurnNumber   int    `json:"turn_number"`
+	AttackerID   uint   `json:"attacker_id"`
+	AttackerName string `json:"attacker_name"`
+	DefenderID   uint   `json:"defender_id"`
+	DefenderName string `json:"defender_name"`
+	Damage       int    `json:"damage"`
+	DefenderHP   int    `json:"defender_hp"`
+	Player1HP    int    `json:"player1_hp"`
+	Player2HP    int    `json:"player2_hp"`
+}
+
+var (
+	spectatorHub     *spectate.Hub
+	spectatorHubOnce sync.Once
+)
+
+// GetSpectatorHub returns the hub sharing this replica's Redis subscription between spectators,
+// or nil when Redis is not available
+func GetSpectatorHub() *spectate.Hub {
+	rc := getRedis()
+	if rc == nil {
+		return nil
+	}
+	spectatorHubOnce.Do(func() {
+		spectatorHub = spectate.NewHub(rc)
+	})
+	return spectatorHub
+}
+
+// CloseSpectatorHub disconnects all spectators
+func CloseSpectatorHub() {
+	if spectatorHub != nil {
+		_ = spectatorHub.Close()
+	}
+}
+
+// publishSpectatorEvent pushes an update to everyone watching the match, on any replica.
+// It publishes synchronously so spectators see events in the order they happened.
+// Spectating is best effort: without Redis nothing is sent and failures are only logged.
+func publishSpectatorEvent(matchID string, eventType string, data interface{}) {
+	rc := getRedis()
+	if rc == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := spectate.Publish(ctx, rc, spectate.ArenaMatchChannel(matchID), eventType, data); err != nil {
+		log.Printf("Failed to publish %s to spectators of arena match %s: %v", eventType, matchID, err)
+	}
+}
+
+// canWatchMatch applies the battle service's viewing rules to a match: players watch their own matches,
+// emperors watch any match and kings watch matches with a player of their faction and no emperor
+func canWatchMatch(ctx context.Context, user *User, match *ArenaMatch) bool {
+	if user.UserID == match.Player1ID || user.UserID == match.Player2ID {
+		return true
+	}
+	switch user.Role {
+	case "light_emperor", "dark_emperor":
+		return true
+	case "light_king", "dark_king":
+	default:
+		return false
+	}
+
+	faction := roleFaction(user.Role)
+	sameFaction := false
+	for _, id := range []uint{match.Player1ID, match.Player2ID} {
+		w, err := GetWarriorByID(ctx, id)
+		if err != nil {
+			return false
+		}
+		if w.Role == "light_emperor" || w.Role == "dark_emperor" {
+			return false
+		}
+		if roleFaction(w.Role) == faction {
+			sameFaction = true
+		}
+	}
+	return sameFaction
+}
+
+// roleFaction returns the faction (light/dark) of a role
+func roleFaction(role string) string {
+	switch role {
+	case "light_emperor", "light_king", "knight", "archer", "mage":
+		return "light"
+	case "dark_emperor", "dark_king":
+		return "dark"
+	}
+	return "unknown"
+}
@@ -0,0 +1,912 @@
+package arena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"network-sec-micro/internal/arena/dto"
+	"network-sec-micro/pkg/bracket"
+
+	"gorm.io/gorm"
+)
+
+// Tournament stages, published as events whenever a tournament moves on
+const (
+	TournamentStageRegistrationOpen = "registration_open"
+	TournamentStageStarted          = "started"
+	TournamentStageRoundStarted     = "round_started"
+	TournamentStageCompleted        = "completed"
+	TournamentStageCancelled        = "cancelled"
+)
+
+const (
+	swissWinPoints  = 3 // A bye counts as a win
+	swissDrawPoints = 1
+
+	defaultTournamentMinPlayers     = 2
+	defaultTournamentMaxPlayers     = 16
+	maxTournamentPlayers            = 256
+	defaultTournamentForfeitMinutes = 15
+)
+
+// TournamentPrizeSplit is the share of the prize pool, in percent, paid to each finishing position
+var TournamentPrizeSplit = []int{50, 30, 20}
+
+// tournamentMu serialises advancing tournaments within this instance; across instances
+// round changes are guarded by TransitionTournament
+var tournamentMu sync.Mutex
+
+// CanOrganizeTournaments checks if a role may create tournaments
+func CanOrganizeTournaments(role string) bool {
+	switch role {
+	case "light_emperor", "dark_emperor", "light_king", "dark_king":
+		return true
+	}
+	return false
+}
+
+func tournamentsAvailable() error {
+	if !SQLDB.Enabled {
+		return errors.New("tournaments require PostgreSQL")
+	}
+	return nil
+}
+
+// ==================== REGISTRATION ====================
+
+// CreateTournament opens a tournament for registration
+func (s *Service) CreateTournament(ctx context.Context, cmd dto.CreateTournamentCommand) (*Tournament, error) {
+	if err := tournamentsAvailable(); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(cmd.Name)
+	format := bracket.Format(cmd.Format)
+	if name == "" {
+		return nil, errors.New("tournament name is required")
+	}
+	if !format.Valid() {
+		return nil, fmt.Errorf("unknown tournament format %q", cmd.Format)
+	}
+	if cmd.EntryFee < 0 {
+		return nil, errors.New("entry fee cannot be negative")
+	}
+	if cmd.MinPlayers == 0 {
+		cmd.MinPlayers = defaultTournamentMinPlayers
+	}
+	if cmd.MaxPlayers == 0 {
+		cmd.MaxPlayers = defaultTournamentMaxPlayers
+	}
+	if cmd.MinPlayers < 2 || cmd.MaxPlayers < cmd.MinPlayers || cmd.MaxPlayers > maxTournamentPlayers {
+		return nil, fmt.Errorf("players must be between 2 and %d, with min_players not above max_players", maxTournamentPlayers)
+	}
+	if cmd.SwissRounds < 0 || (cmd.SwissRounds > 0 && format != bracket.Swiss) {
+		return nil, errors.New("swiss_rounds only applies to swiss tournaments and cannot be negative")
+	}
+	if cmd.ForfeitAfterMinutes == 0 {
+		cmd.ForfeitAfterMinutes = defaultTournamentForfeitMinutes
+	}
+	if cmd.ForfeitAfterMinutes < 0 {
+		return nil, errors.New("forfeit_after_minutes cannot be negative")
+	}
+	now := time.Now()
+	if !cmd.StartsAt.After(now) {
+		return nil, errors.New("tournament must start in the future")
+	}
+
+	t := &Tournament{
+		Name:                name,
+		Format:              string(format),
+		Status:              TournamentStatusRegistration,
+		CreatedByID:         cmd.OrganizerID,
+		CreatedByName:       cmd.OrganizerName,
+		EntryFee:            cmd.EntryFee,
+		MinPlayers:          cmd.MinPlayers,
+		MaxPlayers:          cmd.MaxPlayers,
+		Rounds:              cmd.SwissRounds,
+		ForfeitAfterMinutes: cmd.ForfeitAfterMinutes,
+		StartsAt:            cmd.StartsAt,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+	id, err := GetRepository().CreateTournament(ctx, t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tournament: %w", err)
+	}
+	t.ID = id
+
+	s.publishTournamentStage(t, TournamentStageRegistrationOpen)
+	log.Printf("Arena tournament created: %s (%s), starts %s", t.Name, t.Format, t.StartsAt.Format(time.RFC3339))
+	return t, nil
+}
+
+// RegisterForTournament enters a warrior, charging the entry fee into the prize pool
+func (s *Service) RegisterForTournament(ctx context.Context, cmd dto.TournamentRegistrationCommand) (*TournamentEntry, error) {
+	if err := tournamentsAvailable(); err != nil {
+		return nil, err
+	}
+	repo := GetRepository()
+	t, err := repo.GetTournament(ctx, cmd.TournamentID)
+	if err != nil {
+		return nil, errors.New("tournament not found")
+	}
+	if t.Status != TournamentStatusRegistration || !time.Now().Before(t.StartsAt) {
+		return nil, ErrRegistrationClosed
+	}
+	if t.Players >= t.MaxPlayers {
+		return nil, ErrTournamentFull
+	}
+
+	// Registrations are numbered so that each one's entry fee and refund are charged once
+	registration := 1
+	if prev, err := repo.GetTournamentEntry(ctx, t.ID, cmd.WarriorID); err == nil {
+		if prev.Status != TournamentEntryWithdrawn {
+			return nil, ErrAlreadyRegistered
+		}
+		registration = prev.Registration + 1
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to load tournament entry: %w", err)
+	}
+
+	r, err := s.GetMyRating(ctx, cmd.WarriorID, cmd.WarriorName)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.EntryFee > 0 {
+		key := fmt.Sprintf("arena_tournament_entry:%s:%d:%d", t.ID, cmd.WarriorID, registration)
+		if err := DeductCoins(ctx, cmd.WarriorID, int64(t.EntryFee), fmt.Sprintf("arena_tournament_%s_entry", t.ID), key); err != nil {
+			return nil, fmt.Errorf("failed to pay entry fee: %w", err)
+		}
+	}
+
+	entry := &TournamentEntry{
+		TournamentID: t.ID,
+		WarriorID:    cmd.WarriorID,
+		WarriorName:  cmd.WarriorName,
+		Rating:       r.Rating,
+		Status:       TournamentEntryActive,
+		Registration: registration,
+		RegisteredAt: time.Now(),
+	}
+	if err := repo.AddTournamentEntry(ctx, entry, t.EntryFee); err != nil {
+		// A concurrent request with the same registration number shared this fee; it is refunded only if that one failed too
+		if cur, gerr := repo.GetTournamentEntry(ctx, t.ID, cmd.WarriorID); gerr != nil || cur.Status == TournamentEntryWithdrawn || cur.Registration != registration {
+			s.refundEntryFee(ctx, t, cmd.WarriorID, registration)
+		}
+		return nil, err
+	}
+
+	log.Printf("Warrior %s registered for arena tournament %s", cmd.WarriorName, t.Name)
+	return entry, nil
+}
+
+// WithdrawFromTournament removes a warrior before the tournament starts and refunds the entry fee
+func (s *Service) WithdrawFromTournament(ctx context.Context, cmd dto.TournamentRegistrationCommand) error {
+	if err := tournamentsAvailable(); err != nil {
+		return err
+	}
+	repo := GetRepository()
+	t, err := repo.GetTournament(ctx, cmd.TournamentID)
+	if err != nil {
+		return errors.New("tournament not found")
+	}
+	registration, err := repo.RemoveTournamentEntry(ctx, t.ID, cmd.WarriorID, t.EntryFee)
+	if err != nil {
+		return err
+	}
+	s.refundEntryFee(ctx, t, cmd.WarriorID, registration)
+	return nil
+}
+
+// refundEntryFee pays back the entry fee of one registration
+func (s *Service) refundEntryFee(ctx context.Context, t *Tournament, warriorID uint, registration int) {
+	if t.EntryFee <= 0 {
+		return
+	}
+	key := fmt.Sprintf("arena_tournament_refund:%s:%d:%d", t.ID, warriorID, registration)
+	if err := AddCoins(ctx, warriorID, int64(t.EntryFee), fmt.Sprintf("arena_tournament_%s_refund", t.ID), key); err != nil {
+		log.Printf("Failed to refund tournament %s entry fee to warrior %d: %v", t.ID, warriorID, err)
+	}
+}
+
+// CancelTournament calls off a tournament that has not started. Entry fees are recorded as
+// refunds and paid out like prizes, so a coin service outage only delays them.
+func (s *Service) CancelTournament(ctx context.Context, id string) (*Tournament, error) {
+	if err := tournamentsAvailable(); err != nil {
+		return nil, err
+	}
+	repo := GetRepository()
+	t, err := repo.GetTournament(ctx, id)
+	if err != nil {
+		return nil, errors.New("tournament not found")
+	}
+
+	now := time.Now()
+	ok, err := repo.TransitionTournament(ctx, t.ID, TournamentStatusRegistration, 0, map[string]interface{}{
+		"status": string(TournamentStatusCancelled), "completed_at": now, "updated_at": now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel tournament: %w", err)
+	}
+	if !ok {
+		return nil, errors.New("only tournaments still open for registration can be cancelled")
+	}
+	t.Status = TournamentStatusCancelled
+	t.CompletedAt = &now
+
+	entries, err := repo.ListTournamentEntries(ctx, t.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tournament entries: %w", err)
+	}
+	if t.EntryFee > 0 {
+		for _, e := range entries {
+			e.Prize = t.EntryFee
+		}
+		if err := repo.UpdateTournamentEntries(ctx, entries); err != nil {
+			return nil, fmt.Errorf("failed to record refunds: %w", err)
+		}
+		s.payTournamentPrizes(ctx, entries)
+	}
+
+	s.publishTournamentStage(t, TournamentStageCancelled)
+	log.Printf("Arena tournament %s cancelled with %d entries", t.Name, len(entries))
+	return t, nil
+}
+
+// ==================== BRACKET ====================
+
+// StartTournament closes registration, seeds the entries by rating and creates the first round.
+// Tournaments short of their minimum players are cancelled instead.
+func (s *Service) StartTournament(ctx context.Context, id string) (*Tournament, error) {
+	if err := tournamentsAvailable(); err != nil {
+		return nil, err
+	}
+	tournamentMu.Lock()
+	defer tournamentMu.Unlock()
+
+	repo := GetRepository()
+	t, err := repo.GetTournament(ctx, id)
+	if err != nil {
+		return nil, errors.New("tournament not found")
+	}
+	if t.Status != TournamentStatusRegistration {
+		return nil, fmt.Errorf("tournament is %s", t.Status)
+	}
+	entries, err := repo.ListTournamentEntries(ctx, t.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tournament entries: %w", err)
+	}
+	if len(entries) < t.MinPlayers {
+		log.Printf("Arena tournament %s has %d of %d players needed, cancelling", t.Name, len(entries), t.MinPlayers)
+		return s.CancelTournament(ctx, t.ID)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Rating != entries[j].Rating {
+			return entries[i].Rating > entries[j].Rating
+		}
+		return entries[i].RegisteredAt.Before(entries[j].RegisteredAt)
+	})
+	for i, e := range entries {
+		e.Seed = i + 1
+	}
+	rounds := t.Rounds
+	if t.Format == string(bracket.Swiss) && rounds == 0 {
+		rounds = bracket.SwissRounds(len(entries))
+	}
+
+	now := time.Now()
+	ok, err := repo.TransitionTournament(ctx, t.ID, TournamentStatusRegistration, 0, map[string]interface{}{
+		"status": string(TournamentStatusInProgress), "current_round": 1, "rounds": rounds,
+		"started_at": now, "updated_at": now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start tournament: %w", err)
+	}
+	if !ok {
+		return nil, errors.New("tournament was started or cancelled concurrently")
+	}
+	t.Status = TournamentStatusInProgress
+	t.CurrentRound = 1
+	t.Rounds = rounds
+	t.StartedAt = &now
+
+	if err := repo.UpdateTournamentEntries(ctx, entries); err != nil {
+		return nil, fmt.Errorf("failed to seed tournament: %w", err)
+	}
+	s.publishTournamentStage(t, TournamentStageStarted)
+
+	if err := s.createTournamentRound(ctx, t, pairRound(t, 1, entries, nil), entries); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// tally recomputes the entries' records from the finished matches played so far
+func tally(t *Tournament, entries []*TournamentEntry, played []*TournamentMatch) {
+	byID := make(map[uint]*TournamentEntry, len(entries))
+	for _, e := range entries {
+		e.Wins, e.Losses, e.Draws, e.Points, e.EliminatedRound = 0, 0, 0, 0, 0
+		e.Status = TournamentEntryActive
+		byID[e.WarriorID] = e
+	}
+	maxLosses := bracket.Format(t.Format).MaxLosses()
+
+	for _, m := range played {
+		if !m.IsFinished() {
+			continue
+		}
+		p1, p2 := byID[m.Player1ID], byID[m.Player2ID]
+		if m.WinnerID == nil {
+			if p1 != nil && p2 != nil {
+				p1.Draws++
+				p2.Draws++
+				p1.Points += swissDrawPoints
+				p2.Points += swissDrawPoints
+			}
+			continue
+		}
+		winner, loser := p1, p2
+		if *m.WinnerID == m.Player2ID {
+			winner, loser = p2, p1
+		}
+		if winner != nil {
+			winner.Wins++
+			winner.Points += swissWinPoints
+		}
+		if loser != nil {
+			loser.Losses++
+			if maxLosses > 0 && loser.Losses >= maxLosses && loser.EliminatedRound == 0 {
+				loser.EliminatedRound = m.Round
+				loser.Status = TournamentEntryEliminated
+			}
+		}
+	}
+}
+
+// pairRound works out the pairings of a round from the matches played before it (tallying the entries
+// on the way). Entries must be in seed order. No pairings means the tournament is decided.
+func pairRound(t *Tournament, round int, entries []*TournamentEntry, played []*TournamentMatch) []bracket.Pairing {
+	tally(t, entries, played)
+	format := bracket.Format(t.Format)
+
+	if format == bracket.Swiss {
+		if round > t.Rounds {
+			return nil
+		}
+		history := bracket.NewHistory()
+		for _, m := range played {
+			history.Record(m.Player1ID, m.Player2ID)
+		}
+		standings := make([]bracket.Standing, len(entries))
+		for i, e := range entries {
+			standings[i] = bracket.Standing{ID: e.WarriorID, Points: e.Points, Rating: e.Rating}
+		}
+		return bracket.SwissPairings(standings, history)
+	}
+
+	if round == 1 {
+		seeded := make([]uint, len(entries))
+		for i, e := range entries {
+			seeded[i] = e.WarriorID
+		}
+		return bracket.FirstRound(seeded)
+	}
+
+	losses := make(map[uint]int, len(entries))
+	for _, e := range entries {
+		losses[e.WarriorID] = e.Losses
+	}
+	// Keep bracket order: last round's results slot by slot, then anyone who sat the round out.
+	// Players just dropped from the winners pool lead the losers pool, so its byes move around.
+	var upper, lower []uint
+	seen := make(map[uint]bool)
+	add := func(id uint) {
+		if id == 0 || seen[id] {
+			return
+		}
+		seen[id] = true
+		switch losses[id] {
+		case 0:
+			upper = append(upper, id)
+		case 1:
+			if format == bracket.DoubleElimination {
+				lower = append(lower, id)
+			}
+		}
+	}
+	previous := func(pool bracket.Pool, winnersOnly bool) {
+		for _, m := range played {
+			if m.Round != round-1 || (pool != "" && m.Pool != string(pool)) {
+				continue
+			}
+			if winnersOnly {
+				if m.WinnerID != nil {
+					add(*m.WinnerID)
+				}
+				continue
+			}
+			add(m.Player1ID)
+			add(m.Player2ID)
+		}
+	}
+	previous(bracket.PoolWinners, true)
+	previous(bracket.PoolWinners, false)
+	previous(bracket.PoolLosers, true)
+	previous(bracket.PoolFinal, true)
+	previous("", false)
+	for _, e := range entries {
+		add(e.WarriorID)
+	}
+
+	if format == bracket.DoubleElimination {
+		return bracket.DoubleEliminationRound(upper, lower)
+	}
+	return bracket.EliminationRound(upper)
+}
+
+// createTournamentRound records a round's bracket slots and starts an arena match for each pairing
+func (s *Service) createTournamentRound(ctx context.Context, t *Tournament, pairings []bracket.Pairing, entries []*TournamentEntry) error {
+	names := make(map[uint]string, len(entries))
+	for _, e := range entries {
+		names[e.WarriorID] = e.WarriorName
+	}
+
+	now := time.Now()
+	matches := make([]*TournamentMatch, len(pairings))
+	for i, p := range pairings {
+		m := &TournamentMatch{
+			TournamentID: t.ID,
+			Round:        t.CurrentRound,
+			Pool:         string(p.Pool),
+			Slot:         p.Slot,
+			Player1ID:    p.A,
+			Player1Name:  names[p.A],
+			Player2ID:    p.B,
+			Player2Name:  names[p.B],
+			Status:       TournamentMatchPending,
+			CreatedAt:    now,
+		}
+		if p.IsBye() {
+			winner := p.A
+			m.Status = TournamentMatchBye
+			m.WinnerID = &winner
+			m.WinnerName = names[p.A]
+			m.CompletedAt = &now
+		}
+		matches[i] = m
+	}
+	if err := GetRepository().InsertTournamentMatches(ctx, matches); err != nil {
+		return fmt.Errorf("failed to create round %d: %w", t.CurrentRound, err)
+	}
+
+	for _, m := range matches {
+		if m.Status == TournamentMatchPending {
+			if err := s.launchTournamentMatch(ctx, t, m); err != nil {
+				log.Printf("Failed to start tournament %s match %s, will retry: %v", t.ID, m.ID, err)
+			}
+		}
+	}
+	s.publishTournamentStage(t, TournamentStageRoundStarted)
+	log.Printf("Arena tournament %s round %d started with %d matches", t.Name, t.CurrentRound, len(matches))
+	return nil
+}
+
+// launchTournamentMatch creates the arena match of a pending bracket slot and starts its forfeit clock
+func (s *Service) launchTournamentMatch(ctx context.Context, t *Tournament, m *TournamentMatch) error {
+	player1, err := GetWarriorByID(ctx, m.Player1ID)
+	if err != nil {
+		return fmt.Errorf("failed to get warrior: %w", err)
+	}
+	player2, err := GetWarriorByID(ctx, m.Player2ID)
+	if err != nil {
+		return fmt.Errorf("failed to get warrior: %w", err)
+	}
+
+	now := time.Now()
+	match := newArenaMatch(ctx, player1, player2, now)
+	matchID, err := GetRepository().CreateMatch(ctx, match)
+	if err != nil {
+		return fmt.Errorf("failed to create match: %w", err)
+	}
+	match.ID = matchID
+
+	deadline := now.Add(time.Duration(t.ForfeitAfterMinutes) * time.Minute)
+	ok, err := GetRepository().UpdateTournamentMatch(ctx, m.ID, TournamentMatchPending, map[string]interface{}{
+		"match_id": matchID, "status": string(TournamentMatchInProgress), "deadline": deadline,
+	})
+	if err != nil || !ok {
+		// Someone else launched the slot first; drop the duplicate match
+		_ = GetRepository().UpdateMatchFields(ctx, matchID, map[string]interface{}{"status": MatchStatusCancelled, "updated_at": now})
+		return err
+	}
+	m.MatchID = matchID
+	m.Status = TournamentMatchInProgress
+	m.Deadline = &deadline
+	leaveQueueForMatch(ctx, m.Player1ID, m.Player2ID)
+
+	if err := PublishMatchStarted(matchID, match.Player1ID, match.Player1Name, match.Player2ID, match.Player2Name, matchID); err != nil {
+		log.Printf("Failed to publish match started event: %v", err)
+	}
+	return nil
+}
+
+// ==================== RESULTS ====================
+
+// RecordTournamentResult feeds a completed arena match into its tournament, if it belongs to one,
+// and moves the tournament on once the round is over
+func (s *Service) RecordTournamentResult(ctx context.Context, match *ArenaMatch) error {
+	if !SQLDB.Enabled || match.Status != MatchStatusCompleted {
+		return nil
+	}
+	repo := GetRepository()
+	tm, err := repo.GetTournamentMatchByArenaMatch(ctx, match.ID)
+	if err != nil {
+		return nil // Not a tournament match
+	}
+
+	tournamentMu.Lock()
+	defer tournamentMu.Unlock()
+
+	t, err := repo.GetTournament(ctx, tm.TournamentID)
+	if err != nil {
+		return err
+	}
+	if err := s.completeTournamentMatch(ctx, t, tm, match, TournamentMatchCompleted); err != nil {
+		return err
+	}
+	return s.advanceTournament(ctx, t)
+}
+
+// completeTournamentMatch records the winner of a slot. Elimination brackets need one, so a drawn
+// match goes to the player with more of their HP left, or player1 when even.
+func (s *Service) completeTournamentMatch(ctx context.Context, t *Tournament, tm *TournamentMatch, match *ArenaMatch, status TournamentMatchStatus) error {
+	winnerID, winnerName := match.WinnerID, match.WinnerName
+	if winnerID == nil && t.Format != string(bracket.Swiss) {
+		id, name := match.Player1ID, match.Player1Name
+		if match.Player2HP*max(match.Player1MaxHP, 1) > match.Player1HP*max(match.Player2MaxHP, 1) {
+			id, name = match.Player2ID, match.Player2Name
+		}
+		winnerID, winnerName = &id, name
+	}
+
+	now := time.Now()
+	_, err := GetRepository().UpdateTournamentMatch(ctx, tm.ID, TournamentMatchInProgress, map[string]interface{}{
+		"status": string(status), "winner_id": winnerID, "winner_name": winnerName, "completed_at": now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record tournament match: %w", err)
+	}
+	tm.Status, tm.WinnerID, tm.WinnerName, tm.CompletedAt = status, winnerID, winnerName, &now
+	return nil
+}
+
+// checkTournamentMatch picks up a finished arena match the completion hook missed, or forfeits the match
+// once its deadline has passed. The player whose turn it is at that point is the one holding it up and loses.
+func (s *Service) checkTournamentMatch(ctx context.Context, t *Tournament, tm *TournamentMatch) (bool, error) {
+	match, err := GetRepository().GetMatchByID(ctx, tm.MatchID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load match %s: %w", tm.MatchID, err)
+	}
+	if match.Status == MatchStatusCompleted {
+		// The hook may have missed the rating as well; a match already rated is left alone
+		if err := s.RecordMatchRatings(ctx, match); err != nil {
+			log.Printf("Failed to update arena ratings: %v", err)
+		}
+		return true, s.completeTournamentMatch(ctx, t, tm, match, TournamentMatchCompleted)
+	}
+	if tm.Deadline == nil || time.Now().Before(*tm.Deadline) {
+		return false, nil
+	}
+
+	winnerID, winnerName, loserName := match.Player2ID, match.Player2Name, match.Player1Name
+	if match.CurrentAttacker == 2 {
+		winnerID, winnerName, loserName = match.Player1ID, match.Player1Name, match.Player2Name
+	}
+	now := time.Now()
+	if err := GetRepository().UpdateMatchFields(ctx, tm.MatchID, map[string]interface{}{
+		"status": MatchStatusCompleted, "winner_id": winnerID, "winner_name": winnerName,
+		"completed_at": now, "updated_at": now,
+	}); err != nil {
+		return false, fmt.Errorf("failed to forfeit match %s: %w", tm.MatchID, err)
+	}
+	match.Status, match.WinnerID, match.WinnerName, match.CompletedAt = MatchStatusCompleted, &winnerID, winnerName, &now
+	s.finishMatch(ctx, match)
+
+	log.Printf("Arena tournament %s: %s forfeits match %s", t.Name, loserName, tm.MatchID)
+	return true, s.completeTournamentMatch(ctx, t, tm, match, TournamentMatchForfeit)
+}
+
+// advanceTournament launches what is still pending in the current round, settles what is finished or overdue,
+// and once every slot has a result pairs the next round or finishes the tournament. Callers hold tournamentMu.
+func (s *Service) advanceTournament(ctx context.Context, t *Tournament) error {
+	if t.Status != TournamentStatusInProgress {
+		return nil
+	}
+	repo := GetRepository()
+	entries, err := repo.ListTournamentEntries(ctx, t.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load tournament entries: %w", err)
+	}
+	matches, err := repo.ListTournamentMatches(ctx, t.ID, 0)
+	if err != nil {
+		return fmt.Errorf("failed to load tournament matches: %w", err)
+	}
+
+	var played, current []*TournamentMatch
+	for _, m := range matches {
+		if m.Round < t.CurrentRound {
+			played = append(played, m)
+		} else if m.Round == t.CurrentRound {
+			current = append(current, m)
+		}
+	}
+	if len(current) == 0 {
+		// The round was moved on but never recorded, e.g. after a crash in between
+		return s.createTournamentRound(ctx, t, pairRound(t, t.CurrentRound, entries, played), entries)
+	}
+
+	finished := true
+	for _, m := range current {
+		switch m.Status {
+		case TournamentMatchPending:
+			if err := s.launchTournamentMatch(ctx, t, m); err != nil {
+				log.Printf("Failed to start tournament %s match %s: %v", t.ID, m.ID, err)
+			}
+			finished = false
+		case TournamentMatchInProgress:
+			done, err := s.checkTournamentMatch(ctx, t, m)
+			if err != nil {
+				log.Printf("Failed to check tournament %s match %s: %v", t.ID, m.ID, err)
+			}
+			finished = finished && done && err == nil
+		}
+	}
+	if !finished {
+		return nil
+	}
+
+	played = append(played, current...)
+	next := pairRound(t, t.CurrentRound+1, entries, played)
+	if len(next) == 0 {
+		return s.finishTournament(ctx, t, entries)
+	}
+
+	ok, err := repo.TransitionTournament(ctx, t.ID, TournamentStatusInProgress, t.CurrentRound, map[string]interface{}{
+		"current_round": t.CurrentRound + 1, "updated_at": time.Now(),
+	})
+	if err != nil || !ok {
+		return err
+	}
+	t.CurrentRound++
+	if err := repo.UpdateTournamentEntries(ctx, entries); err != nil {
+		log.Printf("Failed to update tournament %s standings: %v", t.ID, err)
+	}
+	return s.createTournamentRound(ctx, t, next, entries)
+}
+
+// finishTournament places the entries, splits the prize pool and pays it out.
+// Elimination brackets place players by how long they lasted, sharing places lost in the same round;
+// Swiss places by points, then wins, then seed.
+func (s *Service) finishTournament(ctx context.Context, t *Tournament, entries []*TournamentEntry) error {
+	ranked := append([]*TournamentEntry(nil), entries...)
+	var places [][]*TournamentEntry
+	if t.Format == string(bracket.Swiss) {
+		sort.SliceStable(ranked, func(i, j int) bool {
+			a, b := ranked[i], ranked[j]
+			if a.Points != b.Points {
+				return a.Points > b.Points
+			}
+			if a.Wins != b.Wins {
+				return a.Wins > b.Wins
+			}
+			return a.Seed < b.Seed
+		})
+		for _, e := range ranked {
+			places = append(places, []*TournamentEntry{e})
+		}
+	} else {
+		// Still standing (EliminatedRound 0) first, then the latest knocked out
+		sort.SliceStable(ranked, func(i, j int) bool {
+			a, b := ranked[i].EliminatedRound, ranked[j].EliminatedRound
+			if (a == 0) != (b == 0) {
+				return a == 0
+			}
+			return a > b
+		})
+		for i, e := range ranked {
+			if i > 0 && e.EliminatedRound == ranked[i-1].EliminatedRound {
+				places[len(places)-1] = append(places[len(places)-1], e)
+				continue
+			}
+			places = append(places, []*TournamentEntry{e})
+		}
+	}
+
+	ids := make([][]uint, len(places))
+	for i, tied := range places {
+		for _, e := range tied {
+			ids[i] = append(ids[i], e.WarriorID)
+		}
+	}
+	prizes := bracket.SplitPrizes(t.PrizePool, TournamentPrizeSplit, ids)
+	position := 1
+	for _, tied := range places {
+		for _, e := range tied {
+			e.Place = position
+			e.Prize = prizes[e.WarriorID]
+		}
+		position += len(tied)
+	}
+	champion := places[0][0]
+	champion.Status = TournamentEntryWinner
+
+	repo := GetRepository()
+	if err := repo.UpdateTournamentEntries(ctx, entries); err != nil {
+		return fmt.Errorf("failed to record tournament standings: %w", err)
+	}
+	now := time.Now()
+	ok, err := repo.TransitionTournament(ctx, t.ID, TournamentStatusInProgress, t.CurrentRound, map[string]interface{}{
+		"status": string(TournamentStatusCompleted), "winner_id": champion.WarriorID, "winner_name": champion.WarriorName,
+		"completed_at": now, "updated_at": now,
+	})
+	if err != nil || !ok {
+		return err
+	}
+	t.Status = TournamentStatusCompleted
+	t.WinnerID, t.WinnerName = &champion.WarriorID, champion.WarriorName
+	t.CompletedAt = &now
+
+	s.publishTournamentStage(t, TournamentStageCompleted)
+	log.Printf("Arena tournament %s won by %s", t.Name, champion.WarriorName)
+	s.payTournamentPrizes(ctx, entries)
+	return nil
+}
+
+// payTournamentPrizes credits each unpaid prize, or refund of a cancelled tournament, once.
+// The paid flag is claimed first and released again if the coin service fails.
+func (s *Service) payTournamentPrizes(ctx context.Context, entries []*TournamentEntry) {
+	repo := GetRepository()
+	for _, e := range entries {
+		if e.Prize <= 0 || e.PrizePaid {
+			continue
+		}
+		claimed, err := repo.SetTournamentPrizePaid(ctx, e.TournamentID, e.WarriorID, true)
+		if err != nil {
+			log.Printf("Failed to claim tournament %s prize for warrior %d: %v", e.TournamentID, e.WarriorID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		reason := fmt.Sprintf("arena_tournament_%s_place_%d", e.TournamentID, e.Place)
+		if e.Place == 0 {
+			reason = fmt.Sprintf("arena_tournament_%s_refund", e.TournamentID)
+		}
+		if err := AddCoins(ctx, e.WarriorID, int64(e.Prize), reason, fmt.Sprintf("arena_tournament_prize:%s:%d", e.TournamentID, e.WarriorID)); err != nil {
+			log.Printf("Failed to pay tournament %s prize to warrior %d: %v", e.TournamentID, e.WarriorID, err)
+			if _, err := repo.SetTournamentPrizePaid(ctx, e.TournamentID, e.WarriorID, false); err != nil {
+				log.Printf("Failed to release tournament prize claim for warrior %d: %v", e.WarriorID, err)
+			}
+			continue
+		}
+		e.PrizePaid = true
+	}
+}
+
+// StartTournamentScheduler starts tournaments once registration closes, advances running ones
+// (launching matches, forfeiting no-shows, pairing rounds) and retries unpaid prizes, every interval until ctx is done
+func (s *Service) StartTournamentScheduler(ctx context.Context, interval time.Duration) {
+	check := func() {
+		repo := GetRepository()
+		open, _, err := repo.ListTournaments(ctx, string(TournamentStatusRegistration), 0, 0)
+		if err != nil {
+			log.Printf("Arena tournament check failed: %v", err)
+			return
+		}
+		for _, t := range open {
+			if time.Now().Before(t.StartsAt) {
+				continue
+			}
+			if _, err := s.StartTournament(ctx, t.ID); err != nil {
+				log.Printf("Failed to start arena tournament %s: %v", t.ID, err)
+			}
+		}
+
+		running, _, err := repo.ListTournaments(ctx, string(TournamentStatusInProgress), 0, 0)
+		if err != nil {
+			log.Printf("Arena tournament check failed: %v", err)
+			return
+		}
+		for _, t := range running {
+			tournamentMu.Lock()
+			if err := s.advanceTournament(ctx, t); err != nil {
+				log.Printf("Failed to advance arena tournament %s: %v", t.ID, err)
+			}
+			tournamentMu.Unlock()
+		}
+
+		if unpaid, err := repo.ListUnpaidTournamentPrizes(ctx); err == nil {
+			s.payTournamentPrizes(ctx, unpaid)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		check()
+		for {
+			select {
+			case <-ticker.C:
+				check()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *Service) publishTournamentStage(t *Tournament, stage string) {
+	snapshot := *t
+	go func() {
+		if err := PublishTournamentStage(&snapshot, stage); err != nil {
+			log.Printf("Failed to publish tournament %s event: %v", stage, err)
+		}
+	}()
+}
+
+// ==================== QUERIES ====================
+
+// GetTournament returns a tournament with its entries
+func (s *Service) GetTournament(ctx context.Context, id string) (*Tournament, []*TournamentEntry, error) {
+	if err := tournamentsAvailable(); err != nil {
+		return nil, nil, err
+	}
+	t, err := GetRepository().GetTournament(ctx, id)
+	if err != nil {
+		return nil, nil, errors.New("tournament not found")
+	}
+	entries, err := GetRepository().ListTournamentEntries(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load tournament entries: %w", err)
+	}
+	return t, entries, nil
+}
+
+// ListTournaments returns one page of tournaments and the total
+func (s *Service) ListTournaments(ctx context.Context, query dto.ListTournamentsQuery) ([]*Tournament, int64, error) {
+	if err := tournamentsAvailable(); err != nil {
+		return nil, 0, err
+	}
+	tournaments, total, err := GetRepository().ListTournaments(ctx, query.Status, query.Limit, query.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load tournaments: %w", err)
+	}
+	return tournaments, total, nil
+}
+
+// GetTournamentBracket returns a tournament's bracket slots grouped by round
+func (s *Service) GetTournamentBracket(ctx context.Context, id string) ([]TournamentRound, error) {
+	if err := tournamentsAvailable(); err != nil {
+		return nil, err
+	}
+	matches, err := GetRepository().ListTournamentMatches(ctx, id, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bracket: %w", err)
+	}
+	rounds := []TournamentRound{}
+	for _, m := range matches {
+		if len(rounds) == 0 || rounds[len(rounds)-1].Round != m.Round {
+			rounds = append(rounds, TournamentRound{Round: m.Round})
+		}
+		rounds[len(rounds)-1].Matches = append(rounds[len(rounds)-1].Matches, m)
+	}
+	return rounds, nil
+}
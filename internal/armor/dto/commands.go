@@ -9,6 +9,7 @@ type CreateArmorCommand struct {
 	HPBonus      int
 	Price        int
 	MaxDurability int
+	Resistances  map[string]int
 	CreatedBy    string
 }
 
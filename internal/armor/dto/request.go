@@ -9,6 +9,7 @@ type CreateArmorRequest struct {
 	HPBonus      int    `json:"hp_bonus" binding:"required,min=0,max=2000"`
 	Price        int    `json:"price" binding:"required,min=1"`
 	MaxDurability int   `json:"max_durability" binding:"required,min=1,max=2000"`
+	Resistances  map[string]int `json:"resistances" binding:"omitempty,dive,keys,oneof=fire ice lightning shadow,endkeys,min=0,max=75"`
 }
 
 // BuyArmorRequest represents an armor purchase request
@@ -20,6 +20,7 @@ type ArmorResponse struct {
 	Durability   int                `json:"durability"`
 	MaxDurability int               `json:"max_durability"`
 	IsBroken     bool               `json:"is_broken"`
+	Resistances  map[string]int     `json:"resistances,omitempty"`
 	CreatedAt    time.Time          `json:"created_at"`
 	UpdatedAt    time.Time          `json:"updated_at"`
 }
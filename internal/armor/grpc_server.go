@@ -63,15 +63,17 @@ func (s *ArmorServiceServer) ListOwnerArmors(ctx context.Context, req *pb.ListOw
     return &pb.ListOwnerArmorsResponse{ Armors: res }, nil
 }
 
-// ApplyWear reduces durability and sets is_broken when needed
+// ApplyWear reduces durability and sets is_broken when needed. Negative wear gives durability back
+// (up to the maximum), e.g. when a battle is cancelled.
 func (s *ArmorServiceServer) ApplyWear(ctx context.Context, req *pb.ApplyWearRequest) (*pb.ApplyWearResponse, error) {
-    if req.Wear <= 0 { req.Wear = 1 }
+    if req.Wear == 0 { req.Wear = 1 }
     oid, err := primitive.ObjectIDFromHex(req.ArmorId)
     if err != nil { return nil, status.Errorf(codes.InvalidArgument, "invalid armor id") }
     var a Armor
     if err := ArmorColl.FindOne(ctx, bson.M{"_id": oid}).Decode(&a); err != nil { return nil, status.Errorf(codes.NotFound, "armor not found") }
     newDur := a.Durability - int(req.Wear)
     if newDur < 0 { newDur = 0 }
+    if req.Wear < 0 && newDur > a.MaxDurability && a.MaxDurability > 0 { newDur = a.MaxDurability }
     isBroken := newDur == 0
     upd := bson.M{"$set": bson.M{"durability": newDur, "is_broken": isBroken, "updated_at": time.Now()}}
     if _, err := ArmorColl.UpdateByID(ctx, oid, upd); err != nil { return nil, status.Errorf(codes.Internal, "failed to apply wear") }
@@ -83,6 +85,7 @@ func toProtoArmor(a *Armor) *pb.Armor {
         Id: a.ID.Hex(), Name: a.Name, Description: a.Description, Type: string(a.Type), Defense: int32(a.Defense), HpBonus: int32(a.HPBonus), Price: int32(a.Price), CreatedBy: a.CreatedBy,
         OwnedBy: a.OwnedBy,
         Durability: int32(a.Durability), MaxDurability: int32(a.MaxDurability), IsBroken: a.IsBroken,
+        Resistances: func() []*pb.ElementResistance { if len(a.Resistances)==0 {return nil}; out:=make([]*pb.ElementResistance,0,len(a.Resistances)); for e,r:= range a.Resistances { out = append(out, &pb.ElementResistance{Element:e, Percent:int32(r)})}; return out }(),
         Owners: func() []*pb.OwnerRef { if len(a.Owners)==0 {return nil}; out:=make([]*pb.OwnerRef,0,len(a.Owners)); for _,o:= range a.Owners { out = append(out, &pb.OwnerRef{OwnerType:o.OwnerType, OwnerId:o.OwnerID})}; return out }(),
     }
 }
@@ -38,10 +38,10 @@ func (h *Handler) CreateArmor(c *gin.Context) {
     var req dto.CreateArmorRequest
     if !validator.ValidateRequest(c, &req) { return }
     if req.Type == "legendary" { c.JSON(400, dto.ErrorResponse{Error: "invalid_type", Message: "legendary armors cannot be created"}); return }
-    cmd := dto.CreateArmorCommand{ Name: req.Name, Description: req.Description, Type: req.Type, Defense: req.Defense, HPBonus: req.HPBonus, Price: req.Price, MaxDurability: req.MaxDurability, CreatedBy: user.Username }
+    cmd := dto.CreateArmorCommand{ Name: req.Name, Description: req.Description, Type: req.Type, Defense: req.Defense, HPBonus: req.HPBonus, Price: req.Price, MaxDurability: req.MaxDurability, Resistances: req.Resistances, CreatedBy: user.Username }
     a, err := h.Service.CreateArmor(context.Background(), cmd)
     if err != nil { c.JSON(400, dto.ErrorResponse{Error: "creation_failed", Message: err.Error()}); return }
-    c.JSON(201, dto.ArmorResponse{ ID: a.ID, Name: a.Name, Description: a.Description, Type: string(a.Type), Defense: a.Defense, HPBonus: a.HPBonus, Price: a.Price, CreatedBy: a.CreatedBy, OwnedBy: a.OwnedBy, Durability: a.Durability, MaxDurability: a.MaxDurability, IsBroken: a.IsBroken, CreatedAt: a.CreatedAt, UpdatedAt: a.UpdatedAt })
+    c.JSON(201, dto.ArmorResponse{ ID: a.ID, Name: a.Name, Description: a.Description, Type: string(a.Type), Defense: a.Defense, HPBonus: a.HPBonus, Price: a.Price, CreatedBy: a.CreatedBy, OwnedBy: a.OwnedBy, Durability: a.Durability, MaxDurability: a.MaxDurability, IsBroken: a.IsBroken, Resistances: a.Resistances, CreatedAt: a.CreatedAt, UpdatedAt: a.UpdatedAt })
 }
 
 // GetArmors godoc
@@ -61,13 +61,13 @@ func (h *Handler) GetArmors(c *gin.Context) {
     list, err := h.Service.GetArmors(context.Background(), q)
     if err != nil { c.JSON(500, dto.ErrorResponse{Error: "internal_error", Message: err.Error()}); return }
     resp := make([]dto.ArmorResponse, len(list))
-    for i, a := range list { resp[i] = dto.ArmorResponse{ ID: a.ID, Name: a.Name, Description: a.Description, Type: string(a.Type), Defense: a.Defense, HPBonus: a.HPBonus, Price: a.Price, CreatedBy: a.CreatedBy, OwnedBy: a.OwnedBy, Durability: a.Durability, MaxDurability: a.MaxDurability, IsBroken: a.IsBroken, CreatedAt: a.CreatedAt, UpdatedAt: a.UpdatedAt } }
+    for i, a := range list { resp[i] = dto.ArmorResponse{ ID: a.ID, Name: a.Name, Description: a.Description, Type: string(a.Type), Defense: a.Defense, HPBonus: a.HPBonus, Price: a.Price, CreatedBy: a.CreatedBy, OwnedBy: a.OwnedBy, Durability: a.Durability, MaxDurability: a.MaxDurability, IsBroken: a.IsBroken, Resistances: a.Resistances, CreatedAt: a.CreatedAt, UpdatedAt: a.UpdatedAt } }
     c.JSON(http.StatusOK, dto.ArmorsListResponse{ Armors: resp, Count: len(resp) })
 }
 
 // BuyArmor godoc
 // @Summary Buy armor
-// @Description Purchase an armor. Triggers Kafka event for coin deduction via gRPC.
+// @Description Purchase an armor. A warrior's price is held on their coins via gRPC and captured once the armor is assigned.
 // @Tags armors
 // @Accept json
 // @Produce json
@@ -103,7 +103,7 @@ func (h *Handler) GetMyArmors(c *gin.Context) {
     list, err := h.Service.GetArmors(context.Background(), dto.GetArmorsQuery{ OwnedBy: user.Username })
     if err != nil { c.JSON(500, dto.ErrorResponse{Error: "internal_error", Message: err.Error()}); return }
     resp := make([]dto.ArmorResponse, len(list))
-    for i, a := range list { resp[i] = dto.ArmorResponse{ ID: a.ID, Name: a.Name, Description: a.Description, Type: string(a.Type), Defense: a.Defense, HPBonus: a.HPBonus, Price: a.Price, CreatedBy: a.CreatedBy, OwnedBy: a.OwnedBy, Durability: a.Durability, MaxDurability: a.MaxDurability, IsBroken: a.IsBroken, CreatedAt: a.CreatedAt, UpdatedAt: a.UpdatedAt } }
+    for i, a := range list { resp[i] = dto.ArmorResponse{ ID: a.ID, Name: a.Name, Description: a.Description, Type: string(a.Type), Defense: a.Defense, HPBonus: a.HPBonus, Price: a.Price, CreatedBy: a.CreatedBy, OwnedBy: a.OwnedBy, Durability: a.Durability, MaxDurability: a.MaxDurability, IsBroken: a.IsBroken, Resistances: a.Resistances, CreatedAt: a.CreatedAt, UpdatedAt: a.UpdatedAt } }
     c.JSON(http.StatusOK, dto.ArmorsListResponse{ Armors: resp, Count: len(resp) })
 }
 
@@ -30,6 +30,7 @@ type Armor struct {
 	MaxDurability int              `bson:"max_durability" json:"max_durability"`
 	IsBroken    bool               `bson:"is_broken" json:"is_broken"`
 	Owners      []OwnerRef         `bson:"owners,omitempty" json:"owners,omitempty"` // generalized ownership
+	Resistances map[string]int     `bson:"resistances,omitempty" json:"resistances,omitempty"` // element -> percent of that element's damage absorbed
 	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
 }
@@ -84,4 +85,3 @@ func (a *Armor) CanBeBoughtBy(role string) bool {
 		return false
 	}
 }
-
@@ -0,0 +1,84 @@
+package armor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"network-sec-micro/pkg/purchase"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Armor purchases run through the purchase saga in pkg/purchase; this file is the armor's side of it
+
+// purchases returns the saga running armor purchases
+func purchases() *purchase.Saga {
+	return purchase.NewSaga("armor", PurchaseColl, coinHolds{}, armorItem{})
+}
+
+// coinHolds places and settles purchase holds through the coin service
+type coinHolds struct{}
+
+func (coinHolds) PlaceHold(ctx context.Context, warriorID uint, amount int64, reason, reference string) (uint32, error) {
+	return PlaceCoinHold(ctx, warriorID, amount, reason, reference)
+}
+
+func (coinHolds) CaptureHold(ctx context.Context, holdID uint32) error {
+	return CaptureCoinHold(ctx, holdID)
+}
+
+func (coinHolds) ReleaseHold(ctx context.Context, holdID uint32) error {
+	return ReleaseCoinHold(ctx, holdID)
+}
+
+// armorItem assigns a bought armor by adding the buyer to its owners, under the purchase's owner type
+type armorItem struct{}
+
+func (armorItem) Assign(ctx context.Context, p *purchase.Purchase) error {
+	result, err := ArmorColl.UpdateOne(ctx,
+		bson.M{"_id": p.ItemID},
+		bson.M{
+			"$addToSet": bson.M{"owned_by": p.BuyerID, "owners": OwnerRef{OwnerType: p.OwnerType, OwnerID: p.BuyerID}},
+			"$set":      bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update armor: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("armor not found")
+	}
+	return nil
+}
+
+func (armorItem) Revoke(ctx context.Context, p *purchase.Purchase) error {
+	_, err := ArmorColl.UpdateOne(ctx,
+		bson.M{"_id": p.ItemID},
+		bson.M{
+			"$pull": bson.M{"owned_by": p.BuyerID, "owners": bson.M{"owner_type": p.OwnerType, "owner_id": p.BuyerID}},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	return err
+}
+
+func (armorItem) Purchased(ctx context.Context, p *purchase.Purchase) {
+	armor := &Armor{ID: p.ItemID, Name: p.ItemName, Price: p.Price}
+	if err := PublishArmorPurchase(ctx, armor, p.BuyerUserID, p.BuyerUsername, p.OwnerType); err != nil {
+		log.Printf("Failed to publish armor purchase event: %v", err)
+		// Don't fail the purchase if event publishing fails
+	}
+}
+
+// ResumePurchases finishes the armor purchases that have made no progress for a while
+func (s *Service) ResumePurchases(ctx context.Context) (int, error) {
+	return purchases().Resume(ctx)
+}
+
+// StartPurchaseRecovery resumes unfinished armor purchases every interval until ctx is done
+func (s *Service) StartPurchaseRecovery(ctx context.Context, interval time.Duration) {
+	purchases().StartRecovery(ctx, interval)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"network-sec-micro/internal/armor/dto"
+	"network-sec-micro/pkg/purchase"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -38,6 +39,7 @@ func (s *Service) CreateArmor(ctx context.Context, cmd dto.CreateArmorCommand) (
 		Durability:   cmd.MaxDurability, // Initialize with max durability
 		MaxDurability: cmd.MaxDurability,
 		IsBroken:     false,
+		Resistances:  cmd.Resistances,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -51,7 +53,8 @@ func (s *Service) CreateArmor(ctx context.Context, cmd dto.CreateArmorCommand) (
 	return &armor, nil
 }
 
-// BuyArmor handles armor purchase
+// BuyArmor handles armor purchase. A warrior pays through a coin hold that is captured once the
+// armor is theirs; see purchase.go. Other owner types have no coin account and are not charged.
 func (s *Service) BuyArmor(ctx context.Context, cmd dto.BuyArmorCommand) error {
 	armorID, err := primitive.ObjectIDFromHex(cmd.ArmorID)
 	if err != nil {
@@ -77,37 +80,48 @@ func (s *Service) BuyArmor(ctx context.Context, cmd dto.BuyArmorCommand) error {
 		}
 	}
 
-	// Add to owned_by (legacy)
-	armor.OwnedBy = append(armor.OwnedBy, cmd.BuyerID)
-	
-	// Add to owners array (new generalized ownership)
 	ownerType := cmd.OwnerType
 	if ownerType == "" {
 		ownerType = "warrior" // Default to warrior for backward compatibility
 	}
-	armor.Owners = append(armor.Owners, OwnerRef{
-		OwnerType: ownerType,
-		OwnerID:   cmd.BuyerID,
-	})
-
-	armor.UpdatedAt = time.Now()
-
-	_, err = ArmorColl.UpdateOne(ctx,
-		bson.M{"_id": armorID},
-		bson.M{"$set": bson.M{"owned_by": armor.OwnedBy, "owners": armor.Owners, "updated_at": armor.UpdatedAt}},
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to update armor: %w", err)
+	price := armor.Price
+	if ownerType != "warrior" {
+		price = 0
+	}
+
+	now := time.Now()
+	p := &purchase.Purchase{
+		ItemID:        armorID,
+		ItemName:      armor.Name,
+		Price:         price,
+		BuyerID:       cmd.BuyerID,
+		BuyerUsername: cmd.BuyerUsername,
+		BuyerUserID:   cmd.BuyerUserID,
+		OwnerType:     ownerType,
+		State:         purchase.StateStarted,
+		Active:        true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := purchases().Create(ctx, p); err != nil {
+		return err
 	}
 
-	// Publish armor purchase event to Kafka
-	if err := PublishArmorPurchase(ctx, &armor, cmd.BuyerUserID, cmd.BuyerUsername, ownerType); err != nil {
-		log.Printf("Failed to publish armor purchase event: %v", err)
-		// Don't fail the transaction if event publishing fails
+	// With the purchase recorded no other purchase of this armor by the buyer can run, so ownership
+	// seen from now on was assigned by this one
+	owned, err := ArmorColl.CountDocuments(ctx, bson.M{"_id": armorID, "owned_by": cmd.BuyerID})
+	if err != nil || owned > 0 {
+		failure := "you already own this armor"
+		if err != nil {
+			failure = fmt.Sprintf("failed to check ownership: %v", err)
+		}
+		if cancelErr := purchases().Advance(ctx, p, purchase.StateCancelled, failure); cancelErr != nil {
+			log.Printf("Failed to cancel armor purchase %s: %v", p.ID.Hex(), cancelErr)
+		}
+		return errors.New(failure)
 	}
 
-	return nil
+	return purchases().Run(ctx, p)
 }
 
 // GetArmors gets all armors
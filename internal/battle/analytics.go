@@ -0,0 +1,311 @@
+package battle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"network-sec-micro/pkg/kafka"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// The analytics read model keeps daily aggregates of how team battles go: per participant type and side,
+// per winning side, per weapon and armor and per spell. It is built only from battle events on Kafka,
+// never from the battle tables, so balancing queries do not load live battles. Each event is applied in
+// one transaction with a marker keyed on the event, which makes a redelivered event harmless.
+// Spell casts wait for their battle's outcome before they count towards spell effectiveness.
+
+const (
+	analyticsDayLayout       = "2006-01-02"
+	analyticsResultCancelled = "cancelled"
+)
+
+// analyticsTable describes a daily aggregate table: rows are keyed by keys and events add to counters
+type analyticsTable struct {
+	name     string
+	keys     []string
+	counters []string
+}
+
+var (
+	participantAnalytics = analyticsTable{
+		name:     "battle_analytics_participants",
+		keys:     []string{"day", "participant_type", "side"},
+		counters: []string{"battles", "wins", "losses", "draws", "survived", "attacks", "critical_hits", "kills", "damage_dealt", "damage_taken"},
+	}
+	victoryAnalytics = analyticsTable{
+		name:     "battle_analytics_victories",
+		keys:     []string{"day", "winner_side"},
+		counters: []string{"victories", "total_turns"},
+	}
+	equipmentAnalytics = analyticsTable{
+		name:     "battle_analytics_equipment",
+		keys:     []string{"day", "kind", "item_id"},
+		counters: []string{"uses", "damage"},
+	}
+	spellAnalytics = analyticsTable{
+		name:     "battle_analytics_spells",
+		keys:     []string{"day", "spell_type"},
+		counters: []string{"casts", "affected", "decided_casts", "winning_casts"},
+	}
+)
+
+// add inserts row, or adds its counters to the row already stored under the same key
+func (t analyticsTable) add(tx *gorm.DB, row interface{}) error {
+	columns := make([]clause.Column, len(t.keys))
+	for i, key := range t.keys {
+		columns[i] = clause.Column{Name: key}
+	}
+	updates := make(map[string]interface{}, len(t.counters))
+	for _, counter := range t.counters {
+		updates[counter] = gorm.Expr(fmt.Sprintf("%s.%s + excluded.%s", t.name, counter, counter))
+	}
+	return tx.Clauses(clause.OnConflict{Columns: columns, DoUpdates: clause.Assignments(updates)}).Create(row).Error
+}
+
+// ProcessAnalyticsMessage applies one battle event to the analytics read model.
+// Malformed messages are skipped; storage errors are returned so the message is retried.
+func ProcessAnalyticsMessage(message []byte) error {
+	db, err := getGorm()
+	if err != nil {
+		return err
+	}
+
+	var envelope kafka.Event
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		log.Printf("Skipping malformed analytics message: %v", err)
+		return nil
+	}
+
+	switch envelope.EventType {
+	case "battle_turn":
+		var event kafka.BattleTurnEvent
+		if err := json.Unmarshal(message, &event); err != nil {
+			log.Printf("Skipping malformed battle turn event: %v", err)
+			return nil
+		}
+		return db.Transaction(func(tx *gorm.DB) error { return applyTurnEvent(tx, &event) })
+
+	case "battle_completed":
+		var event BattleCompletedEvent
+		if err := json.Unmarshal(message, &event); err != nil {
+			log.Printf("Skipping malformed battle completed event: %v", err)
+			return nil
+		}
+		if event.BattleType != string(BattleTypeTeam) {
+			return nil // Legacy battles carry no roster
+		}
+		return db.Transaction(func(tx *gorm.DB) error { return applyCompletedEvent(tx, &event) })
+
+	case "battle_spell_cast":
+		var event kafka.BattleSpellCastEvent
+		if err := json.Unmarshal(message, &event); err != nil {
+			log.Printf("Skipping malformed spell cast event: %v", err)
+			return nil
+		}
+		return db.Transaction(func(tx *gorm.DB) error { return applySpellCastEvent(tx, &event) })
+
+	case "battle_cancelled", "battle_force_cancelled":
+		var event struct {
+			BattleID string `json:"battle_id"`
+		}
+		if err := json.Unmarshal(message, &event); err != nil {
+			log.Printf("Skipping malformed battle cancelled event: %v", err)
+			return nil
+		}
+		return db.Transaction(func(tx *gorm.DB) error { return applyCancelledEvent(tx, event.BattleID) })
+	}
+
+	return nil
+}
+
+// StartAnalyticsConsumer starts feeding the analytics read model from battle and spell events
+func StartAnalyticsConsumer() error {
+	consumer, err := kafka.NewConsumer(
+		getKafkaBrokers(),
+		"battle-analytics-group",
+		[]string{kafka.TopicBattleTurn, kafka.TopicBattleCompleted, kafka.TopicBattleSpellCast, kafka.TopicBattleCancelled, kafka.TopicBattleForceCancelled},
+		ProcessAnalyticsMessage,
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Println("Battle analytics Kafka consumer started")
+	return consumer.Start()
+}
+
+// applyTurnEvent counts a hit for both participants and the equipment involved. A status effect tick
+// is damage taken only: nobody dealt it.
+func applyTurnEvent(tx *gorm.DB, event *kafka.BattleTurnEvent) error {
+	key := fmt.Sprintf("turn:%s:%d:%s:%s:%s", event.BattleID, event.TurnNumber, event.AttackerID, event.TargetID, event.EffectType)
+	if applied, err := markApplied(tx, key); err != nil || !applied {
+		return err
+	}
+	day := analyticsDay(event.Timestamp)
+
+	if err := participantAnalytics.add(tx, &AnalyticsParticipantDailySQL{
+		Day:             day,
+		ParticipantType: event.TargetType,
+		Side:            event.TargetSide,
+		DamageTaken:     event.DamageDealt,
+	}); err != nil {
+		return err
+	}
+	if event.EffectType != "" {
+		return nil
+	}
+
+	if err := participantAnalytics.add(tx, &AnalyticsParticipantDailySQL{
+		Day:             day,
+		ParticipantType: event.AttackerType,
+		Side:            event.AttackerSide,
+		Attacks:         1,
+		CriticalHits:    boolToCount(event.CriticalHit),
+		Kills:           boolToCount(event.TargetDefeated),
+		DamageDealt:     event.DamageDealt,
+	}); err != nil {
+		return err
+	}
+	if event.WeaponID != "" {
+		if err := equipmentAnalytics.add(tx, &AnalyticsEquipmentDailySQL{Day: day, Kind: "weapon", ItemID: event.WeaponID, Uses: 1, Damage: event.DamageDealt}); err != nil {
+			return err
+		}
+	}
+	if event.ArmorID != "" {
+		if err := equipmentAnalytics.add(tx, &AnalyticsEquipmentDailySQL{Day: day, Kind: "armor", ItemID: event.ArmorID, Uses: 1, Damage: event.DamageDealt}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyCompletedEvent counts the battle's outcome for every participant and its winning side, then
+// decides the spell casts that were waiting for it
+func applyCompletedEvent(tx *gorm.DB, event *BattleCompletedEvent) error {
+	outcome := &AnalyticsBattleOutcomeSQL{BattleID: event.BattleID, Result: event.Result, WinnerSide: event.WinnerSide}
+	if recorded, err := recordOutcome(tx, outcome); err != nil || !recorded {
+		return err
+	}
+	day := analyticsDay(event.Timestamp)
+
+	type group struct{ participantType, side string }
+	rows := make(map[group]*AnalyticsParticipantDailySQL)
+	for _, p := range event.Participants {
+		g := group{p.ParticipantType, p.Side}
+		row, ok := rows[g]
+		if !ok {
+			row = &AnalyticsParticipantDailySQL{Day: day, ParticipantType: p.ParticipantType, Side: p.Side}
+			rows[g] = row
+		}
+		row.Battles++
+		switch {
+		case event.WinnerSide == "":
+			row.Draws++
+		case p.Side == event.WinnerSide:
+			row.Wins++
+		default:
+			row.Losses++
+		}
+		row.Survived += boolToCount(p.Survived)
+	}
+	for _, row := range rows {
+		if err := participantAnalytics.add(tx, row); err != nil {
+			return err
+		}
+	}
+
+	if event.WinnerSide != "" {
+		if err := victoryAnalytics.add(tx, &AnalyticsVictoryDailySQL{Day: day, WinnerSide: event.WinnerSide, Victories: 1, TotalTurns: event.TotalTurns}); err != nil {
+			return err
+		}
+	}
+
+	var casts []AnalyticsSpellCastSQL
+	if err := tx.Where("battle_id = ?", event.BattleID).Find(&casts).Error; err != nil {
+		return err
+	}
+	for _, cast := range casts {
+		if err := decideCast(tx, cast.Day, cast.SpellType, cast.Side, outcome); err != nil {
+			return err
+		}
+	}
+	return tx.Where("battle_id = ?", event.BattleID).Delete(&AnalyticsSpellCastSQL{}).Error
+}
+
+// applySpellCastEvent counts a cast and decides it at once if its battle has already ended
+func applySpellCastEvent(tx *gorm.DB, event *kafka.BattleSpellCastEvent) error {
+	key := fmt.Sprintf("spell:%s:%s:%s:%d", event.BattleID, event.SpellType, event.CasterID, event.Timestamp.UnixNano())
+	if applied, err := markApplied(tx, key); err != nil || !applied {
+		return err
+	}
+	day := analyticsDay(event.Timestamp)
+
+	if err := spellAnalytics.add(tx, &AnalyticsSpellDailySQL{Day: day, SpellType: event.SpellType, Side: event.Side, Casts: 1, Affected: event.AffectedCount}); err != nil {
+		return err
+	}
+
+	var outcome AnalyticsBattleOutcomeSQL
+	err := tx.Where("battle_id = ?", event.BattleID).Take(&outcome).Error
+	if err == nil {
+		return decideCast(tx, day, event.SpellType, event.Side, &outcome)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return tx.Create(&AnalyticsSpellCastSQL{BattleID: event.BattleID, Day: day, SpellType: event.SpellType, Side: event.Side}).Error
+}
+
+// applyCancelledEvent drops the spell casts of a battle that ended without a result
+func applyCancelledEvent(tx *gorm.DB, battleID string) error {
+	if recorded, err := recordOutcome(tx, &AnalyticsBattleOutcomeSQL{BattleID: battleID, Result: analyticsResultCancelled}); err != nil || !recorded {
+		return err
+	}
+	return tx.Where("battle_id = ?", battleID).Delete(&AnalyticsSpellCastSQL{}).Error
+}
+
+// decideCast counts a cast towards spell effectiveness once its battle's outcome is known.
+// Casts in cancelled battles are not decided.
+func decideCast(tx *gorm.DB, day, spellType, side string, outcome *AnalyticsBattleOutcomeSQL) error {
+	if outcome.Result == analyticsResultCancelled {
+		return nil
+	}
+	return spellAnalytics.add(tx, &AnalyticsSpellDailySQL{
+		Day:          day,
+		SpellType:    spellType,
+		Side:         side,
+		DecidedCasts: 1,
+		WinningCasts: boolToCount(outcome.WinnerSide != "" && outcome.WinnerSide == side),
+	})
+}
+
+// markApplied records an event as applied; false means it already was
+func markApplied(tx *gorm.DB, key string) (bool, error) {
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&AnalyticsEventSQL{Key: key, CreatedAt: time.Now()})
+	return result.RowsAffected > 0, result.Error
+}
+
+// recordOutcome records how a battle ended; false means its end was already recorded
+func recordOutcome(tx *gorm.DB, outcome *AnalyticsBattleOutcomeSQL) (bool, error) {
+	outcome.CreatedAt = time.Now()
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(outcome)
+	return result.RowsAffected > 0, result.Error
+}
+
+func analyticsDay(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format(analyticsDayLayout)
+}
+
+func boolToCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,44 @@
+package battle
+
+import (
+	"math/rand"
+)
+
+// Combat rolls are drawn from a per-battle stream so a fight can be replayed
+// turn by turn. Each turn gets its own *rand.Rand derived from the battle seed
+// and the turn number; rolls must be taken from it in a fixed order.
+
+const (
+	streamAttack  int64 = 0 // rolls made by the acting participant
+	streamCounter int64 = 1 // rolls made by a legacy opponent counter-attack
+	streamTarget  int64 = 2 // target picks made by server-controlled participants
+	streamWraith  int64 = 3 // wraith of dragon victims, drawn by the battlespell service
+)
+
+// NewBattleSeed returns a fresh seed for a battle's combat RNG
+func NewBattleSeed() int64 {
+	return rand.Int63()
+}
+
+// TurnRNG rebuilds the combat RNG stream for the given turn of a battle
+func TurnRNG(seed int64, turn int) *rand.Rand {
+	return rand.New(rand.NewSource(mixSeed(seed, turn, streamAttack)))
+}
+
+// counterTurnRNG is the stream used by the opponent counter-attack of a legacy battle turn
+func counterTurnRNG(seed int64, turn int) *rand.Rand {
+	return rand.New(rand.NewSource(mixSeed(seed, turn, streamCounter)))
+}
+
+// targetTurnRNG is the stream used by a Strategy to pick a target on the given turn
+func targetTurnRNG(seed int64, turn int) *rand.Rand {
+	return rand.New(rand.NewSource(mixSeed(seed, turn, streamTarget)))
+}
+
+// mixSeed spreads seed, turn and stream into a single source seed (splitmix64 finalizer)
+func mixSeed(seed int64, turn int, stream int64) int64 {
+	z := uint64(seed) + uint64(turn)*0x9E3779B97F4A7C15 + uint64(stream)*0xD1B54A32D192ED03
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return int64(z ^ (z >> 31))
+}
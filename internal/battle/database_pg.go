@@ -23,7 +23,8 @@ func InitPostgres() error {
         return err
     }
     // AutoMigrate tables for battle service
-    if err := pkgdb.AutoMigrate(db, &BattleSQL{}, &BattleParticipantSQL{}, &BattleTurnSQL{}); err != nil {
+    if err := pkgdb.AutoMigrate(db, &BattleSQL{}, &BattleParticipantSQL{}, &BattleTurnSQL{}, &BattleRewardSQL{},
+        &AnalyticsParticipantDailySQL{}, &AnalyticsVictoryDailySQL{}, &AnalyticsEquipmentDailySQL{}, &AnalyticsSpellDailySQL{}, &AnalyticsSpellCastSQL{}, &AnalyticsBattleOutcomeSQL{}, &AnalyticsEventSQL{}); err != nil {
         return err
     }
     SQLDB.Enabled = true
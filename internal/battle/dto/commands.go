@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // ParticipantInfo represents a participant to be added to battle
 type ParticipantInfo struct {
 	ParticipantID string `json:"participant_id" binding:"required"` // Warrior ID, Enemy ID, or Dragon ID
@@ -11,6 +13,7 @@ type ParticipantInfo struct {
 	MaxHP         int    `json:"max_hp"`
 	AttackPower   int    `json:"attack_power"`
 	Defense       int    `json:"defense"`
+	Element       string `json:"element" binding:"omitempty,oneof=fire ice lightning shadow"` // Innate element, e.g. a dragon's type
 }
 
 // StartBattleCommand represents a command to start a new team-based battle
@@ -20,12 +23,15 @@ type StartBattleCommand struct {
 	LightParticipants []ParticipantInfo `json:"light_participants" binding:"required,min=1"` // At least 1 participant
 	DarkParticipants  []ParticipantInfo `json:"dark_participants" binding:"required,min=1"`  // At least 1 participant
 	MaxTurns      int              `json:"max_turns"` // Maximum turns before draw (default 100)
+	TurnOrder     string           `json:"turn_order"` // "alternating" (default) or "speed"
+	TurnTimeoutSeconds int         `json:"turn_timeout_seconds"` // Per-turn deadline (0 = service default)
+	TimeoutAction string           `json:"timeout_action"` // "skip" (default) or "attack" when a turn times out
+	MaxDurationMinutes int         `json:"max_duration_minutes"` // Wall-clock limit (0 = service default)
 	CreatedBy     string           `json:"created_by"` // Creator username
-    // Optional wager between emperors
+    // Optional wager between emperors; the battle waits until both have approved it
     WagerAmount   int              `json:"wager_amount"`
     LightEmperorID string          `json:"light_emperor_id"`
     DarkEmperorID  string          `json:"dark_emperor_id"`
-    RequireEmperorApproval bool    `json:"require_emperor_approval"`
     // Legacy single battle fields (optional)
     BattleType   string `json:"battle_type,omitempty"`
     WarriorName  string `json:"warrior_name,omitempty"`
@@ -36,6 +42,42 @@ type StartBattleCommand struct {
     OpponentMaxHP int   `json:"opponent_max_hp,omitempty"`
 }
 
+// CreateLobbyCommand represents a command to open a battle lobby that players join before it starts
+type CreateLobbyCommand struct {
+	LightSideName      string            `json:"light_side_name"`
+	DarkSideName       string            `json:"dark_side_name"`
+	LightSlots         int               `json:"light_slots"` // Light side size once full
+	DarkSlots          int               `json:"dark_slots"`  // Dark side size once full
+	LightParticipants  []ParticipantInfo `json:"light_participants"` // Optional: seated up front, counted against the slots
+	DarkParticipants   []ParticipantInfo `json:"dark_participants"`  // Optional: e.g. the enemies players will face
+	ScheduledStartAt   *time.Time        `json:"scheduled_start_at"` // Optional: start automatically at this time once full
+	MaxTurns           int               `json:"max_turns"`
+	TurnOrder          string            `json:"turn_order"`
+	TurnTimeoutSeconds int               `json:"turn_timeout_seconds"`
+	TimeoutAction      string            `json:"timeout_action"`
+	MaxDurationMinutes int               `json:"max_duration_minutes"`
+	CreatedBy          string            `json:"created_by"`
+}
+
+// JoinLobbyCommand represents a command for a player to take an open slot in a lobby
+type JoinLobbyCommand struct {
+	BattleID    string          `json:"battle_id" binding:"required"`
+	Participant ParticipantInfo `json:"participant" binding:"required"`
+}
+
+// SetLobbyReadyCommand represents a command for a lobby participant to answer the ready-check
+type SetLobbyReadyCommand struct {
+	BattleID      string `json:"battle_id" binding:"required"`
+	ParticipantID string `json:"participant_id" binding:"required"`
+	Ready         bool   `json:"ready"`
+}
+
+// LeaveLobbyCommand represents a command for a player to give up their lobby slot
+type LeaveLobbyCommand struct {
+	BattleID      string `json:"battle_id" binding:"required"`
+	ParticipantID string `json:"participant_id" binding:"required"`
+}
+
 // AttackCommand represents a command to perform an attack in battle
 type AttackCommand struct {
 	BattleID      string `json:"battle_id" binding:"required"`
@@ -45,18 +87,31 @@ type AttackCommand struct {
 	TargetName    string `json:"target_name"`   // For validation
 }
 
-// AddParticipantCommand represents a command to add a participant to an existing battle (if still pending)
+// AddParticipantCommand represents a command to add a participant to a pending or in-progress battle
 type AddParticipantCommand struct {
 	BattleID      string           `json:"battle_id" binding:"required"`
 	Participant   ParticipantInfo `json:"participant" binding:"required"`
 }
 
-// RemoveParticipantCommand represents a command to remove a participant from a pending battle
+// RemoveParticipantCommand represents a command to remove a participant from a pending or in-progress battle
 type RemoveParticipantCommand struct {
 	BattleID      string `json:"battle_id" binding:"required"`
 	ParticipantID string `json:"participant_id" binding:"required"`
 }
 
+// ApplyStatusEffectCommand represents a command to put a status effect on a battle participant
+type ApplyStatusEffectCommand struct {
+	BattleID      string `json:"battle_id" binding:"required"`
+	ParticipantID string `json:"participant_id" binding:"required"`
+	Type          string `json:"type" binding:"required"`           // poison, burn, regen, stun, attack_modifier, defense_modifier
+	Magnitude     int    `json:"magnitude"`                         // HP per tick, or percent for modifiers (negative to weaken)
+	DurationTurns int    `json:"duration_turns" binding:"required"` // Turns of the affected participant
+	SourceID      string `json:"source_id,omitempty"`              // Participant or spell applying the effect
+	Tick          string `json:"tick,omitempty"`                    // turn_start or turn_end, effect default if empty
+	Stacking      string `json:"stacking,omitempty"`                // refresh, stack or ignore, effect default if empty
+	MaxStacks     int    `json:"max_stacks,omitempty"`              // Effect default if 0
+}
+
 // CompleteBattleCommand represents a command to manually complete/cancel a battle
 type CompleteBattleCommand struct {
 	BattleID      string `json:"battle_id" binding:"required"`
@@ -95,3 +150,32 @@ type CastSpellCommand struct {
 	TargetDarkEmperorID string `json:"target_dark_emperor_id,omitempty"` // Required for Dragon Emperor spell
 }
 
+
+// SurrenderBattleCommand represents a command for a side's leader to give up a team battle
+type SurrenderBattleCommand struct {
+	BattleID    string `json:"battle_id"`
+	Side        string `json:"side"`         // light or dark
+	RequestedBy string `json:"requested_by"` // Participant ID of the leader
+}
+
+// CancelBattleCommand represents a command for a battle's creator to call it off before the first turn
+type CancelBattleCommand struct {
+	BattleID    string `json:"battle_id"`
+	RequestedBy string `json:"requested_by"` // Creator username
+	Reason      string `json:"reason,omitempty"`
+}
+
+// ForceCancelBattleCommand represents a command for an emperor to stop a battle at any point
+type ForceCancelBattleCommand struct {
+	BattleID        string `json:"battle_id"`
+	RequestedBy     string `json:"requested_by"`    // Username
+	RequestedByID   string `json:"requested_by_id"` // User ID, checked against the wager emperors
+	RequestedByRole string `json:"requested_by_role"`
+	Reason          string `json:"reason,omitempty"`
+}
+
+// ApproveWagerCommand represents a command for an emperor to approve a battle's wager and put up their stake
+type ApproveWagerCommand struct {
+	BattleID  string `json:"battle_id"`
+	EmperorID string `json:"emperor_id"`
+}
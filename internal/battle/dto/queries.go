@@ -4,7 +4,7 @@ import "go.mongodb.org/mongo-driver/bson/primitive"
 
 // GetBattleQuery represents a query to get a battle by ID
 type GetBattleQuery struct {
-	BattleID primitive.ObjectID `json:"battle_id"`
+	BattleID string `json:"battle_id"`
 }
 
 // GetBattlesByWarriorQuery represents a query to get battles for a warrior
@@ -15,6 +15,12 @@ type GetBattlesByWarriorQuery struct {
 	Offset    int    `json:"offset"`
 }
 
+// ListLobbiesQuery represents a query to list lobbies waiting to start
+type ListLobbiesQuery struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
 // GetBattleTurnsQuery represents a query to get turns for a battle
 type GetBattleTurnsQuery struct {
 	BattleID primitive.ObjectID `json:"battle_id"`
@@ -33,3 +39,18 @@ type GetBattleParticipantsQuery struct {
 	BattleID primitive.ObjectID `json:"battle_id"`
 	Side     string             `json:"side"` // "all", "light", "dark"
 }
+
+// ReplayBattleQuery represents a query to rebuild battle state at a given turn
+type ReplayBattleQuery struct {
+	BattleID string `json:"battle_id"`
+	Turn     int    `json:"turn"`   // 0 = state before the first turn, < 0 = latest turn
+	Verify   bool   `json:"verify"` // Re-run the fight and report HP mismatches
+}
+
+// BattleAnalyticsQuery represents a query over the battle analytics read model
+type BattleAnalyticsQuery struct {
+	From    string   `json:"from"`     // First day, 2006-01-02 (default: 29 days before To)
+	To      string   `json:"to"`       // Last day, 2006-01-02 (default: today, UTC)
+	GroupBy []string `json:"group_by"` // participant_type, side, day (default: participant_type, side)
+	Limit   int      `json:"limit"`    // Most-used weapons and armor to return
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // StartBattleRequest represents a request to start a team battle
 type StartBattleRequest struct {
 	LightSideName      string           `json:"light_side_name" binding:"required"` // e.g., "Light Alliance"
@@ -7,7 +9,50 @@ type StartBattleRequest struct {
 	LightParticipants  []ParticipantInfo `json:"light_participants" binding:"required,min=1,dive"`
 	DarkParticipants   []ParticipantInfo `json:"dark_participants" binding:"required,min=1,dive"`
 	MaxTurns           int              `json:"max_turns"` // Default 100 if not specified
+	TurnOrder          string           `json:"turn_order" binding:"omitempty,oneof=alternating speed"` // Default alternating
+	TurnTimeoutSeconds int              `json:"turn_timeout_seconds" binding:"omitempty,min=5,max=3600"` // Per-turn deadline, service default if not specified
+	TimeoutAction      string           `json:"timeout_action" binding:"omitempty,oneof=skip attack"` // Default skip
+	MaxDurationMinutes int              `json:"max_duration_minutes" binding:"omitempty,min=1,max=1440"` // Wall-clock limit, service default if not specified
 	KingApprovals      []uint           `json:"king_approvals,omitempty"` // List of king IDs who approved (required if creator is a king)
+	WagerAmount        int              `json:"wager_amount" binding:"omitempty,min=1"` // Optional: stake each emperor puts up, held in escrow
+	LightEmperorID     string           `json:"light_emperor_id" binding:"required_with=WagerAmount"`
+	DarkEmperorID      string           `json:"dark_emperor_id" binding:"required_with=WagerAmount"`
+}
+
+// CreateLobbyRequest represents a request to open a battle lobby
+type CreateLobbyRequest struct {
+	LightSideName      string            `json:"light_side_name" binding:"required"`
+	DarkSideName       string            `json:"dark_side_name" binding:"required"`
+	LightSlots         int               `json:"light_slots" binding:"required,min=1,max=20"`
+	DarkSlots          int               `json:"dark_slots" binding:"required,min=1,max=20"`
+	LightParticipants  []ParticipantInfo `json:"light_participants" binding:"omitempty,dive"` // Seated up front, counted against the slots
+	DarkParticipants   []ParticipantInfo `json:"dark_participants" binding:"omitempty,dive"`
+	ScheduledStartAt   *time.Time        `json:"scheduled_start_at"` // Optional: RFC 3339, start automatically at this time once full
+	MaxTurns           int               `json:"max_turns"` // Default 100 if not specified
+	TurnOrder          string            `json:"turn_order" binding:"omitempty,oneof=alternating speed"`
+	TurnTimeoutSeconds int               `json:"turn_timeout_seconds" binding:"omitempty,min=5,max=3600"`
+	TimeoutAction      string            `json:"timeout_action" binding:"omitempty,oneof=skip attack"`
+	MaxDurationMinutes int               `json:"max_duration_minutes" binding:"omitempty,min=1,max=1440"`
+	KingApprovals      []uint            `json:"king_approvals,omitempty"` // List of king IDs who approved (required if creator is a king)
+}
+
+// JoinLobbyRequest represents a request to join a lobby as the current user
+type JoinLobbyRequest struct {
+	HP          int `json:"hp"`
+	MaxHP       int `json:"max_hp"`
+	AttackPower int `json:"attack_power"`
+	Defense     int `json:"defense"`
+	Level       int `json:"level"`
+}
+
+// LobbyReadyRequest represents a request to answer a lobby's ready-check
+type LobbyReadyRequest struct {
+	Ready bool `json:"ready"`
+}
+
+// CancelBattleRequest represents a request to cancel or force-cancel a battle
+type CancelBattleRequest struct {
+	Reason string `json:"reason" binding:"max=200"`
 }
 
 // AttackRequest represents a request to perform an attack
@@ -17,16 +62,18 @@ type AttackRequest struct {
 	TargetID   string `json:"target_id" binding:"required"`   // Participant ID
 }
 
-// AddParticipantRequest represents a request to add a participant to battle (pending only)
+// AddParticipantRequest represents a request to add a participant to a pending or in-progress battle
 type AddParticipantRequest struct {
-	BattleID    string          `json:"battle_id" binding:"required"`
-	Participant ParticipantInfo `json:"participant" binding:"required"`
+	BattleID      string          `json:"-" uri:"id"` // Taken from the path
+	Participant   ParticipantInfo `json:"participant" binding:"required"`
+	KingApprovals []uint          `json:"king_approvals,omitempty"` // List of king IDs who approved (required if caller is a king)
 }
 
-// RemoveParticipantRequest represents a request to remove a participant from battle (pending only)
+// RemoveParticipantRequest represents a request to remove a participant from a pending or in-progress battle
 type RemoveParticipantRequest struct {
-	BattleID     string `json:"battle_id" binding:"required"`
-	ParticipantID string `json:"participant_id" binding:"required"`
+	BattleID      string `uri:"id" binding:"required"`
+	ParticipantID string `uri:"pid" binding:"required"`
+	KingApprovals []uint `form:"king_approvals"` // List of king IDs who approved (required if caller is a king)
 }
 
 // ReviveDragonRequest represents a request to revive a dragon in battle
@@ -57,3 +104,11 @@ type CastSpellRequest struct {
 	TargetDragonID      string `json:"target_dragon_id,omitempty"`     // Required for Dragon Emperor spell
 	TargetDarkEmperorID string `json:"target_dark_emperor_id,omitempty"` // Required for Dragon Emperor spell
 }
+
+// BattleAnalyticsRequest represents the query string of the battle analytics endpoint
+type BattleAnalyticsRequest struct {
+	From    string `form:"from"`     // 2006-01-02
+	To      string `form:"to"`       // 2006-01-02
+	GroupBy string `form:"group_by"` // Comma-separated: participant_type, side, day
+	Limit   int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
@@ -14,12 +14,26 @@ type ParticipantResponse struct {
 	MaxHP       int       `json:"max_hp"`
 	AttackPower int       `json:"attack_power"`
 	Defense     int       `json:"defense"`
+	Speed       int       `json:"speed"`
+	Element     string    `json:"element,omitempty"`
 	IsAlive     bool      `json:"is_alive"`
 	IsDefeated  bool      `json:"is_defeated"`
 	DefeatedAt  *string   `json:"defeated_at,omitempty"`
+	StatusEffects []StatusEffectResponse `json:"status_effects,omitempty"`
+	Ready       bool      `json:"ready"`
 	CreatedAt   string    `json:"created_at"`
 }
 
+// StatusEffectResponse represents a status effect active on a participant
+type StatusEffectResponse struct {
+	Type           string `json:"type"`
+	SourceID       string `json:"source_id,omitempty"`
+	Magnitude      int    `json:"magnitude"`
+	Stacks         int    `json:"stacks"`
+	RemainingTurns int    `json:"remaining_turns"`
+	Tick           string `json:"tick"`
+}
+
 // ToParticipantResponse converts a BattleParticipant to ParticipantResponse
 // Mapping helpers are implemented in root battle package to avoid import cycles.
 
@@ -32,6 +46,12 @@ type BattleResponse struct {
 	CurrentTurn           int                   `json:"current_turn"`
 	CurrentParticipantIndex int                 `json:"current_participant_index"`
 	MaxTurns              int                   `json:"max_turns"`
+	TurnOrder             string                `json:"turn_order"`
+	NextParticipant       *ParticipantResponse  `json:"next_participant,omitempty"` // Whose turn it is (in-progress battles)
+	TurnTimeoutSeconds    int                   `json:"turn_timeout_seconds"`
+	TimeoutAction         string                `json:"timeout_action,omitempty"`
+	TurnDeadline          *string               `json:"turn_deadline,omitempty"`
+	ExpiresAt             *string               `json:"expires_at,omitempty"`
 	Status                string                `json:"status"`
 	Result                string                `json:"result,omitempty"`
 	WinnerSide            string                `json:"winner_side,omitempty"`
@@ -40,6 +60,13 @@ type BattleResponse struct {
 	CreatedBy             string                `json:"created_by"`
 	LightParticipants     []ParticipantResponse `json:"light_participants"`
 	DarkParticipants      []ParticipantResponse `json:"dark_participants"`
+	LightSlots            int                   `json:"light_slots,omitempty"` // Lobbies only
+	DarkSlots             int                   `json:"dark_slots,omitempty"`
+	ScheduledStartAt      *string               `json:"scheduled_start_at,omitempty"`
+	LobbyDeadline         *string               `json:"lobby_deadline,omitempty"`
+	WagerAmount           int                   `json:"wager_amount,omitempty"` // Wagered battles only
+	LightEmperorApproved  bool                  `json:"light_emperor_approved,omitempty"`
+	DarkEmperorApproved   bool                  `json:"dark_emperor_approved,omitempty"`
 	StartedAt             *string               `json:"started_at,omitempty"`
 	CompletedAt           *string               `json:"completed_at,omitempty"`
 	CreatedAt             string                `json:"created_at"`
@@ -66,6 +93,10 @@ type BattleTurnResponse struct {
 	TargetHPBefore int   `json:"target_hp_before"`
 	TargetHPAfter  int   `json:"target_hp_after"`
 	TargetDefeated bool  `json:"target_defeated"`
+	EffectType    string `json:"effect_type,omitempty"` // Set for status effect ticks
+	HealingDone   int    `json:"healing_done,omitempty"`
+	Element       string `json:"element,omitempty"` // Set for elemental hits
+	DamageResisted int   `json:"damage_resisted,omitempty"`
 	CreatedAt     string `json:"created_at"`
 }
 
@@ -97,3 +128,103 @@ type BattleStatsResponse struct {
 	TotalCoinsEarned   int     `json:"total_coins_earned"`
 	TotalExperience    int     `json:"total_experience"`
 }
+
+// BattleAnalyticsResponse represents aggregated battle statistics over a date range
+type BattleAnalyticsResponse struct {
+	From         string                 `json:"from"`
+	To           string                 `json:"to"`
+	GroupBy      []string               `json:"group_by"`
+	Participants []ParticipantAnalytics `json:"participants"`
+	Victories    []VictoryAnalytics     `json:"victories"`
+	Weapons      []EquipmentAnalytics   `json:"weapons"`
+	Armor        []EquipmentAnalytics   `json:"armor"`
+	Spells       []SpellAnalytics       `json:"spells"`
+}
+
+// ParticipantAnalytics represents how one group of participants fared; unset fields were not grouped by
+type ParticipantAnalytics struct {
+	Day             string  `json:"day,omitempty"`
+	ParticipantType string  `json:"participant_type,omitempty"`
+	Side            string  `json:"side,omitempty"`
+	Battles         int     `json:"battles"`
+	Wins            int     `json:"wins"`
+	Losses          int     `json:"losses"`
+	Draws           int     `json:"draws"`
+	WinRate         float64 `json:"win_rate"`
+	Survived        int     `json:"survived"`
+	Attacks         int     `json:"attacks"`
+	CriticalHits    int     `json:"critical_hits"`
+	CritRate        float64 `json:"crit_rate"`
+	Kills           int     `json:"kills"`
+	DamageDealt     int     `json:"damage_dealt"`
+	DamageTaken     int     `json:"damage_taken"`
+}
+
+// VictoryAnalytics represents the victories of one side
+type VictoryAnalytics struct {
+	Day          string  `json:"day,omitempty"`
+	WinnerSide   string  `json:"winner_side"`
+	Victories    int     `json:"victories"`
+	AverageTurns float64 `json:"average_turns"`
+}
+
+// EquipmentAnalytics represents how much one weapon or armor was used
+type EquipmentAnalytics struct {
+	ItemID        string  `json:"item_id"`
+	Uses          int     `json:"uses"`
+	Damage        int     `json:"damage"`
+	AverageDamage float64 `json:"average_damage"`
+}
+
+// SpellAnalytics represents how often a spell was cast and how the battles it was cast in ended
+type SpellAnalytics struct {
+	Day             string  `json:"day,omitempty"`
+	SpellType       string  `json:"spell_type"`
+	Side            string  `json:"side"`
+	Casts           int     `json:"casts"`
+	AverageAffected float64 `json:"average_affected"`
+	DecidedCasts    int     `json:"decided_casts"`
+	WinningCasts    int     `json:"winning_casts"`
+	WinRate         float64 `json:"win_rate"` // Winning casts out of decided casts
+}
+
+// SpellModifierResponse represents a spell effect active on a participant
+type SpellModifierResponse struct {
+	SpellType  string `json:"spell_type"`
+	Effect     string `json:"effect"`
+	StackCount int    `json:"stack_count,omitempty"`
+	CastAt     string `json:"cast_at"`
+}
+
+// ReplayParticipantResponse represents a participant's rebuilt state at a turn
+type ReplayParticipantResponse struct {
+	ParticipantID  string                  `json:"participant_id"`
+	Name           string                  `json:"name"`
+	Type           string                  `json:"type"`
+	Side           string                  `json:"side"`
+	HP             int                     `json:"hp"`
+	MaxHP          int                     `json:"max_hp"`
+	IsAlive        bool                    `json:"is_alive"`
+	SpellModifiers []SpellModifierResponse `json:"spell_modifiers"`
+}
+
+// ReplayMismatchResponse represents a turn whose stored values differ from the re-run
+type ReplayMismatchResponse struct {
+	TurnNumber    int    `json:"turn_number"`
+	ParticipantID string `json:"participant_id"`
+	Field         string `json:"field"` // target_hp_before, target_hp_after, critical_hit
+	Stored        int    `json:"stored"`
+	Expected      int    `json:"expected"`
+}
+
+// BattleReplayResponse represents a battle rebuilt at a given turn
+type BattleReplayResponse struct {
+	BattleID     string                      `json:"battle_id"`
+	Turn         int                         `json:"turn"`
+	LastTurn     int                         `json:"last_turn"`
+	Participants []ReplayParticipantResponse `json:"participants"`
+	ActiveSpells []SpellModifierResponse     `json:"active_spells"`
+	Verified     bool                        `json:"verified"`
+	Consistent   bool                        `json:"consistent"`
+	Mismatches   []ReplayMismatchResponse    `json:"mismatches,omitempty"`
+}
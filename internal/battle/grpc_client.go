@@ -122,6 +122,26 @@ func CloseBattlespellClient() {
 	}
 }
 
+// ListBattleSpells fetches every spell recorded for a battle from the battlespell service
+func ListBattleSpells(ctx context.Context, battleID string) ([]*pbBattleSpell.Spell, error) {
+    if battlespellGrpcClient == nil {
+        return nil, fmt.Errorf("battlespell gRPC client not initialized")
+    }
+    resp, err := battlespellGrpcClient.ListBattleSpells(ctx, &pbBattleSpell.ListBattleSpellsRequest{BattleId: battleID})
+    if err != nil { return nil, err }
+    return resp.Spells, nil
+}
+
+// ClearBattleSpells deactivates every spell still active in a battle
+func ClearBattleSpells(ctx context.Context, battleID string) (int32, error) {
+    if battlespellGrpcClient == nil {
+        return 0, fmt.Errorf("battlespell gRPC client not initialized")
+    }
+    resp, err := battlespellGrpcClient.ClearBattleSpells(ctx, &pbBattleSpell.ClearBattleSpellsRequest{BattleId: battleID})
+    if err != nil { return 0, err }
+    return resp.ClearedCount, nil
+}
+
 // CloseWeaponClient closes the weapon gRPC connection
 func CloseWeaponClient() {
     if weaponGrpcConn != nil {
@@ -268,16 +288,17 @@ func ApplyArmorWear(ctx context.Context, armorID string, wear int32) (*pbArmor.A
     return armorGrpcClient.ApplyWear(ctx, &pbArmor.ApplyWearRequest{ArmorId: armorID, Wear: wear})
 }
 
-// AddCoins adds coins to warrior's balance via gRPC
-func AddCoins(ctx context.Context, warriorID uint, amount int64, reason string) error {
+// AddCoins adds coins to warrior's balance via gRPC. Retrying with the same idempotency key never pays twice.
+func AddCoins(ctx context.Context, warriorID uint, amount int64, reason, idempotencyKey string) error {
 	if coinGrpcClient == nil {
 		return fmt.Errorf("coin gRPC client not initialized")
 	}
 
 	req := &pbCoin.AddCoinsRequest{
-		WarriorId: uint32(warriorID),
-		Amount:    amount,
-		Reason:    reason,
+		WarriorId:      uint32(warriorID),
+		Amount:         amount,
+		Reason:         reason,
+		IdempotencyKey: idempotencyKey,
 	}
 
 	resp, err := coinGrpcClient.AddCoins(ctx, req)
@@ -293,16 +314,41 @@ func AddCoins(ctx context.Context, warriorID uint, amount int64, reason string)
 	return nil
 }
 
-// DeductCoins deducts coins from warrior's balance via gRPC
-func DeductCoins(ctx context.Context, warriorID uint, amount int64, reason string) error {
+// LockWagerStake moves an emperor's wager stake on a battle into escrow via gRPC.
+// Locking a stake that is already held succeeds without taking it again.
+func LockWagerStake(ctx context.Context, battleID string, emperorID uint, amount int64) error {
 	if coinGrpcClient == nil {
 		return fmt.Errorf("coin gRPC client not initialized")
 	}
 
-	req := &pbCoin.DeductCoinsRequest{
-		WarriorId: uint32(warriorID),
+	resp, err := coinGrpcClient.LockWagerStake(ctx, &pbCoin.LockWagerStakeRequest{
+		BattleId:  battleID,
+		WarriorId: uint32(emperorID),
 		Amount:    amount,
-		Reason:    reason,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lock wager stake: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("failed to lock wager stake: %s", resp.Message)
+	}
+
+	log.Printf("Locked wager stake of %d from emperor %d for battle %s (already locked: %t)", amount, emperorID, battleID, resp.AlreadyLocked)
+	return nil
+}
+
+// DeductCoins deducts coins from warrior's balance via gRPC. Retrying with the same idempotency key never charges twice.
+func DeductCoins(ctx context.Context, warriorID uint, amount int64, reason, idempotencyKey string) error {
+	if coinGrpcClient == nil {
+		return fmt.Errorf("coin gRPC client not initialized")
+	}
+
+	req := &pbCoin.DeductCoinsRequest{
+		WarriorId:      uint32(warriorID),
+		Amount:         amount,
+		Reason:         reason,
+		IdempotencyKey: idempotencyKey,
 	}
 
 	resp, err := coinGrpcClient.DeductCoins(ctx, req)
@@ -2,10 +2,12 @@ package battle
 
 import (
     "context"
+    "errors"
     "fmt"
     "time"
 
     pb "network-sec-micro/api/proto/battle"
+    "network-sec-micro/internal/battle/dto"
 
     "go.mongodb.org/mongo-driver/bson"
     "go.mongodb.org/mongo-driver/bson/primitive"
@@ -29,11 +31,11 @@ func NewBattleServiceServer(service *Service) *BattleServiceServer {
 
 // GetBattleByID gets battle by ID
 func (s *BattleServiceServer) GetBattleByID(ctx context.Context, req *pb.GetBattleByIDRequest) (*pb.GetBattleByIDResponse, error) {
-    if _, err := primitive.ObjectIDFromHex(req.BattleId); err != nil {
+    if req.BattleId == "" {
         return nil, status.Error(codes.InvalidArgument, "invalid battle ID")
     }
 
-    battle, err := GetRepository().GetBattleByID(ctx, req.BattleId)
+    battle, err := s.service.loadBattle(ctx, req.BattleId)
     if err != nil {
         return nil, status.Error(codes.NotFound, "battle not found")
     }
@@ -80,7 +82,16 @@ func (s *BattleServiceServer) GetActiveBattles(ctx context.Context, req *pb.GetA
 func (s *BattleServiceServer) GetBattleParticipants(ctx context.Context, req *pb.GetBattleParticipantsRequest) (*pb.GetBattleParticipantsResponse, error) {
 	battleID, err := primitive.ObjectIDFromHex(req.BattleId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid battle ID")
+		// Not a legacy Mongo ID: team battles live in the SQL store
+		participants, err := GetRepository().FindParticipants(ctx, req.BattleId, req.Side)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to find participants: %v", err))
+		}
+		resp := &pb.GetBattleParticipantsResponse{}
+		for _, p := range participants {
+			resp.Participants = append(resp.Participants, convertParticipantToProto(p))
+		}
+		return resp, nil
 	}
 
     filter := bson.M{
@@ -112,11 +123,13 @@ func (s *BattleServiceServer) GetBattleParticipants(ctx context.Context, req *pb
 	}, nil
 }
 
-// UpdateParticipantStats updates participant stats
+// UpdateParticipantStats updates participant stats.
+// With expected_version set the write only succeeds if the participant is unchanged since it was read;
+// otherwise it fails with Aborted and the caller should re-read and recompute.
 func (s *BattleServiceServer) UpdateParticipantStats(ctx context.Context, req *pb.UpdateParticipantStatsRequest) (*pb.UpdateParticipantStatsResponse, error) {
 	battleID, err := primitive.ObjectIDFromHex(req.BattleId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid battle ID")
+		return s.updateSQLParticipantStats(ctx, req)
 	}
 
 	updateData := bson.M{
@@ -143,6 +156,36 @@ func (s *BattleServiceServer) UpdateParticipantStats(ctx context.Context, req *p
 	}, nil
 }
 
+// updateSQLParticipantStats is UpdateParticipantStats for team battles in the SQL store
+func (s *BattleServiceServer) updateSQLParticipantStats(ctx context.Context, req *pb.UpdateParticipantStatsRequest) (*pb.UpdateParticipantStatsResponse, error) {
+	fields := map[string]interface{}{
+		"hp":           int(req.Hp),
+		"max_hp":       int(req.MaxHp),
+		"attack_power": int(req.AttackPower),
+		"defense":      int(req.Defense),
+		"is_alive":     req.IsAlive,
+		"updated_at":   time.Now(),
+	}
+
+	var err error
+	if req.ExpectedVersion > 0 {
+		err = GetRepository().UpdateParticipantIfVersion(ctx, req.BattleId, req.ParticipantId, int(req.ExpectedVersion), fields)
+	} else {
+		err = GetRepository().UpdateParticipantByIDs(ctx, req.BattleId, req.ParticipantId, fields)
+	}
+	if errors.Is(err, ErrVersionConflict) {
+		return nil, status.Error(codes.Aborted, "participant was modified concurrently, re-read and retry")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update participant: %v", err))
+	}
+
+	return &pb.UpdateParticipantStatsResponse{
+		Success: true,
+		Message: "participant stats updated successfully",
+	}, nil
+}
+
 // CastSpell casts a spell via gRPC - delegates to battlespell service
 func (s *BattleServiceServer) CastSpell(ctx context.Context, req *pb.CastSpellRequest) (*pb.CastSpellResponse, error) {
 	// Battle service now delegates spell casting to battlespell service
@@ -153,6 +196,43 @@ func (s *BattleServiceServer) CastSpell(ctx context.Context, req *pb.CastSpellRe
 	}, nil
 }
 
+// ApplyStatusEffect puts a status effect on a participant of an in-progress team battle
+func (s *BattleServiceServer) ApplyStatusEffect(ctx context.Context, req *pb.ApplyStatusEffectRequest) (*pb.ApplyStatusEffectResponse, error) {
+	if req.BattleId == "" || req.ParticipantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "battle_id and participant_id are required")
+	}
+
+	participant, err := s.service.ApplyStatusEffect(dto.ApplyStatusEffectCommand{
+		BattleID:      req.BattleId,
+		ParticipantID: req.ParticipantId,
+		Type:          req.Type,
+		Magnitude:     int(req.Magnitude),
+		DurationTurns: int(req.DurationTurns),
+		SourceID:      req.SourceId,
+		Tick:          req.Tick,
+		Stacking:      req.Stacking,
+		MaxStacks:     int(req.MaxStacks),
+	})
+	if err != nil {
+		switch {
+		case err.Error() == "battle not found", err.Error() == "participant not found or not alive":
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, errBattleNotInProgress):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, ErrVersionConflict):
+			return nil, status.Error(codes.Aborted, err.Error())
+		default:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	return &pb.ApplyStatusEffectResponse{
+		Success:     true,
+		Message:     "status effect applied successfully",
+		Participant: convertParticipantToProto(participant),
+	}, nil
+}
+
 // Helper functions to convert between internal models and proto
 func convertBattleToProto(b *Battle) *pb.Battle {
     pbBattle := &pb.Battle{
@@ -167,6 +247,7 @@ func convertBattleToProto(b *Battle) *pb.Battle {
 		CreatedBy:             b.CreatedBy,
 		CreatedAt:             timestamppb.New(b.CreatedAt),
 		UpdatedAt:             timestamppb.New(b.UpdatedAt),
+		Seed:                  b.Seed,
 	}
 
 	if b.Result != "" {
@@ -199,6 +280,7 @@ func convertParticipantToProto(p *BattleParticipant) *pb.BattleParticipant {
 		Defense:       int32(p.Defense),
 		IsAlive:       p.IsAlive,
 		IsDefeated:    p.IsDefeated,
+		Version:       int32(p.Version),
 		CreatedAt:     timestamppb.New(p.CreatedAt),
 		UpdatedAt:     timestamppb.New(p.UpdatedAt),
 	}
@@ -207,6 +289,19 @@ func convertParticipantToProto(p *BattleParticipant) *pb.BattleParticipant {
 		pbParticipant.DefeatedAt = timestamppb.New(*p.DefeatedAt)
 	}
 
+	for _, e := range p.StatusEffects {
+		pbParticipant.StatusEffects = append(pbParticipant.StatusEffects, &pb.StatusEffect{
+			Type:           string(e.Type),
+			SourceId:       e.SourceID,
+			Magnitude:      int32(e.Magnitude),
+			RemainingTurns: int32(e.RemainingTurns),
+			Stacks:         int32(e.Stacks),
+			MaxStacks:      int32(e.MaxStacks),
+			Stacking:       string(e.Stacking),
+			Tick:           string(e.Tick),
+		})
+	}
+
 	return pbParticipant
 }
 
@@ -1,12 +1,15 @@
 package battle
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	pbBattleSpell "network-sec-micro/api/proto/battlespell"
 	"network-sec-micro/internal/battle/dto"
+	"network-sec-micro/pkg/spectate"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -76,7 +79,14 @@ func (h *Handler) StartBattle(c *gin.Context) {
 		LightParticipants:  req.LightParticipants,
 		DarkParticipants:   req.DarkParticipants,
 		MaxTurns:           maxTurns,
+		TurnOrder:          req.TurnOrder,
+		TurnTimeoutSeconds: req.TurnTimeoutSeconds,
+		TimeoutAction:      req.TimeoutAction,
+		MaxDurationMinutes: req.MaxDurationMinutes,
 		CreatedBy:          user.Username,
+		WagerAmount:        req.WagerAmount,
+		LightEmperorID:     req.LightEmperorID,
+		DarkEmperorID:      req.DarkEmperorID,
 	}
 
 	battle, participants, err := h.Service.StartBattle(cmd)
@@ -103,7 +113,7 @@ func (h *Handler) StartBattle(c *gin.Context) {
 
 // Attack godoc
 // @Summary Perform an attack in team battle
-// @Description A participant attacks another participant in an active team battle. Attacker and target must be on different sides.
+// @Description A participant attacks another participant in an active team battle. Attacker and target must be on different sides, and the attacker must be the participant whose turn it is (see next_participant on GET /battles/{id}).
 // @Tags battles
 // @Accept json
 // @Produce json
@@ -112,6 +122,7 @@ func (h *Handler) StartBattle(c *gin.Context) {
 // @Success 200 {object} map[string]interface{} "battle: BattleResponse, turn: BattleTurnResponse"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Attacker acted out of turn"
 // @Router /battles/attack [post]
 func (h *Handler) Attack(c *gin.Context) {
 	user, err := GetCurrentUser(c)
@@ -142,6 +153,13 @@ func (h *Handler) Attack(c *gin.Context) {
 
 	battle, turn, err := h.Service.Attack(cmd)
 	if err != nil {
+		if errors.Is(err, ErrNotYourTurn) {
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:   "not_your_turn",
+				Message: err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
 			Error:   "attack_failed",
 			Message: err.Error(),
@@ -149,19 +167,759 @@ func (h *Handler) Attack(c *gin.Context) {
 		return
 	}
 
-    // Get participants for response
-    lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), req.BattleID, "light")
-    darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), req.BattleID, "dark")
+    // Get participants for response
+    lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), req.BattleID, "light")
+    darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), req.BattleID, "dark")
+
+	response := gin.H{
+        "battle": ToBattleResponse(battle, lightParts, darkParts),
+	}
+
+	if turn != nil {
+        response["turn"] = ToBattleTurnResponse(turn)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AddParticipant godoc
+// @Summary Add a participant to a team battle
+// @Description Bring a reinforcement into a pending or in-progress team battle. The same team composition and authorization rules as starting a battle apply. The newcomer acts last in turn order.
+// @Tags battles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Param request body dto.AddParticipantRequest true "Participant data"
+// @Success 201 {object} map[string]interface{} "battle: BattleResponse, participant: ParticipantResponse"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/{id}/participants [post]
+func (h *Handler) AddParticipant(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var req dto.AddParticipantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		})
+		return
+	}
+	req.BattleID = c.Param("id")
+
+	ctx := c.Request.Context()
+	if err := ValidateBattleAuthorization(ctx, user.Role, user.UserID, req.KingApprovals); err != nil {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "authorization_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	cmd := dto.AddParticipantCommand{
+		BattleID:    req.BattleID,
+		Participant: req.Participant,
+	}
+
+	battle, participant, err := h.Service.AddParticipant(cmd)
+	if err != nil {
+		if err.Error() == "battle not found" {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: "Battle not found",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "participant_add_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	lightParts, _ := GetRepository().FindParticipants(ctx, battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(ctx, battle.ID, "dark")
+
+	c.JSON(http.StatusCreated, gin.H{
+		"battle":      ToBattleResponse(battle, lightParts, darkParts),
+		"participant": ToParticipantResponse(participant),
+	})
+}
+
+// RemoveParticipant godoc
+// @Summary Remove a participant from a team battle
+// @Description Withdraw a participant from a pending or in-progress team battle. The participant stays on record but no longer acts or can be targeted. A pending battle must keep at least one participant per side; in an in-progress battle, withdrawing a side's last fighter forfeits the battle.
+// @Tags battles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Param pid path string true "Participant ID"
+// @Param king_approvals query []int false "King IDs who approved (required if caller is a king)"
+// @Success 200 {object} dto.BattleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/{id}/participants/{pid} [delete]
+func (h *Handler) RemoveParticipant(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var req dto.RemoveParticipantRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		})
+		return
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := ValidateBattleAuthorization(ctx, user.Role, user.UserID, req.KingApprovals); err != nil {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "authorization_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	cmd := dto.RemoveParticipantCommand{
+		BattleID:      req.BattleID,
+		ParticipantID: req.ParticipantID,
+	}
+
+	battle, err := h.Service.RemoveParticipant(cmd)
+	if err != nil {
+		if err.Error() == "battle not found" || err.Error() == "participant not found in this battle" {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "participant_remove_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	lightParts, _ := GetRepository().FindParticipants(ctx, battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(ctx, battle.ID, "dark")
+
+	c.JSON(http.StatusOK, ToBattleResponse(battle, lightParts, darkParts))
+}
+
+// CreateLobby godoc
+// @Summary Open a battle lobby
+// @Description Open a pending team battle with open slots per side for players to join. Participants given up front (e.g. the enemies players will face) are seated and ready straight away. The battle starts once every slot is taken and everyone is ready, or at scheduled_start_at once every slot is taken. A lobby that has not started by its deadline (scheduled_start_at, or 30 minutes by default) is cancelled. Same authorization rules as starting a battle.
+// @Tags lobbies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateLobbyRequest true "Lobby data"
+// @Success 201 {object} dto.BattleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /battles/lobbies [post]
+func (h *Handler) CreateLobby(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var req dto.CreateLobbyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := ValidateBattleAuthorization(ctx, user.Role, user.UserID, req.KingApprovals); err != nil {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "authorization_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	cmd := dto.CreateLobbyCommand{
+		LightSideName:      req.LightSideName,
+		DarkSideName:       req.DarkSideName,
+		LightSlots:         req.LightSlots,
+		DarkSlots:          req.DarkSlots,
+		LightParticipants:  req.LightParticipants,
+		DarkParticipants:   req.DarkParticipants,
+		ScheduledStartAt:   req.ScheduledStartAt,
+		MaxTurns:           req.MaxTurns,
+		TurnOrder:          req.TurnOrder,
+		TurnTimeoutSeconds: req.TurnTimeoutSeconds,
+		TimeoutAction:      req.TimeoutAction,
+		MaxDurationMinutes: req.MaxDurationMinutes,
+		CreatedBy:          user.Username,
+	}
+
+	battle, participants, err := h.Service.CreateLobby(cmd)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "lobby_create_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	lightParts, darkParts := []*BattleParticipant{}, []*BattleParticipant{}
+	for _, p := range participants {
+		if p.Side == TeamSideLight {
+			lightParts = append(lightParts, p)
+		} else {
+			darkParts = append(darkParts, p)
+		}
+	}
+
+	c.JSON(http.StatusCreated, ToBattleResponse(battle, lightParts, darkParts))
+}
+
+// ListLobbies godoc
+// @Summary List open battle lobbies
+// @Description List lobbies waiting for players, soonest deadline first, with their participants and ready states
+// @Tags lobbies
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit (default 20)"
+// @Param offset query int false "Offset (default 0)"
+// @Success 200 {object} dto.BattlesListResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /battles/lobbies [get]
+func (h *Handler) ListLobbies(c *gin.Context) {
+	query := dto.ListLobbiesQuery{
+		Limit:  20,
+		Offset: 0,
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			query.Limit = limit
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			query.Offset = offset
+		}
+	}
+
+	lobbies, total, err := h.Service.ListLobbies(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	responses := make([]*dto.BattleResponse, len(lobbies))
+	for i, lobby := range lobbies {
+		lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), lobby.ID, "light")
+		darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), lobby.ID, "dark")
+		responses[i] = ToBattleResponse(lobby, lightParts, darkParts)
+	}
+
+	c.JSON(http.StatusOK, dto.BattlesListResponse{
+		Battles: responses,
+		Count:   len(responses),
+		Total:   int(total),
+	})
+}
+
+// GetLobby godoc
+// @Summary Get a battle lobby
+// @Description Get a lobby's slots, participants and ready states. Once the lobby has started or been cancelled, its status says so.
+// @Tags lobbies
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Success 200 {object} dto.BattleResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/lobbies/{id} [get]
+func (h *Handler) GetLobby(c *gin.Context) {
+	battle, err := h.Service.GetLobby(dto.GetBattleQuery{BattleID: c.Param("id")})
+	if err != nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: "Lobby not found",
+		})
+		return
+	}
+
+	lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
+	c.JSON(http.StatusOK, ToBattleResponse(battle, lightParts, darkParts))
+}
+
+// JoinLobby godoc
+// @Summary Join a battle lobby
+// @Description Take an open slot in a lobby as the current user. Warriors, light kings and the light emperor join the light side, the dark emperor joins the dark side. The same team composition and healing rules as starting a battle apply. Joining does not mark you ready.
+// @Tags lobbies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Param request body dto.JoinLobbyRequest false "Battle stats"
+// @Success 201 {object} map[string]interface{} "battle: BattleResponse, participant: ParticipantResponse"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/lobbies/{id}/join [post]
+func (h *Handler) JoinLobby(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var req dto.JoinLobbyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "validation_error",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	pType, side := participantForRole(user.Role)
+	cmd := dto.JoinLobbyCommand{
+		BattleID: c.Param("id"),
+		Participant: dto.ParticipantInfo{
+			ParticipantID: fmt.Sprintf("%d", user.UserID),
+			Name:          user.Username,
+			Type:          string(pType),
+			Side:          string(side),
+			Level:         req.Level,
+			HP:            req.HP,
+			MaxHP:         req.MaxHP,
+			AttackPower:   req.AttackPower,
+			Defense:       req.Defense,
+		},
+	}
+
+	battle, participant, err := h.Service.JoinLobby(cmd)
+	if err != nil {
+		if err.Error() == "lobby not found" {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: "Lobby not found",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "lobby_join_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
+
+	c.JSON(http.StatusCreated, gin.H{
+		"battle":      ToBattleResponse(battle, lightParts, darkParts),
+		"participant": ToParticipantResponse(participant),
+	})
+}
+
+// SetLobbyReady godoc
+// @Summary Answer a lobby's ready-check
+// @Description Mark the current user ready (or not ready) in a lobby they joined. When every slot is taken and everyone is ready, the battle starts and the response shows it in progress.
+// @Tags lobbies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Param request body dto.LobbyReadyRequest true "Ready state"
+// @Success 200 {object} dto.BattleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/lobbies/{id}/ready [post]
+func (h *Handler) SetLobbyReady(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var req dto.LobbyReadyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	cmd := dto.SetLobbyReadyCommand{
+		BattleID:      c.Param("id"),
+		ParticipantID: fmt.Sprintf("%d", user.UserID),
+		Ready:         req.Ready,
+	}
+
+	battle, err := h.Service.SetLobbyReady(cmd)
+	if err != nil {
+		if err.Error() == "lobby not found" || err.Error() == "participant not found in this lobby" {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "lobby_ready_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
+	c.JSON(http.StatusOK, ToBattleResponse(battle, lightParts, darkParts))
+}
+
+// LeaveLobby godoc
+// @Summary Leave a battle lobby
+// @Description Give up the current user's slot in a lobby that has not started yet
+// @Tags lobbies
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Success 200 {object} dto.BattleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/lobbies/{id}/join [delete]
+func (h *Handler) LeaveLobby(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	cmd := dto.LeaveLobbyCommand{
+		BattleID:      c.Param("id"),
+		ParticipantID: fmt.Sprintf("%d", user.UserID),
+	}
+
+	battle, err := h.Service.LeaveLobby(cmd)
+	if err != nil {
+		if err.Error() == "lobby not found" || err.Error() == "participant not found in this lobby" {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "lobby_leave_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
+	c.JSON(http.StatusOK, ToBattleResponse(battle, lightParts, darkParts))
+}
+
+// ApproveWager godoc
+// @Summary Approve a battle's wager
+// @Description One of the two emperors of a wagered battle approves the wager. Their stake is locked in escrow by the coin service; the battle starts once both stakes are locked. Approving again does not take the stake twice.
+// @Tags battles
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Success 200 {object} dto.BattleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/{id}/wager/approve [post]
+func (h *Handler) ApproveWager(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	cmd := dto.ApproveWagerCommand{
+		BattleID:  c.Param("id"),
+		EmperorID: fmt.Sprintf("%d", user.UserID),
+	}
+
+	battle, err := h.Service.ApproveWager(cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotWagerEmperor):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: err.Error(),
+			})
+		case err.Error() == "battle not found":
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: err.Error(),
+			})
+		default:
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "wager_approval_failed",
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
+	c.JSON(http.StatusOK, ToBattleResponse(battle, lightParts, darkParts))
+}
+
+// SurrenderBattle godoc
+// @Summary Surrender a battle
+// @Description The leader of a side gives up an in-progress team battle; the other side wins
+// @Tags battles
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Success 200 {object} dto.BattleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/{id}/surrender [post]
+func (h *Handler) SurrenderBattle(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	cmd := dto.SurrenderBattleCommand{
+		BattleID:    c.Param("id"),
+		Side:        getFaction(user.Role),
+		RequestedBy: fmt.Sprintf("%d", user.UserID),
+	}
+
+	battle, err := h.Service.Surrender(cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotSideLeader):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: err.Error(),
+			})
+		case err.Error() == "battle not found":
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: err.Error(),
+			})
+		default:
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "surrender_failed",
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
+	c.JSON(http.StatusOK, ToBattleResponse(battle, lightParts, darkParts))
+}
+
+// CancelBattle godoc
+// @Summary Cancel a battle
+// @Description The creator calls off a battle before its first turn. Nobody is rewarded and any wager is refunded.
+// @Tags battles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Param request body dto.CancelBattleRequest false "Cancel reason"
+// @Success 200 {object} dto.BattleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/{id}/cancel [post]
+func (h *Handler) CancelBattle(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var req dto.CancelBattleRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
 
-	response := gin.H{
-        "battle": ToBattleResponse(battle, lightParts, darkParts),
+	cmd := dto.CancelBattleCommand{
+		BattleID:    c.Param("id"),
+		RequestedBy: user.Username,
+		Reason:      req.Reason,
 	}
 
-	if turn != nil {
-        response["turn"] = ToBattleTurnResponse(turn)
+	battle, err := h.Service.CancelBattle(cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotBattleCreator):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: err.Error(),
+			})
+		case err.Error() == "battle not found":
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: err.Error(),
+			})
+		default:
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "cancel_failed",
+				Message: err.Error(),
+			})
+		}
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
+	c.JSON(http.StatusOK, ToBattleResponse(battle, lightParts, darkParts))
+}
+
+// ForceCancelBattle godoc
+// @Summary Force-cancel a battle
+// @Description An emperor with no wager on the battle stops it at any point. Any wager is refunded, players get partial rewards for the damage they dealt and equipment wear is given back.
+// @Tags battles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Param request body dto.CancelBattleRequest false "Cancel reason"
+// @Success 200 {object} dto.BattleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/{id}/force-cancel [post]
+func (h *Handler) ForceCancelBattle(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var req dto.CancelBattleRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	cmd := dto.ForceCancelBattleCommand{
+		BattleID:        c.Param("id"),
+		RequestedBy:     user.Username,
+		RequestedByID:   fmt.Sprintf("%d", user.UserID),
+		RequestedByRole: user.Role,
+		Reason:          req.Reason,
+	}
+
+	battle, err := h.Service.ForceCancelBattle(cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrForceCancelNotAllowed):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   "forbidden",
+				Message: err.Error(),
+			})
+		case err.Error() == "battle not found":
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: err.Error(),
+			})
+		default:
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "force_cancel_failed",
+				Message: err.Error(),
+			})
+		}
+		return
+	}
+
+	lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
+	c.JSON(http.StatusOK, ToBattleResponse(battle, lightParts, darkParts))
 }
 
 // GetBattle godoc
@@ -187,17 +945,8 @@ func (h *Handler) GetBattle(c *gin.Context) {
 		return
 	}
 
-	objectID, err := primitive.ObjectIDFromHex(battleID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
-			Error:   "validation_error",
-			Message: "invalid battle ID format",
-		})
-		return
-	}
-
 	query := dto.GetBattleQuery{
-		BattleID: objectID,
+		BattleID: battleID,
 	}
 
     battle, err := h.Service.GetBattle(query)
@@ -219,27 +968,59 @@ func (h *Handler) GetBattle(c *gin.Context) {
     // Fetch participants for RBAC check
     lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
     darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
-    user, _ := GetCurrentUser(c)
-    userIDStr := fmt.Sprintf("%d", user.UserID)
-    hasAccess := false
-    // Check if user is a participant
-	for _, p := range lightParts {
-		if p.ParticipantID == userIDStr {
-			hasAccess = true
-			break
+	if !CanViewBattle(c, append(append([]*BattleParticipant{}, lightParts...), darkParts...)) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You do not have permission to view this battle",
+		})
+		return
+	}
+
+    resp := ToBattleResponse(battle, lightParts, darkParts)
+	if battle.BattleType == BattleTypeTeam && battle.Status == BattleStatusInProgress {
+		if next, _, err := h.Service.GetNextParticipant(c.Request.Context(), battle); err == nil {
+			resp.NextParticipant = ToParticipantResponse(next)
 		}
 	}
-	if !hasAccess {
-		for _, p := range darkParts {
-			if p.ParticipantID == userIDStr {
-				hasAccess = true
-				break
-			}
+
+    c.JSON(http.StatusOK, resp)
+}
+
+// WatchBattle godoc
+// @Summary Watch a battle live
+// @Description Streams a battle as it happens: turns, spell casts, participants joining, dragon revivals and completion. Upgrades to WebSocket when asked to, otherwise sends server-sent events. The first event is a snapshot of the battle and the stream ends with a completed event. Same access rules as getting the battle.
+// @Tags battles
+// @Produce json
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Success 200 {object} map[string]interface{} "stream of events: type, timestamp, data"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /battles/{id}/watch [get]
+func (h *Handler) WatchBattle(c *gin.Context) {
+	battleID := c.Param("id")
+
+	battle, err := h.Service.GetBattle(dto.GetBattleQuery{BattleID: battleID})
+	if err != nil {
+		if err.Error() == "battle not found" {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: "Battle not found",
+			})
+			return
 		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
 	}
 
-	// Admin/emperor access
-	if !hasAccess && !CheckBattleAccess(c, 0) {
+	lightParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
+	darkParts, _ := GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
+	if !CanViewBattle(c, append(append([]*BattleParticipant{}, lightParts...), darkParts...)) {
 		c.JSON(http.StatusForbidden, dto.ErrorResponse{
 			Error:   "forbidden",
 			Message: "You do not have permission to view this battle",
@@ -247,7 +1028,41 @@ func (h *Handler) GetBattle(c *gin.Context) {
 		return
 	}
 
-    c.JSON(http.StatusOK, ToBattleResponse(battle, lightParts, darkParts))
+	hub := GetSpectatorHub()
+	if hub == nil {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+			Error:   "unavailable",
+			Message: "live spectating is unavailable",
+		})
+		return
+	}
+	sub, err := hub.Subscribe(spectate.BattleChannel(battle.ID))
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+			Error:   "unavailable",
+			Message: err.Error(),
+		})
+		return
+	}
+	defer sub.Close()
+
+	// Take the snapshot only once subscribed, so nothing happening in between is missed
+	if current, err := h.Service.GetBattle(dto.GetBattleQuery{BattleID: battle.ID}); err == nil {
+		battle = current
+		lightParts, _ = GetRepository().FindParticipants(c.Request.Context(), battle.ID, "light")
+		darkParts, _ = GetRepository().FindParticipants(c.Request.Context(), battle.ID, "dark")
+	}
+	var initial []spectate.Event
+	if snapshot, err := spectate.NewEvent(spectate.EventSnapshot, ToBattleResponse(battle, lightParts, darkParts)); err == nil {
+		initial = append(initial, snapshot)
+	}
+	if !battle.IsActive() {
+		if done, err := spectate.NewEvent(spectate.EventCompleted, ToBattleResponse(battle, nil, nil)); err == nil {
+			initial = append(initial, done)
+		}
+	}
+
+	spectate.Serve(c.Writer, c.Request, sub.C, initial...)
 }
 
 // GetMyBattles godoc
@@ -390,6 +1205,84 @@ func (h *Handler) GetBattleTurns(c *gin.Context) {
 	})
 }
 
+// ReplayBattle godoc
+// @Summary Replay a battle at a turn
+// @Description Rebuild every participant's HP, alive flag and active spell modifiers at a given turn from stored turns and spell records. With verify=true the fight is re-run and turns whose stored HP or crit roll differ are flagged.
+// @Tags battles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Battle ID"
+// @Param turn query int false "Turn number (default: last played turn, 0 = before the first turn)"
+// @Param verify query bool false "Re-run the fight and report mismatches"
+// @Success 200 {object} dto.BattleReplayResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /battles/{id}/replay [get]
+func (h *Handler) ReplayBattle(c *gin.Context) {
+	battleID := c.Param("id")
+	if battleID == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: "battle ID is required",
+		})
+		return
+	}
+
+	turn := -1
+	if v := c.Query("turn"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "validation_error",
+				Message: "turn must be a non-negative integer",
+			})
+			return
+		}
+		turn = n
+	}
+	verify, _ := strconv.ParseBool(c.DefaultQuery("verify", "false"))
+
+	participants, err := GetRepository().FindParticipants(c.Request.Context(), battleID, "all")
+	if err != nil || len(participants) == 0 {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "not_found",
+			Message: "Battle not found",
+		})
+		return
+	}
+	if !CanViewBattle(c, participants) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "forbidden",
+			Message: "You do not have permission to view this battle",
+		})
+		return
+	}
+
+	replay, err := h.Service.ReplayBattle(dto.ReplayBattleQuery{
+		BattleID: battleID,
+		Turn:     turn,
+		Verify:   verify,
+	})
+	if err != nil {
+		if err.Error() == "battle not found" {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   "not_found",
+				Message: "Battle not found",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "replay_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ToBattleReplayResponse(replay))
+}
+
 // GetBattleStats godoc
 // @Summary Get battle statistics
 // @Description Get battle statistics. Warriors see only their own stats. Emperors/Kings can view any warrior's stats via warrior_id query param.
@@ -449,6 +1342,76 @@ func (h *Handler) GetBattleStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// GetBattleAnalytics godoc
+// @Summary Get battle analytics
+// @Description Aggregated team battle statistics for balancing, from the analytics read model: win rate, damage and crit rate per participant type and side, average turns to victory, most-used weapons and armor, and spell effectiveness. Emperors only.
+// @Tags battles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day, YYYY-MM-DD (default 29 days before to)"
+// @Param to query string false "Last day, YYYY-MM-DD (default today, UTC)"
+// @Param group_by query string false "Comma-separated grouping of participant stats: participant_type, side, day (default participant_type,side)"
+// @Param limit query int false "Most-used weapons and armor to return (default 10, max 50)"
+// @Success 200 {object} dto.BattleAnalyticsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /battles/analytics [get]
+func (h *Handler) GetBattleAnalytics(c *gin.Context) {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   "unauthorized",
+			Message: err.Error(),
+		})
+		return
+	}
+	if !isEmperor(user.Role) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "forbidden",
+			Message: "Only emperors can view battle analytics",
+		})
+		return
+	}
+
+	var req dto.BattleAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "validation_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	query := dto.BattleAnalyticsQuery{
+		From:  req.From,
+		To:    req.To,
+		Limit: req.Limit,
+	}
+	if req.GroupBy != "" {
+		query.GroupBy = strings.Split(req.GroupBy, ",")
+	}
+
+	analytics, err := h.Service.GetBattleAnalytics(query)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAnalyticsQuery) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "validation_error",
+				Message: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
 // GetBattleLogs godoc
 // @Summary Get battle logs from Redis
 // @Description Get real-time battle logs stored in Redis. Returns all battle events including attacks, critical hits, and battle state changes.
@@ -485,7 +1448,7 @@ func (h *Handler) GetBattleLogs(c *gin.Context) {
 
 	// Check if battle exists and user has access
 	query := dto.GetBattleQuery{
-		BattleID: objectID,
+		BattleID: battleID,
 	}
 
     battle, err := h.Service.GetBattle(query)
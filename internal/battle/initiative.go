@@ -0,0 +1,160 @@
+package battle
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	pbDragon "network-sec-micro/api/proto/dragon"
+	pbEnemy "network-sec-micro/api/proto/enemy"
+	"network-sec-micro/internal/battle/dto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var dragonGrpcClient pbDragon.DragonServiceClient
+var dragonGrpcConn *grpc.ClientConn
+
+var enemyGrpcClient pbEnemy.EnemyServiceClient
+var enemyGrpcConn *grpc.ClientConn
+
+// Initiative for the speed turn order comes from the participant's level in its own service,
+// never from the request: a base speed per participant type plus speedPerLevel for every level.
+const speedPerLevel = 2
+
+var baseSpeed = map[ParticipantType]int{
+	ParticipantTypeWarrior:      10,
+	ParticipantTypeLightKing:    10,
+	ParticipantTypeLightEmperor: 10,
+	ParticipantTypeDarkKing:     10,
+	ParticipantTypeDarkEmperor:  10,
+	ParticipantTypeEnemy:        8,
+	ParticipantTypeDragon:       6,
+}
+
+// InitDragonClient initializes the gRPC client connection to dragon service
+func InitDragonClient(addr string) error {
+	if addr == "" {
+		addr = os.Getenv("DRAGON_GRPC_ADDR")
+		if addr == "" {
+			addr = "localhost:50059"
+		}
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to dragon gRPC: %w", err)
+	}
+
+	dragonGrpcClient = pbDragon.NewDragonServiceClient(conn)
+	dragonGrpcConn = conn
+	return nil
+}
+
+// CloseDragonClient closes the dragon gRPC connection
+func CloseDragonClient() {
+	if dragonGrpcConn != nil {
+		dragonGrpcConn.Close()
+	}
+}
+
+// InitEnemyClient initializes the gRPC client connection to enemy service
+func InitEnemyClient(addr string) error {
+	if addr == "" {
+		addr = os.Getenv("ENEMY_GRPC_ADDR")
+		if addr == "" {
+			addr = "localhost:50060"
+		}
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to enemy gRPC: %w", err)
+	}
+
+	enemyGrpcClient = pbEnemy.NewEnemyServiceClient(conn)
+	enemyGrpcConn = conn
+	return nil
+}
+
+// CloseEnemyClient closes the enemy gRPC connection
+func CloseEnemyClient() {
+	if enemyGrpcConn != nil {
+		enemyGrpcConn.Close()
+	}
+}
+
+// ParticipantSpeed derives a participant's initiative from its level in the warrior, dragon or
+// enemy service. If that service is not configured the participant is treated as level 1.
+func ParticipantSpeed(ctx context.Context, pType ParticipantType, participantID string) (int, error) {
+	level, err := participantLevel(ctx, pType, participantID)
+	if err != nil {
+		return 0, err
+	}
+	if level < 1 {
+		level = 1
+	}
+	base, ok := baseSpeed[pType]
+	if !ok {
+		base = baseSpeed[ParticipantTypeWarrior]
+	}
+	return base + level*speedPerLevel, nil
+}
+
+// participantSpeeds looks up the initiative of every participant of a new battle, keyed by participant ID
+func participantSpeeds(ctx context.Context, infos []dto.ParticipantInfo) (map[string]int, error) {
+	speeds := make(map[string]int, len(infos))
+	for _, p := range infos {
+		speed, err := ParticipantSpeed(ctx, ParticipantType(p.Type), p.ParticipantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load stats of %s: %w", p.Name, err)
+		}
+		speeds[p.ParticipantID] = speed
+	}
+	return speeds, nil
+}
+
+// participantLevel reads a participant's level from the service that owns it (0 if that service is not configured)
+func participantLevel(ctx context.Context, pType ParticipantType, participantID string) (int, error) {
+	switch pType {
+	case ParticipantTypeDragon:
+		if dragonGrpcClient == nil {
+			return 0, nil
+		}
+		resp, err := dragonGrpcClient.GetDragonByID(ctx, &pbDragon.GetDragonByIDRequest{DragonId: participantID})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get dragon: %w", err)
+		}
+		if !resp.Success {
+			return 0, fmt.Errorf("failed to get dragon: %s", resp.Message)
+		}
+		return int(resp.Dragon.GetLevel()), nil
+	case ParticipantTypeEnemy:
+		if enemyGrpcClient == nil {
+			return 0, nil
+		}
+		resp, err := enemyGrpcClient.GetEnemyByID(ctx, &pbEnemy.GetEnemyByIDRequest{EnemyId: participantID})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get enemy: %w", err)
+		}
+		if !resp.Success {
+			return 0, fmt.Errorf("failed to get enemy: %s", resp.Message)
+		}
+		return int(resp.Enemy.GetLevel()), nil
+	default:
+		// Warriors, kings and emperors are all warrior accounts
+		if warriorGrpcClient == nil {
+			return 0, nil
+		}
+		var warriorID uint
+		if _, err := fmt.Sscanf(participantID, "%d", &warriorID); err != nil {
+			return 0, fmt.Errorf("invalid warrior ID %q", participantID)
+		}
+		warrior, err := GetWarriorByID(ctx, warriorID)
+		if err != nil {
+			return 0, err
+		}
+		return int(warrior.GetLevel()), nil
+	}
+}
@@ -101,6 +101,8 @@ type BattleCompletedEvent struct {
 	CoinsEarned       int       `json:"coins_earned,omitempty"`
 	ExperienceGained  int       `json:"experience_gained,omitempty"`
 	TotalTurns        int       `json:"total_turns"`
+	WinnerSide        string    `json:"winner_side,omitempty"`  // Team battles only
+	Participants      []kafka.BattleParticipantSummary `json:"participants,omitempty"` // Team battles only
 }
 
 // PublishBattleStartedEvent publishes battle started event
@@ -174,3 +176,79 @@ func PublishBattleWagerResolved(battleID string, winnerSide TeamSide, wagerAmoun
     return nil
 }
 
+// PublishBattleRewardEvent publishes one participant's battle reward
+func PublishBattleRewardEvent(reward *BattleReward) error {
+    publisher := GetKafkaPublisher()
+    if publisher == nil { return fmt.Errorf("kafka publisher not initialized") }
+    event := kafka.NewBattleRewardEvent(reward.BattleID, reward.ParticipantID, reward.ParticipantName, string(reward.ParticipantType), string(reward.Side), reward.DamageDealt, reward.Kills, reward.Coins, reward.Experience)
+    if err := publisher.Publish(kafka.TopicBattleReward, event); err != nil { return fmt.Errorf("failed to publish battle reward: %w", err) }
+    log.Printf("Published battle reward: battle=%s participant=%s coins=%d xp=%d", reward.BattleID, reward.ParticipantID, reward.Coins, reward.Experience)
+    return nil
+}
+
+// PublishBattleSurrendered publishes a side's surrender
+func PublishBattleSurrendered(battle *Battle, surrenderedSide TeamSide, surrenderedBy string) error {
+    publisher := GetKafkaPublisher()
+    if publisher == nil { return fmt.Errorf("kafka publisher not initialized") }
+    event := kafka.NewBattleSurrenderedEvent(battle.ID, string(surrenderedSide), surrenderedBy, string(battle.WinnerSide), battle.WagerAmount, battle.CurrentTurn)
+    if err := publisher.Publish(kafka.TopicBattleSurrendered, event); err != nil { return fmt.Errorf("failed to publish battle surrendered: %w", err) }
+    log.Printf("Published battle surrendered: battle=%s side=%s", battle.ID, surrenderedSide)
+    return nil
+}
+
+// PublishBattleCancelled publishes a creator's cancellation
+func PublishBattleCancelled(battle *Battle, cancelledBy, reason string) error {
+    publisher := GetKafkaPublisher()
+    if publisher == nil { return fmt.Errorf("kafka publisher not initialized") }
+    event := kafka.NewBattleCancelledEvent(battle.ID, cancelledBy, reason, battle.WagerAmount, battle.LightEmperorID, battle.DarkEmperorID)
+    if err := publisher.Publish(kafka.TopicBattleCancelled, event); err != nil { return fmt.Errorf("failed to publish battle cancelled: %w", err) }
+    log.Printf("Published battle cancelled: battle=%s by=%s", battle.ID, cancelledBy)
+    return nil
+}
+
+// PublishBattleForceCancelled publishes an emperor's force-cancel
+func PublishBattleForceCancelled(battle *Battle, cancelledBy, reason string, previousStatus BattleStatus, rewardedCoins int) error {
+    publisher := GetKafkaPublisher()
+    if publisher == nil { return fmt.Errorf("kafka publisher not initialized") }
+    event := kafka.NewBattleForceCancelledEvent(battle.ID, cancelledBy, reason, string(previousStatus), battle.WagerAmount, battle.LightEmperorID, battle.DarkEmperorID, rewardedCoins, battle.CurrentTurn)
+    if err := publisher.Publish(kafka.TopicBattleForceCancelled, event); err != nil { return fmt.Errorf("failed to publish battle force-cancelled: %w", err) }
+    log.Printf("Published battle force-cancelled: battle=%s by=%s", battle.ID, cancelledBy)
+    return nil
+}
+
+// PublishTeamBattleCompletedEvent publishes the completed event of a team battle with its participant roster
+func PublishTeamBattleCompletedEvent(battle *Battle, participants []*BattleParticipant, coinsEarned, experienceGained int) error {
+    publisher := GetKafkaPublisher()
+    if publisher == nil { return fmt.Errorf("kafka publisher not initialized") }
+    roster := make([]kafka.BattleParticipantSummary, 0, len(participants))
+    for _, p := range participants {
+        roster = append(roster, kafka.BattleParticipantSummary{ParticipantID: p.ParticipantID, ParticipantType: string(p.Type), Side: string(p.Side), Survived: p.IsAlive})
+    }
+    event := BattleCompletedEvent{
+        EventType:        "battle_completed",
+        Timestamp:        time.Now(),
+        SourceService:    "battle",
+        BattleID:         battle.ID,
+        BattleType:       string(battle.BattleType),
+        WarriorName:      "Team Battle",
+        Result:           string(battle.Result),
+        WinnerName:       string(battle.WinnerSide),
+        CoinsEarned:      coinsEarned,
+        ExperienceGained: experienceGained,
+        TotalTurns:       battle.CurrentTurn,
+        WinnerSide:       string(battle.WinnerSide),
+        Participants:     roster,
+    }
+    if err := publisher.Publish(kafka.TopicBattleCompleted, event); err != nil { return fmt.Errorf("failed to publish battle completed event: %w", err) }
+    log.Printf("Published battle completed event: %s - %s", battle.ID, battle.Result)
+    return nil
+}
+
+// PublishBattleTurnEvent publishes one hit of a team battle for the analytics read model
+func PublishBattleTurnEvent(turn *BattleTurn) error {
+    publisher := GetKafkaPublisher()
+    if publisher == nil { return fmt.Errorf("kafka publisher not initialized") }
+    event := kafka.NewBattleTurnEvent(turn.BattleID, turn.TurnNumber, turn.AttackerID, string(turn.AttackerType), string(turn.AttackerSide), turn.TargetID, string(turn.TargetType), string(turn.TargetSide), turn.DamageDealt, turn.CriticalHit, turn.TargetDefeated, string(turn.EffectType), turn.WeaponID, turn.ArmorID)
+    if err := publisher.Publish(kafka.TopicBattleTurn, event); err != nil { return fmt.Errorf("failed to publish battle turn: %w", err) }
+    return nil
+}
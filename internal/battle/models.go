@@ -53,6 +53,8 @@ const (
 	ParticipantTypeDragon   ParticipantType = "dragon"  // Dragon (fire, ice, lightning, shadow)
 	ParticipantTypeDarkKing  ParticipantType = "dark_king"
 	ParticipantTypeDarkEmperor ParticipantType = "dark_emperor"
+	ParticipantTypeLightKing   ParticipantType = "light_king"
+	ParticipantTypeLightEmperor ParticipantType = "light_emperor"
 )
 
 // BattleParticipant represents a single participant in a battle
@@ -69,11 +71,17 @@ type BattleParticipant struct {
 	MaxHP         int                `bson:"max_hp" json:"max_hp"`
 	AttackPower   int                `bson:"attack_power" json:"attack_power"`
 	Defense       int                `bson:"defense" json:"defense"`
+	Speed         int                `bson:"speed" json:"speed"` // Initiative for speed turn order
+	Element       string             `bson:"element,omitempty" json:"element,omitempty"` // Innate element (dragons); empty = physical
+	Level         int                `bson:"level,omitempty" json:"level,omitempty"` // Used for hierarchy checks and kill rewards
 	
 	// Status
 	IsAlive       bool               `bson:"is_alive" json:"is_alive"`
 	IsDefeated   bool               `bson:"is_defeated" json:"is_defeated"`
 	DefeatedAt   *time.Time         `bson:"defeated_at,omitempty" json:"defeated_at,omitempty"`
+	Version      int                `bson:"version" json:"version"` // Optimistic concurrency version (SQL store)
+	StatusEffects []StatusEffect    `bson:"status_effects,omitempty" json:"status_effects,omitempty"` // Active effects (see status_effects.go)
+	Ready        bool               `bson:"ready" json:"ready"` // Passed the lobby ready-check (see service_lobby.go)
 	
     CreatedAt    time.Time          `json:"created_at"`
     UpdatedAt    time.Time          `json:"updated_at"`
@@ -96,7 +104,14 @@ type Battle struct {
 	// Turn information
 	CurrentTurn   int                `bson:"current_turn" json:"current_turn"`
 	CurrentParticipantIndex int     `bson:"current_participant_index" json:"current_participant_index"` // Index in turn order
+	TurnOrder     TurnOrder          `bson:"turn_order" json:"turn_order"` // alternating or speed
 	MaxTurns      int                `bson:"max_turns" json:"max_turns"`
+	Seed          int64              `bson:"seed" json:"seed"` // Combat RNG seed (see TurnRNG)
+	TurnTimeoutSeconds int           `bson:"turn_timeout_seconds" json:"turn_timeout_seconds"` // 0 = no per-turn deadline
+	TimeoutAction TimeoutAction      `bson:"timeout_action" json:"timeout_action"` // skip or attack when the deadline passes
+	TurnDeadline  *time.Time         `bson:"turn_deadline,omitempty" json:"turn_deadline,omitempty"` // Current participant must act before this
+	ExpiresAt     *time.Time         `bson:"expires_at,omitempty" json:"expires_at,omitempty"` // Wall-clock limit for the whole battle
+	Version       int                `bson:"version" json:"version"` // Optimistic concurrency version (SQL store)
 	
 	// Battle result
 	Status        BattleStatus       `bson:"status" json:"status"`
@@ -134,6 +149,13 @@ type Battle struct {
     DarkEmperorID  string            `json:"dark_emperor_id"`
     LightEmperorApproved bool        `json:"light_emperor_approved"`
     DarkEmperorApproved  bool        `json:"dark_emperor_approved"`
+
+    // Lobby (optional): open slots filled by players before the battle starts
+    LightSlots    int                `json:"light_slots,omitempty"` // Light side size once full
+    DarkSlots     int                `json:"dark_slots,omitempty"`  // Dark side size once full
+    ScheduledStartAt *time.Time      `json:"scheduled_start_at,omitempty"` // Full lobby starts at this time, ready or not
+    LobbyDeadline *time.Time         `json:"lobby_deadline,omitempty"` // Lobby is cancelled if it has not started by then
+    MaxDurationMinutes int           `json:"max_duration_minutes,omitempty"` // Wall-clock limit applied when the lobby starts
 }
 
 // CollectionName returns the MongoDB collection name
@@ -146,6 +168,11 @@ func (b *Battle) IsActive() bool {
 	return b.Status == BattleStatusInProgress || b.Status == BattleStatusPending
 }
 
+// IsLobby checks if battle is a lobby still waiting to start
+func (b *Battle) IsLobby() bool {
+	return b.Status == BattleStatusPending && b.LobbyDeadline != nil
+}
+
 // BattleTurn represents a single turn in a battle
 type BattleTurn struct {
     ID            string             `json:"id"`
@@ -175,6 +202,24 @@ type BattleTurn struct {
 	// Was target defeated in this attack?
 	TargetDefeated bool              `bson:"target_defeated" json:"target_defeated"`
 	
+	// Status effect ticks: the affected participant is both attacker and target
+	EffectType    StatusEffectType   `bson:"effect_type,omitempty" json:"effect_type,omitempty"` // Empty for attacks
+	HealingDone   int                `bson:"healing_done,omitempty" json:"healing_done,omitempty"`
+	
+	// Elemental hits: damage element and how much of the hit the target's element and armor absorbed
+	Element       string             `bson:"element,omitempty" json:"element,omitempty"`
+	DamageResisted int               `bson:"damage_resisted,omitempty" json:"damage_resisted,omitempty"`
+	
+	// Inputs of the hit's damage roll. Equipment and modifiers change after the fact, so they are
+	// recorded for verify mode to re-run the roll from the battle seed.
+	AttackPower   int                `bson:"attack_power,omitempty" json:"attack_power,omitempty"`     // Attacker's, with weapon and modifiers
+	TargetDefense int                `bson:"target_defense,omitempty" json:"target_defense,omitempty"` // Target's, with armor and modifiers
+	Resistance    int                `bson:"resistance,omitempty" json:"resistance,omitempty"`         // Target armor's resistance to the hit's element, in percent
+	
+	// Equipment worn by the hit, so the wear can be given back if the battle is force-cancelled
+	WeaponID      string             `bson:"weapon_id,omitempty" json:"weapon_id,omitempty"` // Attacker's weapon
+	ArmorID       string             `bson:"armor_id,omitempty" json:"armor_id,omitempty"`   // Target's armor
+	
     CreatedAt     time.Time          `json:"created_at"`
 }
 
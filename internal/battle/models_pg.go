@@ -11,7 +11,13 @@ type BattleSQL struct {
     DarkSideName            string `gorm:"size:255"`
     CurrentTurn             int
     CurrentParticipantIndex int
+    TurnOrder               string `gorm:"size:16"`
     MaxTurns                int
+    Seed                    int64
+    TurnTimeoutSeconds      int
+    TimeoutAction           string `gorm:"size:16"`
+    TurnDeadline            *time.Time `gorm:"index"`
+    ExpiresAt               *time.Time `gorm:"index"`
     Status                  string `gorm:"size:32;index"`
     Result                  string `gorm:"size:32"`
     WinnerSide              string `gorm:"size:16"`
@@ -25,6 +31,12 @@ type BattleSQL struct {
     DarkEmperorID           string `gorm:"size:64"`
     LightEmperorApproved    bool   `gorm:"not null;default:false"`
     DarkEmperorApproved     bool   `gorm:"not null;default:false"`
+    LightSlots              int
+    DarkSlots               int
+    ScheduledStartAt        *time.Time
+    LobbyDeadline           *time.Time `gorm:"index"`
+    MaxDurationMinutes      int
+    Version                 int    `gorm:"not null;default:1"`
 }
 
 func (BattleSQL) TableName() string { return "battles" }
@@ -40,9 +52,15 @@ type BattleParticipantSQL struct {
     MaxHP         int
     AttackPower   int
     Defense       int
+    Speed         int
+    Element       string `gorm:"size:16"`
+    Level         int
     IsAlive       bool  `gorm:"not null;default:true"`
     IsDefeated    bool  `gorm:"not null;default:false"`
     DefeatedAt    *time.Time
+    StatusEffects string `gorm:"type:text"` // JSON-encoded []StatusEffect
+    Ready         bool  `gorm:"not null;default:false"`
+    Version       int   `gorm:"not null;default:1"`
     CreatedAt     time.Time
     UpdatedAt     time.Time
 }
@@ -66,9 +84,114 @@ type BattleTurnSQL struct {
     TargetHPBefore  int
     TargetHPAfter   int
     TargetDefeated  bool
+    EffectType      string `gorm:"size:32"`
+    HealingDone     int
+    Element         string `gorm:"size:16"`
+    DamageResisted  int
+    AttackPower     int
+    TargetDefense   int
+    Resistance      int
+    WeaponID        string `gorm:"size:64"`
+    ArmorID         string `gorm:"size:64"`
     CreatedAt       time.Time
 }
 
 func (BattleTurnSQL) TableName() string { return "battle_turns" }
 
+type BattleRewardSQL struct {
+    ID              uint   `gorm:"primaryKey;autoIncrement"`
+    BattleID        uint   `gorm:"uniqueIndex:idx_battle_reward;not null"`
+    ParticipantID   string `gorm:"uniqueIndex:idx_battle_reward;size:64"`
+    ParticipantName string `gorm:"size:255"`
+    ParticipantType string `gorm:"size:32"`
+    Side            string `gorm:"size:8"`
+    DamageDealt     int
+    Kills           int
+    Coins           int
+    Experience      int
+    Paid            bool   `gorm:"not null;default:false"`
+    CreatedAt       time.Time
+}
+
+func (BattleRewardSQL) TableName() string { return "battle_rewards" }
+
+// Analytics read model tables, fed by battle events (see analytics.go). Aggregates are kept per UTC day
+// so any date range can be summed up from them.
+
+type AnalyticsParticipantDailySQL struct {
+    Day             string `gorm:"primaryKey;size:10"` // 2006-01-02
+    ParticipantType string `gorm:"primaryKey;size:32"`
+    Side            string `gorm:"primaryKey;size:8"`
+    Battles         int
+    Wins            int
+    Losses          int
+    Draws           int
+    Survived        int
+    Attacks         int
+    CriticalHits    int
+    Kills           int
+    DamageDealt     int
+    DamageTaken     int
+}
+
+func (AnalyticsParticipantDailySQL) TableName() string { return "battle_analytics_participants" }
+
+type AnalyticsVictoryDailySQL struct {
+    Day        string `gorm:"primaryKey;size:10"`
+    WinnerSide string `gorm:"primaryKey;size:8"`
+    Victories  int
+    TotalTurns int
+}
+
+func (AnalyticsVictoryDailySQL) TableName() string { return "battle_analytics_victories" }
+
+type AnalyticsEquipmentDailySQL struct {
+    Day    string `gorm:"primaryKey;size:10"`
+    Kind   string `gorm:"primaryKey;size:8"` // weapon or armor
+    ItemID string `gorm:"primaryKey;size:64"`
+    Uses   int    // Hits made with the weapon, or taken wearing the armor
+    Damage int    // Damage those hits did
+}
+
+func (AnalyticsEquipmentDailySQL) TableName() string { return "battle_analytics_equipment" }
+
+type AnalyticsSpellDailySQL struct {
+    Day          string `gorm:"primaryKey;size:10"`
+    SpellType    string `gorm:"primaryKey;size:32"`
+    Side         string `gorm:"size:8"`
+    Casts        int
+    Affected     int
+    DecidedCasts int // Casts in battles that have since ended with a result
+    WinningCasts int // Of those, casts for the side that won
+}
+
+func (AnalyticsSpellDailySQL) TableName() string { return "battle_analytics_spells" }
+
+// AnalyticsSpellCastSQL is a cast waiting for its battle to end before it counts towards spell effectiveness
+type AnalyticsSpellCastSQL struct {
+    ID        uint   `gorm:"primaryKey;autoIncrement"`
+    BattleID  string `gorm:"size:64;index"`
+    Day       string `gorm:"size:10"`
+    SpellType string `gorm:"size:32"`
+    Side      string `gorm:"size:8"`
+}
+
+func (AnalyticsSpellCastSQL) TableName() string { return "battle_analytics_spell_casts" }
+
+// AnalyticsBattleOutcomeSQL is how a battle ended, so spell casts seen after the end are decided straight away
+type AnalyticsBattleOutcomeSQL struct {
+    BattleID   string `gorm:"primaryKey;size:64"`
+    Result     string `gorm:"size:32"` // A battle result, or "cancelled"
+    WinnerSide string `gorm:"size:8"`
+    CreatedAt  time.Time
+}
+
+func (AnalyticsBattleOutcomeSQL) TableName() string { return "battle_analytics_outcomes" }
+
+// AnalyticsEventSQL marks an event as applied, so a redelivered one is not counted twice
+type AnalyticsEventSQL struct {
+    Key       string `gorm:"primaryKey;size:191"`
+    CreatedAt time.Time
+}
 
+func (AnalyticsEventSQL) TableName() string { return "battle_analytics_events" }
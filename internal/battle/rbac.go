@@ -3,6 +3,7 @@ package battle
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 
 	"network-sec-micro/internal/battle/dto"
@@ -76,6 +77,22 @@ func CheckBattleAccess(c *gin.Context, battleWarriorID uint) bool {
 	return user.UserID == battleWarriorID
 }
 
+// CanViewBattle checks if the current user is a participant of the battle or may view it by role
+func CanViewBattle(c *gin.Context, participants []*BattleParticipant) bool {
+	user, err := GetCurrentUser(c)
+	if err != nil {
+		return false
+	}
+	userIDStr := fmt.Sprintf("%d", user.UserID)
+	for _, p := range participants {
+		if p.ParticipantID == userIDStr {
+			return true
+		}
+	}
+	// Admin/emperor access
+	return CheckBattleAccess(c, 0)
+}
+
 // isEmperor checks if role is an emperor
 func isEmperor(role string) bool {
 	return role == "light_emperor" || role == "dark_emperor"
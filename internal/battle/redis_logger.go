@@ -16,7 +16,7 @@ type BattleLogEntry struct {
 	BattleID      string    `json:"battle_id"`
 	TurnNumber    int       `json:"turn_number"`
 	Timestamp     time.Time `json:"timestamp"`
-	EventType     string    `json:"event_type"` // "warrior_attack", "opponent_attack", "critical_hit", "battle_start", "battle_end"
+	EventType     string    `json:"event_type"` // "warrior_attack", "opponent_attack", "critical_hit", "battle_start", "battle_end", "participant_joined", "participant_left", "effect_applied", "effect_tick"
 	AttackerID    string    `json:"attacker_id"`
 	AttackerName  string    `json:"attacker_name"`
 	AttackerType  string    `json:"attacker_type"` // "warrior", "enemy", "dragon", etc.
@@ -29,6 +29,10 @@ type BattleLogEntry struct {
 	CriticalHit    bool      `json:"critical_hit"`
 	TargetHPBefore int       `json:"target_hp_before"`
 	TargetHPAfter  int       `json:"target_hp_after"`
+	EffectType     string    `json:"effect_type,omitempty"`
+	HealingDone    int       `json:"healing_done,omitempty"`
+	Element        string    `json:"element,omitempty"`
+	DamageResisted int       `json:"damage_resisted,omitempty"`
 	Message        string    `json:"message,omitempty"`
 }
 
@@ -55,6 +59,8 @@ func LogBattleTurn(ctx context.Context, battleID primitive.ObjectID, turn *Battl
 		CriticalHit:    turn.CriticalHit,
 		TargetHPBefore: turn.TargetHPBefore,
 		TargetHPAfter:  turn.TargetHPAfter,
+		Element:        turn.Element,
+		DamageResisted: turn.DamageResisted,
 		Message:        message,
 	}
 
@@ -167,6 +173,83 @@ func LogBattleEnd(ctx context.Context, battle *Battle, message string) error {
 	return err
 }
 
+// LogParticipantEvent logs a roster change ("participant_joined", "participant_left") at the battle's current turn
+func LogParticipantEvent(ctx context.Context, battle *Battle, participant *BattleParticipant, eventType string, message string) error {
+	if redisClient == nil {
+		return fmt.Errorf("redis client not initialized")
+	}
+
+	logEntry := BattleLogEntry{
+		BattleID:     battle.ID,
+		TurnNumber:   battle.CurrentTurn,
+		Timestamp:    time.Now(),
+		EventType:    eventType,
+		AttackerID:   participant.ParticipantID,
+		AttackerName: participant.Name,
+		AttackerType: string(participant.Type),
+		AttackerSide: string(participant.Side),
+		Message:      message,
+	}
+
+	logData, err := json.Marshal(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+
+	streamKey := fmt.Sprintf("battle:logs:%s", battle.ID)
+	_, err = redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey,
+		Values: map[string]interface{}{
+			"data": string(logData),
+		},
+	}).Result()
+
+	return err
+}
+
+// LogStatusEffectTick logs a status effect tick recorded as a battle turn
+func LogStatusEffectTick(ctx context.Context, battle *Battle, turn *BattleTurn, message string) error {
+	if redisClient == nil {
+		return fmt.Errorf("redis client not initialized")
+	}
+
+	logEntry := BattleLogEntry{
+		BattleID:       battle.ID,
+		TurnNumber:     turn.TurnNumber,
+		Timestamp:      turn.CreatedAt,
+		EventType:      "effect_tick",
+		AttackerID:     turn.AttackerID,
+		AttackerName:   turn.AttackerName,
+		AttackerType:   string(turn.AttackerType),
+		AttackerSide:   string(turn.AttackerSide),
+		TargetID:       turn.TargetID,
+		TargetName:     turn.TargetName,
+		TargetType:     string(turn.TargetType),
+		TargetSide:     string(turn.TargetSide),
+		DamageDealt:    turn.DamageDealt,
+		TargetHPBefore: turn.TargetHPBefore,
+		TargetHPAfter:  turn.TargetHPAfter,
+		EffectType:     string(turn.EffectType),
+		HealingDone:    turn.HealingDone,
+		Message:        message,
+	}
+
+	logData, err := json.Marshal(logEntry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+
+	streamKey := fmt.Sprintf("battle:logs:%s", battle.ID)
+	_, err = redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey,
+		Values: map[string]interface{}{
+			"data": string(logData),
+		},
+	}).Result()
+
+	return err
+}
+
 // GetBattleLogs retrieves battle logs from Redis
 func GetBattleLogs(ctx context.Context, battleID primitive.ObjectID, limit int64) ([]BattleLogEntry, error) {
 	if redisClient == nil {
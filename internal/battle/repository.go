@@ -2,7 +2,9 @@ package battle
 
 import (
     "context"
+    "errors"
     "os"
+    "time"
 )
 
 // Repository abstracts persistence for Battle domain
@@ -11,13 +13,30 @@ type Repository interface {
     CreateBattle(ctx context.Context, b *Battle) (string, error)
     InsertParticipants(ctx context.Context, participants []*BattleParticipant) error
     UpdateBattleFields(ctx context.Context, id string, fields map[string]interface{}) error
+    UpdateBattleFieldsIfVersion(ctx context.Context, id string, version int, fields map[string]interface{}) error
     GetParticipantByIDs(ctx context.Context, battleID string, participantID string) (*BattleParticipant, error)
     UpdateParticipantByIDs(ctx context.Context, battleID string, participantID string, fields map[string]interface{}) error
+    UpdateParticipantIfVersion(ctx context.Context, battleID string, participantID string, version int, fields map[string]interface{}) error
     InsertTurn(ctx context.Context, turn *BattleTurn) error
+    ListTurns(ctx context.Context, battleID string, uptoTurn int) ([]*BattleTurn, error)
     FindParticipants(ctx context.Context, battleID string, sideFilter string) ([]*BattleParticipant, error)
     CountAliveBySide(ctx context.Context, battleID string, side TeamSide) (int, error)
+    ListOverdueBattles(ctx context.Context, now time.Time, limit int) ([]*Battle, error)
+    ClaimTurnDeadline(ctx context.Context, id string, now time.Time, lease time.Time) (bool, error)
+    UpdateBattleFieldsIfStatus(ctx context.Context, id string, status BattleStatus, fields map[string]interface{}) (bool, error)
+    ListLobbies(ctx context.Context, limit int, offset int) ([]*Battle, int64, error)
+    ListDueLobbies(ctx context.Context, now time.Time, limit int) ([]*Battle, error)
+    DeleteParticipant(ctx context.Context, battleID string, participantID string) error
+    InsertRewards(ctx context.Context, rewards []*BattleReward) error
+    ListRewards(ctx context.Context, battleID string) ([]*BattleReward, error)
+    ListUnpaidRewards(ctx context.Context, limit int) ([]*BattleReward, error)
+    SetRewardPaid(ctx context.Context, battleID string, participantID string, paid bool) (bool, error)
+    InTransaction(ctx context.Context, fn func(repo Repository) error) error
 }
 
+// ErrVersionConflict is returned by the IfVersion updates when the row changed since it was read
+var ErrVersionConflict = errors.New("battle data was modified concurrently")
+
 var defaultRepo Repository
 
 // GetRepository returns a singleton repo based on env (BATTLE_STORE=redis|mongo)
@@ -4,11 +4,14 @@ import (
     "context"
     "errors"
     "fmt"
+    "time"
 
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
 )
 
-type sqlRepo struct{}
+// sqlRepo runs on the shared handle, or on tx when it was handed out by InTransaction
+type sqlRepo struct{ tx *gorm.DB }
 
 func getGorm() (*gorm.DB, error) {
     if !SQLDB.Enabled { return nil, errors.New("sql not enabled") }
@@ -17,8 +20,23 @@ func getGorm() (*gorm.DB, error) {
     return db, nil
 }
 
+func (r *sqlRepo) gorm() (*gorm.DB, error) {
+    if r.tx != nil { return r.tx, nil }
+    return getGorm()
+}
+
+// InTransaction runs fn against a repository bound to one transaction, committed only if fn succeeds.
+// Nested calls join the transaction already open.
+func (r *sqlRepo) InTransaction(ctx context.Context, fn func(repo Repository) error) error {
+    if r.tx != nil { return fn(r) }
+    db, err := getGorm(); if err != nil { return err }
+    return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        return fn(&sqlRepo{tx: tx})
+    })
+}
+
 func (r *sqlRepo) GetBattleByID(ctx context.Context, id string) (*Battle, error) {
-    db, err := getGorm(); if err != nil { return nil, err }
+    db, err := r.gorm(); if err != nil { return nil, err }
     var row BattleSQL
     if tx := db.WithContext(ctx).First(&row, "id = ?", id); tx.Error != nil { return nil, tx.Error }
     b := &Battle{
@@ -28,7 +46,13 @@ func (r *sqlRepo) GetBattleByID(ctx context.Context, id string) (*Battle, error)
         DarkSideName: row.DarkSideName,
         CurrentTurn: row.CurrentTurn,
         CurrentParticipantIndex: row.CurrentParticipantIndex,
+        TurnOrder: TurnOrder(row.TurnOrder),
         MaxTurns: row.MaxTurns,
+        Seed: row.Seed,
+        TurnTimeoutSeconds: row.TurnTimeoutSeconds,
+        TimeoutAction: TimeoutAction(row.TimeoutAction),
+        TurnDeadline: row.TurnDeadline,
+        ExpiresAt: row.ExpiresAt,
         Status: BattleStatus(row.Status),
         Result: BattleResult(row.Result),
         WinnerSide: TeamSide(row.WinnerSide),
@@ -42,19 +66,36 @@ func (r *sqlRepo) GetBattleByID(ctx context.Context, id string) (*Battle, error)
         DarkEmperorID: row.DarkEmperorID,
         LightEmperorApproved: row.LightEmperorApproved,
         DarkEmperorApproved: row.DarkEmperorApproved,
+        LightSlots: row.LightSlots,
+        DarkSlots: row.DarkSlots,
+        ScheduledStartAt: row.ScheduledStartAt,
+        LobbyDeadline: row.LobbyDeadline,
+        MaxDurationMinutes: row.MaxDurationMinutes,
+        Version: row.Version,
+    }
+    if b.Status == BattleStatusCompleted || b.Status == BattleStatusCancelled {
+        rewards, err := r.ListRewards(ctx, b.ID)
+        if err != nil { return nil, err }
+        b.CoinsEarned, b.ExperienceGained = rewardMaps(rewards)
     }
     return b, nil
 }
 
 func (r *sqlRepo) CreateBattle(ctx context.Context, b *Battle) (string, error) {
-    db, err := getGorm(); if err != nil { return "", err }
+    db, err := r.gorm(); if err != nil { return "", err }
     row := &BattleSQL{
         BattleType: string(b.BattleType),
         LightSideName: b.LightSideName,
         DarkSideName: b.DarkSideName,
         CurrentTurn: b.CurrentTurn,
         CurrentParticipantIndex: b.CurrentParticipantIndex,
+        TurnOrder: string(b.TurnOrder),
         MaxTurns: b.MaxTurns,
+        Seed: b.Seed,
+        TurnTimeoutSeconds: b.TurnTimeoutSeconds,
+        TimeoutAction: string(b.TimeoutAction),
+        TurnDeadline: b.TurnDeadline,
+        ExpiresAt: b.ExpiresAt,
         Status: string(b.Status),
         Result: string(b.Result),
         WinnerSide: string(b.WinnerSide),
@@ -68,13 +109,18 @@ func (r *sqlRepo) CreateBattle(ctx context.Context, b *Battle) (string, error) {
         DarkEmperorID: b.DarkEmperorID,
         LightEmperorApproved: b.LightEmperorApproved,
         DarkEmperorApproved: b.DarkEmperorApproved,
+        LightSlots: b.LightSlots,
+        DarkSlots: b.DarkSlots,
+        ScheduledStartAt: b.ScheduledStartAt,
+        LobbyDeadline: b.LobbyDeadline,
+        MaxDurationMinutes: b.MaxDurationMinutes,
     }
     if tx := db.WithContext(ctx).Create(row); tx.Error != nil { return "", tx.Error }
     return fmt.Sprintf("%d", row.ID), nil
 }
 
 func (r *sqlRepo) InsertParticipants(ctx context.Context, participants []*BattleParticipant) error {
-    db, err := getGorm(); if err != nil { return err }
+    db, err := r.gorm(); if err != nil { return err }
     rows := make([]*BattleParticipantSQL, 0, len(participants))
     for _, p := range participants {
         var battleID uint
@@ -89,9 +135,14 @@ func (r *sqlRepo) InsertParticipants(ctx context.Context, participants []*Battle
             MaxHP: p.MaxHP,
             AttackPower: p.AttackPower,
             Defense: p.Defense,
+            Speed: p.Speed,
+            Element: p.Element,
+            Level: p.Level,
             IsAlive: p.IsAlive,
             IsDefeated: p.IsDefeated,
             DefeatedAt: p.DefeatedAt,
+            StatusEffects: encodeStatusEffects(p.StatusEffects),
+            Ready: p.Ready,
             CreatedAt: p.CreatedAt,
             UpdatedAt: p.UpdatedAt,
         })
@@ -99,13 +150,113 @@ func (r *sqlRepo) InsertParticipants(ctx context.Context, participants []*Battle
     return db.WithContext(ctx).Create(&rows).Error
 }
 
+// withVersionBump copies fields and increments the row version, so every write invalidates earlier reads
+func withVersionBump(fields map[string]interface{}) map[string]interface{} {
+    out := make(map[string]interface{}, len(fields)+1)
+    for k, v := range fields { out[k] = v }
+    out["version"] = gorm.Expr("version + 1")
+    return out
+}
+
 func (r *sqlRepo) UpdateBattleFields(ctx context.Context, id string, fields map[string]interface{}) error {
-    db, err := getGorm(); if err != nil { return err }
-    return db.WithContext(ctx).Model(&BattleSQL{}).Where("id = ?", id).Updates(fields).Error
+    db, err := r.gorm(); if err != nil { return err }
+    return db.WithContext(ctx).Model(&BattleSQL{}).Where("id = ?", id).Updates(withVersionBump(fields)).Error
+}
+
+// UpdateBattleFieldsIfVersion updates a battle only if it is still at version (ErrVersionConflict otherwise)
+func (r *sqlRepo) UpdateBattleFieldsIfVersion(ctx context.Context, id string, version int, fields map[string]interface{}) error {
+    db, err := r.gorm(); if err != nil { return err }
+    tx := db.WithContext(ctx).Model(&BattleSQL{}).Where("id = ? AND version = ?", id, version).Updates(withVersionBump(fields))
+    if tx.Error != nil { return tx.Error }
+    if tx.RowsAffected == 0 { return ErrVersionConflict }
+    return nil
+}
+
+// ListOverdueBattles returns in-progress battles whose turn deadline or wall-clock limit has passed
+func (r *sqlRepo) ListOverdueBattles(ctx context.Context, now time.Time, limit int) ([]*Battle, error) {
+    db, err := r.gorm(); if err != nil { return nil, err }
+    var rows []BattleSQL
+    query := db.WithContext(ctx).
+        Where("status = ?", string(BattleStatusInProgress)).
+        Where("(turn_deadline IS NOT NULL AND turn_deadline <= ?) OR (expires_at IS NOT NULL AND expires_at <= ?)", now, now).
+        Order("id ASC")
+    if limit > 0 {
+        query = query.Limit(limit)
+    }
+    if err := query.Find(&rows).Error; err != nil { return nil, err }
+    out := make([]*Battle, 0, len(rows))
+    for _, row := range rows {
+        b, err := r.GetBattleByID(ctx, fmt.Sprintf("%d", row.ID))
+        if err != nil { return nil, err }
+        out = append(out, b)
+    }
+    return out, nil
+}
+
+// ClaimTurnDeadline moves an overdue turn deadline to lease; false means another replica claimed it first
+func (r *sqlRepo) ClaimTurnDeadline(ctx context.Context, id string, now time.Time, lease time.Time) (bool, error) {
+    db, err := r.gorm(); if err != nil { return false, err }
+    tx := db.WithContext(ctx).Model(&BattleSQL{}).
+        Where("id = ? AND status = ? AND turn_deadline IS NOT NULL AND turn_deadline <= ?", id, string(BattleStatusInProgress), now).
+        Updates(withVersionBump(map[string]interface{}{"turn_deadline": lease}))
+    if tx.Error != nil { return false, tx.Error }
+    return tx.RowsAffected == 1, nil
+}
+
+// UpdateBattleFieldsIfStatus updates a battle only while it is still in status; false means nothing was updated
+func (r *sqlRepo) UpdateBattleFieldsIfStatus(ctx context.Context, id string, status BattleStatus, fields map[string]interface{}) (bool, error) {
+    db, err := r.gorm(); if err != nil { return false, err }
+    tx := db.WithContext(ctx).Model(&BattleSQL{}).Where("id = ? AND status = ?", id, string(status)).Updates(withVersionBump(fields))
+    if tx.Error != nil { return false, tx.Error }
+    return tx.RowsAffected == 1, nil
+}
+
+// ListLobbies returns lobbies still waiting to start, soonest deadline first, and how many there are in total
+func (r *sqlRepo) ListLobbies(ctx context.Context, limit int, offset int) ([]*Battle, int64, error) {
+    db, err := r.gorm(); if err != nil { return nil, 0, err }
+    query := db.WithContext(ctx).Model(&BattleSQL{}).Where("status = ? AND lobby_deadline IS NOT NULL", string(BattleStatusPending))
+    var total int64
+    if err := query.Count(&total).Error; err != nil { return nil, 0, err }
+    var rows []BattleSQL
+    query = query.Order("lobby_deadline ASC, id ASC")
+    if limit > 0 {
+        query = query.Limit(limit)
+    }
+    if offset > 0 {
+        query = query.Offset(offset)
+    }
+    if err := query.Find(&rows).Error; err != nil { return nil, 0, err }
+    out := make([]*Battle, 0, len(rows))
+    for _, row := range rows {
+        b, err := r.GetBattleByID(ctx, fmt.Sprintf("%d", row.ID))
+        if err != nil { return nil, 0, err }
+        out = append(out, b)
+    }
+    return out, total, nil
+}
+
+// ListDueLobbies returns lobbies whose scheduled start or deadline has passed
+func (r *sqlRepo) ListDueLobbies(ctx context.Context, now time.Time, limit int) ([]*Battle, error) {
+    db, err := r.gorm(); if err != nil { return nil, err }
+    var rows []BattleSQL
+    query := db.WithContext(ctx).
+        Where("status = ? AND lobby_deadline IS NOT NULL AND lobby_deadline <= ?", string(BattleStatusPending), now).
+        Order("id ASC")
+    if limit > 0 {
+        query = query.Limit(limit)
+    }
+    if err := query.Find(&rows).Error; err != nil { return nil, err }
+    out := make([]*Battle, 0, len(rows))
+    for _, row := range rows {
+        b, err := r.GetBattleByID(ctx, fmt.Sprintf("%d", row.ID))
+        if err != nil { return nil, err }
+        out = append(out, b)
+    }
+    return out, nil
 }
 
 func (r *sqlRepo) GetParticipantByIDs(ctx context.Context, battleID string, participantID string) (*BattleParticipant, error) {
-    db, err := getGorm(); if err != nil { return nil, err }
+    db, err := r.gorm(); if err != nil { return nil, err }
     var bid uint
     fmt.Sscanf(battleID, "%d", &bid)
     var row BattleParticipantSQL
@@ -122,9 +273,15 @@ func (r *sqlRepo) GetParticipantByIDs(ctx context.Context, battleID string, part
         MaxHP: row.MaxHP,
         AttackPower: row.AttackPower,
         Defense: row.Defense,
+        Speed: row.Speed,
+        Element: row.Element,
+        Level: row.Level,
         IsAlive: row.IsAlive,
         IsDefeated: row.IsDefeated,
         DefeatedAt: row.DefeatedAt,
+        StatusEffects: decodeStatusEffects(row.StatusEffects),
+        Ready: row.Ready,
+        Version: row.Version,
         CreatedAt: row.CreatedAt,
         UpdatedAt: row.UpdatedAt,
     }
@@ -132,14 +289,35 @@ func (r *sqlRepo) GetParticipantByIDs(ctx context.Context, battleID string, part
 }
 
 func (r *sqlRepo) UpdateParticipantByIDs(ctx context.Context, battleID string, participantID string, fields map[string]interface{}) error {
-    db, err := getGorm(); if err != nil { return err }
+    db, err := r.gorm(); if err != nil { return err }
     var bid uint
     fmt.Sscanf(battleID, "%d", &bid)
-    return db.WithContext(ctx).Model(&BattleParticipantSQL{}).Where("battle_id = ? AND participant_id = ?", bid, participantID).Updates(fields).Error
+    return db.WithContext(ctx).Model(&BattleParticipantSQL{}).Where("battle_id = ? AND participant_id = ?", bid, participantID).Updates(withVersionBump(fields)).Error
+}
+
+// UpdateParticipantIfVersion updates a participant only if it is still at version (ErrVersionConflict otherwise)
+func (r *sqlRepo) UpdateParticipantIfVersion(ctx context.Context, battleID string, participantID string, version int, fields map[string]interface{}) error {
+    db, err := r.gorm(); if err != nil { return err }
+    var bid uint
+    fmt.Sscanf(battleID, "%d", &bid)
+    tx := db.WithContext(ctx).Model(&BattleParticipantSQL{}).
+        Where("battle_id = ? AND participant_id = ? AND version = ?", bid, participantID, version).
+        Updates(withVersionBump(fields))
+    if tx.Error != nil { return tx.Error }
+    if tx.RowsAffected == 0 { return ErrVersionConflict }
+    return nil
+}
+
+// DeleteParticipant removes a participant outright, for players leaving a lobby before the battle starts
+func (r *sqlRepo) DeleteParticipant(ctx context.Context, battleID string, participantID string) error {
+    db, err := r.gorm(); if err != nil { return err }
+    var bid uint
+    fmt.Sscanf(battleID, "%d", &bid)
+    return db.WithContext(ctx).Where("battle_id = ? AND participant_id = ?", bid, participantID).Delete(&BattleParticipantSQL{}).Error
 }
 
 func (r *sqlRepo) InsertTurn(ctx context.Context, turn *BattleTurn) error {
-    db, err := getGorm(); if err != nil { return err }
+    db, err := r.gorm(); if err != nil { return err }
     var bid uint
     fmt.Sscanf(turn.BattleID, "%d", &bid)
     row := &BattleTurnSQL{
@@ -158,13 +336,67 @@ func (r *sqlRepo) InsertTurn(ctx context.Context, turn *BattleTurn) error {
         TargetHPBefore: turn.TargetHPBefore,
         TargetHPAfter: turn.TargetHPAfter,
         TargetDefeated: turn.TargetDefeated,
+        EffectType: string(turn.EffectType),
+        HealingDone: turn.HealingDone,
+        Element: turn.Element,
+        DamageResisted: turn.DamageResisted,
+        AttackPower: turn.AttackPower,
+        TargetDefense: turn.TargetDefense,
+        Resistance: turn.Resistance,
+        WeaponID: turn.WeaponID,
+        ArmorID: turn.ArmorID,
         CreatedAt: turn.CreatedAt,
     }
     return db.WithContext(ctx).Create(row).Error
 }
 
+// ListTurns returns turns in play order; uptoTurn <= 0 returns every turn
+func (r *sqlRepo) ListTurns(ctx context.Context, battleID string, uptoTurn int) ([]*BattleTurn, error) {
+    db, err := r.gorm(); if err != nil { return nil, err }
+    var bid uint
+    fmt.Sscanf(battleID, "%d", &bid)
+    var rows []BattleTurnSQL
+    query := db.WithContext(ctx).Where("battle_id = ?", bid)
+    if uptoTurn > 0 {
+        query = query.Where("turn_number <= ?", uptoTurn)
+    }
+    if err := query.Order("turn_number ASC, id ASC").Find(&rows).Error; err != nil { return nil, err }
+    out := make([]*BattleTurn, 0, len(rows))
+    for _, t := range rows {
+        out = append(out, &BattleTurn{
+            ID: fmt.Sprintf("%d", t.ID),
+            BattleID: fmt.Sprintf("%d", t.BattleID),
+            TurnNumber: t.TurnNumber,
+            AttackerID: t.AttackerID,
+            AttackerName: t.AttackerName,
+            AttackerType: ParticipantType(t.AttackerType),
+            AttackerSide: TeamSide(t.AttackerSide),
+            TargetID: t.TargetID,
+            TargetName: t.TargetName,
+            TargetType: ParticipantType(t.TargetType),
+            TargetSide: TeamSide(t.TargetSide),
+            DamageDealt: t.DamageDealt,
+            CriticalHit: t.CriticalHit,
+            TargetHPBefore: t.TargetHPBefore,
+            TargetHPAfter: t.TargetHPAfter,
+            TargetDefeated: t.TargetDefeated,
+            EffectType: StatusEffectType(t.EffectType),
+            HealingDone: t.HealingDone,
+            Element: t.Element,
+            DamageResisted: t.DamageResisted,
+            AttackPower: t.AttackPower,
+            TargetDefense: t.TargetDefense,
+            Resistance: t.Resistance,
+            WeaponID: t.WeaponID,
+            ArmorID: t.ArmorID,
+            CreatedAt: t.CreatedAt,
+        })
+    }
+    return out, nil
+}
+
 func (r *sqlRepo) FindParticipants(ctx context.Context, battleID string, sideFilter string) ([]*BattleParticipant, error) {
-    db, err := getGorm(); if err != nil { return nil, err }
+    db, err := r.gorm(); if err != nil { return nil, err }
     var bid uint
     fmt.Sscanf(battleID, "%d", &bid)
     var rows []BattleParticipantSQL
@@ -172,7 +404,7 @@ func (r *sqlRepo) FindParticipants(ctx context.Context, battleID string, sideFil
     if sideFilter != "all" && sideFilter != "" {
         query = query.Where("side = ?", sideFilter)
     }
-    if err := query.Find(&rows).Error; err != nil { return nil, err }
+    if err := query.Order("id ASC").Find(&rows).Error; err != nil { return nil, err }
     out := make([]*BattleParticipant, 0, len(rows))
     for _, rrow := range rows {
         rp := rrow
@@ -187,9 +419,15 @@ func (r *sqlRepo) FindParticipants(ctx context.Context, battleID string, sideFil
             MaxHP: rp.MaxHP,
             AttackPower: rp.AttackPower,
             Defense: rp.Defense,
+            Speed: rp.Speed,
+            Element: rp.Element,
+            Level: rp.Level,
             IsAlive: rp.IsAlive,
             IsDefeated: rp.IsDefeated,
             DefeatedAt: rp.DefeatedAt,
+            StatusEffects: decodeStatusEffects(rp.StatusEffects),
+            Ready: rp.Ready,
+            Version: rp.Version,
             CreatedAt: rp.CreatedAt,
             UpdatedAt: rp.UpdatedAt,
         })
@@ -198,7 +436,7 @@ func (r *sqlRepo) FindParticipants(ctx context.Context, battleID string, sideFil
 }
 
 func (r *sqlRepo) CountAliveBySide(ctx context.Context, battleID string, side TeamSide) (int, error) {
-    db, err := getGorm(); if err != nil { return 0, err }
+    db, err := r.gorm(); if err != nil { return 0, err }
     var bid uint
     fmt.Sscanf(battleID, "%d", &bid)
     var count int64
@@ -208,4 +446,77 @@ func (r *sqlRepo) CountAliveBySide(ctx context.Context, battleID string, side Te
     return int(count), nil
 }
 
+// InsertRewards records battle rewards; rewards already recorded for a participant are left untouched
+func (r *sqlRepo) InsertRewards(ctx context.Context, rewards []*BattleReward) error {
+    if len(rewards) == 0 { return nil }
+    db, err := r.gorm(); if err != nil { return err }
+    rows := make([]*BattleRewardSQL, 0, len(rewards))
+    for _, rw := range rewards {
+        var bid uint
+        fmt.Sscanf(rw.BattleID, "%d", &bid)
+        rows = append(rows, &BattleRewardSQL{
+            BattleID: bid,
+            ParticipantID: rw.ParticipantID,
+            ParticipantName: rw.ParticipantName,
+            ParticipantType: string(rw.ParticipantType),
+            Side: string(rw.Side),
+            DamageDealt: rw.DamageDealt,
+            Kills: rw.Kills,
+            Coins: rw.Coins,
+            Experience: rw.Experience,
+            Paid: rw.Paid,
+            CreatedAt: rw.CreatedAt,
+        })
+    }
+    return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
 
+// ListRewards returns the recorded rewards of a battle
+func (r *sqlRepo) ListRewards(ctx context.Context, battleID string) ([]*BattleReward, error) {
+    db, err := r.gorm(); if err != nil { return nil, err }
+    var bid uint
+    fmt.Sscanf(battleID, "%d", &bid)
+    var rows []BattleRewardSQL
+    if err := db.WithContext(ctx).Where("battle_id = ?", bid).Order("id ASC").Find(&rows).Error; err != nil { return nil, err }
+    return toBattleRewards(rows), nil
+}
+
+// ListUnpaidRewards returns up to limit recorded rewards not paid yet, oldest first
+func (r *sqlRepo) ListUnpaidRewards(ctx context.Context, limit int) ([]*BattleReward, error) {
+    db, err := r.gorm(); if err != nil { return nil, err }
+    var rows []BattleRewardSQL
+    if err := db.WithContext(ctx).Where("paid = ?", false).Order("id ASC").Limit(limit).Find(&rows).Error; err != nil { return nil, err }
+    return toBattleRewards(rows), nil
+}
+
+func toBattleRewards(rows []BattleRewardSQL) []*BattleReward {
+    out := make([]*BattleReward, 0, len(rows))
+    for _, row := range rows {
+        out = append(out, &BattleReward{
+            BattleID: fmt.Sprintf("%d", row.BattleID),
+            ParticipantID: row.ParticipantID,
+            ParticipantName: row.ParticipantName,
+            ParticipantType: ParticipantType(row.ParticipantType),
+            Side: TeamSide(row.Side),
+            DamageDealt: row.DamageDealt,
+            Kills: row.Kills,
+            Coins: row.Coins,
+            Experience: row.Experience,
+            Paid: row.Paid,
+            CreatedAt: row.CreatedAt,
+        })
+    }
+    return out
+}
+
+// SetRewardPaid flips a reward's paid flag; false means it already had that value (another caller got there first)
+func (r *sqlRepo) SetRewardPaid(ctx context.Context, battleID string, participantID string, paid bool) (bool, error) {
+    db, err := r.gorm(); if err != nil { return false, err }
+    var bid uint
+    fmt.Sscanf(battleID, "%d", &bid)
+    tx := db.WithContext(ctx).Model(&BattleRewardSQL{}).
+        Where("battle_id = ? AND participant_id = ? AND paid = ?", bid, participantID, !paid).
+        Update("paid", paid)
+    if tx.Error != nil { return false, tx.Error }
+    return tx.RowsAffected == 1, nil
+}
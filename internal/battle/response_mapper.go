@@ -16,14 +16,27 @@ func ToParticipantResponse(p *BattleParticipant) *dto.ParticipantResponse {
         MaxHP:         p.MaxHP,
         AttackPower:   p.AttackPower,
         Defense:       p.Defense,
+        Speed:         p.Speed,
+        Element:       p.Element,
         IsAlive:       p.IsAlive,
         IsDefeated:    p.IsDefeated,
+        Ready:         p.Ready,
         CreatedAt:     p.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
     }
     if p.DefeatedAt != nil {
         defeatedStr := p.DefeatedAt.Format("2006-01-02T15:04:05Z07:00")
         resp.DefeatedAt = &defeatedStr
     }
+    for _, e := range p.StatusEffects {
+        resp.StatusEffects = append(resp.StatusEffects, dto.StatusEffectResponse{
+            Type:           string(e.Type),
+            SourceID:       e.SourceID,
+            Magnitude:      e.Magnitude,
+            Stacks:         e.Stacks,
+            RemainingTurns: e.RemainingTurns,
+            Tick:           string(e.Tick),
+        })
+    }
     return resp
 }
 
@@ -36,8 +49,16 @@ func ToBattleResponse(b *Battle, lightParticipants []*BattleParticipant, darkPar
         CurrentTurn:             b.CurrentTurn,
         CurrentParticipantIndex: b.CurrentParticipantIndex,
         MaxTurns:                b.MaxTurns,
+        TurnOrder:               string(b.TurnOrder),
+        TurnTimeoutSeconds:      b.TurnTimeoutSeconds,
+        TimeoutAction:           string(b.TimeoutAction),
         Status:                  string(b.Status),
         CreatedBy:               b.CreatedBy,
+        LightSlots:              b.LightSlots,
+        DarkSlots:               b.DarkSlots,
+        WagerAmount:             b.WagerAmount,
+        LightEmperorApproved:    b.LightEmperorApproved,
+        DarkEmperorApproved:     b.DarkEmperorApproved,
         CreatedAt:               b.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
     }
     if b.Result != "" {
@@ -50,6 +71,22 @@ func ToBattleResponse(b *Battle, lightParticipants []*BattleParticipant, darkPar
         startedStr := b.StartedAt.Format("2006-01-02T15:04:05Z07:00")
         resp.StartedAt = &startedStr
     }
+    if b.TurnDeadline != nil {
+        deadlineStr := b.TurnDeadline.Format("2006-01-02T15:04:05Z07:00")
+        resp.TurnDeadline = &deadlineStr
+    }
+    if b.ExpiresAt != nil {
+        expiresStr := b.ExpiresAt.Format("2006-01-02T15:04:05Z07:00")
+        resp.ExpiresAt = &expiresStr
+    }
+    if b.ScheduledStartAt != nil {
+        scheduledStr := b.ScheduledStartAt.Format("2006-01-02T15:04:05Z07:00")
+        resp.ScheduledStartAt = &scheduledStr
+    }
+    if b.LobbyDeadline != nil {
+        lobbyDeadlineStr := b.LobbyDeadline.Format("2006-01-02T15:04:05Z07:00")
+        resp.LobbyDeadline = &lobbyDeadlineStr
+    }
     if b.CompletedAt != nil {
         completedStr := b.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
         resp.CompletedAt = &completedStr
@@ -87,8 +124,62 @@ func ToBattleTurnResponse(t *BattleTurn) *dto.BattleTurnResponse {
         TargetHPBefore: t.TargetHPBefore,
         TargetHPAfter:  t.TargetHPAfter,
         TargetDefeated: t.TargetDefeated,
+        EffectType:     string(t.EffectType),
+        HealingDone:    t.HealingDone,
+        Element:        t.Element,
+        DamageResisted: t.DamageResisted,
         CreatedAt:      t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
     }
 }
 
 
+
+func ToSpellModifierResponse(m SpellModifier) dto.SpellModifierResponse {
+    return dto.SpellModifierResponse{
+        SpellType:  m.SpellType,
+        Effect:     m.Effect,
+        StackCount: m.StackCount,
+        CastAt:     m.CastAt.Format("2006-01-02T15:04:05Z07:00"),
+    }
+}
+
+func ToBattleReplayResponse(r *BattleReplay) *dto.BattleReplayResponse {
+    resp := &dto.BattleReplayResponse{
+        BattleID:     r.BattleID,
+        Turn:         r.Turn,
+        LastTurn:     r.LastTurn,
+        Participants: make([]dto.ReplayParticipantResponse, 0, len(r.Participants)),
+        ActiveSpells: make([]dto.SpellModifierResponse, 0, len(r.ActiveSpells)),
+        Verified:     r.Verified,
+        Consistent:   len(r.Mismatches) == 0,
+    }
+    for _, st := range r.Participants {
+        pr := dto.ReplayParticipantResponse{
+            ParticipantID:  st.Participant.ParticipantID,
+            Name:           st.Participant.Name,
+            Type:           string(st.Participant.Type),
+            Side:           string(st.Participant.Side),
+            HP:             st.HP,
+            MaxHP:          st.MaxHP,
+            IsAlive:        st.IsAlive,
+            SpellModifiers: make([]dto.SpellModifierResponse, 0, len(st.SpellModifiers)),
+        }
+        for _, m := range st.SpellModifiers {
+            pr.SpellModifiers = append(pr.SpellModifiers, ToSpellModifierResponse(m))
+        }
+        resp.Participants = append(resp.Participants, pr)
+    }
+    for _, m := range r.ActiveSpells {
+        resp.ActiveSpells = append(resp.ActiveSpells, ToSpellModifierResponse(m))
+    }
+    for _, m := range r.Mismatches {
+        resp.Mismatches = append(resp.Mismatches, dto.ReplayMismatchResponse{
+            TurnNumber:    m.TurnNumber,
+            ParticipantID: m.ParticipantID,
+            Field:         m.Field,
+            Stored:        m.Stored,
+            Expected:      m.Expected,
+        })
+    }
+    return resp
+}
@@ -0,0 +1,242 @@
+package battle
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Reward rates. Coins and experience both grow with damage dealt and with the level of each opponent
+// the participant helped defeat.
+const (
+	rewardBaseCoins              = 25 // for surviving on the winning side
+	rewardDamagePerCoin          = 10 // one coin per this much damage dealt
+	rewardCoinsPerKillLevel      = 15
+	rewardBaseExperience         = 50
+	rewardDamagePerExperience    = 5 // one experience point per this much damage dealt
+	rewardExperiencePerKillLevel = 20
+)
+
+// BattleReward is what one participant earned in a finished battle
+type BattleReward struct {
+	BattleID        string          `json:"battle_id"`
+	ParticipantID   string          `json:"participant_id"`
+	ParticipantName string          `json:"participant_name"`
+	ParticipantType ParticipantType `json:"participant_type"`
+	Side            TeamSide        `json:"side"`
+	DamageDealt     int             `json:"damage_dealt"`
+	Kills           int             `json:"kills"`
+	Coins           int             `json:"coins"`
+	Experience      int             `json:"experience"`
+	Paid            bool            `json:"paid"` // Coins credited and reward event published
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+// CalculateRewards works out rewards for the winning side from the turn log.
+// Only participants still alive that dealt damage are rewarded; server-side units (enemies, dragons)
+// earn nothing. A kill counts for everyone who damaged the opponent since it last entered the fight,
+// the same credit ParticipantKillTracker gives.
+func CalculateRewards(battle *Battle, participants []*BattleParticipant, turns []*BattleTurn) []*BattleReward {
+	if battle.WinnerSide == "" {
+		return nil
+	}
+
+	byID := make(map[string]*BattleParticipant, len(participants))
+	for _, p := range participants {
+		byID[p.ParticipantID] = p
+	}
+
+	damage := make(map[string]int)
+	kills := make(map[string]int)
+	killLevels := make(map[string]int)
+	contributors := make(map[string][]string) // target -> attackers since it (re)entered the fight
+	for _, t := range turns {
+		if t.EffectType == "" && t.DamageDealt > 0 {
+			damage[t.AttackerID] += t.DamageDealt
+			if !containsString(contributors[t.TargetID], t.AttackerID) {
+				contributors[t.TargetID] = append(contributors[t.TargetID], t.AttackerID)
+			}
+		}
+		if !t.TargetDefeated {
+			continue
+		}
+		level := 1
+		if target, ok := byID[t.TargetID]; ok && target.Level > 1 {
+			level = target.Level
+		}
+		for _, attackerID := range contributors[t.TargetID] {
+			kills[attackerID]++
+			killLevels[attackerID] += level
+		}
+		delete(contributors, t.TargetID)
+	}
+
+	now := time.Now()
+	var rewards []*BattleReward
+	for _, p := range participants {
+		if p.Side != battle.WinnerSide || !p.IsAlive || damage[p.ParticipantID] == 0 {
+			continue
+		}
+		if p.Type == ParticipantTypeEnemy || p.Type == ParticipantTypeDragon {
+			continue
+		}
+		dealt := damage[p.ParticipantID]
+		rewards = append(rewards, &BattleReward{
+			BattleID:        battle.ID,
+			ParticipantID:   p.ParticipantID,
+			ParticipantName: p.Name,
+			ParticipantType: p.Type,
+			Side:            p.Side,
+			DamageDealt:     dealt,
+			Kills:           kills[p.ParticipantID],
+			Coins:           rewardBaseCoins + dealt/rewardDamagePerCoin + killLevels[p.ParticipantID]*rewardCoinsPerKillLevel,
+			Experience:      rewardBaseExperience + dealt/rewardDamagePerExperience + killLevels[p.ParticipantID]*rewardExperiencePerKillLevel,
+			CreatedAt:       now,
+		})
+	}
+	return rewards
+}
+
+// CalculatePartialRewards works out rewards for a battle called off before it was decided.
+// Every player who dealt damage, on either side and whether still standing or not, earns the damage
+// share of a normal reward; nobody gets the survival base or kill bonuses.
+func CalculatePartialRewards(battle *Battle, participants []*BattleParticipant, turns []*BattleTurn) []*BattleReward {
+	damage := make(map[string]int)
+	for _, t := range turns {
+		if t.EffectType == "" && t.DamageDealt > 0 {
+			damage[t.AttackerID] += t.DamageDealt
+		}
+	}
+
+	now := time.Now()
+	var rewards []*BattleReward
+	for _, p := range participants {
+		if p.Type == ParticipantTypeEnemy || p.Type == ParticipantTypeDragon {
+			continue
+		}
+		dealt := damage[p.ParticipantID]
+		coins, experience := dealt/rewardDamagePerCoin, dealt/rewardDamagePerExperience
+		if coins == 0 && experience == 0 {
+			continue
+		}
+		rewards = append(rewards, &BattleReward{
+			BattleID:        battle.ID,
+			ParticipantID:   p.ParticipantID,
+			ParticipantName: p.Name,
+			ParticipantType: p.Type,
+			Side:            p.Side,
+			DamageDealt:     dealt,
+			Coins:           coins,
+			Experience:      experience,
+			CreatedAt:       now,
+		})
+	}
+	return rewards
+}
+
+// distributeRewards records the rewards of a completed team battle, fills its reward maps and pays them out.
+// Recording is idempotent per battle and participant, so a repeated call pays nobody twice.
+func (s *Service) distributeRewards(ctx context.Context, battle *Battle) error {
+	return s.recordRewards(ctx, battle, CalculateRewards)
+}
+
+// distributePartialRewards does the same for a battle that was force-cancelled
+func (s *Service) distributePartialRewards(ctx context.Context, battle *Battle) error {
+	return s.recordRewards(ctx, battle, CalculatePartialRewards)
+}
+
+func (s *Service) recordRewards(ctx context.Context, battle *Battle, calculate func(*Battle, []*BattleParticipant, []*BattleTurn) []*BattleReward) error {
+	participants, err := GetRepository().FindParticipants(ctx, battle.ID, "all")
+	if err != nil {
+		return fmt.Errorf("failed to load participants: %w", err)
+	}
+	turns, err := GetRepository().ListTurns(ctx, battle.ID, 0)
+	if err != nil {
+		return fmt.Errorf("failed to load turns: %w", err)
+	}
+
+	if err := GetRepository().InsertRewards(ctx, calculate(battle, participants, turns)); err != nil {
+		return fmt.Errorf("failed to record rewards: %w", err)
+	}
+	rewards, err := GetRepository().ListRewards(ctx, battle.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load rewards: %w", err)
+	}
+	battle.CoinsEarned, battle.ExperienceGained = rewardMaps(rewards)
+
+	go s.payRewards(context.Background(), rewards)
+	return nil
+}
+
+// SweepUnpaidRewards retries the payout of rewards whose coins could not be credited when their
+// battle ended. It returns how many rewards this call paid.
+func (s *Service) SweepUnpaidRewards(ctx context.Context) (int, error) {
+	rewards, err := GetRepository().ListUnpaidRewards(ctx, sweepBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list unpaid rewards: %w", err)
+	}
+	return s.payRewards(ctx, rewards), nil
+}
+
+// payRewards credits coins and publishes a reward event for each reward not yet paid, and returns
+// how many it paid. The paid flag is claimed first so concurrent callers cannot credit the same reward
+// twice; it is released again if the coin service fails, leaving the reward to SweepUnpaidRewards.
+func (s *Service) payRewards(ctx context.Context, rewards []*BattleReward) int {
+	paid := 0
+	for _, reward := range rewards {
+		if reward.Paid {
+			continue
+		}
+		claimed, err := GetRepository().SetRewardPaid(ctx, reward.BattleID, reward.ParticipantID, true)
+		if err != nil {
+			log.Printf("Failed to claim reward for participant %s in battle %s: %v", reward.ParticipantID, reward.BattleID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		var warriorID uint
+		if _, err := fmt.Sscanf(reward.ParticipantID, "%d", &warriorID); err == nil && reward.Coins > 0 {
+			reason := fmt.Sprintf("battle_reward_%s_%s", reward.BattleID, reward.ParticipantID)
+			if err := AddCoins(ctx, warriorID, int64(reward.Coins), reason, "battle_reward:"+reward.BattleID+":"+reward.ParticipantID); err != nil {
+				log.Printf("Failed to pay %d coins to participant %s for battle %s: %v", reward.Coins, reward.ParticipantID, reward.BattleID, err)
+				if _, err := GetRepository().SetRewardPaid(ctx, reward.BattleID, reward.ParticipantID, false); err != nil {
+					log.Printf("Failed to release reward claim for participant %s: %v", reward.ParticipantID, err)
+				}
+				continue
+			}
+		}
+		reward.Paid = true
+		paid++
+
+		if err := PublishBattleRewardEvent(reward); err != nil {
+			log.Printf("Failed to publish battle reward: %v", err)
+		}
+	}
+	return paid
+}
+
+// rewardMaps builds the participant_id -> coins and participant_id -> experience maps of a battle
+func rewardMaps(rewards []*BattleReward) (map[string]int, map[string]int) {
+	if len(rewards) == 0 {
+		return nil, nil
+	}
+	coins := make(map[string]int, len(rewards))
+	experience := make(map[string]int, len(rewards))
+	for _, r := range rewards {
+		coins[r.ParticipantID] = r.Coins
+		experience[r.ParticipantID] = r.Experience
+	}
+	return coins, experience
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
 )
 
 // SetupRoutes configures all routes for the battle service
@@ -66,6 +67,18 @@ func SetupRoutes(r *gin.Engine, handler *Handler) {
 			// Battle CRUD operations
 			protected.POST("/battles", handler.StartBattle)
 			protected.POST("/battles/attack", handler.Attack)
+			protected.POST("/battles/:id/participants", handler.AddParticipant)
+			protected.DELETE("/battles/:id/participants/:pid", handler.RemoveParticipant)
+			protected.POST("/battles/lobbies", handler.CreateLobby)
+			protected.GET("/battles/lobbies", handler.ListLobbies)
+			protected.GET("/battles/lobbies/:id", handler.GetLobby)
+			protected.POST("/battles/lobbies/:id/join", handler.JoinLobby)
+			protected.DELETE("/battles/lobbies/:id/join", handler.LeaveLobby)
+			protected.POST("/battles/lobbies/:id/ready", handler.SetLobbyReady)
+			protected.POST("/battles/:id/wager/approve", handler.ApproveWager)
+			protected.POST("/battles/:id/surrender", handler.SurrenderBattle)
+			protected.POST("/battles/:id/cancel", handler.CancelBattle)
+			protected.POST("/battles/:id/force-cancel", handler.ForceCancelBattle)
 			protected.POST("/battles/revive-dragon", handler.ReviveDragon)
 			protected.POST("/battles/dark-emperor-join", handler.DarkEmperorJoinBattle)
 			protected.POST("/battles/sacrifice-dragon", handler.SacrificeDragon)
@@ -78,8 +91,11 @@ func SetupRoutes(r *gin.Engine, handler *Handler) {
 			rbac.GET("/battles/:id", handler.GetBattle)
 			rbac.GET("/battles/my-battles", handler.GetMyBattles)
 			rbac.GET("/battles/stats", handler.GetBattleStats)
+			rbac.GET("/battles/analytics", handler.GetBattleAnalytics)
 			rbac.GET("/battles/:id/turns", handler.GetBattleTurns)
 			rbac.GET("/battles/:id/logs", handler.GetBattleLogs)
+			rbac.GET("/battles/:id/replay", handler.ReplayBattle)
+			rbac.GET("/battles/:id/watch", handler.WatchBattle)
 			}
 		}
 	}
@@ -8,7 +8,9 @@ import (
 	"math/rand"
 	"time"
 
+	pbArmor "network-sec-micro/api/proto/armor"
 	"network-sec-micro/internal/battle/dto"
+	"network-sec-micro/pkg/element"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -76,6 +78,7 @@ func (s *Service) oldStartBattle(cmd dto.StartBattleCommand) (*Battle, error) {
 		CurrentTurn:   0,
 		MaxTurns:      maxTurns,
 		Status:        BattleStatusPending,
+		Seed:          NewBattleSeed(),
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
@@ -128,19 +131,11 @@ func (s *Service) Attack(cmd dto.AttackCommand) (*Battle, *BattleTurn, error) {
 	ctx := context.Background()
 
 	// Get battle
-	battleID, err := primitive.ObjectIDFromHex(cmd.BattleID)
+	found, err := s.loadBattle(ctx, cmd.BattleID)
 	if err != nil {
-		return nil, nil, errors.New("invalid battle ID")
-	}
-
-	var battle Battle
-	err = BattleColl.FindOne(ctx, bson.M{"_id": battleID}).Decode(&battle)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, nil, errors.New("battle not found")
-		}
-		return nil, nil, fmt.Errorf("failed to get battle: %w", err)
+		return nil, nil, err
 	}
+	battle := *found
 
 	// Validate battle status
 	if battle.Status != BattleStatusInProgress {
@@ -155,7 +150,12 @@ func (s *Service) Attack(cmd dto.AttackCommand) (*Battle, *BattleTurn, error) {
 
 	// Handle team battle vs legacy single battle
 	if battle.BattleType == BattleTypeTeam {
-		return s.performTeamBattleAttack(ctx, &battle, cmd)
+		updated, turn, err := s.performTeamBattleAttack(ctx, &battle, cmd)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Server-controlled participants act until it's a player's turn again
+		return s.playAutomatedTurns(ctx, updated), turn, nil
 	}
 
 	// Legacy single battle logic below
@@ -204,13 +204,14 @@ func (s *Service) Attack(cmd dto.AttackCommand) (*Battle, *BattleTurn, error) {
 		}
 	}
 
-	// Warrior attacks opponent
+	// Warrior attacks opponent (rolls come from this turn's stream)
+	rng := TurnRNG(battle.Seed, battle.CurrentTurn+1)
 	warriorPower := int(warrior.TotalPower)
 	targetDefense := battle.OpponentDefense() + opponentDefenseBonus
-	damage := s.calculateDamage(warriorPower + weaponBonus, targetDefense)
+	damage := s.CalculateDamage(rng, warriorPower + weaponBonus, targetDefense)
 	
 	// Critical hit chance (10%)
-	isCritical := rand.Float64() < 0.1
+	isCritical := rng.Float64() < 0.1
 	if isCritical {
 		damage = int(float64(damage) * 1.5)
 	}
@@ -262,6 +263,7 @@ func (s *Service) Attack(cmd dto.AttackCommand) (*Battle, *BattleTurn, error) {
 	if err := LogBattleTurn(ctx, battleOID, turn, &tempBattle, eventType, message); err != nil {
 		log.Printf("Warning: failed to log battle turn to Redis: %v", err)
 	}
+	publishSpectatorTurn(turn)
 
 	// Check if opponent is defeated
 	if battle.OpponentHP <= 0 {
@@ -309,7 +311,8 @@ func (s *Service) Attack(cmd dto.AttackCommand) (*Battle, *BattleTurn, error) {
 		}
 
 		// Opponent attacks
-		opponentDamage := s.calculateOpponentDamage(&currentBattle)
+		oppRng := counterTurnRNG(currentBattle.Seed, currentBattle.CurrentTurn)
+		opponentDamage := s.CalculateOpponentDamage(oppRng, &currentBattle)
 		// Apply warrior's armor defense bonus
 		if warriorDefenseBonus > 0 {
 			opponentDamage = opponentDamage - warriorDefenseBonus
@@ -317,7 +320,7 @@ func (s *Service) Attack(cmd dto.AttackCommand) (*Battle, *BattleTurn, error) {
 				opponentDamage = 1 // Minimum 1 damage
 			}
 		}
-		opponentCritical := rand.Float64() < 0.05 // 5% crit for opponent
+		opponentCritical := oppRng.Float64() < 0.05 // 5% crit for opponent
 		if opponentCritical {
 			opponentDamage = int(float64(opponentDamage) * 1.5)
 		}
@@ -365,6 +368,7 @@ func (s *Service) Attack(cmd dto.AttackCommand) (*Battle, *BattleTurn, error) {
 		if err := LogBattleTurn(oppCtx, currentBattleOID, opponentTurn, &tempBattleForLog, oppEventType, oppMessage); err != nil {
 			log.Printf("Warning: failed to log opponent turn to Redis: %v", err)
 		}
+		publishSpectatorTurn(opponentTurn)
 
 		// Check if warrior is defeated
 		if currentBattle.WarriorHP <= 0 {
@@ -429,7 +433,7 @@ func (s *Service) completeBattle(ctx context.Context, battle *Battle, result Bat
 
 		// Add coins to warrior via gRPC
 		go func() {
-			if err := AddCoins(ctx, battle.WarriorID, int64(coinsEarnedInt), fmt.Sprintf("battle_victory_%s", battle.ID)); err != nil {
+			if err := AddCoins(ctx, battle.WarriorID, int64(coinsEarnedInt), fmt.Sprintf("battle_victory_%s", battle.ID), "battle_victory:"+battle.ID); err != nil {
 				log.Printf("Failed to add coins to warrior %d after battle victory: %v", battle.WarriorID, err)
 			}
 		}()
@@ -437,7 +441,7 @@ func (s *Service) completeBattle(ctx context.Context, battle *Battle, result Bat
 		// Deduct coins from warrior if lost (penalty)
 		penalty := 25 // Base penalty
 		go func() {
-			if err := DeductCoins(ctx, battle.WarriorID, int64(penalty), fmt.Sprintf("battle_defeat_penalty_%s", battle.ID)); err != nil {
+			if err := DeductCoins(ctx, battle.WarriorID, int64(penalty), fmt.Sprintf("battle_defeat_penalty_%s", battle.ID), "battle_defeat_penalty:"+battle.ID); err != nil {
 				// Log but don't fail - penalty might fail if insufficient balance
 				log.Printf("Failed to deduct penalty coins from warrior %d after battle defeat: %v", battle.WarriorID, err)
 			}
@@ -477,6 +481,8 @@ func (s *Service) completeBattle(ctx context.Context, battle *Battle, result Bat
 		}
 	}()
 
+	publishSpectatorCompleted(battle)
+
 	// Publish battle completed event
 	go PublishBattleCompletedEvent(
 		battle.ID,
@@ -514,12 +520,45 @@ func (s *Service) handleBattleTimeout(ctx context.Context, battle *Battle) (*Bat
 	return s.completeBattle(ctx, battle, result, winnerName, winnerID)
 }
 
-// performTeamBattleAttack handles team battle participant-based attacks
+// maxVersionRetries bounds how often a write is retried after a version conflict
+const maxVersionRetries = 5
+
+// performTeamBattleAttack handles team battle participant-based attacks.
+// Concurrent writers are detected through row versions; on a conflict the battle is reloaded
+// and the attack is re-validated, so a racing second attack fails the turn check instead of overwriting.
 func (s *Service) performTeamBattleAttack(ctx context.Context, battle *Battle, cmd dto.AttackCommand) (*Battle, *BattleTurn, error) {
+	for attempt := 0; ; attempt++ {
+		updated, turn, err := s.attemptTeamBattleAttack(ctx, battle, cmd)
+		if !errors.Is(err, ErrVersionConflict) || attempt >= maxVersionRetries {
+			return updated, turn, err
+		}
+
+		fresh, err := GetRepository().GetBattleByID(ctx, battle.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to reload battle: %w", err)
+		}
+		if fresh.Status != BattleStatusInProgress {
+			return nil, nil, errBattleNotInProgress
+		}
+		battle = fresh
+	}
+}
+
+// attemptTeamBattleAttack performs one attack against the battle as read; ErrVersionConflict means nothing was written
+func (s *Service) attemptTeamBattleAttack(ctx context.Context, battle *Battle, cmd dto.AttackCommand) (*Battle, *BattleTurn, error) {
 	if cmd.AttackerID == "" || cmd.TargetID == "" {
 		return nil, nil, errors.New("attacker_id and target_id are required for team battles")
 	}
 
+	// Enforce turn order: only the participant at the head of the queue may act
+	next, nextIndex, err := s.GetNextParticipant(ctx, battle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve turn order: %w", err)
+	}
+	if next.ParticipantID != cmd.AttackerID {
+		return nil, nil, fmt.Errorf("%w: it is %s's turn (participant %s)", ErrNotYourTurn, next.Name, next.ParticipantID)
+	}
+
 	// Get attacker and target participants
 	attacker, err := GetRepository().GetParticipantByIDs(ctx, battle.ID, cmd.AttackerID)
 	if err != nil {
@@ -541,6 +580,11 @@ func (s *Service) performTeamBattleAttack(ctx context.Context, battle *Battle, c
 		return nil, nil, errors.New("attacker is not alive")
 	}
 
+	// A stunned participant's turn is played out by playAutomatedTurns
+	if attacker.HasStatusEffect(StatusEffectStun) {
+		return nil, nil, errors.New("attacker is stunned")
+	}
+
 	// Validate target is alive
 	if !target.IsAlive {
 		return nil, nil, errors.New("target is not alive")
@@ -549,6 +593,7 @@ func (s *Service) performTeamBattleAttack(ctx context.Context, battle *Battle, c
 	// Get attacker's weapons for bonus damage
 	weaponBonus := 0
 	var usedWeaponID string
+	hitElement := element.Element(attacker.Element) // a weapon's affinity overrides the attacker's own
 	if string(attacker.Type) == "warrior" || string(attacker.Type) == "enemy" || string(attacker.Type) == "dragon" {
 		if ws, err := ListWeaponsByOwner(ctx, string(attacker.Type), attacker.ParticipantID); err == nil {
 			maxD := 0
@@ -557,18 +602,21 @@ func (s *Service) performTeamBattleAttack(ctx context.Context, battle *Battle, c
 				if int(w.Damage) > maxD { 
 					maxD = int(w.Damage)
 					usedWeaponID = w.Id 
+					if w.Element != "" {
+						hitElement = element.Element(w.Element)
+					} else {
+						hitElement = element.Element(attacker.Element)
+					}
 				}
 			}
 			weaponBonus = maxD
-			if usedWeaponID != "" { 
-				_, _ = ApplyWeaponWear(ctx, usedWeaponID, 1) 
-			}
 		}
 	}
 
 	// Get target's armors for defense bonus
 	targetDefenseBonus := 0
 	var usedArmorID string
+	var usedArmorResistances []*pbArmor.ElementResistance
 	if string(target.Type) == "warrior" || string(target.Type) == "enemy" || string(target.Type) == "dragon" {
 		if armors, err := ListArmorsByOwner(ctx, string(target.Type), target.ParticipantID); err == nil {
 			maxDef := 0
@@ -577,86 +625,133 @@ func (s *Service) performTeamBattleAttack(ctx context.Context, battle *Battle, c
 				if int(a.Defense) > maxDef { 
 					maxDef = int(a.Defense)
 					usedArmorID = a.Id 
+					usedArmorResistances = a.Resistances
 				}
 			}
 			targetDefenseBonus = maxDef
-			if usedArmorID != "" { 
-				_, _ = ApplyArmorWear(ctx, usedArmorID, 1) 
-			}
 		}
 	}
 
-	// Calculate damage
-	attackerPower := attacker.AttackPower + weaponBonus
-	targetDefense := target.Defense + targetDefenseBonus
-	damage := s.calculateDamage(attackerPower, targetDefense)
-
-	// Critical hit chance (10%)
-	isCritical := rand.Float64() < 0.1
-	if isCritical {
-		damage = int(float64(damage) * 1.5)
+	// Calculate damage (rolls come from this turn's stream)
+	attackerPower := attacker.ModifiedAttack(attacker.AttackPower + weaponBonus)
+	targetDefense := target.ModifiedDefense(target.Defense + targetDefenseBonus)
+	resistance := 0
+	for _, r := range usedArmorResistances {
+		if element.Element(r.Element) == hitElement {
+			resistance = int(r.Percent)
+		}
 	}
+	hit := rollTeamHit(TurnRNG(battle.Seed, battle.CurrentTurn+1), attackerPower, targetDefense, hitElement, element.Element(target.Element), resistance)
+	damage, isCritical, damageResisted := hit.damage, hit.critical, hit.resisted
+
+	// Claim the turn: increment it and pass initiative to the next slot in the queue.
+	// Only the battle version read above may do this, which serialises concurrent attacks.
+	// The claim, the status effect ticks, the damage and the turn record are written in one
+	// transaction, so a failed write never uses up a turn without recording it.
+	claimed := *battle
+	claimed.CurrentTurn++
+	claimed.CurrentParticipantIndex = nextIndex + 1
+	claimed.UpdatedAt = time.Now()
+	claimed.TurnDeadline = claimed.nextTurnDeadline(claimed.UpdatedAt)
+	updateData := map[string]interface{}{
+		"current_turn": claimed.CurrentTurn,
+		"current_participant_index": claimed.CurrentParticipantIndex,
+		"turn_deadline": claimed.TurnDeadline,
+		"updated_at": claimed.UpdatedAt,
+	}
+
+	var turn *BattleTurn
+	var startTicks, endTicks []*BattleTurn
+	err = GetRepository().InTransaction(ctx, func(repo Repository) error {
+		if err := repo.UpdateBattleFieldsIfVersion(ctx, battle.ID, battle.Version, updateData); err != nil {
+			if errors.Is(err, ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to update battle: %w", err)
+		}
 
-	// Apply damage
-	targetHPBefore := target.HP
-	target.HP -= damage
-	if target.HP < 0 {
-		target.HP = 0
-	}
+		// Start-of-turn effects may take the attacker out before it strikes
+		attacker, ticks, err := s.runEffectPhase(ctx, repo, &claimed, attacker, EffectTickTurnStart)
+		if err != nil {
+			return fmt.Errorf("failed to apply status effects: %w", err)
+		}
+		startTicks = ticks
+		if !attacker.IsAlive {
+			return nil
+		}
 
-	// Check if target is defeated
-	targetDefeated := target.HP <= 0
-	if targetDefeated {
-		target.IsAlive = false
-		target.IsDefeated = true
-		now := time.Now()
-		target.DefeatedAt = &now
+		// Apply damage (a spell may change the target meanwhile, so this retries on its own)
+		target, targetHPBefore, err := s.applyDamage(ctx, repo, battle.ID, target, damage)
+		if err != nil {
+			return fmt.Errorf("failed to update target participant: %w", err)
+		}
+
+		// Create turn record
+		turn = &BattleTurn{
+			BattleID:      battle.ID,
+			TurnNumber:    claimed.CurrentTurn,
+			AttackerID:    attacker.ParticipantID,
+			AttackerName:  attacker.Name,
+			AttackerType:  attacker.Type,
+			AttackerSide:  attacker.Side,
+			TargetID:      target.ParticipantID,
+			TargetName:    target.Name,
+			TargetType:    target.Type,
+			TargetSide:    target.Side,
+			DamageDealt:   damage,
+			CriticalHit:   isCritical,
+			TargetHPBefore: targetHPBefore,
+			TargetHPAfter: target.HP,
+			TargetDefeated: !target.IsAlive,
+			Element:       string(hitElement),
+			DamageResisted: damageResisted,
+			AttackPower:   attackerPower,
+			TargetDefense: targetDefense,
+			Resistance:    resistance,
+			WeaponID:      usedWeaponID,
+			ArmorID:       usedArmorID,
+			CreatedAt:     time.Now(),
+		}
+		if err := repo.InsertTurn(ctx, turn); err != nil {
+			return fmt.Errorf("failed to record turn: %w", err)
+		}
+
+		_, endTicks, err = s.runEffectPhase(ctx, repo, &claimed, attacker, EffectTickTurnEnd)
+		if err != nil {
+			return fmt.Errorf("failed to apply status effects: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+	claimed.Version++
+	*battle = claimed
 
-	// Update target participant
-	updateTarget := map[string]interface{}{
-		"hp": target.HP,
-		"is_alive": target.IsAlive,
-		"is_defeated": target.IsDefeated,
-		"updated_at": time.Now(),
+	if turn == nil {
+		// The attacker fell to its own start-of-turn effects
+		publishEffectTicks(battle, startTicks)
+		return s.finishTeamTurn(ctx, battle, startTicks[len(startTicks)-1])
 	}
-	if target.DefeatedAt != nil {
-		updateTarget["defeated_at"] = target.DefeatedAt
+
+	// Equipment wears only once the attack has happened
+	if usedWeaponID != "" {
+		_, _ = ApplyWeaponWear(ctx, usedWeaponID, 1)
 	}
-	if err := GetRepository().UpdateParticipantByIDs(ctx, battle.ID, target.ParticipantID, updateTarget); err != nil {
-		return nil, nil, fmt.Errorf("failed to update target participant: %w", err)
+	if usedArmorID != "" {
+		_, _ = ApplyArmorWear(ctx, usedArmorID, 1)
 	}
 
-	// Increment battle turn
-	battle.CurrentTurn++
-	battle.CurrentParticipantIndex++
-	battle.UpdatedAt = time.Now()
+	publishEffectTicks(battle, startTicks)
+	publishSpectatorTurn(turn)
+	go PublishBattleTurnEvent(turn)
+	publishEffectTicks(battle, endTicks)
 
-	// Create turn record
-	turn := &BattleTurn{
-		BattleID:      battle.ID,
-		TurnNumber:    battle.CurrentTurn,
-		AttackerID:    attacker.ParticipantID,
-		AttackerName:  attacker.Name,
-		AttackerType:  attacker.Type,
-		AttackerSide:  attacker.Side,
-		TargetID:      target.ParticipantID,
-		TargetName:    target.Name,
-		TargetType:    target.Type,
-		TargetSide:    target.Side,
-		DamageDealt:   damage,
-		CriticalHit:   isCritical,
-		TargetHPBefore: targetHPBefore,
-		TargetHPAfter: target.HP,
-		TargetDefeated: targetDefeated,
-		CreatedAt:     time.Now(),
-	}
-
-	if err := GetRepository().InsertTurn(ctx, turn); err != nil {
-		return nil, nil, fmt.Errorf("failed to record turn: %w", err)
-	}
+	return s.finishTeamTurn(ctx, battle, turn)
+}
 
-	// Check if battle is complete (one side has no alive participants)
+// finishTeamTurn completes the battle if a side has no alive participants left after a turn
+func (s *Service) finishTeamTurn(ctx context.Context, battle *Battle, turn *BattleTurn) (*Battle, *BattleTurn, error) {
 	lightAlive, err := GetRepository().CountAliveBySide(ctx, battle.ID, TeamSideLight)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to count light side: %w", err)
@@ -674,25 +769,63 @@ func (s *Service) performTeamBattleAttack(ctx context.Context, battle *Battle, c
 		return s.completeTeamBattle(ctx, battle, BattleResultLightVictory)
 	}
 
-	// Update battle
-	updateData := map[string]interface{}{
-		"current_turn": battle.CurrentTurn,
-		"current_participant_index": battle.CurrentParticipantIndex,
-		"updated_at": battle.UpdatedAt,
-	}
-	if err := GetRepository().UpdateBattleFields(ctx, battle.ID, updateData); err != nil {
-		return nil, nil, fmt.Errorf("failed to update battle: %w", err)
-	}
-
 	return battle, turn, nil
 }
 
+// applyDamage subtracts damage from the target's current HP with a versioned update,
+// re-reading the target after a conflict. It returns the updated target and its HP before the hit.
+func (s *Service) applyDamage(ctx context.Context, repo Repository, battleID string, target *BattleParticipant, damage int) (*BattleParticipant, int, error) {
+	for attempt := 0; ; attempt++ {
+		hpBefore := target.HP
+		hpAfter := hpBefore - damage
+		if hpAfter < 0 {
+			hpAfter = 0
+		}
+
+		now := time.Now()
+		updateTarget := map[string]interface{}{
+			"hp": hpAfter,
+			"updated_at": now,
+		}
+		if hpAfter <= 0 {
+			updateTarget["is_alive"] = false
+			updateTarget["is_defeated"] = true
+			updateTarget["defeated_at"] = &now
+		}
+
+		err := repo.UpdateParticipantIfVersion(ctx, battleID, target.ParticipantID, target.Version, updateTarget)
+		if err == nil {
+			target.HP = hpAfter
+			target.Version++
+			target.UpdatedAt = now
+			if hpAfter <= 0 {
+				target.IsAlive = false
+				target.IsDefeated = true
+				target.DefeatedAt = &now
+			}
+			return target, hpBefore, nil
+		}
+		if !errors.Is(err, ErrVersionConflict) || attempt >= maxVersionRetries {
+			return nil, 0, err
+		}
+
+		target, err = repo.GetParticipantByIDs(ctx, battleID, target.ParticipantID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("target is no longer alive: %w", err)
+		}
+	}
+}
+
+// errBattleNotInProgress is returned by completeTeamBattle when the battle was already finished elsewhere
+var errBattleNotInProgress = errors.New("battle is not in progress")
+
 // completeTeamBattle marks a team battle as completed
 func (s *Service) completeTeamBattle(ctx context.Context, battle *Battle, result BattleResult) (*Battle, *BattleTurn, error) {
 	now := time.Now()
 	battle.Status = BattleStatusCompleted
 	battle.Result = result
 	battle.CompletedAt = &now
+	battle.TurnDeadline = nil
 
 	if result == BattleResultLightVictory {
 		battle.WinnerSide = TeamSideLight
@@ -705,43 +838,88 @@ func (s *Service) completeTeamBattle(ctx context.Context, battle *Battle, result
 		"result": battle.Result,
 		"winner_side": battle.WinnerSide,
 		"completed_at": battle.CompletedAt,
+		"turn_deadline": nil,
 		"updated_at": now,
 	}
-	if err := GetRepository().UpdateBattleFields(ctx, battle.ID, updateData); err != nil {
+	// Only the first caller completes the battle (attack, sweeper on any replica)
+	completed, err := GetRepository().UpdateBattleFieldsIfStatus(ctx, battle.ID, BattleStatusInProgress, updateData)
+	if err != nil {
 		return nil, nil, fmt.Errorf("failed to complete battle: %w", err)
 	}
+	if !completed {
+		return nil, nil, errBattleNotInProgress
+	}
+
+	// Only the caller that completed the battle gets here, but recording is idempotent regardless
+	if err := s.distributeRewards(ctx, battle); err != nil {
+		log.Printf("Failed to distribute rewards for battle %s: %v", battle.ID, err)
+	}
+	totalCoins, totalExperience := 0, 0
+	for pid, coins := range battle.CoinsEarned {
+		totalCoins += coins
+		totalExperience += battle.ExperienceGained[pid]
+	}
+
+	publishSpectatorCompleted(battle)
+
+	resolveWager(battle)
 
-	// Publish battle completed event (simplified signature for team battles)
+	// Publish battle completed event with the roster the analytics read model needs
 	go func() {
-		_ = PublishBattleCompletedEvent(
-			battle.ID,
-			battle.BattleType,
-			0, // No single warrior ID in team battles
-			"Team Battle",
-			string(result),
-			string(battle.WinnerSide),
-			0, // Coins earned (calculated separately)
-			0, // Experience gained (calculated separately)
-			battle.CurrentTurn,
-		)
+		participants, err := GetRepository().FindParticipants(context.Background(), battle.ID, "all")
+		if err != nil {
+			log.Printf("Failed to load participants of battle %s for its completed event: %v", battle.ID, err)
+		}
+		// Coins and experience are totals across the winning side
+		_ = PublishTeamBattleCompletedEvent(battle, participants, totalCoins, totalExperience)
 	}()
 
 	return battle, nil, nil
 }
 
 // Helper functions
-func (s *Service) calculateDamage(attackerPower, targetDefense int) int {
+
+// CalculateDamage rolls attack damage using the given turn RNG
+func (s *Service) CalculateDamage(rng *rand.Rand, attackerPower, targetDefense int) int {
+	return calculateDamage(rng, attackerPower, targetDefense)
+}
+
+// teamHit is the outcome of a rolled team battle hit
+type teamHit struct {
+	damage   int
+	critical bool
+	resisted int // Taken off by the elemental matchup and armor resistance
+}
+
+// rollTeamHit rolls a team battle hit in a fixed order: the damage factor, then the crit, then the
+// elemental matchup and armor resistance. Live attacks and replay verification both go through it.
+func rollTeamHit(rng *rand.Rand, attackPower, defense int, hitElement, targetElement element.Element, resistance int) teamHit {
+	damage := calculateDamage(rng, attackPower, defense)
+
+	// Critical hit chance (10%)
+	critical := rng.Float64() < 0.1
+	if critical {
+		damage = int(float64(damage) * 1.5)
+	}
+
+	// Elemental hits are weakened or strengthened by the target's own element, then reduced by armor resistance
+	damage, resisted := element.Apply(damage, hitElement, targetElement, resistance)
+	return teamHit{damage: damage, critical: critical, resisted: resisted}
+}
+
+func calculateDamage(rng *rand.Rand, attackerPower, targetDefense int) int {
 	baseDamage := attackerPower - targetDefense
 	if baseDamage < 10 {
 		baseDamage = 10 // Minimum damage
 	}
 
 	// Add randomness (±20%)
-	randomFactor := 0.8 + (rand.Float64() * 0.4)
+	randomFactor := 0.8 + (rng.Float64() * 0.4)
 	return int(float64(baseDamage) * randomFactor)
 }
 
-func (s *Service) calculateOpponentDamage(battle *Battle) int {
+// CalculateOpponentDamage rolls legacy opponent damage using the given turn RNG
+func (s *Service) CalculateOpponentDamage(rng *rand.Rand, battle *Battle) int {
 	// Simple opponent damage calculation
 	// In production, this would fetch opponent stats from enemy/dragon service
 	opponentAttack := 50 // Default
@@ -756,7 +934,7 @@ func (s *Service) calculateOpponentDamage(battle *Battle) int {
 		damage = 10
 	}
 
-	randomFactor := 0.8 + (rand.Float64() * 0.4)
+	randomFactor := 0.8 + (rng.Float64() * 0.4)
 	return int(float64(damage) * randomFactor)
 }
 
@@ -772,10 +950,22 @@ func (b *Battle) OpponentDefense() int {
 
 // GetBattle gets a battle by ID
 func (s *Service) GetBattle(query dto.GetBattleQuery) (*Battle, error) {
-	ctx := context.Background()
+	return s.loadBattle(context.Background(), query.BattleID)
+}
+
+// loadBattle loads a battle from the SQL store, falling back to legacy Mongo documents
+func (s *Service) loadBattle(ctx context.Context, battleID string) (*Battle, error) {
+	if b, err := GetRepository().GetBattleByID(ctx, battleID); err == nil {
+		return b, nil
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(battleID)
+	if err != nil || BattleColl == nil {
+		return nil, errors.New("battle not found")
+	}
 
 	var battle Battle
-	err := BattleColl.FindOne(ctx, bson.M{"_id": query.BattleID}).Decode(&battle)
+	err = BattleColl.FindOne(ctx, bson.M{"_id": objectID}).Decode(&battle)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, errors.New("battle not found")
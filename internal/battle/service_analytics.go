@@ -0,0 +1,217 @@
+package battle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"network-sec-micro/internal/battle/dto"
+)
+
+// ErrInvalidAnalyticsQuery is returned for analytics queries with a bad date range or grouping
+var ErrInvalidAnalyticsQuery = errors.New("invalid analytics query")
+
+const (
+	analyticsDefaultDays  = 30
+	analyticsMaxDays      = 366
+	analyticsDefaultLimit = 10
+)
+
+// analyticsGroups are the dimensions participant statistics can be grouped by
+var analyticsGroups = map[string]bool{"participant_type": true, "side": true, "day": true}
+
+// GetBattleAnalytics sums the analytics read model over a date range
+func (s *Service) GetBattleAnalytics(query dto.BattleAnalyticsQuery) (*dto.BattleAnalyticsResponse, error) {
+	from, to, err := analyticsRange(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	groupBy, err := analyticsGroupBy(query.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = analyticsDefaultLimit
+	}
+
+	db, err := getGorm()
+	if err != nil {
+		return nil, err
+	}
+	byDay := containsString(groupBy, "day")
+
+	var participants []AnalyticsParticipantDailySQL
+	q := db.Table(participantAnalytics.name).Where("day BETWEEN ? AND ?", from, to).
+		Select(strings.Join(append(append([]string{}, groupBy...), sumColumns(participantAnalytics.counters)...), ", "))
+	if len(groupBy) > 0 {
+		q = q.Group(strings.Join(groupBy, ", ")).Order(strings.Join(groupBy, ", "))
+	}
+	if err := q.Scan(&participants).Error; err != nil {
+		return nil, fmt.Errorf("failed to load participant analytics: %w", err)
+	}
+
+	victoryGroups := []string{"winner_side"}
+	if byDay {
+		victoryGroups = []string{"day", "winner_side"}
+	}
+	var victories []AnalyticsVictoryDailySQL
+	if err := db.Table(victoryAnalytics.name).Where("day BETWEEN ? AND ?", from, to).
+		Select(strings.Join(append(victoryGroups, sumColumns(victoryAnalytics.counters)...), ", ")).
+		Group(strings.Join(victoryGroups, ", ")).Order(strings.Join(victoryGroups, ", ")).
+		Scan(&victories).Error; err != nil {
+		return nil, fmt.Errorf("failed to load victory analytics: %w", err)
+	}
+
+	spellGroups := []string{"spell_type", "side"}
+	if byDay {
+		spellGroups = []string{"day", "spell_type", "side"}
+	}
+	var spells []AnalyticsSpellDailySQL
+	if err := db.Table(spellAnalytics.name).Where("day BETWEEN ? AND ?", from, to).
+		Select(strings.Join(append(spellGroups, sumColumns(spellAnalytics.counters)...), ", ")).
+		Group(strings.Join(spellGroups, ", ")).Order(strings.Join(spellGroups, ", ")).
+		Scan(&spells).Error; err != nil {
+		return nil, fmt.Errorf("failed to load spell analytics: %w", err)
+	}
+
+	equipment := make(map[string][]AnalyticsEquipmentDailySQL, 2)
+	for _, kind := range []string{"weapon", "armor"} {
+		var rows []AnalyticsEquipmentDailySQL
+		if err := db.Table(equipmentAnalytics.name).Where("kind = ? AND day BETWEEN ? AND ?", kind, from, to).
+			Select("item_id, SUM(uses) AS uses, SUM(damage) AS damage").
+			Group("item_id").Order("uses DESC, item_id").Limit(limit).
+			Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to load %s analytics: %w", kind, err)
+		}
+		equipment[kind] = rows
+	}
+
+	response := &dto.BattleAnalyticsResponse{
+		From:         from,
+		To:           to,
+		GroupBy:      groupBy,
+		Participants: make([]dto.ParticipantAnalytics, 0, len(participants)),
+		Victories:    make([]dto.VictoryAnalytics, 0, len(victories)),
+		Weapons:      toEquipmentAnalytics(equipment["weapon"]),
+		Armor:        toEquipmentAnalytics(equipment["armor"]),
+		Spells:       make([]dto.SpellAnalytics, 0, len(spells)),
+	}
+	for _, p := range participants {
+		response.Participants = append(response.Participants, dto.ParticipantAnalytics{
+			Day:             p.Day,
+			ParticipantType: p.ParticipantType,
+			Side:            p.Side,
+			Battles:         p.Battles,
+			Wins:            p.Wins,
+			Losses:          p.Losses,
+			Draws:           p.Draws,
+			WinRate:         ratio(p.Wins, p.Battles),
+			Survived:        p.Survived,
+			Attacks:         p.Attacks,
+			CriticalHits:    p.CriticalHits,
+			CritRate:        ratio(p.CriticalHits, p.Attacks),
+			Kills:           p.Kills,
+			DamageDealt:     p.DamageDealt,
+			DamageTaken:     p.DamageTaken,
+		})
+	}
+	for _, v := range victories {
+		response.Victories = append(response.Victories, dto.VictoryAnalytics{
+			Day:          v.Day,
+			WinnerSide:   v.WinnerSide,
+			Victories:    v.Victories,
+			AverageTurns: ratio(v.TotalTurns, v.Victories),
+		})
+	}
+	for _, sp := range spells {
+		response.Spells = append(response.Spells, dto.SpellAnalytics{
+			Day:             sp.Day,
+			SpellType:       sp.SpellType,
+			Side:            sp.Side,
+			Casts:           sp.Casts,
+			AverageAffected: ratio(sp.Affected, sp.Casts),
+			DecidedCasts:    sp.DecidedCasts,
+			WinningCasts:    sp.WinningCasts,
+			WinRate:         ratio(sp.WinningCasts, sp.DecidedCasts),
+		})
+	}
+	return response, nil
+}
+
+// analyticsRange validates a query's date range, defaulting to the last 30 days
+func analyticsRange(fromStr, toStr string) (string, string, error) {
+	to := time.Now().UTC()
+	if toStr != "" {
+		t, err := time.Parse(analyticsDayLayout, toStr)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: to must be a date like 2006-01-02", ErrInvalidAnalyticsQuery)
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -(analyticsDefaultDays - 1))
+	if fromStr != "" {
+		f, err := time.Parse(analyticsDayLayout, fromStr)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: from must be a date like 2006-01-02", ErrInvalidAnalyticsQuery)
+		}
+		from = f
+	}
+
+	fromDay, toDay := from.Format(analyticsDayLayout), to.Format(analyticsDayLayout)
+	if fromDay > toDay {
+		return "", "", fmt.Errorf("%w: from is after to", ErrInvalidAnalyticsQuery)
+	}
+	if to.Sub(from) >= analyticsMaxDays*24*time.Hour {
+		return "", "", fmt.Errorf("%w: the range cannot exceed %d days", ErrInvalidAnalyticsQuery, analyticsMaxDays)
+	}
+	return fromDay, toDay, nil
+}
+
+// analyticsGroupBy validates and de-duplicates the grouping, defaulting to participant type and side
+func analyticsGroupBy(groups []string) ([]string, error) {
+	if len(groups) == 0 {
+		return []string{"participant_type", "side"}, nil
+	}
+	result := make([]string, 0, len(groups))
+	for _, g := range groups {
+		g = strings.TrimSpace(g)
+		if !analyticsGroups[g] {
+			return nil, fmt.Errorf("%w: cannot group by %q", ErrInvalidAnalyticsQuery, g)
+		}
+		if !containsString(result, g) {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+func sumColumns(counters []string) []string {
+	columns := make([]string, len(counters))
+	for i, c := range counters {
+		columns[i] = fmt.Sprintf("SUM(%s) AS %s", c, c)
+	}
+	return columns
+}
+
+func toEquipmentAnalytics(rows []AnalyticsEquipmentDailySQL) []dto.EquipmentAnalytics {
+	result := make([]dto.EquipmentAnalytics, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, dto.EquipmentAnalytics{
+			ItemID:        r.ItemID,
+			Uses:          r.Uses,
+			Damage:        r.Damage,
+			AverageDamage: ratio(r.Damage, r.Uses),
+		})
+	}
+	return result
+}
+
+// ratio divides, treating an empty denominator as no data
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
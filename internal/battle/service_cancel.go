@@ -0,0 +1,274 @@
+package battle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"network-sec-micro/internal/battle/dto"
+)
+
+// A battle can end early in three ways, each with its own settlement:
+//   - Surrender: a side's leader gives up an in-progress team battle. It ends as a normal win for the
+//     other side: the winners get full rewards, the wager goes to their emperor and wear is kept.
+//   - Cancel: the creator calls the battle off before the first turn. Nobody is rewarded and the wager
+//     is refunded; nothing has been fought, so there is no wear to give back.
+//   - Force-cancel: an emperor who has no wager on the battle stops it at any point. The wager is
+//     refunded, players get partial rewards for the damage they dealt and equipment wear is given back.
+// In every case the spells still active in the battle are cleared.
+
+var (
+	// ErrNotSideLeader is returned when someone other than a side's leader tries to surrender
+	ErrNotSideLeader = errors.New("only the side's leader can surrender")
+	// ErrNotBattleCreator is returned when someone other than the creator tries to cancel a battle
+	ErrNotBattleCreator = errors.New("only the battle's creator can cancel it")
+	// ErrForceCancelNotAllowed is returned when the requester may not force-cancel the battle
+	ErrForceCancelNotAllowed = errors.New("force-cancel is not allowed")
+)
+
+// Surrender ends an in-progress team battle as a victory for the other side
+func (s *Service) Surrender(cmd dto.SurrenderBattleCommand) (*Battle, error) {
+	ctx := context.Background()
+
+	battle, err := GetRepository().GetBattleByID(ctx, cmd.BattleID)
+	if err != nil {
+		return nil, errors.New("battle not found")
+	}
+	if battle.BattleType != BattleTypeTeam {
+		return nil, errors.New("only team battles can be surrendered")
+	}
+	if battle.Status != BattleStatusInProgress {
+		return nil, errBattleNotInProgress
+	}
+
+	side := TeamSide(cmd.Side)
+	result := BattleResultDarkVictory
+	switch side {
+	case TeamSideLight:
+	case TeamSideDark:
+		result = BattleResultLightVictory
+	default:
+		return nil, fmt.Errorf("invalid side %q", cmd.Side)
+	}
+
+	participants, err := GetRepository().FindParticipants(ctx, battle.ID, "all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load participants: %w", err)
+	}
+	if leader := sideLeader(battle, participants, side); leader == "" || leader != cmd.RequestedBy {
+		return nil, ErrNotSideLeader
+	}
+
+	battle, _, err = s.completeTeamBattle(ctx, battle, result)
+	if err != nil {
+		return nil, err
+	}
+
+	s.clearBattleSpells(ctx, battle.ID)
+	go func() {
+		message := fmt.Sprintf("%s side surrendered", side)
+		if err := LogBattleEnd(context.Background(), battle, message); err != nil {
+			log.Printf("Failed to log battle end: %v", err)
+		}
+	}()
+	go func() {
+		_ = PublishBattleSurrendered(battle, side, cmd.RequestedBy)
+	}()
+
+	log.Printf("Battle %s: %s side surrendered (leader %s)", battle.ID, side, cmd.RequestedBy)
+	return battle, nil
+}
+
+// CancelBattle lets the creator call off a battle that has not had its first turn yet
+func (s *Service) CancelBattle(cmd dto.CancelBattleCommand) (*Battle, error) {
+	ctx := context.Background()
+
+	battle, err := GetRepository().GetBattleByID(ctx, cmd.BattleID)
+	if err != nil {
+		return nil, errors.New("battle not found")
+	}
+	if battle.CreatedBy == "" || battle.CreatedBy != cmd.RequestedBy {
+		return nil, ErrNotBattleCreator
+	}
+	if !battle.IsActive() {
+		return nil, errors.New("battle is no longer active")
+	}
+	if battle.CurrentTurn > 0 {
+		return nil, errors.New("battle can only be cancelled before the first turn")
+	}
+
+	// Pinning the version makes sure no turn was played since the battle was read
+	now := time.Now()
+	updateData := map[string]interface{}{
+		"status":        BattleStatusCancelled,
+		"completed_at":  &now,
+		"turn_deadline": nil,
+		"updated_at":    now,
+	}
+	if err := GetRepository().UpdateBattleFieldsIfVersion(ctx, battle.ID, battle.Version, updateData); err != nil {
+		return nil, fmt.Errorf("failed to cancel battle: %w", err)
+	}
+	battle.Status = BattleStatusCancelled
+	battle.CompletedAt = &now
+	battle.TurnDeadline = nil
+	battle.UpdatedAt = now
+	battle.Version++
+
+	resolveWager(battle)
+	s.clearBattleSpells(ctx, battle.ID)
+	publishSpectatorCompleted(battle)
+	go func() {
+		_ = PublishBattleCancelled(battle, cmd.RequestedBy, cmd.Reason)
+	}()
+
+	log.Printf("Battle %s cancelled by its creator %s", battle.ID, cmd.RequestedBy)
+	return battle, nil
+}
+
+// ForceCancelBattle lets an emperor stop any active team battle they have no wager on
+func (s *Service) ForceCancelBattle(cmd dto.ForceCancelBattleCommand) (*Battle, error) {
+	ctx := context.Background()
+
+	if !isEmperor(cmd.RequestedByRole) {
+		return nil, fmt.Errorf("%w: only emperors can force-cancel battles", ErrForceCancelNotAllowed)
+	}
+	battle, err := GetRepository().GetBattleByID(ctx, cmd.BattleID)
+	if err != nil {
+		return nil, errors.New("battle not found")
+	}
+	if battle.BattleType != BattleTypeTeam {
+		return nil, errors.New("only team battles can be force-cancelled")
+	}
+	if cmd.RequestedByID != "" && (cmd.RequestedByID == battle.LightEmperorID || cmd.RequestedByID == battle.DarkEmperorID) {
+		return nil, fmt.Errorf("%w: emperors with a wager on the battle cannot cancel it", ErrForceCancelNotAllowed)
+	}
+	if !battle.IsActive() {
+		return nil, errors.New("battle is no longer active")
+	}
+
+	// Only the first caller settles the battle (attack, sweeper, another cancel)
+	previousStatus := battle.Status
+	now := time.Now()
+	updateData := map[string]interface{}{
+		"status":        BattleStatusCancelled,
+		"completed_at":  &now,
+		"turn_deadline": nil,
+		"updated_at":    now,
+	}
+	cancelled, err := GetRepository().UpdateBattleFieldsIfStatus(ctx, battle.ID, previousStatus, updateData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel battle: %w", err)
+	}
+	if !cancelled {
+		return nil, errors.New("battle is no longer active")
+	}
+	battle.Status = BattleStatusCancelled
+	battle.CompletedAt = &now
+	battle.TurnDeadline = nil
+	battle.UpdatedAt = now
+	battle.Version++
+
+	rewardedCoins := 0
+	if battle.CurrentTurn > 0 {
+		if err := s.distributePartialRewards(ctx, battle); err != nil {
+			log.Printf("Failed to distribute partial rewards for battle %s: %v", battle.ID, err)
+		}
+		for _, coins := range battle.CoinsEarned {
+			rewardedCoins += coins
+		}
+		s.restoreEquipmentWear(ctx, battle.ID)
+	}
+
+	resolveWager(battle)
+	s.clearBattleSpells(ctx, battle.ID)
+	publishSpectatorCompleted(battle)
+	go func() {
+		_ = PublishBattleForceCancelled(battle, cmd.RequestedBy, cmd.Reason, previousStatus, rewardedCoins)
+	}()
+
+	log.Printf("Battle %s force-cancelled by %s at turn %d", battle.ID, cmd.RequestedBy, battle.CurrentTurn)
+	return battle, nil
+}
+
+// sideLeader returns the participant ID allowed to speak for side: the emperor who wagered on it,
+// otherwise the highest-ranked player still standing (emperor, then king, then warrior; earliest joined first)
+func sideLeader(battle *Battle, participants []*BattleParticipant, side TeamSide) string {
+	if side == TeamSideLight && battle.LightEmperorID != "" {
+		return battle.LightEmperorID
+	}
+	if side == TeamSideDark && battle.DarkEmperorID != "" {
+		return battle.DarkEmperorID
+	}
+
+	var leader *BattleParticipant
+	for _, p := range participants {
+		if p.Side != side || !p.IsAlive || leaderRank(p.Type) == 0 {
+			continue
+		}
+		if leader == nil || leaderRank(p.Type) > leaderRank(leader.Type) ||
+			(leaderRank(p.Type) == leaderRank(leader.Type) && joinedBefore(p, leader)) {
+			leader = p
+		}
+	}
+	if leader == nil {
+		return ""
+	}
+	return leader.ParticipantID
+}
+
+// leaderRank orders participant types for leadership; NPCs cannot lead
+func leaderRank(t ParticipantType) int {
+	switch t {
+	case ParticipantTypeLightEmperor, ParticipantTypeDarkEmperor:
+		return 3
+	case ParticipantTypeLightKing, ParticipantTypeDarkKing:
+		return 2
+	case ParticipantTypeWarrior:
+		return 1
+	}
+	return 0
+}
+
+// restoreEquipmentWear gives back the durability every weapon and armor lost in a battle.
+// It is best effort: equipment that cannot be reached keeps its wear.
+func (s *Service) restoreEquipmentWear(ctx context.Context, battleID string) {
+	turns, err := GetRepository().ListTurns(ctx, battleID, 0)
+	if err != nil {
+		log.Printf("Failed to load turns of battle %s to restore wear: %v", battleID, err)
+		return
+	}
+	weaponWear := make(map[string]int32)
+	armorWear := make(map[string]int32)
+	for _, t := range turns {
+		if t.WeaponID != "" {
+			weaponWear[t.WeaponID]++
+		}
+		if t.ArmorID != "" {
+			armorWear[t.ArmorID]++
+		}
+	}
+	for id, wear := range weaponWear {
+		if _, err := ApplyWeaponWear(ctx, id, -wear); err != nil {
+			log.Printf("Failed to restore wear of weapon %s: %v", id, err)
+		}
+	}
+	for id, wear := range armorWear {
+		if _, err := ApplyArmorWear(ctx, id, -wear); err != nil {
+			log.Printf("Failed to restore wear of armor %s: %v", id, err)
+		}
+	}
+}
+
+// clearBattleSpells deactivates the spells of a finished battle; failures are only logged
+func (s *Service) clearBattleSpells(ctx context.Context, battleID string) {
+	if GetBattlespellClient() == nil {
+		return
+	}
+	cctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if _, err := ClearBattleSpells(cctx, battleID); err != nil {
+		log.Printf("Failed to clear spells of battle %s: %v", battleID, err)
+	}
+}
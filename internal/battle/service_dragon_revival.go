@@ -10,6 +10,8 @@ import (
 	"os"
 	"time"
 
+	"network-sec-micro/pkg/spectate"
+
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -94,6 +96,7 @@ func (s *Service) ReviveDragonInBattle(ctx context.Context, battleID primitive.O
 	if err != nil {
 		return nil, fmt.Errorf("failed to revive dragon participant: %w", err)
 	}
+	publishSpectatorEvent(battleID.Hex(), spectate.EventDragonRevived, ToParticipantResponse(&participant))
 
 	// Log revival to Redis
 	go func() {
@@ -296,6 +299,7 @@ func (s *Service) DarkEmperorJoinBattle(ctx context.Context, battleID primitive.
 	if err != nil {
 		return nil, fmt.Errorf("failed to add dark emperor to battle: %w", err)
 	}
+	publishSpectatorEvent(battleID.Hex(), spectate.EventParticipantJoined, ToParticipantResponse(participant))
 
 	// Log to Redis
 	go func() {
@@ -0,0 +1,493 @@
+package battle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"network-sec-micro/internal/battle/dto"
+	"network-sec-micro/pkg/spectate"
+)
+
+// A lobby is a pending team battle with open slots per side. Players join, answer a ready-check,
+// and the battle starts as soon as every slot is taken and everyone is ready, or at the scheduled
+// start time once every slot is taken. A lobby that has not started by its deadline is cancelled.
+
+const defaultLobbyTimeoutMinutes = 30
+
+// lobbyTimeout returns how long an unscheduled lobby stays open.
+// BATTLE_LOBBY_TIMEOUT_MINUTES overrides the default.
+func lobbyTimeout() time.Duration {
+	minutes := defaultLobbyTimeoutMinutes
+	if v, err := strconv.Atoi(os.Getenv("BATTLE_LOBBY_TIMEOUT_MINUTES")); err == nil && v > 0 {
+		minutes = v
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// participantForRole returns the participant type and side a player joins a lobby as
+func participantForRole(role string) (ParticipantType, TeamSide) {
+	switch role {
+	case "knight", "archer", "mage":
+		return ParticipantTypeWarrior, TeamSideLight
+	case "light_king":
+		return ParticipantTypeLightKing, TeamSideLight
+	case "light_emperor":
+		return ParticipantTypeLightEmperor, TeamSideLight
+	case "dark_emperor":
+		return ParticipantTypeDarkEmperor, TeamSideDark
+	}
+	return ParticipantType(role), TeamSide(getFaction(role))
+}
+
+// CreateLobby opens a pending team battle with open slots. Participants given up front (for example the
+// enemies players will face) are seated and ready straight away and count against their side's slots.
+func (s *Service) CreateLobby(cmd dto.CreateLobbyCommand) (*Battle, []*BattleParticipant, error) {
+	ctx := context.Background()
+
+	if cmd.LightSlots <= 0 || cmd.DarkSlots <= 0 {
+		return nil, nil, errors.New("each side needs at least one slot")
+	}
+	if len(cmd.LightParticipants) > cmd.LightSlots {
+		return nil, nil, fmt.Errorf("light side has %d participants but only %d slots", len(cmd.LightParticipants), cmd.LightSlots)
+	}
+	if len(cmd.DarkParticipants) > cmd.DarkSlots {
+		return nil, nil, fmt.Errorf("dark side has %d participants but only %d slots", len(cmd.DarkParticipants), cmd.DarkSlots)
+	}
+	if err := ValidateBattleParticipants(dto.StartBattleCommand{
+		LightParticipants: cmd.LightParticipants,
+		DarkParticipants:  cmd.DarkParticipants,
+	}); err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
+	}
+	speeds, err := participantSpeeds(ctx, append(append([]dto.ParticipantInfo{}, cmd.LightParticipants...), cmd.DarkParticipants...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	deadline := now.Add(lobbyTimeout()).UTC()
+	var scheduledStart *time.Time
+	if cmd.ScheduledStartAt != nil {
+		if !cmd.ScheduledStartAt.After(now) {
+			return nil, nil, errors.New("scheduled start must be in the future")
+		}
+		start := cmd.ScheduledStartAt.UTC()
+		scheduledStart = &start
+		deadline = start
+	}
+
+	lightSideName := cmd.LightSideName
+	if lightSideName == "" {
+		lightSideName = "Light Alliance"
+	}
+	darkSideName := cmd.DarkSideName
+	if darkSideName == "" {
+		darkSideName = "Dark Forces"
+	}
+	maxTurns := cmd.MaxTurns
+	if maxTurns <= 0 {
+		maxTurns = 100 // Default for team battles
+	}
+	turnOrder, err := ParseTurnOrder(cmd.TurnOrder)
+	if err != nil {
+		return nil, nil, err
+	}
+	timeoutAction, err := ParseTimeoutAction(cmd.TimeoutAction)
+	if err != nil {
+		return nil, nil, err
+	}
+	turnTimeout, maxDuration := turnTimeoutDefaults()
+	if cmd.TurnTimeoutSeconds > 0 {
+		turnTimeout = cmd.TurnTimeoutSeconds
+	}
+	if cmd.MaxDurationMinutes > 0 {
+		maxDuration = time.Duration(cmd.MaxDurationMinutes) * time.Minute
+	}
+
+	battle := &Battle{
+		BattleType:         BattleTypeTeam,
+		LightSideName:      lightSideName,
+		DarkSideName:       darkSideName,
+		MaxTurns:           maxTurns,
+		TurnOrder:          turnOrder,
+		TurnTimeoutSeconds: turnTimeout,
+		TimeoutAction:      timeoutAction,
+		Status:             BattleStatusPending,
+		Seed:               NewBattleSeed(),
+		CreatedBy:          cmd.CreatedBy,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		LightSlots:         cmd.LightSlots,
+		DarkSlots:          cmd.DarkSlots,
+		ScheduledStartAt:   scheduledStart,
+		LobbyDeadline:      &deadline,
+		MaxDurationMinutes: int(maxDuration / time.Minute),
+	}
+	battleID, err := GetRepository().CreateBattle(ctx, battle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create lobby: %w", err)
+	}
+	battle.ID = battleID
+
+	participants := make([]*BattleParticipant, 0, len(cmd.LightParticipants)+len(cmd.DarkParticipants))
+	for _, pInfo := range append(append([]dto.ParticipantInfo{}, cmd.LightParticipants...), cmd.DarkParticipants...) {
+		participant := newLobbyParticipant(battle.ID, pInfo, speeds[pInfo.ParticipantID], now)
+		participant.Ready = true
+		participants = append(participants, participant)
+	}
+	if len(participants) > 0 {
+		if err := GetRepository().InsertParticipants(ctx, participants); err != nil {
+			return nil, nil, fmt.Errorf("failed to create participants: %w", err)
+		}
+	}
+
+	log.Printf("Lobby %s opened by %s: %d vs %d slots, deadline %s", battle.ID, cmd.CreatedBy, battle.LightSlots, battle.DarkSlots, deadline.Format(time.RFC3339))
+	return battle, participants, nil
+}
+
+// JoinLobby seats a player in an open slot on their side. The same composition and healing
+// checks as starting a battle apply; the player still has to answer the ready-check.
+func (s *Service) JoinLobby(cmd dto.JoinLobbyCommand) (*Battle, *BattleParticipant, error) {
+	ctx := context.Background()
+
+	battle, err := s.loadLobby(ctx, cmd.BattleID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	participants, err := GetRepository().FindParticipants(ctx, battle.ID, "all")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load participants: %w", err)
+	}
+	for _, p := range participants {
+		if p.ParticipantID == cmd.Participant.ParticipantID {
+			return nil, nil, fmt.Errorf("participant %s is already in this lobby", cmd.Participant.ParticipantID)
+		}
+	}
+
+	side := TeamSide(cmd.Participant.Side)
+	if countSide(participants, side) >= battle.slotsFor(side) {
+		return nil, nil, fmt.Errorf("%s side is full", side)
+	}
+
+	if err := ValidateBattleParticipants(rosterWith(participants, &cmd.Participant)); err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if cmd.Participant.Type == "warrior" {
+		var warriorID uint
+		if _, err := fmt.Sscanf(cmd.Participant.ParticipantID, "%d", &warriorID); err == nil {
+			if err := CheckWarriorCanBattle(ctx, warriorID); err != nil {
+				return nil, nil, fmt.Errorf("participant %s cannot battle: %w", cmd.Participant.Name, err)
+			}
+		}
+	}
+
+	speed, err := ParticipantSpeed(ctx, ParticipantType(cmd.Participant.Type), cmd.Participant.ParticipantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load stats of %s: %w", cmd.Participant.Name, err)
+	}
+
+	participant := newLobbyParticipant(battle.ID, cmd.Participant, speed, time.Now())
+	if err := GetRepository().InsertParticipants(ctx, []*BattleParticipant{participant}); err != nil {
+		return nil, nil, fmt.Errorf("failed to join lobby: %w", err)
+	}
+
+	// Two players may have taken the last slot at once; whoever joined first keeps it
+	seated, err := GetRepository().FindParticipants(ctx, battle.ID, string(side))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load participants: %w", err)
+	}
+	for i, p := range seated {
+		if p.ParticipantID != participant.ParticipantID {
+			continue
+		}
+		if i >= battle.slotsFor(side) {
+			if err := GetRepository().DeleteParticipant(ctx, battle.ID, participant.ParticipantID); err != nil {
+				log.Printf("Failed to undo overbooked join of %s to lobby %s: %v", participant.Name, battle.ID, err)
+			}
+			return nil, nil, fmt.Errorf("%s side is full", side)
+		}
+		participant = p
+	}
+
+	publishSpectatorEvent(battle.ID, spectate.EventParticipantJoined, ToParticipantResponse(participant))
+	go func() {
+		message := fmt.Sprintf("%s joined the %s side of the lobby", participant.Name, participant.Side)
+		if err := LogParticipantEvent(context.Background(), battle, participant, "participant_joined", message); err != nil {
+			log.Printf("Failed to log lobby join: %v", err)
+		}
+	}()
+
+	return battle, participant, nil
+}
+
+// SetLobbyReady records a participant's answer to the ready-check. The battle starts once every
+// slot is taken and every participant is ready.
+func (s *Service) SetLobbyReady(cmd dto.SetLobbyReadyCommand) (*Battle, error) {
+	ctx := context.Background()
+
+	battle, err := s.loadLobby(ctx, cmd.BattleID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := GetRepository().GetParticipantByIDs(ctx, battle.ID, cmd.ParticipantID); err != nil {
+		return nil, errors.New("participant not found in this lobby")
+	}
+	update := map[string]interface{}{
+		"ready":      cmd.Ready,
+		"updated_at": time.Now(),
+	}
+	if err := GetRepository().UpdateParticipantByIDs(ctx, battle.ID, cmd.ParticipantID, update); err != nil {
+		return nil, fmt.Errorf("failed to update ready state: %w", err)
+	}
+	if !cmd.Ready {
+		return battle, nil
+	}
+
+	participants, err := GetRepository().FindParticipants(ctx, battle.ID, "all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load participants: %w", err)
+	}
+	if !battle.lobbyFull(participants) || !allReady(participants) {
+		return battle, nil
+	}
+	if _, err := s.startPendingBattle(ctx, battle); err != nil {
+		return nil, err
+	}
+	return s.loadBattle(ctx, battle.ID)
+}
+
+// LeaveLobby frees a player's slot in a lobby that has not started yet
+func (s *Service) LeaveLobby(cmd dto.LeaveLobbyCommand) (*Battle, error) {
+	ctx := context.Background()
+
+	battle, err := s.loadLobby(ctx, cmd.BattleID)
+	if err != nil {
+		return nil, err
+	}
+	participant, err := GetRepository().GetParticipantByIDs(ctx, battle.ID, cmd.ParticipantID)
+	if err != nil {
+		return nil, errors.New("participant not found in this lobby")
+	}
+	if err := GetRepository().DeleteParticipant(ctx, battle.ID, participant.ParticipantID); err != nil {
+		return nil, fmt.Errorf("failed to leave lobby: %w", err)
+	}
+
+	go func() {
+		message := fmt.Sprintf("%s left the %s side of the lobby", participant.Name, participant.Side)
+		if err := LogParticipantEvent(context.Background(), battle, participant, "participant_left", message); err != nil {
+			log.Printf("Failed to log lobby leave: %v", err)
+		}
+	}()
+
+	return battle, nil
+}
+
+// ListLobbies lists lobbies waiting to start, soonest deadline first
+func (s *Service) ListLobbies(query dto.ListLobbiesQuery) ([]*Battle, int64, error) {
+	lobbies, total, err := GetRepository().ListLobbies(context.Background(), query.Limit, query.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list lobbies: %w", err)
+	}
+	return lobbies, total, nil
+}
+
+// GetLobby gets a battle that was opened as a lobby, whether or not it has started since
+func (s *Service) GetLobby(query dto.GetBattleQuery) (*Battle, error) {
+	battle, err := GetRepository().GetBattleByID(context.Background(), query.BattleID)
+	if err != nil || battle.LobbyDeadline == nil {
+		return nil, errors.New("lobby not found")
+	}
+	return battle, nil
+}
+
+// SweepDueLobbies starts full lobbies whose scheduled start has come and cancels lobbies past their
+// deadline that could not start. It returns how many lobbies this call acted on.
+func (s *Service) SweepDueLobbies(ctx context.Context, now time.Time) (int, error) {
+	lobbies, err := GetRepository().ListDueLobbies(ctx, now.UTC(), sweepBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due lobbies: %w", err)
+	}
+
+	handled := 0
+	for _, lobby := range lobbies {
+		participants, err := GetRepository().FindParticipants(ctx, lobby.ID, "all")
+		if err != nil {
+			log.Printf("Lobby sweeper: lobby %s: %v", lobby.ID, err)
+			continue
+		}
+
+		var acted bool
+		switch {
+		case lobby.ScheduledStartAt != nil && lobby.lobbyFull(participants):
+			acted, err = s.startPendingBattle(ctx, lobby)
+		case !lobby.lobbyFull(participants):
+			acted, err = s.cancelLobby(ctx, lobby, "not enough players joined")
+		default:
+			acted, err = s.cancelLobby(ctx, lobby, "not everyone was ready")
+		}
+		if err != nil {
+			log.Printf("Lobby sweeper: lobby %s: %v", lobby.ID, err)
+			continue
+		}
+		if acted {
+			handled++
+		}
+	}
+	return handled, nil
+}
+
+// startPendingBattle moves a lobby, or a wagered battle both emperors approved, to in-progress.
+// False means another caller started or cancelled it first.
+func (s *Service) startPendingBattle(ctx context.Context, battle *Battle) (bool, error) {
+	now := time.Now()
+	turnDeadline := battle.nextTurnDeadline(now)
+	var expiresAt *time.Time
+	if battle.MaxDurationMinutes > 0 {
+		expires := now.Add(time.Duration(battle.MaxDurationMinutes) * time.Minute).UTC()
+		expiresAt = &expires
+	}
+	updateData := map[string]interface{}{
+		"status":        BattleStatusInProgress,
+		"started_at":    &now,
+		"turn_deadline": turnDeadline,
+		"expires_at":    expiresAt,
+		"updated_at":    now,
+	}
+	started, err := GetRepository().UpdateBattleFieldsIfStatus(ctx, battle.ID, BattleStatusPending, updateData)
+	if err != nil {
+		return false, fmt.Errorf("failed to start battle: %w", err)
+	}
+	if !started {
+		return false, nil
+	}
+	battle.Status = BattleStatusInProgress
+	battle.StartedAt = &now
+	battle.TurnDeadline = turnDeadline
+	battle.ExpiresAt = expiresAt
+	battle.UpdatedAt = now
+	battle.Version++
+
+	go func() {
+		message := fmt.Sprintf("Savaş başladı: %s vs %s", battle.LightSideName, battle.DarkSideName)
+		if err := LogBattleStart(context.Background(), battle, message); err != nil {
+			log.Printf("Failed to log battle start: %v", err)
+		}
+	}()
+	go PublishBattleStartedEvent(battle.ID, battle.BattleType, 0, "Team Battle", "", "", "")
+
+	s.playAutomatedTurns(ctx, battle)
+	return true, nil
+}
+
+// cancelLobby cancels a lobby that could not start; false means it started or was cancelled meanwhile
+func (s *Service) cancelLobby(ctx context.Context, battle *Battle, reason string) (bool, error) {
+	now := time.Now()
+	updateData := map[string]interface{}{
+		"status":       BattleStatusCancelled,
+		"completed_at": &now,
+		"updated_at":   now,
+	}
+	cancelled, err := GetRepository().UpdateBattleFieldsIfStatus(ctx, battle.ID, BattleStatusPending, updateData)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel lobby: %w", err)
+	}
+	if !cancelled {
+		return false, nil
+	}
+	battle.Status = BattleStatusCancelled
+	battle.CompletedAt = &now
+	battle.UpdatedAt = now
+	battle.Version++
+
+	log.Printf("Lobby %s cancelled: %s", battle.ID, reason)
+	publishSpectatorCompleted(battle)
+	return true, nil
+}
+
+// loadLobby loads a lobby that is still waiting to start
+func (s *Service) loadLobby(ctx context.Context, battleID string) (*Battle, error) {
+	battle, err := GetRepository().GetBattleByID(ctx, battleID)
+	if err != nil || battle.LobbyDeadline == nil {
+		return nil, errors.New("lobby not found")
+	}
+	if !battle.IsLobby() {
+		return nil, errors.New("lobby is no longer open")
+	}
+	return battle, nil
+}
+
+// slotsFor returns the size of side once the lobby is full
+func (b *Battle) slotsFor(side TeamSide) int {
+	if side == TeamSideLight {
+		return b.LightSlots
+	}
+	if side == TeamSideDark {
+		return b.DarkSlots
+	}
+	return 0
+}
+
+// lobbyFull checks if every slot on both sides is taken
+func (b *Battle) lobbyFull(participants []*BattleParticipant) bool {
+	return countSide(participants, TeamSideLight) >= b.LightSlots && countSide(participants, TeamSideDark) >= b.DarkSlots
+}
+
+// countSide counts the active participants on side
+func countSide(participants []*BattleParticipant, side TeamSide) int {
+	n := 0
+	for _, p := range participants {
+		if p.IsAlive && p.Side == side {
+			n++
+		}
+	}
+	return n
+}
+
+// allReady checks if every active participant passed the ready-check
+func allReady(participants []*BattleParticipant) bool {
+	for _, p := range participants {
+		if p.IsAlive && !p.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// newLobbyParticipant builds a participant seated in a lobby, with the same HP defaults as StartBattle
+func newLobbyParticipant(battleID string, pInfo dto.ParticipantInfo, speed int, now time.Time) *BattleParticipant {
+	participant := &BattleParticipant{
+		BattleID:      battleID,
+		ParticipantID: pInfo.ParticipantID,
+		Name:          pInfo.Name,
+		Type:          ParticipantType(pInfo.Type),
+		Side:          TeamSide(pInfo.Side),
+		HP:            pInfo.HP,
+		MaxHP:         pInfo.MaxHP,
+		AttackPower:   pInfo.AttackPower,
+		Defense:       pInfo.Defense,
+		Speed:         speed,
+		Element:       pInfo.Element,
+		Level:         pInfo.Level,
+		IsAlive:       true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if participant.HP <= 0 {
+		participant.HP = participant.MaxHP
+	}
+	if participant.MaxHP <= 0 {
+		participant.MaxHP = participant.HP
+	}
+	if participant.HP == 0 && participant.MaxHP == 0 {
+		participant.MaxHP = 100 // Default
+		participant.HP = participant.MaxHP
+	}
+	return participant
+}
@@ -0,0 +1,248 @@
+package battle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"network-sec-micro/internal/battle/dto"
+	"network-sec-micro/pkg/spectate"
+)
+
+// AddParticipant brings a reinforcement into a pending or in-progress team battle.
+// The new participant acts last in join order; whoever was due to act keeps the turn.
+func (s *Service) AddParticipant(cmd dto.AddParticipantCommand) (*Battle, *BattleParticipant, error) {
+	ctx := context.Background()
+
+	battle, err := s.loadOpenTeamBattle(ctx, cmd.BattleID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	participants, err := GetRepository().FindParticipants(ctx, battle.ID, "all")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load participants: %w", err)
+	}
+	for _, p := range participants {
+		if p.ParticipantID == cmd.Participant.ParticipantID {
+			return nil, nil, fmt.Errorf("participant %s is already in this battle", cmd.Participant.ParticipantID)
+		}
+	}
+
+	// Same composition rules as StartBattle, applied to the team after the join
+	if err := ValidateBattleParticipants(rosterWith(participants, &cmd.Participant)); err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if cmd.Participant.Type == "warrior" {
+		var warriorID uint
+		if _, err := fmt.Sscanf(cmd.Participant.ParticipantID, "%d", &warriorID); err == nil {
+			if err := CheckWarriorCanBattle(ctx, warriorID); err != nil {
+				return nil, nil, fmt.Errorf("participant %s cannot battle: %w", cmd.Participant.Name, err)
+			}
+		}
+	}
+
+	speed, err := ParticipantSpeed(ctx, ParticipantType(cmd.Participant.Type), cmd.Participant.ParticipantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load stats of %s: %w", cmd.Participant.Name, err)
+	}
+
+	now := time.Now()
+	pInfo := cmd.Participant
+	participant := &BattleParticipant{
+		BattleID:      battle.ID,
+		ParticipantID: pInfo.ParticipantID,
+		Name:          pInfo.Name,
+		Type:          ParticipantType(pInfo.Type),
+		Side:          TeamSide(pInfo.Side),
+		HP:            pInfo.HP,
+		MaxHP:         pInfo.MaxHP,
+		AttackPower:   pInfo.AttackPower,
+		Defense:       pInfo.Defense,
+		Speed:         speed,
+		Element:       pInfo.Element,
+		Level:         pInfo.Level,
+		IsAlive:       true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if participant.HP <= 0 {
+		participant.HP = participant.MaxHP
+	}
+	if participant.MaxHP <= 0 {
+		participant.MaxHP = participant.HP
+	}
+	if participant.HP == 0 && participant.MaxHP == 0 {
+		participant.MaxHP = 100 // Default
+		participant.HP = participant.MaxHP
+	}
+
+	if err := GetRepository().InsertParticipants(ctx, []*BattleParticipant{participant}); err != nil {
+		return nil, nil, fmt.Errorf("failed to add participant: %w", err)
+	}
+
+	if err := s.rebuildTurnQueue(ctx, battle, participants); err != nil {
+		return nil, nil, err
+	}
+	publishSpectatorEvent(battle.ID, spectate.EventParticipantJoined, ToParticipantResponse(participant))
+
+	go func() {
+		message := fmt.Sprintf("%s joined the %s side", participant.Name, participant.Side)
+		if err := LogParticipantEvent(context.Background(), battle, participant, "participant_joined", message); err != nil {
+			log.Printf("Failed to log participant join: %v", err)
+		}
+	}()
+
+	// A server-controlled reinforcement may be next in line
+	if battle.Status == BattleStatusInProgress {
+		battle = s.playAutomatedTurns(ctx, battle)
+	}
+
+	return battle, participant, nil
+}
+
+// RemoveParticipant withdraws a participant from a pending or in-progress team battle.
+// The participant stays on record but no longer acts or can be targeted. If a side of an
+// in-progress battle is left without fighters, that side forfeits.
+func (s *Service) RemoveParticipant(cmd dto.RemoveParticipantCommand) (*Battle, error) {
+	ctx := context.Background()
+
+	battle, err := s.loadOpenTeamBattle(ctx, cmd.BattleID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := GetRepository().GetParticipantByIDs(ctx, battle.ID, cmd.ParticipantID)
+	if err != nil {
+		return nil, errors.New("participant not found in this battle")
+	}
+	participants, err := GetRepository().FindParticipants(ctx, battle.ID, "all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load participants: %w", err)
+	}
+
+	remaining, err := GetRepository().CountAliveBySide(ctx, battle.ID, participant.Side)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count %s side: %w", participant.Side, err)
+	}
+	if remaining <= 1 && battle.Status == BattleStatusPending {
+		return nil, fmt.Errorf("%s side must keep at least one participant", participant.Side)
+	}
+
+	retreat := map[string]interface{}{
+		"is_alive":   false,
+		"updated_at": time.Now(),
+	}
+	if err := GetRepository().UpdateParticipantIfVersion(ctx, battle.ID, participant.ParticipantID, participant.Version, retreat); err != nil {
+		return nil, fmt.Errorf("failed to remove participant: %w", err)
+	}
+	participant.IsAlive = false
+
+	go func() {
+		message := fmt.Sprintf("%s left the %s side", participant.Name, participant.Side)
+		if err := LogParticipantEvent(context.Background(), battle, participant, "participant_left", message); err != nil {
+			log.Printf("Failed to log participant leave: %v", err)
+		}
+	}()
+
+	if battle.Status == BattleStatusInProgress && remaining <= 1 {
+		result := BattleResultDarkVictory
+		if participant.Side == TeamSideDark {
+			result = BattleResultLightVictory
+		}
+		completed, _, err := s.completeTeamBattle(ctx, battle, result)
+		if err != nil {
+			return nil, err
+		}
+		return completed, nil
+	}
+
+	// If the leaver was due to act, the turn passes to the next in line, which may be server-controlled
+	if err := s.rebuildTurnQueue(ctx, battle, participants); err != nil {
+		return nil, err
+	}
+	if battle.Status == BattleStatusInProgress {
+		battle = s.playAutomatedTurns(ctx, battle)
+	}
+
+	return battle, nil
+}
+
+// loadOpenTeamBattle loads a team battle that can still change roster
+func (s *Service) loadOpenTeamBattle(ctx context.Context, battleID string) (*Battle, error) {
+	battle, err := GetRepository().GetBattleByID(ctx, battleID)
+	if err != nil {
+		return nil, errors.New("battle not found")
+	}
+	if battle.BattleType != BattleTypeTeam {
+		return nil, errors.New("participants can only be changed in team battles")
+	}
+	if battle.Status != BattleStatusPending && battle.Status != BattleStatusInProgress {
+		return nil, errors.New("battle is already over")
+	}
+	return battle, nil
+}
+
+// rebuildTurnQueue rebuilds a battle's turn queue after its roster changed and points
+// CurrentParticipantIndex at whoever was due to act under the old roster, before. A participant
+// who left keeps their slot, so the turn passes from it to the next alive participant in line.
+func (s *Service) rebuildTurnQueue(ctx context.Context, battle *Battle, before []*BattleParticipant) error {
+	actor, _, ok := NextInTurnQueue(BuildTurnQueue(before, battle.TurnOrder), battle.CurrentParticipantIndex)
+	if !ok {
+		return nil
+	}
+	participants, err := GetRepository().FindParticipants(ctx, battle.ID, "all")
+	if err != nil {
+		return fmt.Errorf("failed to load participants: %w", err)
+	}
+	for i, p := range BuildTurnQueue(participants, battle.TurnOrder) {
+		if p.ParticipantID != actor.ParticipantID {
+			continue
+		}
+		if i == battle.CurrentParticipantIndex {
+			return nil
+		}
+		battle.CurrentParticipantIndex = i
+		battle.UpdatedAt = time.Now()
+		update := map[string]interface{}{
+			"current_participant_index": battle.CurrentParticipantIndex,
+			"updated_at":                battle.UpdatedAt,
+		}
+		if err := GetRepository().UpdateBattleFieldsIfVersion(ctx, battle.ID, battle.Version, update); err != nil {
+			return fmt.Errorf("failed to update turn order: %w", err)
+		}
+		battle.Version++
+		return nil
+	}
+	return nil
+}
+
+// rosterWith builds the team composition of a battle's active participants plus a newcomer, for validation
+func rosterWith(participants []*BattleParticipant, newcomer *dto.ParticipantInfo) dto.StartBattleCommand {
+	var cmd dto.StartBattleCommand
+	for _, p := range participants {
+		if !p.IsAlive {
+			continue
+		}
+		info := dto.ParticipantInfo{
+			ParticipantID: p.ParticipantID,
+			Name:          p.Name,
+			Type:          string(p.Type),
+			Side:          string(p.Side),
+		}
+		if p.Side == TeamSideLight {
+			cmd.LightParticipants = append(cmd.LightParticipants, info)
+		} else {
+			cmd.DarkParticipants = append(cmd.DarkParticipants, info)
+		}
+	}
+	if newcomer.Side == string(TeamSideLight) {
+		cmd.LightParticipants = append(cmd.LightParticipants, *newcomer)
+	} else {
+		cmd.DarkParticipants = append(cmd.DarkParticipants, *newcomer)
+	}
+	return cmd
+}
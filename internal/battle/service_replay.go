@@ -0,0 +1,325 @@
+package battle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	pbBattleSpell "network-sec-micro/api/proto/battlespell"
+	"network-sec-micro/internal/battle/dto"
+	"network-sec-micro/pkg/element"
+)
+
+// SpellModifier is a lasting spell effect applied to a participant
+type SpellModifier struct {
+	SpellType  string
+	Effect     string
+	StackCount int
+	CastAt     time.Time
+}
+
+// ReplayState is a participant rebuilt at a given turn
+type ReplayState struct {
+	Participant    *BattleParticipant
+	HP             int
+	MaxHP          int
+	IsAlive        bool
+	SpellModifiers []SpellModifier
+}
+
+// ReplayMismatch flags a stored turn value that differs from the re-run
+type ReplayMismatch struct {
+	TurnNumber    int
+	ParticipantID string
+	Field         string
+	Stored        int
+	Expected      int
+}
+
+// BattleReplay is the state of a battle rebuilt at a given turn
+type BattleReplay struct {
+	BattleID     string
+	Turn         int
+	LastTurn     int
+	Participants []*ReplayState
+	ActiveSpells []SpellModifier
+	Verified     bool
+	Mismatches   []ReplayMismatch
+}
+
+// spell effects as shown in replays
+var spellEffects = map[string]string{
+	"call_of_the_light_king": "attack_x2",
+	"resistance":             "defense_x2",
+	"destroy_the_light":      "attack_defense_x0.7",
+	"dragon_emperor":         "dark_emperor_stats",
+	"wraith_of_dragon":       "wraith_on_dragon_kill",
+}
+
+// ReplayBattle rebuilds every participant's HP, alive flag and active spell modifiers at a turn.
+// State at turn N is the state after turn N was played and before turn N+1, so spells cast
+// between the two turns are included. With Verify set, the fight is re-run from the first turn:
+// every attack is rolled again from the battle seed and any stored damage, crit or HP that differs
+// from the re-run is reported.
+func (s *Service) ReplayBattle(query dto.ReplayBattleQuery) (*BattleReplay, error) {
+	ctx := context.Background()
+	repo := GetRepository()
+
+	battle, err := repo.GetBattleByID(ctx, query.BattleID)
+	if err != nil {
+		return nil, errors.New("battle not found")
+	}
+
+	participants, err := repo.FindParticipants(ctx, battle.ID, "all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	turns, err := repo.ListTurns(ctx, battle.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get turns: %w", err)
+	}
+
+	lastTurn := 0
+	if len(turns) > 0 {
+		lastTurn = turns[len(turns)-1].TurnNumber
+	}
+	upto := query.Turn
+	if upto < 0 {
+		upto = lastTurn
+	}
+	if upto > lastTurn {
+		return nil, fmt.Errorf("turn %d is beyond the last played turn %d", upto, lastTurn)
+	}
+
+	// Spell records live in the battlespell service; replay HP/alive state even if it is unavailable
+	spells, err := ListBattleSpells(ctx, battle.ID)
+	if err != nil {
+		log.Printf("Warning: replay of battle %s without spell records: %v", battle.ID, err)
+		spells = nil
+	}
+
+	replay := &BattleReplay{
+		BattleID: battle.ID,
+		Turn:     upto,
+		LastTurn: lastTurn,
+	}
+
+	states, active := replayWalk(battle, participants, turns, spells, upto, false, nil)
+	for _, p := range participants {
+		replay.Participants = append(replay.Participants, states[p.ParticipantID])
+	}
+	replay.ActiveSpells = active
+
+	if query.Verify {
+		replay.Verified = true
+		replayWalk(battle, participants, turns, spells, upto, true, func(m ReplayMismatch) {
+			replay.Mismatches = append(replay.Mismatches, m)
+		})
+	}
+
+	return replay, nil
+}
+
+// replayWalk plays turns up to upto on fresh state. When recompute is set, every attack's damage is
+// re-rolled and HP derived from it instead of the stored values, and every difference is passed to
+// onMismatch.
+func replayWalk(battle *Battle, participants []*BattleParticipant, turns []*BattleTurn, spells []*pbBattleSpell.Spell, upto int, recompute bool, onMismatch func(ReplayMismatch)) (map[string]*ReplayState, []SpellModifier) {
+	states := initialReplayStates(participants, turns, spells)
+	affected := make(map[int][]string, len(spells)) // spell index -> participant IDs
+	nextSpell := 0
+
+	applySpellsBefore := func(cutoff *time.Time) {
+		for nextSpell < len(spells) {
+			sp := spells[nextSpell]
+			if cutoff != nil && !sp.CastAt.AsTime().Before(*cutoff) {
+				return
+			}
+			affected[nextSpell] = applyReplaySpell(states, sp)
+			nextSpell++
+		}
+	}
+
+	// Spells cast before the next unplayed turn belong to the replayed state
+	var at *time.Time
+	for _, t := range turns {
+		if t.TurnNumber > upto {
+			cutoff := t.CreatedAt
+			at = &cutoff
+			break
+		}
+	}
+
+	for _, t := range turns {
+		if t.TurnNumber > upto {
+			break
+		}
+		cutoff := t.CreatedAt
+		applySpellsBefore(&cutoff)
+
+		st, ok := states[t.TargetID]
+		if !ok {
+			continue
+		}
+		if !recompute {
+			st.HP = t.TargetHPAfter
+			st.IsAlive = !t.TargetDefeated && t.TargetHPAfter > 0
+			continue
+		}
+
+		if t.TargetHPBefore != st.HP {
+			onMismatch(ReplayMismatch{TurnNumber: t.TurnNumber, ParticipantID: t.TargetID, Field: "target_hp_before", Stored: t.TargetHPBefore, Expected: st.HP})
+		}
+		damage := t.DamageDealt
+		if battle.Seed != 0 && t.EffectType == "" {
+			damage = verifyHit(battle.Seed, t, st, onMismatch)
+		}
+		expected := st.HP - damage + t.HealingDone
+		if expected < 0 {
+			expected = 0
+		}
+		if expected > st.MaxHP {
+			expected = st.MaxHP
+		}
+		if t.TargetHPAfter != expected {
+			onMismatch(ReplayMismatch{TurnNumber: t.TurnNumber, ParticipantID: t.TargetID, Field: "target_hp_after", Stored: t.TargetHPAfter, Expected: expected})
+		}
+		st.HP = expected
+		st.IsAlive = expected > 0
+	}
+	applySpellsBefore(at)
+
+	// Attach lasting spells that are still active at the replayed point
+	var active []SpellModifier
+	for i := 0; i < nextSpell; i++ {
+		sp := spells[i]
+		effect, lasting := spellEffects[sp.SpellType]
+		if !lasting {
+			continue
+		}
+		if !sp.IsActive && (at == nil || sp.UpdatedAt.AsTime().Before(*at)) {
+			continue // expired before this point
+		}
+		mod := SpellModifier{
+			SpellType:  sp.SpellType,
+			Effect:     effect,
+			StackCount: int(sp.StackCount),
+			CastAt:     sp.CastAt.AsTime(),
+		}
+		active = append(active, mod)
+		for _, pid := range affected[i] {
+			states[pid].SpellModifiers = append(states[pid].SpellModifiers, mod)
+		}
+	}
+
+	return states, active
+}
+
+// verifyHit re-rolls an attack from the battle seed and the turn's recorded inputs, reports every
+// stored value that differs from the roll and returns the damage the roll dealt. Turns recorded
+// before their inputs were kept only have their crit roll checked.
+func verifyHit(seed int64, t *BattleTurn, target *ReplayState, onMismatch func(ReplayMismatch)) int {
+	check := func(field string, stored, expected int) {
+		if stored != expected {
+			onMismatch(ReplayMismatch{TurnNumber: t.TurnNumber, ParticipantID: t.TargetID, Field: field, Stored: stored, Expected: expected})
+		}
+	}
+
+	rng := TurnRNG(seed, t.TurnNumber)
+	if t.AttackPower == 0 {
+		// Same roll order as rollTeamHit: damage factor, then crit
+		_ = rng.Float64()
+		check("critical_hit", boolToInt(t.CriticalHit), boolToInt(rng.Float64() < 0.1))
+		return t.DamageDealt
+	}
+
+	hit := rollTeamHit(rng, t.AttackPower, t.TargetDefense, element.Element(t.Element), element.Element(target.Participant.Element), t.Resistance)
+	check("critical_hit", boolToInt(t.CriticalHit), boolToInt(hit.critical))
+	check("damage_dealt", t.DamageDealt, hit.damage)
+	check("damage_resisted", t.DamageResisted, hit.resisted)
+	return hit.damage
+}
+
+// initialReplayStates builds the state of every participant before the first turn
+func initialReplayStates(participants []*BattleParticipant, turns []*BattleTurn, spells []*pbBattleSpell.Spell) map[string]*ReplayState {
+	byID := make(map[string]*BattleParticipant, len(participants))
+	for _, p := range participants {
+		byID[p.ParticipantID] = p
+	}
+
+	// Dragon Emperor permanently raises a dragon's max HP; undo it to get the starting value
+	maxHPBonus := make(map[string]int)
+	for _, sp := range spells {
+		if sp.SpellType != "dragon_emperor" {
+			continue
+		}
+		if emperor, ok := byID[sp.TargetDarkEmperorId]; ok {
+			maxHPBonus[sp.TargetDragonId] += emperor.MaxHP
+		}
+	}
+
+	states := make(map[string]*ReplayState, len(participants))
+	for _, p := range participants {
+		st := &ReplayState{
+			Participant: p,
+			MaxHP:       p.MaxHP - maxHPBonus[p.ParticipantID],
+			HP:          p.HP,
+			IsAlive:     true,
+		}
+		if maxHPBonus[p.ParticipantID] > 0 {
+			st.HP = st.MaxHP
+		}
+		// The first hit taken records the HP the participant entered the battle with
+		for _, t := range turns {
+			if t.TargetID == p.ParticipantID {
+				if maxHPBonus[p.ParticipantID] == 0 {
+					st.HP = t.TargetHPBefore
+				}
+				break
+			}
+		}
+		states[p.ParticipantID] = st
+	}
+	return states
+}
+
+// applyReplaySpell applies a spell's effect on replay state and returns the affected participants
+func applyReplaySpell(states map[string]*ReplayState, sp *pbBattleSpell.Spell) []string {
+	var ids []string
+	switch sp.SpellType {
+	case "call_of_the_light_king", "resistance", "destroy_the_light":
+		for id, st := range states {
+			if st.Participant.Side == TeamSideLight && st.Participant.Type == ParticipantTypeWarrior && st.IsAlive {
+				ids = append(ids, id)
+			}
+		}
+	case "rebirth":
+		for _, st := range states {
+			if st.Participant.Side == TeamSideLight && st.Participant.Type == ParticipantTypeWarrior && !st.IsAlive {
+				st.HP = st.MaxHP
+				st.IsAlive = true
+			}
+		}
+	case "dragon_emperor":
+		dragon, ok := states[sp.TargetDragonId]
+		emperor, ok2 := states[sp.TargetDarkEmperorId]
+		if ok && ok2 {
+			dragon.MaxHP += emperor.MaxHP
+			dragon.HP += emperor.MaxHP
+			if dragon.HP > dragon.MaxHP {
+				dragon.HP = dragon.MaxHP
+			}
+			ids = append(ids, sp.TargetDragonId)
+		}
+	}
+	return ids
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
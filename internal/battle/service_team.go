@@ -10,7 +10,6 @@ import (
     "network-sec-micro/internal/battle/dto"
     "go.mongodb.org/mongo-driver/bson"
     "go.mongodb.org/mongo-driver/bson/primitive"
-    "go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // StartBattle creates and starts a new team-based battle
@@ -29,6 +28,9 @@ func (s *Service) StartBattle(cmd dto.StartBattleCommand) (*Battle, []*BattlePar
 	if err := ValidateBattleParticipants(cmd); err != nil {
 		return nil, nil, fmt.Errorf("validation failed: %w", err)
 	}
+	if err := validateWager(cmd); err != nil {
+		return nil, nil, err
+	}
 
 	// Check if any warrior participants are currently healing
 	for _, p := range cmd.LightParticipants {
@@ -53,6 +55,12 @@ func (s *Service) StartBattle(cmd dto.StartBattleCommand) (*Battle, []*BattlePar
 		}
 	}
 
+	// Initiative comes from each participant's own record
+	speeds, err := participantSpeeds(ctx, append(append([]dto.ParticipantInfo{}, cmd.LightParticipants...), cmd.DarkParticipants...))
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Set defaults
 	lightSideName := cmd.LightSideName
 	if lightSideName == "" {
@@ -68,6 +76,22 @@ func (s *Service) StartBattle(cmd dto.StartBattleCommand) (*Battle, []*BattlePar
 		maxTurns = 100 // Default for team battles
 	}
 
+	turnOrder, err := ParseTurnOrder(cmd.TurnOrder)
+	if err != nil {
+		return nil, nil, err
+	}
+	timeoutAction, err := ParseTimeoutAction(cmd.TimeoutAction)
+	if err != nil {
+		return nil, nil, err
+	}
+	turnTimeout, maxDuration := turnTimeoutDefaults()
+	if cmd.TurnTimeoutSeconds > 0 {
+		turnTimeout = cmd.TurnTimeoutSeconds
+	}
+	if cmd.MaxDurationMinutes > 0 {
+		maxDuration = time.Duration(cmd.MaxDurationMinutes) * time.Minute
+	}
+
 	// Create battle
 	now := time.Now()
 	battle := &Battle{
@@ -77,7 +101,11 @@ func (s *Service) StartBattle(cmd dto.StartBattleCommand) (*Battle, []*BattlePar
 		CurrentTurn:           0,
 		CurrentParticipantIndex: 0,
 		MaxTurns:              maxTurns,
+		TurnOrder:             turnOrder,
+		TurnTimeoutSeconds:    turnTimeout,
+		TimeoutAction:         timeoutAction,
         Status:                BattleStatusPending,
+		Seed:                  NewBattleSeed(),
 		CreatedBy:             cmd.CreatedBy,
 		CreatedAt:             now,
 		UpdatedAt:             now,
@@ -105,6 +133,9 @@ func (s *Service) StartBattle(cmd dto.StartBattleCommand) (*Battle, []*BattlePar
 			MaxHP:        pInfo.MaxHP,
 			AttackPower:  pInfo.AttackPower,
 			Defense:      pInfo.Defense,
+			Speed:        speeds[pInfo.ParticipantID],
+			Element:      pInfo.Element,
+			Level:        pInfo.Level,
 			IsAlive:      true,
 			IsDefeated:   false,
 			CreatedAt:    now,
@@ -138,6 +169,9 @@ func (s *Service) StartBattle(cmd dto.StartBattleCommand) (*Battle, []*BattlePar
 			MaxHP:        pInfo.MaxHP,
 			AttackPower:  pInfo.AttackPower,
 			Defense:      pInfo.Defense,
+			Speed:        speeds[pInfo.ParticipantID],
+			Element:      pInfo.Element,
+			Level:        pInfo.Level,
 			IsAlive:      true,
 			IsDefeated:   false,
 			CreatedAt:    now,
@@ -166,15 +200,22 @@ func (s *Service) StartBattle(cmd dto.StartBattleCommand) (*Battle, []*BattlePar
         }
     }
 
-    // Start the battle if no emperor approval required or wager is zero
-    if !(cmd.RequireEmperorApproval && battle.WagerAmount > 0) {
+    // A wagered battle waits until both emperors have approved and their stakes are in escrow
+    if battle.WagerAmount <= 0 {
         battle.Status = BattleStatusInProgress
         battle.StartedAt = &now
+        battle.TurnDeadline = battle.nextTurnDeadline(now)
+        if maxDuration > 0 {
+            expiresAt := now.Add(maxDuration).UTC()
+            battle.ExpiresAt = &expiresAt
+        }
     }
 
     updateData := map[string]interface{}{
         "status":     battle.Status,
         "started_at": battle.StartedAt,
+        "turn_deadline": battle.TurnDeadline,
+        "expires_at": battle.ExpiresAt,
         "updated_at": time.Now(),
         "wager_amount": battle.WagerAmount,
         "light_emperor_id": battle.LightEmperorID,
@@ -201,40 +242,102 @@ func (s *Service) StartBattle(cmd dto.StartBattleCommand) (*Battle, []*BattlePar
             "",
             "",
         )
+
+        // With speed order a server-controlled participant may open the fight
+        battle = s.playAutomatedTurns(ctx, battle)
+        if battle.CurrentTurn > 0 {
+            if refreshed, err := GetRepository().FindParticipants(ctx, battle.ID, "all"); err == nil {
+                participants = refreshed
+            }
+        }
     }
 
 	return battle, participants, nil
 }
 
-// GetNextParticipant gets the next alive participant in turn order
+// GetNextParticipant gets the participant whose turn it is, skipping dead participants.
+// The returned index is the participant's position in the battle's turn queue.
 func (s *Service) GetNextParticipant(ctx context.Context, battle *Battle) (*BattleParticipant, int, error) {
-	// Get all alive participants ordered by creation (turn order)
-	filter := bson.M{
-		"battle_id": battle.ID,
-		"is_alive":  true,
-	}
-
-	opts := options.Find().SetSort(bson.M{"created_at": 1}) // Oldest first = turn order
-	cursor, err := BattleParticipantColl.Find(ctx, filter, opts)
+	participants, err := GetRepository().FindParticipants(ctx, battle.ID, "all")
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to find participants: %w", err)
 	}
-	defer cursor.Close(ctx)
-
-	var participants []BattleParticipant
-	if err := cursor.All(ctx, &participants); err != nil {
-		return nil, 0, fmt.Errorf("failed to decode participants: %w", err)
-	}
 
-	if len(participants) == 0 {
+	queue := BuildTurnQueue(participants, battle.TurnOrder)
+	participant, index, ok := NextInTurnQueue(queue, battle.CurrentParticipantIndex)
+	if !ok {
 		return nil, 0, errors.New("no alive participants found")
 	}
 
-	// Get participant at current index (with wrap-around)
-	index := battle.CurrentParticipantIndex % len(participants)
-	participant := participants[index]
+	return participant, index, nil
+}
+
+// playAutomatedTurns lets server-controlled participants (see StrategyFor) act while it is their turn,
+// and plays out the turns of stunned participants. It stops at a player's turn, when the battle ends
+// or on the first error.
+func (s *Service) playAutomatedTurns(ctx context.Context, battle *Battle) *Battle {
+	for battle.Status == BattleStatusInProgress && battle.CurrentTurn < battle.MaxTurns {
+		next, nextIndex, err := s.GetNextParticipant(ctx, battle)
+		if err != nil {
+			log.Printf("Automated turn skipped for battle %s: %v", battle.ID, err)
+			return battle
+		}
+		if next.HasStatusEffect(StatusEffectStun) {
+			updated, err := s.playStunnedTurn(ctx, battle, next, nextIndex)
+			if err != nil {
+				log.Printf("Stunned turn of %s failed in battle %s: %v", next.Name, battle.ID, err)
+				return battle
+			}
+			battle = updated
+			continue
+		}
+		strategy, automated := StrategyFor(next.Type)
+		if !automated {
+			return battle
+		}
+
+		opponents, err := aliveOpponents(ctx, battle.ID, next)
+		if err != nil {
+			log.Printf("Automated turn skipped for battle %s: %v", battle.ID, err)
+			return battle
+		}
+
+		target := strategy.ChooseTarget(next, opponents, targetTurnRNG(battle.Seed, battle.CurrentTurn+1))
+		if target == nil {
+			return battle
+		}
 
-	return &participant, index, nil
+		updated, _, err := s.performTeamBattleAttack(ctx, battle, dto.AttackCommand{
+			BattleID:   battle.ID,
+			AttackerID: next.ParticipantID,
+			TargetID:   target.ParticipantID,
+		})
+		if err != nil {
+			log.Printf("Automated turn by %s (%s) failed in battle %s: %v", next.Name, strategy.Name(), battle.ID, err)
+			return battle
+		}
+		battle = updated
+	}
+	return battle
+}
+
+// aliveOpponents returns the living participants on the other side from actor
+func aliveOpponents(ctx context.Context, battleID string, actor *BattleParticipant) ([]*BattleParticipant, error) {
+	opponentSide := TeamSideLight
+	if actor.Side == TeamSideLight {
+		opponentSide = TeamSideDark
+	}
+	candidates, err := GetRepository().FindParticipants(ctx, battleID, string(opponentSide))
+	if err != nil {
+		return nil, err
+	}
+	opponents := make([]*BattleParticipant, 0, len(candidates))
+	for _, p := range candidates {
+		if p.IsAlive {
+			opponents = append(opponents, p)
+		}
+	}
+	return opponents, nil
 }
 
 // CheckTeamStatus checks if a team has any alive participants
@@ -0,0 +1,137 @@
+package battle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"network-sec-micro/internal/battle/dto"
+)
+
+// A wagered team battle stays pending until both emperors approve the wager. Approving locks the
+// emperor's stake in the coin service's escrow, and the battle starts once both stakes are held.
+// However the battle ends, resolveWager tells the coin service to pay the pot to the winner's emperor
+// or, without a winner, to refund both stakes. The coin service makes both steps idempotent by battle ID.
+
+// ErrNotWagerEmperor is returned when someone other than the two emperors tries to approve a wager
+var ErrNotWagerEmperor = errors.New("only the emperors party to the wager can approve it")
+
+// validateWager checks the wager of a new battle
+func validateWager(cmd dto.StartBattleCommand) error {
+	if cmd.WagerAmount < 0 {
+		return errors.New("wager amount cannot be negative")
+	}
+	if cmd.WagerAmount == 0 {
+		return nil
+	}
+	if cmd.LightEmperorID == "" || cmd.DarkEmperorID == "" {
+		return errors.New("a wager needs both a light and a dark emperor")
+	}
+	if cmd.LightEmperorID == cmd.DarkEmperorID {
+		return errors.New("an emperor cannot wager against themselves")
+	}
+	for _, id := range []string{cmd.LightEmperorID, cmd.DarkEmperorID} {
+		if _, err := strconv.ParseUint(id, 10, 32); err != nil {
+			return fmt.Errorf("invalid emperor ID %q", id)
+		}
+	}
+	return nil
+}
+
+// ApproveWager locks an emperor's stake in escrow and records their approval. Approving again does
+// not take the stake twice. The battle starts once both emperors have approved.
+func (s *Service) ApproveWager(cmd dto.ApproveWagerCommand) (*Battle, error) {
+	ctx := context.Background()
+
+	battle, err := GetRepository().GetBattleByID(ctx, cmd.BattleID)
+	if err != nil {
+		return nil, errors.New("battle not found")
+	}
+	if battle.WagerAmount <= 0 {
+		return nil, errors.New("battle has no wager")
+	}
+
+	var side TeamSide
+	switch cmd.EmperorID {
+	case battle.LightEmperorID:
+		side = TeamSideLight
+	case battle.DarkEmperorID:
+		side = TeamSideDark
+	default:
+		return nil, ErrNotWagerEmperor
+	}
+	if battle.Status != BattleStatusPending {
+		return nil, errors.New("the wager can only be approved before the battle starts")
+	}
+
+	if !battle.wagerApprovedBy(side) {
+		emperorID, err := strconv.ParseUint(cmd.EmperorID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid emperor ID %q", cmd.EmperorID)
+		}
+		if err := LockWagerStake(ctx, battle.ID, uint(emperorID), int64(battle.WagerAmount)); err != nil {
+			return nil, err
+		}
+		updateData := map[string]interface{}{
+			string(side) + "_emperor_approved": true,
+			"updated_at":                       time.Now(),
+		}
+		// Record the approval only while the battle is still pending; the status read above may be stale
+		approved, err := GetRepository().UpdateBattleFieldsIfStatus(ctx, battle.ID, BattleStatusPending, updateData)
+		if err != nil {
+			// The stake stays in escrow: approving again reuses it and a cancel refunds it
+			return nil, fmt.Errorf("failed to record wager approval: %w", err)
+		}
+		if !approved {
+			// A pending battle only stops being pending when it is cancelled, and resolving a
+			// cancelled battle's wager refunds every locked stake, including the one just taken
+			if current, err := GetRepository().GetBattleByID(ctx, battle.ID); err == nil {
+				resolveWager(current)
+			}
+			return nil, errors.New("the wager can only be approved before the battle starts; the stake is refunded")
+		}
+		log.Printf("Battle %s: %s emperor %s approved the wager of %d", battle.ID, side, cmd.EmperorID, battle.WagerAmount)
+	}
+
+	// Reload to see the other emperor's approval, or a cancel that raced this one
+	battle, err = GetRepository().GetBattleByID(ctx, battle.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload battle: %w", err)
+	}
+	switch {
+	case battle.Status == BattleStatusCancelled:
+		// The cancel may have resolved the wager before this stake was locked; resolving again refunds it
+		resolveWager(battle)
+		return nil, errors.New("battle was cancelled; the stake is refunded")
+	case battle.Status == BattleStatusPending && battle.LightEmperorApproved && battle.DarkEmperorApproved:
+		if _, err := s.startPendingBattle(ctx, battle); err != nil {
+			return nil, err
+		}
+		return s.loadBattle(ctx, battle.ID)
+	}
+	return battle, nil
+}
+
+// wagerApprovedBy checks if side's emperor has approved the wager
+func (b *Battle) wagerApprovedBy(side TeamSide) bool {
+	if side == TeamSideLight {
+		return b.LightEmperorApproved
+	}
+	return b.DarkEmperorApproved
+}
+
+// resolveWager tells the coin service how a wagered battle ended: the winner side's emperor takes the
+// pot and without a winner both stakes are refunded. Stakes that were never locked are not touched.
+func resolveWager(battle *Battle) {
+	if battle.WagerAmount <= 0 {
+		return
+	}
+	battleID, winnerSide, amount := battle.ID, battle.WinnerSide, battle.WagerAmount
+	lightEmperorID, darkEmperorID := battle.LightEmperorID, battle.DarkEmperorID
+	go func() {
+		_ = PublishBattleWagerResolved(battleID, winnerSide, amount, lightEmperorID, darkEmperorID)
+	}()
+}
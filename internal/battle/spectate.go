@@ -0,0 +1,56 @@
+package battle
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"network-sec-micro/pkg/spectate"
+)
+
+var (
+	spectatorHub     *spectate.Hub
+	spectatorHubOnce sync.Once
+)
+
+// GetSpectatorHub returns the hub sharing this replica's Redis subscription between spectators,
+// or nil when Redis is not available
+func GetSpectatorHub() *spectate.Hub {
+	if redisClient == nil {
+		return nil
+	}
+	spectatorHubOnce.Do(func() {
+		spectatorHub = spectate.NewHub(redisClient)
+	})
+	return spectatorHub
+}
+
+// CloseSpectatorHub disconnects all spectators
+func CloseSpectatorHub() {
+	if spectatorHub != nil {
+		_ = spectatorHub.Close()
+	}
+}
+
+// publishSpectatorEvent pushes an update to everyone watching the battle, on any replica.
+// It publishes synchronously so spectators see events in the order they happened.
+// Spectating is best effort: without Redis nothing is sent and failures are only logged.
+func publishSpectatorEvent(battleID string, eventType string, data interface{}) {
+	if redisClient == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := spectate.Publish(ctx, redisClient, spectate.BattleChannel(battleID), eventType, data); err != nil {
+		log.Printf("Failed to publish %s to spectators of battle %s: %v", eventType, battleID, err)
+	}
+}
+
+func publishSpectatorTurn(turn *BattleTurn) {
+	publishSpectatorEvent(turn.BattleID, spectate.EventTurn, ToBattleTurnResponse(turn))
+}
+
+func publishSpectatorCompleted(battle *Battle) {
+	publishSpectatorEvent(battle.ID, spectate.EventCompleted, ToBattleResponse(battle, nil, nil))
+}
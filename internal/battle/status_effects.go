@@ -0,0 +1,449 @@
+package battle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"network-sec-micro/internal/battle/dto"
+)
+
+// StatusEffectType identifies a status effect on a battle participant
+type StatusEffectType string
+
+const (
+	StatusEffectPoison  StatusEffectType = "poison"           // Damage over time
+	StatusEffectBurn    StatusEffectType = "burn"             // Damage over time
+	StatusEffectRegen   StatusEffectType = "regen"            // Heal over time, up to max HP
+	StatusEffectStun    StatusEffectType = "stun"             // Participant loses its turn
+	StatusEffectAttack  StatusEffectType = "attack_modifier"  // Percent change to attack power, applied at attack time
+	StatusEffectDefense StatusEffectType = "defense_modifier" // Percent change to defense, applied at attack time
+)
+
+// EffectTick is the point in the affected participant's turn at which an effect takes hold
+type EffectTick string
+
+const (
+	EffectTickTurnStart EffectTick = "turn_start"
+	EffectTickTurnEnd   EffectTick = "turn_end"
+)
+
+// EffectStacking decides what re-applying an active effect from the same source does
+type EffectStacking string
+
+const (
+	EffectStackingRefresh EffectStacking = "refresh" // New magnitude and duration replace the active ones
+	EffectStackingStack   EffectStacking = "stack"   // One more stack (up to MaxStacks), duration reset
+	EffectStackingIgnore  EffectStacking = "ignore"  // Active effect is kept as is
+)
+
+// StatusEffect is an effect attached to a participant for a number of its own turns.
+// Effects are identified by type and source: the same effect from two sources is tracked twice.
+type StatusEffect struct {
+	Type           StatusEffectType `bson:"type" json:"type"`
+	SourceID       string           `bson:"source_id,omitempty" json:"source_id,omitempty"` // Participant or spell that applied it
+	Magnitude      int              `bson:"magnitude" json:"magnitude"`                     // HP per tick, or percent for modifiers, per stack
+	RemainingTurns int              `bson:"remaining_turns" json:"remaining_turns"`         // Counted down at the end of each of the participant's turns
+	Stacks         int              `bson:"stacks" json:"stacks"`
+	MaxStacks      int              `bson:"max_stacks" json:"max_stacks"`
+	Stacking       EffectStacking   `bson:"stacking" json:"stacking"`
+	Tick           EffectTick       `bson:"tick" json:"tick"`
+	AppliedTurn    int              `bson:"applied_turn" json:"applied_turn"` // Battle turn the effect was (last) applied on
+}
+
+// statusEffectDefaults are the tick and stacking behaviour of each effect type unless the caller overrides them
+var statusEffectDefaults = map[StatusEffectType]StatusEffect{
+	StatusEffectPoison:  {Tick: EffectTickTurnStart, Stacking: EffectStackingStack, MaxStacks: 5},
+	StatusEffectBurn:    {Tick: EffectTickTurnEnd, Stacking: EffectStackingRefresh, MaxStacks: 1},
+	StatusEffectRegen:   {Tick: EffectTickTurnStart, Stacking: EffectStackingRefresh, MaxStacks: 1},
+	StatusEffectStun:    {Tick: EffectTickTurnStart, Stacking: EffectStackingIgnore, MaxStacks: 1},
+	StatusEffectAttack:  {Tick: EffectTickTurnStart, Stacking: EffectStackingStack, MaxStacks: 3},
+	StatusEffectDefense: {Tick: EffectTickTurnStart, Stacking: EffectStackingStack, MaxStacks: 3},
+}
+
+// NewStatusEffect builds an effect with the type's default tick and stacking; empty tick/stacking
+// and a zero maxStacks keep the defaults. Stun always takes hold at the start of the turn.
+func NewStatusEffect(effectType string, magnitude, turns int, sourceID string, tick string, stacking string, maxStacks int) (StatusEffect, error) {
+	e, ok := statusEffectDefaults[StatusEffectType(effectType)]
+	if !ok {
+		return StatusEffect{}, fmt.Errorf("invalid status effect %q (use poison, burn, regen, stun, attack_modifier or defense_modifier)", effectType)
+	}
+	if turns <= 0 {
+		return StatusEffect{}, errors.New("status effect duration must be at least one turn")
+	}
+	e.Type = StatusEffectType(effectType)
+	e.SourceID = sourceID
+	e.Magnitude = magnitude
+	e.RemainingTurns = turns
+	e.Stacks = 1
+
+	switch EffectTick(tick) {
+	case "":
+	case EffectTickTurnStart, EffectTickTurnEnd:
+		if e.Type != StatusEffectStun {
+			e.Tick = EffectTick(tick)
+		}
+	default:
+		return StatusEffect{}, fmt.Errorf("invalid effect tick %q (use turn_start or turn_end)", tick)
+	}
+
+	switch EffectStacking(stacking) {
+	case "":
+	case EffectStackingRefresh, EffectStackingStack, EffectStackingIgnore:
+		e.Stacking = EffectStacking(stacking)
+	default:
+		return StatusEffect{}, fmt.Errorf("invalid effect stacking %q (use refresh, stack or ignore)", stacking)
+	}
+
+	if maxStacks > 0 {
+		e.MaxStacks = maxStacks
+	}
+	return e, nil
+}
+
+// AddStatusEffect applies e to a participant's effects following e's stacking rule
+func AddStatusEffect(effects []StatusEffect, e StatusEffect) []StatusEffect {
+	out := append([]StatusEffect(nil), effects...)
+	for i := range out {
+		active := &out[i]
+		if active.Type != e.Type || active.SourceID != e.SourceID {
+			continue
+		}
+		switch e.Stacking {
+		case EffectStackingIgnore:
+		case EffectStackingStack:
+			if active.Stacks < e.MaxStacks {
+				active.Stacks++
+			}
+			active.Magnitude = e.Magnitude
+			active.RemainingTurns = e.RemainingTurns
+			active.AppliedTurn = e.AppliedTurn
+		default:
+			stacks := active.Stacks
+			*active = e
+			active.Stacks = stacks
+		}
+		return out
+	}
+	return append(out, e)
+}
+
+// countDownStatusEffects spends one turn of every effect and drops the ones that ran out
+func countDownStatusEffects(effects []StatusEffect) []StatusEffect {
+	var out []StatusEffect
+	for _, e := range effects {
+		e.RemainingTurns--
+		if e.RemainingTurns > 0 {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// HasStatusEffect reports whether the participant is under an effect of the given type
+func (p *BattleParticipant) HasStatusEffect(effectType StatusEffectType) bool {
+	for _, e := range p.StatusEffects {
+		if e.Type == effectType {
+			return true
+		}
+	}
+	return false
+}
+
+// ModifiedAttack applies the participant's attack modifiers to base (attack power plus weapon bonus)
+func (p *BattleParticipant) ModifiedAttack(base int) int {
+	return p.modifiedStat(StatusEffectAttack, base)
+}
+
+// ModifiedDefense applies the participant's defense modifiers to base (defense plus armor bonus)
+func (p *BattleParticipant) ModifiedDefense(base int) int {
+	return p.modifiedStat(StatusEffectDefense, base)
+}
+
+func (p *BattleParticipant) modifiedStat(modifier StatusEffectType, base int) int {
+	percent := 100
+	for _, e := range p.StatusEffects {
+		if e.Type == modifier {
+			percent += e.Magnitude * e.Stacks
+		}
+	}
+	if percent < 0 {
+		percent = 0
+	}
+	return base * percent / 100
+}
+
+// encodeStatusEffects serialises effects for the status_effects column
+func encodeStatusEffects(effects []StatusEffect) string {
+	if len(effects) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(effects)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeStatusEffects reads the status_effects column
+func decodeStatusEffects(data string) []StatusEffect {
+	if data == "" {
+		return nil
+	}
+	var effects []StatusEffect
+	if err := json.Unmarshal([]byte(data), &effects); err != nil {
+		log.Printf("Warning: ignoring unreadable status effects: %v", err)
+		return nil
+	}
+	return effects
+}
+
+// ApplyStatusEffect attaches a status effect to a living participant of an in-progress team battle
+func (s *Service) ApplyStatusEffect(cmd dto.ApplyStatusEffectCommand) (*BattleParticipant, error) {
+	ctx := context.Background()
+
+	battle, err := GetRepository().GetBattleByID(ctx, cmd.BattleID)
+	if err != nil {
+		return nil, errors.New("battle not found")
+	}
+	if battle.BattleType != BattleTypeTeam || battle.Status != BattleStatusInProgress {
+		return nil, errBattleNotInProgress
+	}
+
+	effect, err := NewStatusEffect(cmd.Type, cmd.Magnitude, cmd.DurationTurns, cmd.SourceID, cmd.Tick, cmd.Stacking, cmd.MaxStacks)
+	if err != nil {
+		return nil, err
+	}
+	effect.AppliedTurn = battle.CurrentTurn
+
+	participant, err := GetRepository().GetParticipantByIDs(ctx, battle.ID, cmd.ParticipantID)
+	if err != nil {
+		return nil, errors.New("participant not found or not alive")
+	}
+
+	for attempt := 0; ; attempt++ {
+		effects := AddStatusEffect(participant.StatusEffects, effect)
+		now := time.Now()
+		update := map[string]interface{}{
+			"status_effects": encodeStatusEffects(effects),
+			"updated_at":     now,
+		}
+		err := GetRepository().UpdateParticipantIfVersion(ctx, battle.ID, participant.ParticipantID, participant.Version, update)
+		if err == nil {
+			participant.StatusEffects = effects
+			participant.Version++
+			participant.UpdatedAt = now
+			break
+		}
+		if !errors.Is(err, ErrVersionConflict) || attempt >= maxVersionRetries {
+			return nil, fmt.Errorf("failed to apply status effect: %w", err)
+		}
+		participant, err = GetRepository().GetParticipantByIDs(ctx, battle.ID, cmd.ParticipantID)
+		if err != nil {
+			return nil, errors.New("participant not found or not alive")
+		}
+	}
+
+	go func() {
+		message := fmt.Sprintf("%s is affected by %s for %d turn(s)", participant.Name, effect.Type, effect.RemainingTurns)
+		if err := LogParticipantEvent(context.Background(), battle, participant, "effect_applied", message); err != nil {
+			log.Printf("Failed to log status effect: %v", err)
+		}
+	}()
+
+	// A participant stunned on its own turn loses it right away
+	if effect.Type == StatusEffectStun {
+		s.playAutomatedTurns(ctx, battle)
+	}
+
+	return participant, nil
+}
+
+// runEffectPhase lets the effects of p that tick in phase take hold: damage and healing over time,
+// and stun at the start of the turn. Every tick is recorded through repo as a BattleTurn on the battle's
+// current turn number; callers publish the ticks with publishEffectTicks once the turn is committed.
+// At turn end, effect durations are counted down.
+func (s *Service) runEffectPhase(ctx context.Context, repo Repository, battle *Battle, p *BattleParticipant, phase EffectTick) (*BattleParticipant, []*BattleTurn, error) {
+	for attempt := 0; ; attempt++ {
+		if len(p.StatusEffects) == 0 {
+			return p, nil, nil
+		}
+
+		hp := p.HP
+		var ticks []*BattleTurn
+		for _, e := range p.StatusEffects {
+			if e.Tick != phase || hp <= 0 {
+				continue
+			}
+			amount := e.Magnitude * e.Stacks
+			turn := effectTurn(battle, p, e.Type, hp)
+			switch e.Type {
+			case StatusEffectPoison, StatusEffectBurn:
+				hp -= amount
+				if hp < 0 {
+					hp = 0
+				}
+				turn.DamageDealt = amount
+			case StatusEffectRegen:
+				hp += amount
+				if hp > p.MaxHP {
+					hp = p.MaxHP
+				}
+				turn.HealingDone = amount
+			case StatusEffectStun:
+			default:
+				continue // modifiers act at attack time
+			}
+			turn.TargetHPAfter = hp
+			turn.TargetDefeated = hp <= 0
+			ticks = append(ticks, turn)
+		}
+
+		effects := p.StatusEffects
+		if phase == EffectTickTurnEnd {
+			effects = countDownStatusEffects(effects)
+		}
+		if len(ticks) == 0 && phase == EffectTickTurnStart {
+			return p, nil, nil
+		}
+
+		now := time.Now()
+		update := map[string]interface{}{
+			"hp":             hp,
+			"status_effects": encodeStatusEffects(effects),
+			"updated_at":     now,
+		}
+		if hp <= 0 {
+			update["is_alive"] = false
+			update["is_defeated"] = true
+			update["defeated_at"] = &now
+		}
+
+		err := repo.UpdateParticipantIfVersion(ctx, battle.ID, p.ParticipantID, p.Version, update)
+		if err == nil {
+			p.HP = hp
+			p.StatusEffects = effects
+			p.Version++
+			p.UpdatedAt = now
+			if hp <= 0 {
+				p.IsAlive = false
+				p.IsDefeated = true
+				p.DefeatedAt = &now
+			}
+			for _, t := range ticks {
+				t.CreatedAt = now
+				if err := repo.InsertTurn(ctx, t); err != nil {
+					return nil, nil, fmt.Errorf("failed to record status effect: %w", err)
+				}
+			}
+			return p, ticks, nil
+		}
+		if !errors.Is(err, ErrVersionConflict) || attempt >= maxVersionRetries {
+			return nil, nil, err
+		}
+
+		p, err = repo.GetParticipantByIDs(ctx, battle.ID, p.ParticipantID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("participant is no longer alive: %w", err)
+		}
+	}
+}
+
+// playStunnedTurn spends a stunned participant's turn: its effects tick as usual but it does not act.
+// The turn is claimed and its ticks recorded in one transaction, so a failed write leaves the turn unplayed.
+func (s *Service) playStunnedTurn(ctx context.Context, battle *Battle, p *BattleParticipant, index int) (*Battle, error) {
+	claimed := *battle
+	claimed.CurrentTurn++
+	claimed.CurrentParticipantIndex = index + 1
+	claimed.UpdatedAt = time.Now()
+	claimed.TurnDeadline = claimed.nextTurnDeadline(claimed.UpdatedAt)
+	updateData := map[string]interface{}{
+		"current_turn":              claimed.CurrentTurn,
+		"current_participant_index": claimed.CurrentParticipantIndex,
+		"turn_deadline":             claimed.TurnDeadline,
+		"updated_at":                claimed.UpdatedAt,
+	}
+
+	var ticks []*BattleTurn
+	err := GetRepository().InTransaction(ctx, func(repo Repository) error {
+		if err := repo.UpdateBattleFieldsIfVersion(ctx, battle.ID, battle.Version, updateData); err != nil {
+			return err
+		}
+		p, startTicks, err := s.runEffectPhase(ctx, repo, &claimed, p, EffectTickTurnStart)
+		if err != nil {
+			return fmt.Errorf("failed to apply status effects: %w", err)
+		}
+		ticks = startTicks
+		if p.IsAlive {
+			_, endTicks, err := s.runEffectPhase(ctx, repo, &claimed, p, EffectTickTurnEnd)
+			if err != nil {
+				return fmt.Errorf("failed to apply status effects: %w", err)
+			}
+			ticks = append(ticks, endTicks...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	claimed.Version++
+	*battle = claimed
+	publishEffectTicks(battle, ticks)
+
+	updated, _, err := s.finishTeamTurn(ctx, battle, nil)
+	return updated, err
+}
+
+// publishEffectTicks sends committed status effect ticks to spectators, Kafka and the battle's Redis log
+func publishEffectTicks(battle *Battle, ticks []*BattleTurn) {
+	for _, t := range ticks {
+		publishSpectatorTurn(t)
+		go PublishBattleTurnEvent(t)
+	}
+	logEffectTicks(battle, ticks)
+}
+
+// effectTurn starts the turn record of a status effect tick on p
+func effectTurn(battle *Battle, p *BattleParticipant, effectType StatusEffectType, hp int) *BattleTurn {
+	return &BattleTurn{
+		BattleID:       battle.ID,
+		TurnNumber:     battle.CurrentTurn,
+		AttackerID:     p.ParticipantID,
+		AttackerName:   p.Name,
+		AttackerType:   p.Type,
+		AttackerSide:   p.Side,
+		TargetID:       p.ParticipantID,
+		TargetName:     p.Name,
+		TargetType:     p.Type,
+		TargetSide:     p.Side,
+		EffectType:     effectType,
+		TargetHPBefore: hp,
+	}
+}
+
+// logEffectTicks writes status effect ticks to the battle's Redis log
+func logEffectTicks(battle *Battle, ticks []*BattleTurn) {
+	if len(ticks) == 0 {
+		return
+	}
+	go func() {
+		for _, t := range ticks {
+			var message string
+			switch {
+			case t.EffectType == StatusEffectStun:
+				message = fmt.Sprintf("%s is stunned and loses the turn", t.TargetName)
+			case t.HealingDone > 0:
+				message = fmt.Sprintf("%s regenerates %d HP", t.TargetName, t.HealingDone)
+			default:
+				message = fmt.Sprintf("%s takes %d %s damage", t.TargetName, t.DamageDealt, t.EffectType)
+			}
+			if err := LogStatusEffectTick(context.Background(), battle, t, message); err != nil {
+				log.Printf("Failed to log status effect tick: %v", err)
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,116 @@
+package battle
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Strategy chooses the target of a server-controlled participant on its turn
+type Strategy interface {
+	Name() string
+	ChooseTarget(actor *BattleParticipant, opponents []*BattleParticipant, rng *rand.Rand) *BattleParticipant
+}
+
+// LowestHPStrategy finishes off the weakest opponent
+type LowestHPStrategy struct{}
+
+func (LowestHPStrategy) Name() string { return "lowest_hp" }
+
+func (LowestHPStrategy) ChooseTarget(actor *BattleParticipant, opponents []*BattleParticipant, rng *rand.Rand) *BattleParticipant {
+	var target *BattleParticipant
+	for _, p := range opponents {
+		if target == nil || p.HP < target.HP {
+			target = p
+		}
+	}
+	return target
+}
+
+// HighestThreatStrategy goes after the opponent with the most attack power
+type HighestThreatStrategy struct{}
+
+func (HighestThreatStrategy) Name() string { return "highest_threat" }
+
+func (HighestThreatStrategy) ChooseTarget(actor *BattleParticipant, opponents []*BattleParticipant, rng *rand.Rand) *BattleParticipant {
+	var target *BattleParticipant
+	for _, p := range opponents {
+		if target == nil || p.AttackPower > target.AttackPower || (p.AttackPower == target.AttackPower && p.HP < target.HP) {
+			target = p
+		}
+	}
+	return target
+}
+
+// RandomStrategy picks any opponent using the battle's RNG stream
+type RandomStrategy struct{}
+
+func (RandomStrategy) Name() string { return "random" }
+
+func (RandomStrategy) ChooseTarget(actor *BattleParticipant, opponents []*BattleParticipant, rng *rand.Rand) *BattleParticipant {
+	if len(opponents) == 0 {
+		return nil
+	}
+	return opponents[rng.Intn(len(opponents))]
+}
+
+var (
+	strategyMu sync.RWMutex
+
+	// strategies holds every strategy that can be assigned by name
+	strategies = map[string]Strategy{
+		LowestHPStrategy{}.Name():      LowestHPStrategy{},
+		HighestThreatStrategy{}.Name(): HighestThreatStrategy{},
+		RandomStrategy{}.Name():        RandomStrategy{},
+	}
+
+	// typeStrategies maps server-controlled participant types to their strategy
+	typeStrategies = map[ParticipantType]Strategy{
+		ParticipantTypeEnemy:       LowestHPStrategy{},
+		ParticipantTypeDragon:      HighestThreatStrategy{},
+		ParticipantTypeDarkEmperor: HighestThreatStrategy{},
+	}
+)
+
+// RegisterStrategy makes a custom strategy available by name
+func RegisterStrategy(s Strategy) {
+	strategyMu.Lock()
+	defer strategyMu.Unlock()
+	strategies[s.Name()] = s
+}
+
+// SetParticipantStrategy sets the strategy used by a participant type (by registered name)
+func SetParticipantStrategy(pType ParticipantType, name string) error {
+	strategyMu.Lock()
+	defer strategyMu.Unlock()
+	s, ok := strategies[name]
+	if !ok {
+		return fmt.Errorf("unknown strategy %q", name)
+	}
+	typeStrategies[pType] = s
+	return nil
+}
+
+// StrategyFor returns the strategy for a participant type; false means the participant is player-controlled
+func StrategyFor(pType ParticipantType) (Strategy, bool) {
+	strategyMu.RLock()
+	defer strategyMu.RUnlock()
+	s, ok := typeStrategies[pType]
+	return s, ok
+}
+
+// LoadStrategiesFromEnv applies BATTLE_STRATEGY_ENEMY, BATTLE_STRATEGY_DRAGON and BATTLE_STRATEGY_DARK_EMPEROR
+func LoadStrategiesFromEnv() {
+	for _, pType := range []ParticipantType{ParticipantTypeEnemy, ParticipantTypeDragon, ParticipantTypeDarkEmperor} {
+		name := os.Getenv("BATTLE_STRATEGY_" + strings.ToUpper(string(pType)))
+		if name == "" {
+			continue
+		}
+		if err := SetParticipantStrategy(pType, name); err != nil {
+			log.Printf("Warning: %v for %s, keeping default", err, pType)
+		}
+	}
+}
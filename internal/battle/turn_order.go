@@ -0,0 +1,91 @@
+package battle
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// TurnOrder decides how participants of a team battle are queued
+type TurnOrder string
+
+const (
+	TurnOrderAlternating TurnOrder = "alternating" // Light and dark sides alternate, each side in join order
+	TurnOrderSpeed       TurnOrder = "speed"       // Highest speed acts first, ties in join order
+)
+
+// ErrNotYourTurn is returned when a participant acts out of turn
+var ErrNotYourTurn = errors.New("not your turn")
+
+// ParseTurnOrder returns the turn order for a request value (alternating by default)
+func ParseTurnOrder(v string) (TurnOrder, error) {
+	switch TurnOrder(v) {
+	case "", TurnOrderAlternating:
+		return TurnOrderAlternating, nil
+	case TurnOrderSpeed:
+		return TurnOrderSpeed, nil
+	default:
+		return "", fmt.Errorf("invalid turn order %q (use alternating or speed)", v)
+	}
+}
+
+// BuildTurnQueue returns every participant of a battle in initiative order.
+// Dead participants stay in the queue so indexes are stable; NextInTurnQueue skips them.
+func BuildTurnQueue(participants []*BattleParticipant, order TurnOrder) []*BattleParticipant {
+	joined := make([]*BattleParticipant, len(participants))
+	copy(joined, participants)
+	sort.SliceStable(joined, func(i, j int) bool { return joinedBefore(joined[i], joined[j]) })
+
+	if order == TurnOrderSpeed {
+		sort.SliceStable(joined, func(i, j int) bool { return joined[i].Speed > joined[j].Speed })
+		return joined
+	}
+
+	// Alternating: light, dark, light, dark ... leftovers of the larger side at the end
+	var light, dark []*BattleParticipant
+	for _, p := range joined {
+		if p.Side == TeamSideLight {
+			light = append(light, p)
+		} else {
+			dark = append(dark, p)
+		}
+	}
+	queue := make([]*BattleParticipant, 0, len(joined))
+	for i := 0; i < len(light) || i < len(dark); i++ {
+		if i < len(light) {
+			queue = append(queue, light[i])
+		}
+		if i < len(dark) {
+			queue = append(queue, dark[i])
+		}
+	}
+	return queue
+}
+
+// NextInTurnQueue returns the first alive participant at or after index, wrapping around
+func NextInTurnQueue(queue []*BattleParticipant, index int) (*BattleParticipant, int, bool) {
+	if len(queue) == 0 {
+		return nil, 0, false
+	}
+	if index < 0 {
+		index = 0
+	}
+	for i := 0; i < len(queue); i++ {
+		pos := (index + i) % len(queue)
+		if queue[pos].IsAlive {
+			return queue[pos], pos, true
+		}
+	}
+	return nil, 0, false
+}
+
+// joinedBefore orders participants by the time they joined, then by row ID
+func joinedBefore(a, b *BattleParticipant) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	var ai, bi uint64
+	fmt.Sscanf(a.ID, "%d", &ai)
+	fmt.Sscanf(b.ID, "%d", &bi)
+	return ai < bi
+}
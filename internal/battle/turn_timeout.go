@@ -0,0 +1,219 @@
+package battle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"network-sec-micro/internal/battle/dto"
+)
+
+// TimeoutAction decides what happens to a participant who lets the turn deadline pass
+type TimeoutAction string
+
+const (
+	TimeoutActionSkip   TimeoutAction = "skip"   // Turn passes to the next participant
+	TimeoutActionAttack TimeoutAction = "attack" // Participant makes a basic attack on the weakest opponent
+)
+
+const (
+	defaultTurnTimeoutSeconds = 60
+	defaultMaxDurationMinutes = 60
+	sweepBatchSize            = 50
+)
+
+// ParseTimeoutAction returns the timeout action for a request value (skip by default)
+func ParseTimeoutAction(v string) (TimeoutAction, error) {
+	switch TimeoutAction(v) {
+	case "", TimeoutActionSkip:
+		return TimeoutActionSkip, nil
+	case TimeoutActionAttack:
+		return TimeoutActionAttack, nil
+	default:
+		return "", fmt.Errorf("invalid timeout action %q (use skip or attack)", v)
+	}
+}
+
+// turnTimeoutDefaults returns the per-turn timeout and battle duration limit used when a request leaves them unset.
+// BATTLE_TURN_TIMEOUT_SECONDS and BATTLE_MAX_DURATION_MINUTES override them; 0 disables the limit.
+func turnTimeoutDefaults() (turnTimeout int, maxDuration time.Duration) {
+	turnTimeout = defaultTurnTimeoutSeconds
+	if v, err := strconv.Atoi(os.Getenv("BATTLE_TURN_TIMEOUT_SECONDS")); err == nil && v >= 0 {
+		turnTimeout = v
+	}
+	minutes := defaultMaxDurationMinutes
+	if v, err := strconv.Atoi(os.Getenv("BATTLE_MAX_DURATION_MINUTES")); err == nil && v >= 0 {
+		minutes = v
+	}
+	return turnTimeout, time.Duration(minutes) * time.Minute
+}
+
+// nextTurnDeadline returns the deadline for the participant about to act, nil when turns are untimed
+func (b *Battle) nextTurnDeadline(now time.Time) *time.Time {
+	if b.TurnTimeoutSeconds <= 0 {
+		return nil
+	}
+	deadline := now.Add(time.Duration(b.TurnTimeoutSeconds) * time.Second).UTC()
+	return &deadline
+}
+
+// RunTurnSweeper sweeps overdue battles, due lobbies and unpaid rewards every interval until ctx is cancelled.
+// Every replica may run it: each overdue turn or battle is claimed with a conditional update first.
+func (s *Service) RunTurnSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.SweepOverdueBattles(ctx, time.Now()); err != nil {
+				log.Printf("Battle sweeper: %v", err)
+			} else if n > 0 {
+				log.Printf("Battle sweeper handled %d overdue battle(s)", n)
+			}
+			if n, err := s.SweepDueLobbies(ctx, time.Now()); err != nil {
+				log.Printf("Lobby sweeper: %v", err)
+			} else if n > 0 {
+				log.Printf("Lobby sweeper handled %d due lobby(ies)", n)
+			}
+			if n, err := s.SweepUnpaidRewards(ctx); err != nil {
+				log.Printf("Reward sweeper: %v", err)
+			} else if n > 0 {
+				log.Printf("Reward sweeper paid %d reward(s)", n)
+			}
+		}
+	}
+}
+
+// SweepOverdueBattles completes battles past their wall-clock limit and resolves turns past their deadline.
+// It returns how many battles this call acted on; battles claimed by another replica are not counted.
+func (s *Service) SweepOverdueBattles(ctx context.Context, now time.Time) (int, error) {
+	battles, err := GetRepository().ListOverdueBattles(ctx, now.UTC(), sweepBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list overdue battles: %w", err)
+	}
+
+	handled := 0
+	for _, b := range battles {
+		var acted bool
+		if b.ExpiresAt != nil && !now.Before(*b.ExpiresAt) {
+			acted, err = s.expireTeamBattle(ctx, b)
+		} else {
+			acted, err = s.resolveTurnTimeout(ctx, b, now)
+		}
+		if err != nil {
+			log.Printf("Battle sweeper: battle %s: %v", b.ID, err)
+			continue
+		}
+		if acted {
+			handled++
+		}
+	}
+	return handled, nil
+}
+
+// expireTeamBattle ends a battle that ran past its wall-clock limit; the side with more HP left wins
+func (s *Service) expireTeamBattle(ctx context.Context, battle *Battle) (bool, error) {
+	participants, err := GetRepository().FindParticipants(ctx, battle.ID, "all")
+	if err != nil {
+		return false, fmt.Errorf("failed to load participants: %w", err)
+	}
+	lightHP, darkHP := 0, 0
+	for _, p := range participants {
+		if !p.IsAlive {
+			continue
+		}
+		if p.Side == TeamSideLight {
+			lightHP += p.HP
+		} else {
+			darkHP += p.HP
+		}
+	}
+
+	result := BattleResultDraw
+	if lightHP > darkHP {
+		result = BattleResultLightVictory
+	} else if darkHP > lightHP {
+		result = BattleResultDarkVictory
+	}
+
+	if _, _, err := s.completeTeamBattle(ctx, battle, result); err != nil {
+		if err == errBattleNotInProgress {
+			return false, nil
+		}
+		return false, err
+	}
+	log.Printf("Battle %s expired after its time limit: %s", battle.ID, result)
+	return true, nil
+}
+
+// resolveTurnTimeout skips the idle participant or plays a basic attack for them, depending on the battle's TimeoutAction
+func (s *Service) resolveTurnTimeout(ctx context.Context, battle *Battle, now time.Time) (bool, error) {
+	// Hold the turn for one more period while we act so other replicas leave it alone
+	lease := battle.nextTurnDeadline(now)
+	if lease == nil {
+		return false, nil
+	}
+	claimed, err := GetRepository().ClaimTurnDeadline(ctx, battle.ID, now.UTC(), *lease)
+	if err != nil || !claimed {
+		return false, err
+	}
+	battle.TurnDeadline = lease
+	battle.Version++ // the claim bumped the row version
+
+	idle, idleIndex, err := s.GetNextParticipant(ctx, battle)
+	if err != nil {
+		return false, err
+	}
+
+	if battle.TimeoutAction == TimeoutActionAttack {
+		if target := s.timeoutTarget(ctx, battle, idle); target != nil {
+			updated, _, err := s.performTeamBattleAttack(ctx, battle, dto.AttackCommand{
+				BattleID:   battle.ID,
+				AttackerID: idle.ParticipantID,
+				TargetID:   target.ParticipantID,
+			})
+			if err != nil {
+				return false, fmt.Errorf("timeout attack by %s failed: %w", idle.Name, err)
+			}
+			log.Printf("Battle %s: %s ran out of time and made a basic attack on %s", battle.ID, idle.Name, target.Name)
+			s.playAutomatedTurns(ctx, updated)
+			return true, nil
+		}
+	}
+
+	// Skip: initiative moves on without a turn being recorded
+	battle.CurrentParticipantIndex = idleIndex + 1
+	battle.UpdatedAt = time.Now()
+	battle.TurnDeadline = battle.nextTurnDeadline(battle.UpdatedAt)
+	updateData := map[string]interface{}{
+		"current_participant_index": battle.CurrentParticipantIndex,
+		"turn_deadline": battle.TurnDeadline,
+		"updated_at": battle.UpdatedAt,
+	}
+	if err := GetRepository().UpdateBattleFieldsIfVersion(ctx, battle.ID, battle.Version, updateData); err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			// The participant acted after all
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to skip turn: %w", err)
+	}
+	log.Printf("Battle %s: %s ran out of time, turn skipped", battle.ID, idle.Name)
+	s.playAutomatedTurns(ctx, battle)
+	return true, nil
+}
+
+// timeoutTarget picks the target of a timed-out participant's basic attack
+func (s *Service) timeoutTarget(ctx context.Context, battle *Battle, actor *BattleParticipant) *BattleParticipant {
+	opponents, err := aliveOpponents(ctx, battle.ID, actor)
+	if err != nil {
+		return nil
+	}
+	return LowestHPStrategy{}.ChooseTarget(actor, opponents, targetTurnRNG(battle.Seed, battle.CurrentTurn+1))
+}
@@ -36,14 +36,11 @@ func GetParticipantLevel(pType ParticipantType) ParticipantLevel {
 		return LevelDragon
 	case ParticipantTypeEnemy:
 		return LevelEnemy
+	case ParticipantTypeLightKing:
+		return LevelLightKing
+	case ParticipantTypeLightEmperor:
+		return LevelLightEmperor
 	default:
-		// Check for light_king and light_emperor by string comparison
-		if string(pType) == "light_king" {
-			return LevelLightKing
-		}
-		if string(pType) == "light_emperor" {
-			return LevelLightEmperor
-		}
 		return 0
 	}
 }
@@ -63,9 +60,9 @@ func ValidateBattleParticipants(cmd dto.StartBattleCommand) error {
 
 		// Type validation
 		validLightTypes := map[string]bool{
-			"warrior":       true,
-			"light_king":    true,
-			"light_emperor": true,
+			string(ParticipantTypeWarrior):      true,
+			string(ParticipantTypeLightKing):    true,
+			string(ParticipantTypeLightEmperor): true,
 		}
 		if !validLightTypes[p.Type] {
 			return fmt.Errorf("invalid participant type for light side: %s (allowed: warrior, light_king, light_emperor)", p.Type)
@@ -0,0 +1,24 @@
+package battlespell
+
+import (
+	"math/rand"
+)
+
+// Random picks made by spells come from the battle's combat RNG so a fight can be
+// replayed. The stream layout mirrors internal/battle/combat_rng.go: the seed, turn
+// and stream are mixed the same way and wraith victims use their own stream.
+
+const streamWraith int64 = 3
+
+// wraithTurnRNG is the stream used to pick a Wraith of Dragon victim on the given turn
+func wraithTurnRNG(seed int64, turn int) *rand.Rand {
+	return rand.New(rand.NewSource(mixSeed(seed, turn, streamWraith)))
+}
+
+// mixSeed spreads seed, turn and stream into a single source seed (splitmix64 finalizer)
+func mixSeed(seed int64, turn int, stream int64) int64 {
+	z := uint64(seed) + uint64(turn)*0x9E3779B97F4A7C15 + uint64(stream)*0xD1B54A32D192ED03
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return int64(z ^ (z >> 31))
+}
@@ -9,9 +9,15 @@ import (
 	pbBattle "network-sec-micro/api/proto/battle"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
+// maxStatsRetries bounds how often ModifyParticipantStats re-reads a participant after a conflict
+const maxStatsRetries = 5
+
 var battleGrpcClient pbBattle.BattleServiceClient
 var battleGrpcConn *grpc.ClientConn
 
@@ -68,6 +74,49 @@ func UpdateParticipantStats(ctx context.Context, battleID, participantID string,
 	return err
 }
 
+// ModifyParticipantStats applies change to a participant and writes the result back with the version it was read at.
+// If the battle service reports a concurrent update (Aborted), the participant is re-read and change is applied again.
+func ModifyParticipantStats(ctx context.Context, battleID string, p *pbBattle.BattleParticipant, change func(p *pbBattle.BattleParticipant)) error {
+	if battleGrpcClient == nil {
+		return fmt.Errorf("battle gRPC client not initialized")
+	}
+
+	for attempt := 0; ; attempt++ {
+		updated := proto.Clone(p).(*pbBattle.BattleParticipant)
+		change(updated)
+
+		_, err := battleGrpcClient.UpdateParticipantStats(ctx, &pbBattle.UpdateParticipantStatsRequest{
+			BattleId:        battleID,
+			ParticipantId:   p.ParticipantId,
+			Hp:              updated.Hp,
+			MaxHp:           updated.MaxHp,
+			AttackPower:     updated.AttackPower,
+			Defense:         updated.Defense,
+			IsAlive:         updated.IsAlive,
+			ExpectedVersion: p.Version,
+		})
+		if status.Code(err) != codes.Aborted || attempt >= maxStatsRetries {
+			return err
+		}
+
+		participants, err := GetBattleParticipants(ctx, battleID, p.Side)
+		if err != nil {
+			return fmt.Errorf("failed to re-read participant: %w", err)
+		}
+		var fresh *pbBattle.BattleParticipant
+		for _, candidate := range participants {
+			if candidate.ParticipantId == p.ParticipantId {
+				fresh = candidate
+				break
+			}
+		}
+		if fresh == nil {
+			return fmt.Errorf("participant %s is no longer in the battle", p.ParticipantId)
+		}
+		p = fresh
+	}
+}
+
 // GetBattleParticipants gets battle participants via battle service gRPC
 func GetBattleParticipants(ctx context.Context, battleID, side string) ([]*pbBattle.BattleParticipant, error) {
 	if battleGrpcClient == nil {
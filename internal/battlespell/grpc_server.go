@@ -3,11 +3,13 @@ package battlespell
 import (
 	"context"
 	"fmt"
+	"time"
 
 	pb "network-sec-micro/api/proto/battlespell"
 	"network-sec-micro/internal/battlespell/dto"
 
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -56,8 +58,8 @@ func (s *BattleSpellServiceServer) CastSpell(ctx context.Context, req *pb.CastSp
 
 // GetActiveSpells gets active spells for a battle
 func (s *BattleSpellServiceServer) GetActiveSpells(ctx context.Context, req *pb.GetActiveSpellsRequest) (*pb.GetActiveSpellsResponse, error) {
-	battleID, err := primitive.ObjectIDFromHex(req.BattleId)
-	if err != nil {
+	battleID := req.BattleId
+	if battleID == "" {
 		return nil, status.Error(codes.InvalidArgument, "invalid battle ID")
 	}
 
@@ -86,10 +88,58 @@ func (s *BattleSpellServiceServer) GetActiveSpells(ctx context.Context, req *pb.
 	}, nil
 }
 
+// ListBattleSpells lists every spell recorded for a battle, oldest first
+func (s *BattleSpellServiceServer) ListBattleSpells(ctx context.Context, req *pb.ListBattleSpellsRequest) (*pb.ListBattleSpellsResponse, error) {
+	battleID := req.BattleId
+	if battleID == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid battle ID")
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "cast_at", Value: 1}})
+	cursor, err := SpellColl.Find(ctx, bson.M{"battle_id": battleID}, opts)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to find spells: %v", err))
+	}
+	defer cursor.Close(ctx)
+
+	var spells []*pb.Spell
+	for cursor.Next(ctx) {
+		var spell Spell
+		if err := cursor.Decode(&spell); err != nil {
+			continue
+		}
+		spells = append(spells, convertSpellToProto(&spell))
+	}
+
+	return &pb.ListBattleSpellsResponse{
+		Spells: spells,
+	}, nil
+}
+
+// ClearBattleSpells deactivates every active spell of a battle that ended early
+func (s *BattleSpellServiceServer) ClearBattleSpells(ctx context.Context, req *pb.ClearBattleSpellsRequest) (*pb.ClearBattleSpellsResponse, error) {
+	battleID := req.BattleId
+	if battleID == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid battle ID")
+	}
+
+	result, err := SpellColl.UpdateMany(ctx,
+		bson.M{"battle_id": battleID, "is_active": true},
+		bson.M{"$set": bson.M{"is_active": false, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to clear spells: %v", err))
+	}
+
+	return &pb.ClearBattleSpellsResponse{
+		ClearedCount: int32(result.ModifiedCount),
+	}, nil
+}
+
 // TriggerWraithOfDragon triggers wraith effect
 func (s *BattleSpellServiceServer) TriggerWraithOfDragon(ctx context.Context, req *pb.TriggerWraithOfDragonRequest) (*pb.TriggerWraithOfDragonResponse, error) {
-	battleID, err := primitive.ObjectIDFromHex(req.BattleId)
-	if err != nil {
+	battleID := req.BattleId
+	if battleID == "" {
 		return nil, status.Error(codes.InvalidArgument, "invalid battle ID")
 	}
 
@@ -123,7 +173,7 @@ func (s *BattleSpellServiceServer) TriggerWraithOfDragon(ctx context.Context, re
 func convertSpellToProto(s *Spell) *pb.Spell {
 	pbSpell := &pb.Spell{
 		Id:               s.ID.Hex(),
-		BattleId:         s.BattleID.Hex(),
+		BattleId:         s.BattleID,
 		SpellType:        string(s.SpellType),
 		Side:             string(s.Side),
 		CasterUsername:   s.CasterUsername,
@@ -0,0 +1,89 @@
+package battlespell
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	"network-sec-micro/internal/battlespell/dto"
+	"network-sec-micro/pkg/kafka"
+)
+
+var kafkaPublisher *kafka.Publisher
+var kafkaPublisherOnce sync.Once
+
+// GetKafkaPublisher returns the Kafka publisher singleton
+func GetKafkaPublisher() *kafka.Publisher {
+	kafkaPublisherOnce.Do(func() {
+		brokers := getKafkaBrokers()
+		publisher, err := kafka.NewPublisher(brokers)
+		if err != nil {
+			log.Printf("Warning: Failed to initialize Kafka publisher: %v", err)
+			return
+		}
+		kafkaPublisher = publisher
+		log.Println("Kafka publisher initialized for battlespell service")
+	})
+	return kafkaPublisher
+}
+
+// CloseKafkaPublisher closes the Kafka publisher
+func CloseKafkaPublisher() {
+	if kafkaPublisher != nil {
+		kafkaPublisher.Close()
+	}
+}
+
+func getKafkaBrokers() []string {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		return []string{"localhost:9092"}
+	}
+	var result []string
+	for _, b := range splitAndTrim(brokers, ",") {
+		if b != "" {
+			result = append(result, b)
+		}
+	}
+	if len(result) == 0 {
+		return []string{"localhost:9092"}
+	}
+	return result
+}
+
+func splitAndTrim(s, sep string) []string {
+	parts := []string{}
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || string(s[i]) == sep {
+			part := s[start:i]
+			for len(part) > 0 && (part[0] == ' ' || part[0] == '\t') {
+				part = part[1:]
+			}
+			for len(part) > 0 && (part[len(part)-1] == ' ' || part[len(part)-1] == '\t') {
+				part = part[:len(part)-1]
+			}
+			parts = append(parts, part)
+			start = i + 1
+		}
+	}
+	return parts
+}
+
+// PublishSpellCastEvent publishes a spell cast for the battle analytics read model
+func PublishSpellCastEvent(cmd dto.CastSpellCommand, affected int) error {
+	publisher := GetKafkaPublisher()
+	if publisher == nil {
+		return fmt.Errorf("kafka publisher not initialized")
+	}
+
+	side := string(SpellType(cmd.SpellType).Side())
+	event := kafka.NewBattleSpellCastEvent(cmd.BattleID, cmd.SpellType, cmd.CasterUserID, cmd.CasterRole, side, affected)
+	if err := publisher.Publish(kafka.TopicBattleSpellCast, event); err != nil {
+		return fmt.Errorf("failed to publish spell cast event: %w", err)
+	}
+
+	log.Printf("Published spell cast event: battle=%s spell=%s affected=%d", cmd.BattleID, cmd.SpellType, affected)
+	return nil
+}
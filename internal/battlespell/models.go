@@ -44,7 +44,7 @@ func (st SpellType) Side() TeamSide {
 // Spell represents a spell cast during battle
 type Spell struct {
 	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	BattleID      primitive.ObjectID `bson:"battle_id" json:"battle_id"`
+	BattleID      string             `bson:"battle_id" json:"battle_id"` // Battle service ID, as a decimal string
 	SpellType     SpellType          `bson:"spell_type" json:"spell_type"`
 	Side          TeamSide           `bson:"side" json:"side"`
 
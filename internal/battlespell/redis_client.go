@@ -0,0 +1,64 @@
+package battlespell
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"network-sec-micro/internal/battlespell/dto"
+	"network-sec-micro/pkg/spectate"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var redisClient *redis.Client
+
+// InitRedisClient initializes the Redis client used to push spell casts to battle spectators
+func InitRedisClient() error {
+	addr := getEnv("REDIS_ADDR", "localhost:6379")
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: getEnv("REDIS_PASSWORD", ""),
+		DB:       0,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	redisClient = client
+	log.Printf("Connected to Redis at %s", addr)
+	return nil
+}
+
+// CloseRedisClient closes the Redis connection
+func CloseRedisClient() {
+	if redisClient != nil {
+		redisClient.Close()
+	}
+}
+
+// publishSpellCast tells everyone watching the battle about a cast; without Redis nothing is sent
+func publishSpellCast(cmd dto.CastSpellCommand, affected int) {
+	if redisClient == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	cast := spectate.SpellCast{
+		SpellType:     cmd.SpellType,
+		CasterID:      cmd.CasterUserID,
+		CasterName:    cmd.CasterUsername,
+		AffectedCount: affected,
+	}
+	if err := spectate.Publish(ctx, redisClient, spectate.BattleChannel(cmd.BattleID), spectate.EventSpellCast, cast); err != nil {
+		log.Printf("Failed to publish spell cast to spectators of battle %s: %v", cmd.BattleID, err)
+	}
+}
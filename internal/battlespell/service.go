@@ -6,8 +6,6 @@ import (
 	"fmt"
 
 	"network-sec-micro/internal/battlespell/dto"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Service handles battlespell business logic with CQRS pattern
@@ -18,8 +16,20 @@ func NewService() *Service {
 	return &Service{}
 }
 
-// CastSpell casts a spell in a battle
+// CastSpell casts a spell in a battle and lets the battle's spectators know
 func (s *Service) CastSpell(ctx context.Context, cmd dto.CastSpellCommand) (int, error) {
+	affected, err := s.castSpell(ctx, cmd)
+	if err != nil {
+		return 0, err
+	}
+	publishSpellCast(cmd, affected)
+	go func() {
+		_ = PublishSpellCastEvent(cmd, affected)
+	}()
+	return affected, nil
+}
+
+func (s *Service) castSpell(ctx context.Context, cmd dto.CastSpellCommand) (int, error) {
 	// Validate spell type
 	spellType := SpellType(cmd.SpellType)
 	if spellType != SpellCallOfTheLightKing && spellType != SpellResistance && spellType != SpellRebirth &&
@@ -32,8 +42,8 @@ func (s *Service) CastSpell(ctx context.Context, cmd dto.CastSpellCommand) (int,
 		return 0, fmt.Errorf("role %s cannot cast spell %s", cmd.CasterRole, cmd.SpellType)
 	}
 
-	battleID, err := primitive.ObjectIDFromHex(cmd.BattleID)
-	if err != nil {
+	battleID := cmd.BattleID
+	if battleID == "" {
 		return 0, errors.New("invalid battle ID")
 	}
 
@@ -10,14 +10,12 @@ import (
 	pbBattle "network-sec-micro/api/proto/battle"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // CastCallOfTheLightKing doubles attack power for all warrior units for the entire battle duration
-func (s *Service) CastCallOfTheLightKing(ctx context.Context, battleID primitive.ObjectID, casterUsername string, casterUserID string) (int, error) {
+func (s *Service) CastCallOfTheLightKing(ctx context.Context, battleID string, casterUsername string, casterUserID string) (int, error) {
 	// Get battle via gRPC
-	battleIDStr := battleID.Hex()
-	battle, err := GetBattleByID(ctx, battleIDStr)
+	battle, err := GetBattleByID(ctx, battleID)
 	if err != nil {
 		return 0, errors.New("battle not found")
 	}
@@ -39,7 +37,7 @@ func (s *Service) CastCallOfTheLightKing(ctx context.Context, battleID primitive
 	}
 
 	// Get all warrior participants on light side via gRPC
-	participants, err := GetBattleParticipants(ctx, battleIDStr, "light")
+	participants, err := GetBattleParticipants(ctx, battleID, "light")
 	if err != nil {
 		return 0, fmt.Errorf("failed to get battle participants: %w", err)
 	}
@@ -48,9 +46,9 @@ func (s *Service) CastCallOfTheLightKing(ctx context.Context, battleID primitive
 	updatedCount := 0
 	for _, p := range participants {
 		if p.Type == "warrior" && p.IsAlive {
-			newAttackPower := p.AttackPower * 2
-
-			err = UpdateParticipantStats(ctx, battleIDStr, p.ParticipantId, p.Hp, p.MaxHp, newAttackPower, p.Defense, p.IsAlive)
+			err = ModifyParticipantStats(ctx, battleID, p, func(p *pbBattle.BattleParticipant) {
+				p.AttackPower *= 2
+			})
 			if err != nil {
 				log.Printf("Failed to update warrior %s attack power: %v", p.Name, err)
 				continue
@@ -79,7 +77,7 @@ func (s *Service) CastCallOfTheLightKing(ctx context.Context, battleID primitive
 		log.Printf("Warning: failed to record spell cast: %v", err)
 	}
 
-	log.Printf("Call of the Light King spell cast by %s in battle %s - %d warriors affected", casterUsername, battleID.Hex(), updatedCount)
+	log.Printf("Call of the Light King spell cast by %s in battle %s - %d warriors affected", casterUsername, battleID, updatedCount)
 	return updatedCount, nil
 }
 
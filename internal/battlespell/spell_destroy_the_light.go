@@ -10,14 +10,12 @@ import (
 	pbBattle "network-sec-micro/api/proto/battle"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // CastDestroyTheLight reduces warrior attack and defense by 30% (stackable up to 2 times: 70% → 49%)
-func (s *Service) CastDestroyTheLight(ctx context.Context, battleID primitive.ObjectID, casterUsername string, casterUserID string) (int, error) {
+func (s *Service) CastDestroyTheLight(ctx context.Context, battleID string, casterUsername string, casterUserID string) (int, error) {
 	// Get battle via gRPC
-	battleIDStr := battleID.Hex()
-	battle, err := GetBattleByID(ctx, battleIDStr)
+	battle, err := GetBattleByID(ctx, battleID)
 	if err != nil {
 		return 0, errors.New("battle not found")
 	}
@@ -52,7 +50,7 @@ func (s *Service) CastDestroyTheLight(ctx context.Context, battleID primitive.Ob
 	}
 
 	// Get all warrior participants on light side via gRPC
-	participants, err := GetBattleParticipants(ctx, battleIDStr, "light")
+	participants, err := GetBattleParticipants(ctx, battleID, "light")
 	if err != nil {
 		return 0, fmt.Errorf("failed to get battle participants: %w", err)
 	}
@@ -61,18 +59,18 @@ func (s *Service) CastDestroyTheLight(ctx context.Context, battleID primitive.Ob
 	updatedCount := 0
 	for _, p := range participants {
 		if p.Type == "warrior" && p.IsAlive {
-			newAttackPower := int32(float64(p.AttackPower) * reductionMultiplier)
-			newDefense := int32(float64(p.Defense) * reductionMultiplier)
-
-			// Minimum values (at least 1)
-			if newAttackPower < 1 {
-				newAttackPower = 1
-			}
-			if newDefense < 1 {
-				newDefense = 1
-			}
-
-			err = UpdateParticipantStats(ctx, battleIDStr, p.ParticipantId, p.Hp, p.MaxHp, newAttackPower, newDefense, p.IsAlive)
+			err = ModifyParticipantStats(ctx, battleID, p, func(p *pbBattle.BattleParticipant) {
+				p.AttackPower = int32(float64(p.AttackPower) * reductionMultiplier)
+				p.Defense = int32(float64(p.Defense) * reductionMultiplier)
+
+				// Minimum values (at least 1)
+				if p.AttackPower < 1 {
+					p.AttackPower = 1
+				}
+				if p.Defense < 1 {
+					p.Defense = 1
+				}
+			})
 			if err != nil {
 				log.Printf("Failed to reduce warrior %s stats: %v", p.Name, err)
 				continue
@@ -103,6 +101,6 @@ func (s *Service) CastDestroyTheLight(ctx context.Context, battleID primitive.Ob
 		log.Printf("Warning: failed to record spell cast: %v", err)
 	}
 
-	log.Printf("Destroy the Light spell cast by %s in battle %s - Stack %d/2, %d warriors affected", casterUsername, battleID.Hex(), newStackCount, updatedCount)
+	log.Printf("Destroy the Light spell cast by %s in battle %s - Stack %d/2, %d warriors affected", casterUsername, battleID, newStackCount, updatedCount)
 	return updatedCount, nil
 }
@@ -10,14 +10,12 @@ import (
 	pbBattle "network-sec-micro/api/proto/battle"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // CastDragonEmperor adds Dark Emperor's stats to dragon for the entire battle duration
-func (s *Service) CastDragonEmperor(ctx context.Context, battleID primitive.ObjectID, dragonParticipantID string, darkEmperorParticipantID string, casterUsername string, casterUserID string) error {
+func (s *Service) CastDragonEmperor(ctx context.Context, battleID string, dragonParticipantID string, darkEmperorParticipantID string, casterUsername string, casterUserID string) error {
 	// Get battle via gRPC
-	battleIDStr := battleID.Hex()
-	battle, err := GetBattleByID(ctx, battleIDStr)
+	battle, err := GetBattleByID(ctx, battleID)
 	if err != nil {
 		return errors.New("battle not found")
 	}
@@ -27,7 +25,7 @@ func (s *Service) CastDragonEmperor(ctx context.Context, battleID primitive.Obje
 	}
 
 	// Get all participants via gRPC
-	participants, err := GetBattleParticipants(ctx, battleIDStr, "")
+	participants, err := GetBattleParticipants(ctx, battleID, "")
 	if err != nil {
 		return fmt.Errorf("failed to get battle participants: %w", err)
 	}
@@ -66,16 +64,15 @@ func (s *Service) CastDragonEmperor(ctx context.Context, battleID primitive.Obje
 	}
 
 	// Add Dark Emperor stats to dragon
-	newAttackPower := dragonParticipant.AttackPower + darkEmperorParticipant.AttackPower
-	newDefense := dragonParticipant.Defense + darkEmperorParticipant.Defense
-	newMaxHP := dragonParticipant.MaxHp + darkEmperorParticipant.MaxHp
-	hpBonus := darkEmperorParticipant.MaxHp
-	newHP := dragonParticipant.Hp + hpBonus
-	if newHP > newMaxHP {
-		newHP = newMaxHP
-	}
-
-	err = UpdateParticipantStats(ctx, battleIDStr, dragonParticipantID, int32(newHP), int32(newMaxHP), newAttackPower, newDefense, dragonParticipant.IsAlive)
+	err = ModifyParticipantStats(ctx, battleID, dragonParticipant, func(p *pbBattle.BattleParticipant) {
+		p.AttackPower += darkEmperorParticipant.AttackPower
+		p.Defense += darkEmperorParticipant.Defense
+		p.MaxHp += darkEmperorParticipant.MaxHp
+		p.Hp += darkEmperorParticipant.MaxHp
+		if p.Hp > p.MaxHp {
+			p.Hp = p.MaxHp
+		}
+	})
 	if err != nil {
 		return fmt.Errorf("failed to enhance dragon: %w", err)
 	}
@@ -101,6 +98,6 @@ func (s *Service) CastDragonEmperor(ctx context.Context, battleID primitive.Obje
 		log.Printf("Warning: failed to record spell cast: %v", err)
 	}
 
-	log.Printf("Dragon Emperor spell cast by %s in battle %s - Dragon enhanced", casterUsername, battleID.Hex())
+	log.Printf("Dragon Emperor spell cast by %s in battle %s - Dragon enhanced", casterUsername, battleID)
 	return nil
 }
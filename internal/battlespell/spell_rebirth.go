@@ -9,15 +9,12 @@ import (
 
 	pbBattle "network-sec-micro/api/proto/battle"
 
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // CastRebirth revives all defeated warrior units
-func (s *Service) CastRebirth(ctx context.Context, battleID primitive.ObjectID, casterUsername string, casterUserID string) (int, error) {
+func (s *Service) CastRebirth(ctx context.Context, battleID string, casterUsername string, casterUserID string) (int, error) {
 	// Get battle via gRPC
-	battleIDStr := battleID.Hex()
-	battle, err := GetBattleByID(ctx, battleIDStr)
+	battle, err := GetBattleByID(ctx, battleID)
 	if err != nil {
 		return 0, errors.New("battle not found")
 	}
@@ -27,7 +24,7 @@ func (s *Service) CastRebirth(ctx context.Context, battleID primitive.ObjectID,
 	}
 
 	// Get all participants on light side via gRPC
-	participants, err := GetBattleParticipants(ctx, battleIDStr, "light")
+	participants, err := GetBattleParticipants(ctx, battleID, "light")
 	if err != nil {
 		return 0, fmt.Errorf("failed to get battle participants: %w", err)
 	}
@@ -47,7 +44,10 @@ func (s *Service) CastRebirth(ctx context.Context, battleID primitive.ObjectID,
 	// Revive all defeated warriors
 	revivedCount := 0
 	for _, p := range defeatedWarriors {
-		err = UpdateParticipantStats(ctx, battleIDStr, p.ParticipantId, p.MaxHp, p.MaxHp, p.AttackPower, p.Defense, true)
+		err = ModifyParticipantStats(ctx, battleID, p, func(p *pbBattle.BattleParticipant) {
+			p.Hp = p.MaxHp
+			p.IsAlive = true
+		})
 		if err != nil {
 			log.Printf("Failed to revive warrior %s: %v", p.Name, err)
 			continue
@@ -75,6 +75,6 @@ func (s *Service) CastRebirth(ctx context.Context, battleID primitive.ObjectID,
 		log.Printf("Warning: failed to record spell cast: %v", err)
 	}
 
-	log.Printf("Rebirth spell cast by %s in battle %s - %d warriors revived", casterUsername, battleID.Hex(), revivedCount)
+	log.Printf("Rebirth spell cast by %s in battle %s - %d warriors revived", casterUsername, battleID, revivedCount)
 	return revivedCount, nil
 }
@@ -10,14 +10,12 @@ import (
 	pbBattle "network-sec-micro/api/proto/battle"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // CastResistance doubles defense for all warrior units for the entire battle duration
-func (s *Service) CastResistance(ctx context.Context, battleID primitive.ObjectID, casterUsername string, casterUserID string) (int, error) {
+func (s *Service) CastResistance(ctx context.Context, battleID string, casterUsername string, casterUserID string) (int, error) {
 	// Get battle via gRPC
-	battleIDStr := battleID.Hex()
-	battle, err := GetBattleByID(ctx, battleIDStr)
+	battle, err := GetBattleByID(ctx, battleID)
 	if err != nil {
 		return 0, errors.New("battle not found")
 	}
@@ -39,7 +37,7 @@ func (s *Service) CastResistance(ctx context.Context, battleID primitive.ObjectI
 	}
 
 	// Get all warrior participants on light side via gRPC
-	participants, err := GetBattleParticipants(ctx, battleIDStr, "light")
+	participants, err := GetBattleParticipants(ctx, battleID, "light")
 	if err != nil {
 		return 0, fmt.Errorf("failed to get battle participants: %w", err)
 	}
@@ -48,9 +46,9 @@ func (s *Service) CastResistance(ctx context.Context, battleID primitive.ObjectI
 	updatedCount := 0
 	for _, p := range participants {
 		if p.Type == "warrior" && p.IsAlive {
-			newDefense := p.Defense * 2
-
-			err = UpdateParticipantStats(ctx, battleIDStr, p.ParticipantId, p.Hp, p.MaxHp, p.AttackPower, newDefense, p.IsAlive)
+			err = ModifyParticipantStats(ctx, battleID, p, func(p *pbBattle.BattleParticipant) {
+				p.Defense *= 2
+			})
 			if err != nil {
 				log.Printf("Failed to update warrior %s defense: %v", p.Name, err)
 				continue
@@ -79,6 +77,6 @@ func (s *Service) CastResistance(ctx context.Context, battleID primitive.ObjectI
 		log.Printf("Warning: failed to record spell cast: %v", err)
 	}
 
-	log.Printf("Resistance spell cast by %s in battle %s - %d warriors affected", casterUsername, battleID.Hex(), updatedCount)
+	log.Printf("Resistance spell cast by %s in battle %s - %d warriors affected", casterUsername, battleID, updatedCount)
 	return updatedCount, nil
 }
@@ -5,20 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"math/rand"
+	"sort"
 	"time"
 
 	pbBattle "network-sec-micro/api/proto/battle"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // CastWraithOfDragon enables wraith effect: when dragon kills warrior, random warrior also dies (max 25 times)
-func (s *Service) CastWraithOfDragon(ctx context.Context, battleID primitive.ObjectID, casterUsername string, casterUserID string) error {
+func (s *Service) CastWraithOfDragon(ctx context.Context, battleID string, casterUsername string, casterUserID string) error {
 	// Get battle via gRPC
-	battleIDStr := battleID.Hex()
-	battle, err := GetBattleByID(ctx, battleIDStr)
+	battle, err := GetBattleByID(ctx, battleID)
 	if err != nil {
 		return errors.New("battle not found")
 	}
@@ -59,13 +57,13 @@ func (s *Service) CastWraithOfDragon(ctx context.Context, battleID primitive.Obj
 		return fmt.Errorf("failed to record spell cast: %w", err)
 	}
 
-	log.Printf("Wraith of Dragon spell cast by %s in battle %s", casterUsername, battleID.Hex())
+	log.Printf("Wraith of Dragon spell cast by %s in battle %s", casterUsername, battleID)
 	return nil
 }
 
 // TriggerWraithOfDragon triggers the wraith effect when dragon kills a warrior
 // Returns the additional warrior ID that was destroyed, or empty string if none
-func (s *Service) TriggerWraithOfDragon(ctx context.Context, battleID primitive.ObjectID) (string, error) {
+func (s *Service) TriggerWraithOfDragon(ctx context.Context, battleID string) (string, error) {
 	// Get active Wraith of Dragon spell
 	var spell Spell
 	err := SpellColl.FindOne(ctx, bson.M{
@@ -90,8 +88,7 @@ func (s *Service) TriggerWraithOfDragon(ctx context.Context, battleID primitive.
 	}
 
 	// Get all alive warrior participants on light side via gRPC
-	battleIDStr := battleID.Hex()
-	participants, err := GetBattleParticipants(ctx, battleIDStr, "light")
+	participants, err := GetBattleParticipants(ctx, battleID, "light")
 	if err != nil {
 		return "", fmt.Errorf("failed to get battle participants: %w", err)
 	}
@@ -108,12 +105,22 @@ func (s *Service) TriggerWraithOfDragon(ctx context.Context, battleID primitive.
 		return "", nil
 	}
 
-	// Select random warrior to destroy
-	randomIndex := rand.Intn(len(aliveWarriors))
-	targetWarrior := aliveWarriors[randomIndex]
+	// Select the warrior to destroy from the battle's seeded stream for this turn
+	battle, err := GetBattleByID(ctx, battleID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get battle: %w", err)
+	}
+	sort.Slice(aliveWarriors, func(i, j int) bool {
+		return aliveWarriors[i].ParticipantId < aliveWarriors[j].ParticipantId
+	})
+	rng := wraithTurnRNG(battle.Seed, int(battle.CurrentTurn))
+	targetWarrior := aliveWarriors[rng.Intn(len(aliveWarriors))]
 
 	// Destroy the random warrior via gRPC
-	err = UpdateParticipantStats(ctx, battleIDStr, targetWarrior.ParticipantId, 0, targetWarrior.MaxHp, targetWarrior.AttackPower, targetWarrior.Defense, false)
+	err = ModifyParticipantStats(ctx, battleID, targetWarrior, func(p *pbBattle.BattleParticipant) {
+		p.Hp = 0
+		p.IsAlive = false
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to destroy warrior: %w", err)
 	}
@@ -128,6 +135,6 @@ func (s *Service) TriggerWraithOfDragon(ctx context.Context, battleID primitive.
 		log.Printf("Warning: failed to update wraith count: %v", err)
 	}
 
-	log.Printf("Wraith of Dragon triggered in battle %s - %s destroyed (count: %d/25)", battleID.Hex(), targetWarrior.Name, newWraithCount)
+	log.Printf("Wraith of Dragon triggered in battle %s - %s destroyed (count: %d/25)", battleID, targetWarrior.Name, newWraithCount)
 	return targetWarrior.ParticipantId, nil
 }
@@ -39,7 +39,7 @@ func InitDatabase() error {
 	log.Println("Coin service MySQL database connection established")
 
 	// Auto migrate the schema
-	if err := DB.AutoMigrate(&Transaction{}); err != nil {
+	if err := DB.AutoMigrate(&Transaction{}, &WagerEscrow{}, &IdempotencyRecord{}, &LedgerEntry{}, &LedgerPosting{}, &CoinHold{}); err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
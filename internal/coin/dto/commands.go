@@ -1,25 +1,32 @@
 package dto
 
+import "time"
+
 // DeductCoinsCommand represents a command to deduct coins
 type DeductCoinsCommand struct {
-	WarriorID uint
-	Amount    int64
-	Reason    string
+	WarriorID      uint
+	Amount         int64
+	Reason         string
+	IdempotencyKey string // Optional; a replay with the same key returns the first result
+	Account        string // Ledger account where the coins go; defaults to the treasury
 }
 
 // AddCoinsCommand represents a command to add coins
 type AddCoinsCommand struct {
-	WarriorID uint
-	Amount    int64
-	Reason    string
+	WarriorID      uint
+	Amount         int64
+	Reason         string
+	IdempotencyKey string // Optional; a replay with the same key returns the first result
+	Account        string // Ledger account where the coins come from; defaults to the treasury
 }
 
 // TransferCoinsCommand represents a command to transfer coins
 type TransferCoinsCommand struct {
-	FromWarriorID uint
-	ToWarriorID   uint
-	Amount        int64
-	Reason        string
+	FromWarriorID  uint
+	ToWarriorID    uint
+	Amount         int64
+	Reason         string
+	IdempotencyKey string // Optional; a replay with the same key returns the first result
 }
 
 // CreateTransactionCommand represents a command to create a transaction record
@@ -32,3 +39,31 @@ type CreateTransactionCommand struct {
 	BalanceAfter    int64
 }
 
+// LockWagerStakeCommand represents a command to move an emperor's wager stake into escrow
+type LockWagerStakeCommand struct {
+	BattleID  string
+	WarriorID uint
+	Amount    int64
+}
+
+// ResolveWagerCommand represents a command to settle a battle's escrowed wager
+type ResolveWagerCommand struct {
+	BattleID        string
+	WinnerWarriorID uint // 0 refunds every stake
+}
+
+// PlaceHoldCommand represents a command to reserve coins on a warrior's balance
+type PlaceHoldCommand struct {
+	WarriorID uint
+	Amount    int64
+	Reason    string
+	Reference string        // Required; placing a hold again under the same reference returns the first hold
+	Account   string        // Ledger account where the coins go on capture; defaults to the treasury
+	ExpiresIn time.Duration // How long the hold lasts unless captured or released; 0 uses the default
+}
+
+// CaptureHoldCommand represents a command to take the coins a hold reserved
+type CaptureHoldCommand struct {
+	HoldID uint
+	Amount int64 // Part of the hold to take, releasing the rest; 0 takes all of it
+}
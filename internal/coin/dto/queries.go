@@ -12,3 +12,11 @@ type GetTransactionHistoryQuery struct {
 	Offset    int
 }
 
+
+// ListHoldsQuery represents a query to list a warrior's holds
+type ListHoldsQuery struct {
+	WarriorID uint
+	Status    string // Optional; e.g. "held" for the holds still reserving coins
+	Limit     int
+	Offset    int
+}
@@ -3,6 +3,7 @@ package coin
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 
 	"network-sec-micro/internal/coin/dto"
@@ -41,10 +42,13 @@ func ProcessEnemyAttackMessage(message []byte) error {
 	// Deduct coins from warrior
 	service := NewService()
 
+	// The attack has no ID of its own; the enemy, the victim and the event time identify it
 	if err := service.DeductCoins(context.Background(), dto.DeductCoinsCommand{
-		WarriorID: event.WarriorID,
-		Amount:    int64(event.StolenValue),
-		Reason:    "goblin_attack: " + event.EnemyName + " stole your coins",
+		WarriorID:      event.WarriorID,
+		Amount:         int64(event.StolenValue),
+		Reason:         "goblin_attack: " + event.EnemyName + " stole your coins",
+		IdempotencyKey: fmt.Sprintf("goblin_attack:%s:%d:%s", event.EnemyID, event.WarriorID, event.Timestamp),
+		Account:        EnemyAccount(event.EnemyID),
 	}); err != nil {
 		log.Printf("Failed to deduct coins from warrior %d: %v", event.WarriorID, err)
 		return err
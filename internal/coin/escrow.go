@@ -0,0 +1,144 @@
+package coin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"network-sec-micro/internal/coin/dto"
+
+	"gorm.io/gorm"
+)
+
+// Emperors' wagers on team battles are escrowed: each stake is taken from the emperor's balance when
+// they approve the wager and held until the battle service reports how the battle ended. The winner
+// then gets the whole pot, or every stake goes back on a draw or cancel. Both steps are idempotent by
+// battle ID, so a retried call or a redelivered event never moves coins twice.
+
+// WagerResolution reports what resolving a battle's wager moved
+type WagerResolution struct {
+	PaidOut  int64 // Pot paid to the winner
+	Refunded int64 // Stakes given back
+}
+
+// LockWagerStake takes an emperor's stake on a battle into escrow. Locking a stake that is already
+// held returns it with alreadyLocked set; a different amount for the same battle is rejected.
+func (s *Service) LockWagerStake(ctx context.Context, cmd dto.LockWagerStakeCommand) (escrow *WagerEscrow, alreadyLocked bool, err error) {
+	if cmd.BattleID == "" {
+		return nil, false, errors.New("battle ID is required")
+	}
+	if cmd.Amount <= 0 {
+		return nil, false, errors.New("amount must be positive")
+	}
+
+	existing, err := s.repo.GetWagerEscrow(ctx, cmd.BattleID, cmd.WarriorID)
+	if err == nil {
+		return sameStake(existing, cmd.Amount)
+	}
+	if !errors.Is(err, ErrEscrowNotFound) {
+		return nil, false, fmt.Errorf("lock wager stake failed: %w", err)
+	}
+
+	err = s.repo.ExecuteInTransaction(ctx, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		if _, err := debit(ctx, repo, cmd.WarriorID, cmd.Amount, AccountWagerEscrow, TransactionTypeEscrowLock, "battle_wager: "+cmd.BattleID); err != nil {
+			return err
+		}
+
+		escrow = &WagerEscrow{
+			BattleID:  cmd.BattleID,
+			WarriorID: cmd.WarriorID,
+			Amount:    cmd.Amount,
+			Status:    EscrowStatusLocked,
+		}
+		return repo.CreateWagerEscrow(ctx, escrow)
+	})
+	if err != nil {
+		// A concurrent call may have locked the stake first, in which case the unique index rolled this one back
+		if existing, getErr := s.repo.GetWagerEscrow(ctx, cmd.BattleID, cmd.WarriorID); getErr == nil {
+			return sameStake(existing, cmd.Amount)
+		}
+		return nil, false, fmt.Errorf("lock wager stake failed: %w", err)
+	}
+
+	return escrow, false, nil
+}
+
+// sameStake accepts a repeated lock if it is for the stake already held
+func sameStake(existing *WagerEscrow, amount int64) (*WagerEscrow, bool, error) {
+	if existing.Amount != amount {
+		return nil, false, fmt.Errorf("a stake of %d is already locked for this battle", existing.Amount)
+	}
+	return existing, true, nil
+}
+
+// ResolveWager settles a battle's escrowed wager: with a winner the whole pot goes to them, without
+// one every stake is refunded. Stakes resolved by an earlier call are left alone, so resolving twice
+// moves nothing the second time.
+func (s *Service) ResolveWager(ctx context.Context, cmd dto.ResolveWagerCommand) (*WagerResolution, error) {
+	if cmd.BattleID == "" {
+		return nil, errors.New("battle ID is required")
+	}
+
+	var resolution WagerResolution
+	err := s.repo.ExecuteInTransaction(ctx, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		resolution = WagerResolution{}
+
+		escrows, err := repo.ListLockedWagerEscrows(ctx, cmd.BattleID)
+		if err != nil {
+			return err
+		}
+		if len(escrows) == 0 {
+			return nil
+		}
+
+		// Lock everyone who may be paid up front, so the locks are taken in warrior ID order
+		warriorIDs := make([]uint, 0, len(escrows)+1)
+		for _, escrow := range escrows {
+			warriorIDs = append(warriorIDs, escrow.WarriorID)
+		}
+		if cmd.WinnerWarriorID != 0 {
+			warriorIDs = append(warriorIDs, cmd.WinnerWarriorID)
+		}
+		if _, err := repo.LockWarriors(ctx, warriorIDs...); err != nil {
+			return err
+		}
+
+		status := EscrowStatusRefunded
+		if cmd.WinnerWarriorID != 0 {
+			status = EscrowStatusPaidOut
+		}
+		now := time.Now()
+		for _, escrow := range escrows {
+			resolved, err := repo.ResolveWagerEscrow(ctx, escrow.ID, status, cmd.WinnerWarriorID, now)
+			if err != nil {
+				return err
+			}
+			if !resolved {
+				continue // Resolved by a concurrent call
+			}
+			if cmd.WinnerWarriorID != 0 {
+				resolution.PaidOut += escrow.Amount
+				continue
+			}
+			if _, err := credit(ctx, repo, escrow.WarriorID, escrow.Amount, AccountWagerEscrow, TransactionTypeEscrowRefund, "battle_wager_refund: "+cmd.BattleID); err != nil {
+				return err
+			}
+			resolution.Refunded += escrow.Amount
+		}
+
+		if resolution.PaidOut > 0 {
+			_, err := credit(ctx, repo, cmd.WinnerWarriorID, resolution.PaidOut, AccountWagerEscrow, TransactionTypeEscrowPayout, "battle_wager_payout: "+cmd.BattleID)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resolve wager failed: %w", err)
+	}
+
+	return &resolution, nil
+}
@@ -3,7 +3,7 @@ package coin
 import (
 	"context"
 	"errors"
-	"log"
+	"time"
 
 	pb "network-sec-micro/api/proto/coin"
 	"network-sec-micro/internal/coin/dto"
@@ -11,7 +11,6 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"gorm.io/gorm"
 )
 
 // CoinServiceServer implements the CoinService gRPC interface
@@ -27,169 +26,157 @@ func NewCoinServiceServer(service *Service) *CoinServiceServer {
 	}
 }
 
-// Warrior represents a warrior from the warrior database
-type Warrior struct {
-	ID          uint
-	CoinBalance int
-}
-
-// GetBalance returns warrior's coin balance from warrior database
+// GetBalance returns warrior's coin balance, split into the coins held and the coins available
 func (s *CoinServiceServer) GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (*pb.GetBalanceResponse, error) {
-	var warrior Warrior
-	if err := DB.Table("warriors").Where("id = ?", req.WarriorId).First(&warrior).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	balance, err := s.Service.GetWalletBalance(ctx, dto.GetBalanceQuery{WarriorID: uint(req.WarriorId)})
+	if err != nil {
+		if errors.Is(err, ErrWarriorNotFound) {
 			return nil, status.Errorf(codes.NotFound, "warrior not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get warrior: %v", err)
 	}
 
 	return &pb.GetBalanceResponse{
-		WarriorId: uint32(warrior.ID),
-		Balance:   int64(warrior.CoinBalance),
+		WarriorId: req.WarriorId,
+		Balance:   balance.Balance,
+		Held:      balance.Held,
+		Available: balance.Available,
 	}, nil
 }
 
-// DeductCoins deducts coins from warrior's balance
+// DeductCoins deducts coins from warrior's balance. A request whose idempotency key was already
+// used gets the first result back and nothing is deducted again.
 func (s *CoinServiceServer) DeductCoins(ctx context.Context, req *pb.DeductCoinsRequest) (*pb.DeductCoinsResponse, error) {
-	var warrior Warrior
-	if err := DB.Table("warriors").Where("id = ?", req.WarriorId).First(&warrior).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	change, err := s.Service.Deduct(ctx, dto.DeductCoinsCommand{
+		WarriorID:      uint(req.WarriorId),
+		Amount:         req.Amount,
+		Reason:         req.Reason,
+		IdempotencyKey: req.IdempotencyKey,
+		Account:        req.Account,
+	})
+	if err != nil {
+		if errors.Is(err, ErrWarriorNotFound) {
 			return nil, status.Errorf(codes.NotFound, "warrior not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get warrior: %v", err)
-	}
-
-	balanceBefore := int64(warrior.CoinBalance)
-
-	if balanceBefore < req.Amount {
+		message, rejected := rejection(err)
+		if !rejected {
+			return nil, status.Errorf(codes.Internal, "failed to deduct coins: %v", err)
+		}
+		balance, _ := s.Service.GetBalance(ctx, dto.GetBalanceQuery{WarriorID: uint(req.WarriorId)})
 		return &pb.DeductCoinsResponse{
 			Success:       false,
-			WarriorId:     uint32(warrior.ID),
-			BalanceBefore: balanceBefore,
-			BalanceAfter:  balanceBefore,
-			Message:       "insufficient balance",
+			WarriorId:     req.WarriorId,
+			BalanceBefore: balance,
+			BalanceAfter:  balance,
+			Message:       message,
 		}, nil
 	}
 
-	warrior.CoinBalance -= int(req.Amount)
-	balanceAfter := int64(warrior.CoinBalance)
-
-	if err := DB.Table("warriors").Where("id = ?", req.WarriorId).Update("coin_balance", warrior.CoinBalance).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update balance: %v", err)
+	message := "coins deducted successfully"
+	if change.Replayed {
+		message = "coins already deducted for this idempotency key"
 	}
-
-	// Create transaction record
-	if _, err := s.Service.CreateTransaction(ctx, dto.CreateTransactionCommand{
-		WarriorID:       warrior.ID,
-		Amount:          -req.Amount,
-		TransactionType: string(TransactionTypeDeduct),
-		Reason:          req.Reason,
-		BalanceBefore:   balanceBefore,
-		BalanceAfter:    balanceAfter,
-	}); err != nil {
-		// Log error but don't fail the deduction
-		log.Printf("Failed to create transaction record: %v", err)
-	}
-
 	return &pb.DeductCoinsResponse{
 		Success:       true,
-		WarriorId:     uint32(warrior.ID),
-		BalanceBefore: balanceBefore,
-		BalanceAfter:  balanceAfter,
-		Message:       "coins deducted successfully",
+		WarriorId:     uint32(change.WarriorID),
+		BalanceBefore: change.BalanceBefore,
+		BalanceAfter:  change.BalanceAfter,
+		Message:       message,
+		Replayed:      change.Replayed,
 	}, nil
 }
 
-// AddCoins adds coins to warrior's balance
+// AddCoins adds coins to warrior's balance. A request whose idempotency key was already used gets
+// the first result back and nothing is added again.
 func (s *CoinServiceServer) AddCoins(ctx context.Context, req *pb.AddCoinsRequest) (*pb.AddCoinsResponse, error) {
-	var warrior Warrior
-	if err := DB.Table("warriors").Where("id = ?", req.WarriorId).First(&warrior).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+	change, err := s.Service.Add(ctx, dto.AddCoinsCommand{
+		WarriorID:      uint(req.WarriorId),
+		Amount:         req.Amount,
+		Reason:         req.Reason,
+		IdempotencyKey: req.IdempotencyKey,
+		Account:        req.Account,
+	})
+	if err != nil {
+		if errors.Is(err, ErrWarriorNotFound) {
 			return nil, status.Errorf(codes.NotFound, "warrior not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get warrior: %v", err)
-	}
-
-	balanceBefore := int64(warrior.CoinBalance)
-	warrior.CoinBalance += int(req.Amount)
-	balanceAfter := int64(warrior.CoinBalance)
-
-	if err := DB.Table("warriors").Where("id = ?", req.WarriorId).Update("coin_balance", warrior.CoinBalance).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to update balance: %v", err)
+		message, rejected := rejection(err)
+		if !rejected {
+			return nil, status.Errorf(codes.Internal, "failed to add coins: %v", err)
+		}
+		return &pb.AddCoinsResponse{
+			Success:   false,
+			WarriorId: req.WarriorId,
+			Message:   message,
+		}, nil
 	}
 
-	// Create transaction record
-	if _, err := s.Service.CreateTransaction(ctx, dto.CreateTransactionCommand{
-		WarriorID:       warrior.ID,
-		Amount:          req.Amount,
-		TransactionType: string(TransactionTypeAdd),
-		Reason:          req.Reason,
-		BalanceBefore:   balanceBefore,
-		BalanceAfter:    balanceAfter,
-	}); err != nil {
-		// Log error but don't fail the addition
-		log.Printf("Failed to create transaction record: %v", err)
+	message := "coins added successfully"
+	if change.Replayed {
+		message = "coins already added for this idempotency key"
 	}
-
 	return &pb.AddCoinsResponse{
 		Success:       true,
-		WarriorId:     uint32(warrior.ID),
-		BalanceBefore: balanceBefore,
-		BalanceAfter:  balanceAfter,
-		Message:       "coins added successfully",
+		WarriorId:     uint32(change.WarriorID),
+		BalanceBefore: change.BalanceBefore,
+		BalanceAfter:  change.BalanceAfter,
+		Message:       message,
+		Replayed:      change.Replayed,
 	}, nil
 }
 
-// TransferCoins transfers coins between warriors
+// TransferCoins transfers coins between warriors in one transaction. A request whose idempotency key
+// was already used gets the first result back and nothing is transferred again.
 func (s *CoinServiceServer) TransferCoins(ctx context.Context, req *pb.TransferCoinsRequest) (*pb.TransferCoinsResponse, error) {
-	// Deduct from sender
-	deductResp, err := s.DeductCoins(ctx, &pb.DeductCoinsRequest{
-		WarriorId: req.FromWarriorId,
-		Amount:    req.Amount,
-		Reason:    "transfer_out: " + req.Reason,
+	change, err := s.Service.Transfer(ctx, dto.TransferCoinsCommand{
+		FromWarriorID:  uint(req.FromWarriorId),
+		ToWarriorID:    uint(req.ToWarriorId),
+		Amount:         req.Amount,
+		Reason:         req.Reason,
+		IdempotencyKey: req.IdempotencyKey,
 	})
-	if err != nil || !deductResp.Success {
+	if err != nil {
+		message, rejected := rejection(err)
+		if errors.Is(err, ErrWarriorNotFound) {
+			message, rejected = ErrWarriorNotFound.Error(), true
+		}
+		if !rejected {
+			return nil, status.Errorf(codes.Internal, "failed to transfer coins: %v", err)
+		}
 		return &pb.TransferCoinsResponse{
 			Success:       false,
 			FromWarriorId: req.FromWarriorId,
 			ToWarriorId:   req.ToWarriorId,
 			Amount:        req.Amount,
-			Message:       "failed to deduct coins from sender",
+			Message:       message,
 		}, nil
 	}
 
-	// Add to receiver
-	addResp, err := s.AddCoins(ctx, &pb.AddCoinsRequest{
-		WarriorId: req.ToWarriorId,
-		Amount:    req.Amount,
-		Reason:    "transfer_in: " + req.Reason,
-	})
-	if err != nil || !addResp.Success {
-		// Rollback: add coins back to sender
-		s.AddCoins(ctx, &pb.AddCoinsRequest{
-			WarriorId: req.FromWarriorId,
-			Amount:    req.Amount,
-			Reason:    "rollback transfer_out",
-		})
-
-		return &pb.TransferCoinsResponse{
-			Success:       false,
-			FromWarriorId: req.FromWarriorId,
-			ToWarriorId:   req.ToWarriorId,
-			Amount:        req.Amount,
-			Message:       "failed to add coins to receiver, transaction rolled back",
-		}, nil
+	message := "coins transferred successfully"
+	if change.Replayed {
+		message = "coins already transferred for this idempotency key"
 	}
-
 	return &pb.TransferCoinsResponse{
 		Success:       true,
 		FromWarriorId: req.FromWarriorId,
 		ToWarriorId:   req.ToWarriorId,
 		Amount:        req.Amount,
-		Message:       "coins transferred successfully",
+		Message:       message,
+		Replayed:      change.Replayed,
 	}, nil
 }
 
+// rejection reports whether err is a request the service turned down, rather than a failure to
+// reach the database, and the message to send back for it
+func rejection(err error) (string, bool) {
+	for _, reason := range []error{ErrInvalidAmount, ErrSelfTransfer, ErrInsufficientBalance, ErrIdempotencyKeyReused, ErrInvalidAccount, ErrHoldCaptured, ErrHoldReleased, ErrHoldExpired, ErrInvalidHoldExpiry} {
+		if errors.Is(err, reason) {
+			return reason.Error(), true
+		}
+	}
+	return "", false
+}
+
 // GetTransactionHistory returns transaction history for a warrior
 func (s *CoinServiceServer) GetTransactionHistory(ctx context.Context, req *pb.GetTransactionHistoryRequest) (*pb.GetTransactionHistoryResponse, error) {
 	limit := int(req.Limit)
@@ -224,3 +211,184 @@ func (s *CoinServiceServer) GetTransactionHistory(ctx context.Context, req *pb.G
 		Total:        int32(count),
 	}, nil
 }
+
+// LockWagerStake moves an emperor's wager stake into escrow
+func (s *CoinServiceServer) LockWagerStake(ctx context.Context, req *pb.LockWagerStakeRequest) (*pb.LockWagerStakeResponse, error) {
+	if req.BattleId == "" || req.WarriorId == 0 || req.Amount <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "battle_id, warrior_id and a positive amount are required")
+	}
+
+	escrow, alreadyLocked, err := s.Service.LockWagerStake(ctx, dto.LockWagerStakeCommand{
+		BattleID:  req.BattleId,
+		WarriorID: uint(req.WarriorId),
+		Amount:    req.Amount,
+	})
+	if err != nil {
+		// Failures the emperor can act on are reported in the response, like DeductCoins
+		return &pb.LockWagerStakeResponse{
+			Success:   false,
+			BattleId:  req.BattleId,
+			WarriorId: req.WarriorId,
+			Amount:    req.Amount,
+			Message:   err.Error(),
+		}, nil
+	}
+
+	balance, _ := s.Service.GetBalance(ctx, dto.GetBalanceQuery{WarriorID: escrow.WarriorID})
+	message := "wager stake locked"
+	if alreadyLocked {
+		message = "wager stake already locked"
+	}
+	return &pb.LockWagerStakeResponse{
+		Success:       true,
+		BattleId:      escrow.BattleID,
+		WarriorId:     uint32(escrow.WarriorID),
+		Amount:        escrow.Amount,
+		BalanceAfter:  balance,
+		AlreadyLocked: alreadyLocked,
+		Message:       message,
+	}, nil
+}
+
+// ResolveWager pays a battle's escrowed pot to the winner or refunds every stake
+func (s *CoinServiceServer) ResolveWager(ctx context.Context, req *pb.ResolveWagerRequest) (*pb.ResolveWagerResponse, error) {
+	if req.BattleId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "battle_id is required")
+	}
+
+	resolution, err := s.Service.ResolveWager(ctx, dto.ResolveWagerCommand{
+		BattleID:        req.BattleId,
+		WinnerWarriorID: uint(req.WinnerWarriorId),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to resolve wager: %v", err)
+	}
+
+	return &pb.ResolveWagerResponse{
+		Success:  true,
+		BattleId: req.BattleId,
+		PaidOut:  resolution.PaidOut,
+		Refunded: resolution.Refunded,
+		Message:  "wager resolved",
+	}, nil
+}
+
+// PlaceHold reserves coins on a warrior's balance. A hold placed again under the same reference is
+// returned as it stands and nothing more is reserved.
+func (s *CoinServiceServer) PlaceHold(ctx context.Context, req *pb.PlaceHoldRequest) (*pb.PlaceHoldResponse, error) {
+	if req.Reference == "" || req.WarriorId == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "warrior_id and reference are required")
+	}
+
+	hold, err := s.Service.PlaceHold(ctx, dto.PlaceHoldCommand{
+		WarriorID: uint(req.WarriorId),
+		Amount:    req.Amount,
+		Reason:    req.Reason,
+		Reference: req.Reference,
+		Account:   req.Account,
+		ExpiresIn: time.Duration(req.ExpiresInSeconds) * time.Second,
+	})
+	if err != nil {
+		if errors.Is(err, ErrWarriorNotFound) {
+			return nil, status.Errorf(codes.NotFound, "warrior not found")
+		}
+		message, rejected := rejection(err)
+		if !rejected {
+			return nil, status.Errorf(codes.Internal, "failed to place hold: %v", err)
+		}
+		return &pb.PlaceHoldResponse{Success: false, Message: message}, nil
+	}
+
+	message := "coins held"
+	if hold.Status != HoldStatusHeld {
+		message = "hold was already " + string(hold.Status)
+	}
+	return &pb.PlaceHoldResponse{Success: true, Hold: toProtoHold(hold), Message: message}, nil
+}
+
+// CaptureHold takes the coins a hold reserved, or part of them. Capturing a captured hold again for
+// the same amount changes nothing.
+func (s *CoinServiceServer) CaptureHold(ctx context.Context, req *pb.CaptureHoldRequest) (*pb.CaptureHoldResponse, error) {
+	hold, err := s.Service.CaptureHold(ctx, dto.CaptureHoldCommand{HoldID: uint(req.HoldId), Amount: req.Amount})
+	if err != nil {
+		if errors.Is(err, ErrHoldNotFound) {
+			return nil, status.Errorf(codes.NotFound, "hold not found")
+		}
+		message, rejected := rejection(err)
+		if !rejected {
+			return nil, status.Errorf(codes.Internal, "failed to capture hold: %v", err)
+		}
+		response := &pb.CaptureHoldResponse{Success: false, Message: message}
+		if hold != nil {
+			response.Hold = toProtoHold(hold)
+		}
+		return response, nil
+	}
+
+	balance, _ := s.Service.GetBalance(ctx, dto.GetBalanceQuery{WarriorID: hold.WarriorID})
+	return &pb.CaptureHoldResponse{Success: true, Hold: toProtoHold(hold), BalanceAfter: balance, Message: "hold captured"}, nil
+}
+
+// ReleaseHold gives the coins a hold reserved back. Releasing a released hold changes nothing.
+func (s *CoinServiceServer) ReleaseHold(ctx context.Context, req *pb.ReleaseHoldRequest) (*pb.ReleaseHoldResponse, error) {
+	hold, err := s.Service.ReleaseHold(ctx, uint(req.HoldId))
+	if err != nil {
+		if errors.Is(err, ErrHoldNotFound) {
+			return nil, status.Errorf(codes.NotFound, "hold not found")
+		}
+		message, rejected := rejection(err)
+		if !rejected {
+			return nil, status.Errorf(codes.Internal, "failed to release hold: %v", err)
+		}
+		response := &pb.ReleaseHoldResponse{Success: false, Message: message}
+		if hold != nil {
+			response.Hold = toProtoHold(hold)
+		}
+		return response, nil
+	}
+
+	return &pb.ReleaseHoldResponse{Success: true, Hold: toProtoHold(hold), Message: "hold released"}, nil
+}
+
+// ListHolds returns a warrior's holds, newest first
+func (s *CoinServiceServer) ListHolds(ctx context.Context, req *pb.ListHoldsRequest) (*pb.ListHoldsResponse, error) {
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = 50
+	}
+
+	holds, count, err := s.Service.ListHolds(ctx, dto.ListHoldsQuery{
+		WarriorID: uint(req.WarriorId),
+		Status:    req.Status,
+		Limit:     limit,
+		Offset:    int(req.Offset),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list holds: %v", err)
+	}
+
+	protoHolds := make([]*pb.Hold, len(holds))
+	for i := range holds {
+		protoHolds[i] = toProtoHold(&holds[i])
+	}
+
+	return &pb.ListHoldsResponse{
+		Holds: protoHolds,
+		Total: int32(count),
+	}, nil
+}
+
+func toProtoHold(hold *CoinHold) *pb.Hold {
+	return &pb.Hold{
+		Id:             uint32(hold.ID),
+		Reference:      hold.Reference,
+		WarriorId:      uint32(hold.WarriorID),
+		Amount:         hold.Amount,
+		CapturedAmount: hold.CapturedAmount,
+		Account:        hold.Account,
+		Reason:         hold.Reason,
+		Status:         string(hold.Status),
+		CreatedAt:      timestamppb.New(hold.CreatedAt),
+		ExpiresAt:      timestamppb.New(hold.ExpiresAt),
+	}
+}
@@ -0,0 +1,273 @@
+package coin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"network-sec-micro/internal/coin/dto"
+
+	"gorm.io/gorm"
+)
+
+// A hold reserves coins for a purchase whose outcome, or final price, is not known yet. Held coins
+// stay in the warrior's balance but no deduction, transfer or other hold can spend them. Capturing
+// the hold takes all or part of the coins to its account and gives back the rest; releasing it gives
+// them all back. A hold that is neither captured nor released before it expires gives its coins back
+// by itself. Every step is safe to retry: a hold is placed once per reference, and a hold that is
+// already closed stays that way.
+
+const (
+	holdDefaultExpiry = 15 * time.Minute
+	holdMaxExpiry     = 24 * time.Hour
+)
+
+var (
+	// ErrHoldCaptured is returned when releasing a hold whose coins were already taken
+	ErrHoldCaptured = errors.New("hold was already captured")
+	// ErrHoldReleased is returned when capturing a hold that was already released
+	ErrHoldReleased = errors.New("hold was already released")
+	// ErrHoldExpired is returned when capturing a hold that expired first
+	ErrHoldExpired = errors.New("hold has expired")
+	// ErrInvalidHoldExpiry is returned for a hold that would last less than nothing or too long
+	ErrInvalidHoldExpiry = errors.New("hold expiry must be between 0 and 24 hours")
+)
+
+// WalletBalance is a warrior's balance split into the coins held and the coins they can spend
+type WalletBalance struct {
+	WarriorID uint
+	Balance   int64 // Every coin the warrior has, held or not
+	Held      int64 // Reserved by holds
+	Available int64 // Balance minus Held
+}
+
+// PlaceHold reserves coins on a warrior's balance. Placing a hold under a reference that is already
+// used returns the first hold, whatever its status; a different warrior or amount is rejected.
+func (s *Service) PlaceHold(ctx context.Context, cmd dto.PlaceHoldCommand) (*CoinHold, error) {
+	if cmd.Reference == "" {
+		return nil, errors.New("hold reference is required")
+	}
+	if cmd.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if cmd.ExpiresIn < 0 || cmd.ExpiresIn > holdMaxExpiry {
+		return nil, ErrInvalidHoldExpiry
+	}
+	expiresIn := cmd.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = holdDefaultExpiry
+	}
+
+	account, err := counterpartyAccount(cmd.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetHoldByReference(ctx, cmd.Reference)
+	if err == nil {
+		return sameHold(existing, cmd)
+	}
+	if !errors.Is(err, ErrHoldNotFound) {
+		return nil, fmt.Errorf("place hold failed: %w", err)
+	}
+
+	hold := &CoinHold{
+		Reference: cmd.Reference,
+		WarriorID: cmd.WarriorID,
+		Amount:    cmd.Amount,
+		Account:   account,
+		Reason:    cmd.Reason,
+		Status:    HoldStatusHeld,
+		ExpiresAt: time.Now().Add(expiresIn),
+	}
+	err = s.repo.ExecuteInTransaction(ctx, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		balances, err := repo.LockWarriors(ctx, cmd.WarriorID)
+		if err != nil {
+			return err
+		}
+		held, err := repo.SumActiveHolds(ctx, cmd.WarriorID)
+		if err != nil {
+			return err
+		}
+		if balances[cmd.WarriorID]-held < cmd.Amount {
+			return ErrInsufficientBalance
+		}
+
+		return repo.CreateHold(ctx, hold)
+	})
+	if err != nil {
+		// A concurrent call may have placed the hold first, in which case the unique reference rolled this one back
+		if existing, getErr := s.repo.GetHoldByReference(ctx, cmd.Reference); getErr == nil {
+			return sameHold(existing, cmd)
+		}
+		return nil, fmt.Errorf("place hold failed: %w", err)
+	}
+
+	return hold, nil
+}
+
+// sameHold accepts a repeated hold if it reserves the same coins
+func sameHold(existing *CoinHold, cmd dto.PlaceHoldCommand) (*CoinHold, error) {
+	if existing.WarriorID != cmd.WarriorID || existing.Amount != cmd.Amount {
+		return nil, ErrIdempotencyKeyReused
+	}
+	return existing, nil
+}
+
+// CaptureHold takes the coins a hold reserved, or part of them, from the warrior's wallet to the
+// hold's account; the rest is given back. Capturing a captured hold again for the same amount changes
+// nothing; a released or expired hold cannot be captured.
+func (s *Service) CaptureHold(ctx context.Context, cmd dto.CaptureHoldCommand) (*CoinHold, error) {
+	if cmd.Amount < 0 {
+		return nil, ErrInvalidAmount
+	}
+	hold, err := s.closeHold(ctx, cmd.HoldID, HoldStatusCaptured, cmd.Amount)
+	if err != nil {
+		return hold, fmt.Errorf("capture hold failed: %w", err)
+	}
+	return hold, nil
+}
+
+// ReleaseHold gives the coins a hold reserved back to the warrior's available balance. Releasing a
+// released or expired hold changes nothing; a captured hold cannot be released.
+func (s *Service) ReleaseHold(ctx context.Context, holdID uint) (*CoinHold, error) {
+	hold, err := s.closeHold(ctx, holdID, HoldStatusReleased, 0)
+	if err != nil {
+		return hold, fmt.Errorf("release hold failed: %w", err)
+	}
+	return hold, nil
+}
+
+// closeHold captures or releases a hold. A hold that was already closed another way is returned with
+// ErrHoldCaptured, ErrHoldReleased or ErrHoldExpired.
+func (s *Service) closeHold(ctx context.Context, holdID uint, status HoldStatus, amount int64) (*CoinHold, error) {
+	var hold *CoinHold
+	err := s.repo.ExecuteInTransaction(ctx, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+
+		var err error
+		if hold, err = repo.GetHold(ctx, holdID); err != nil {
+			return err
+		}
+		// Holds change only under their warrior's lock, so read the hold again once it is taken
+		if _, err := repo.LockWarriors(ctx, hold.WarriorID); err != nil {
+			return err
+		}
+		if hold, err = repo.GetHold(ctx, holdID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if hold.Status == HoldStatusHeld && !hold.ExpiresAt.After(now) {
+			hold.Status = HoldStatusExpired // Not marked by ExpireHolds yet
+		}
+
+		capture := amount
+		if status == HoldStatusCaptured && capture == 0 {
+			capture = hold.Amount
+		}
+		switch hold.Status {
+		case HoldStatusCaptured:
+			if status == HoldStatusCaptured && hold.CapturedAmount == capture {
+				return nil
+			}
+			return ErrHoldCaptured
+		case HoldStatusReleased, HoldStatusExpired:
+			if status == HoldStatusReleased {
+				return nil
+			}
+			if hold.Status == HoldStatusExpired {
+				return ErrHoldExpired
+			}
+			return ErrHoldReleased
+		}
+		if capture > hold.Amount {
+			return fmt.Errorf("%w: cannot capture more than the %d coins held", ErrInvalidAmount, hold.Amount)
+		}
+
+		if _, err := repo.CloseHold(ctx, hold.ID, status, capture, now); err != nil {
+			return err
+		}
+		hold.Status, hold.CapturedAmount, hold.ClosedAt, hold.UpdatedAt = status, capture, &now, now
+
+		if status == HoldStatusCaptured {
+			// The hold no longer counts against the balance it is taken from
+			if _, err := debit(ctx, repo, hold.WarriorID, capture, hold.Account, TransactionTypeHoldCapture, hold.Reason); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrHoldCaptured) && !errors.Is(err, ErrHoldReleased) && !errors.Is(err, ErrHoldExpired) {
+		return nil, err
+	}
+	return hold, err
+}
+
+// ListHolds lists a warrior's holds, newest first. Holds past their expiry are marked expired first,
+// so none is listed as held once it no longer reserves anything.
+func (s *Service) ListHolds(ctx context.Context, query dto.ListHoldsQuery) ([]CoinHold, int64, error) {
+	if _, err := s.repo.ExpireHolds(ctx, time.Now()); err != nil {
+		return nil, 0, fmt.Errorf("list holds failed: %w", err)
+	}
+	holds, count, err := s.repo.ListHolds(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list holds failed: %w", err)
+	}
+	return holds, count, nil
+}
+
+// GetWalletBalance gets a warrior's balance together with the coins held and available
+func (s *Service) GetWalletBalance(ctx context.Context, query dto.GetBalanceQuery) (*WalletBalance, error) {
+	balance, err := s.repo.GetWarriorBalance(ctx, query.WarriorID)
+	if err != nil {
+		return nil, fmt.Errorf("get balance failed: %w", err)
+	}
+	held, err := s.repo.SumActiveHolds(ctx, query.WarriorID)
+	if err != nil {
+		return nil, fmt.Errorf("get balance failed: %w", err)
+	}
+	return &WalletBalance{WarriorID: query.WarriorID, Balance: balance, Held: held, Available: balance - held}, nil
+}
+
+// ExpireHolds marks the holds past their expiry as expired. Their coins are available again as soon
+// as they expire; marking them only keeps their status honest.
+func (s *Service) ExpireHolds(ctx context.Context) (int64, error) {
+	expired, err := s.repo.ExpireHolds(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("expire holds failed: %w", err)
+	}
+	return expired, nil
+}
+
+// StartHoldExpirer expires holds every interval until ctx is done
+func (s *Service) StartHoldExpirer(ctx context.Context, interval time.Duration) {
+	check := func() {
+		expired, err := s.ExpireHolds(ctx)
+		if err != nil {
+			log.Printf("Hold expiry failed: %v", err)
+			return
+		}
+		if expired > 0 {
+			log.Printf("Expired %d coin holds", expired)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		check()
+		for {
+			select {
+			case <-ticker.C:
+				check()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
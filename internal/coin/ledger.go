@@ -0,0 +1,229 @@
+package coin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Every coin movement is also a double-entry ledger entry: coins leave one account and enter another,
+// so an entry's postings sum to zero. Warriors' wallets sit next to the game's own accounts: the
+// treasury that pays rewards and takes penalties, the shop and service revenue accounts, the wager
+// escrow and enemies' wallets. warriors.coin_balance stays the balance the other services read; the
+// reconciliation job checks it against the wallet postings and reports any drift.
+
+// Ledger accounts owned by the game
+const (
+	AccountTreasury       = "treasury"        // Pays rewards and opening balances, takes penalties
+	AccountShopRevenue    = "shop_revenue"    // Weapon and armor purchases
+	AccountServiceRevenue = "service_revenue" // Heals and repairs
+	AccountWagerEscrow    = "wager_escrow"    // Emperors' stakes until their battle is resolved
+
+	walletAccountPrefix = "warrior:"
+	enemyAccountPrefix  = "enemy:"
+)
+
+var (
+	// ErrInvalidAccount is returned for a ledger account coins cannot be moved to or from directly
+	ErrInvalidAccount = errors.New("invalid ledger account")
+	// ErrUnbalancedEntry is returned for a ledger entry whose postings do not sum to zero
+	ErrUnbalancedEntry = errors.New("ledger entry is not balanced")
+)
+
+// WalletAccount is the ledger account of a warrior's coins
+func WalletAccount(warriorID uint) string {
+	return walletAccountPrefix + strconv.FormatUint(uint64(warriorID), 10)
+}
+
+// EnemyAccount is the ledger account of an enemy's coins
+func EnemyAccount(enemyID string) string {
+	return enemyAccountPrefix + enemyID
+}
+
+// counterpartyAccount resolves the account on the other side of a deduction or addition, defaulting to
+// the treasury. Wallets and the escrow are moved only by transfers and wagers.
+func counterpartyAccount(account string) (string, error) {
+	switch {
+	case account == "":
+		return AccountTreasury, nil
+	case account == AccountTreasury, account == AccountShopRevenue, account == AccountServiceRevenue:
+		return account, nil
+	case strings.HasPrefix(account, enemyAccountPrefix) && len(account) > len(enemyAccountPrefix):
+		return account, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrInvalidAccount, account)
+}
+
+// post records a balanced ledger entry. A wallet that has never been posted to is opened first with
+// the warrior's current balance, so it must be posted before the warrior's balance changes.
+func post(ctx context.Context, repo *Repository, description string, postings ...LedgerPosting) error {
+	var sum int64
+	for _, p := range postings {
+		sum += p.Amount
+	}
+	if len(postings) < 2 || sum != 0 {
+		return ErrUnbalancedEntry
+	}
+
+	for _, p := range postings {
+		if id, ok := walletOwner(p.Account); ok {
+			if err := openWallet(ctx, repo, id); err != nil {
+				return err
+			}
+		}
+	}
+
+	return repo.CreateLedgerEntry(ctx, &LedgerEntry{Description: description, Postings: postings})
+}
+
+// openWallet brings a wallet into the ledger. Warriors start with coins the coin service never moved,
+// so the first posting to a wallet is preceded by an opening entry from the treasury.
+func openWallet(ctx context.Context, repo *Repository, warriorID uint) error {
+	account := WalletAccount(warriorID)
+	opened, err := repo.HasLedgerPostings(ctx, account)
+	if err != nil || opened {
+		return err
+	}
+
+	balance, err := repo.GetWarriorBalance(ctx, warriorID)
+	if err != nil {
+		return err
+	}
+	if balance == 0 {
+		return nil
+	}
+	return repo.CreateLedgerEntry(ctx, &LedgerEntry{
+		Description: "opening_balance",
+		Postings: []LedgerPosting{
+			{Account: AccountTreasury, Amount: -balance},
+			{Account: account, Amount: balance},
+		},
+	})
+}
+
+func walletOwner(account string) (uint, bool) {
+	if !strings.HasPrefix(account, walletAccountPrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(account, walletAccountPrefix), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// GetLedgerBalance derives an account's balance from its postings
+func (s *Service) GetLedgerBalance(ctx context.Context, account string) (int64, error) {
+	balance, err := s.repo.GetLedgerBalance(ctx, account)
+	if err != nil {
+		return 0, fmt.Errorf("get ledger balance failed: %w", err)
+	}
+	return balance, nil
+}
+
+// WalletDrift is a warrior whose balance does not match their wallet's postings
+type WalletDrift struct {
+	WarriorID     uint
+	Balance       int64 // warriors.coin_balance
+	LedgerBalance int64 // Sum of the wallet's postings
+}
+
+// LedgerReconciliation reports where the ledger and the stored balances disagree
+type LedgerReconciliation struct {
+	CheckedWallets    int
+	UnopenedWallets   int // Warriors whose coins have never moved; they are opened on first use
+	Drifts            []WalletDrift
+	EscrowHeld        int64 // Stakes still locked in escrow
+	EscrowLedger      int64 // Balance of the wager escrow account
+	UnbalancedEntries []uint
+}
+
+// Clean reports whether the reconciliation found nothing wrong
+func (r *LedgerReconciliation) Clean() bool {
+	return len(r.Drifts) == 0 && len(r.UnbalancedEntries) == 0 && r.EscrowHeld == r.EscrowLedger
+}
+
+// ReconcileLedger checks every wallet's postings against the warrior's stored balance, the escrow
+// account against the stakes held and every entry for balance
+func (s *Service) ReconcileLedger(ctx context.Context) (*LedgerReconciliation, error) {
+	balances, err := s.repo.ListWarriorBalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile ledger failed: %w", err)
+	}
+	wallets, err := s.repo.ListLedgerBalances(ctx, walletAccountPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile ledger failed: %w", err)
+	}
+
+	report := &LedgerReconciliation{}
+	opened := make(map[uint]bool, len(wallets))
+	for _, wallet := range wallets {
+		id, ok := walletOwner(wallet.Account)
+		if !ok {
+			continue
+		}
+		opened[id] = true
+		report.CheckedWallets++
+		if balance := balances[id]; balance != wallet.Balance {
+			report.Drifts = append(report.Drifts, WalletDrift{WarriorID: id, Balance: balance, LedgerBalance: wallet.Balance})
+		}
+	}
+	for id := range balances {
+		if !opened[id] {
+			report.UnopenedWallets++
+		}
+	}
+
+	if report.EscrowHeld, err = s.repo.SumLockedWagerEscrows(ctx); err != nil {
+		return nil, fmt.Errorf("reconcile ledger failed: %w", err)
+	}
+	if report.EscrowLedger, err = s.repo.GetLedgerBalance(ctx, AccountWagerEscrow); err != nil {
+		return nil, fmt.Errorf("reconcile ledger failed: %w", err)
+	}
+	if report.UnbalancedEntries, err = s.repo.ListUnbalancedLedgerEntries(ctx); err != nil {
+		return nil, fmt.Errorf("reconcile ledger failed: %w", err)
+	}
+
+	return report, nil
+}
+
+// StartLedgerReconciler reconciles the ledger every interval until ctx is done and logs any drift
+func (s *Service) StartLedgerReconciler(ctx context.Context, interval time.Duration) {
+	check := func() {
+		report, err := s.ReconcileLedger(ctx)
+		if err != nil {
+			log.Printf("Ledger reconciliation failed: %v", err)
+			return
+		}
+		for _, d := range report.Drifts {
+			log.Printf("Ledger drift for warrior %d: balance %d, ledger %d", d.WarriorID, d.Balance, d.LedgerBalance)
+		}
+		if report.EscrowHeld != report.EscrowLedger {
+			log.Printf("Ledger drift for wager escrow: %d held, ledger %d", report.EscrowHeld, report.EscrowLedger)
+		}
+		if len(report.UnbalancedEntries) > 0 {
+			log.Printf("Unbalanced ledger entries: %v", report.UnbalancedEntries)
+		}
+		if report.Clean() {
+			log.Printf("Ledger reconciled: %d wallets match", report.CheckedWallets)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		check()
+		for {
+			select {
+			case <-ticker.C:
+				check()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
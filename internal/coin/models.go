@@ -10,6 +10,10 @@ const (
 	TransactionTypeDeduct    TransactionType = "deduct"
 	TransactionTypeTransferIn  TransactionType = "transfer_in"
 	TransactionTypeTransferOut TransactionType = "transfer_out"
+	TransactionTypeEscrowLock   TransactionType = "escrow_lock"   // Wager stake moved into escrow
+	TransactionTypeEscrowPayout TransactionType = "escrow_payout" // Wager pot paid to the winner
+	TransactionTypeEscrowRefund TransactionType = "escrow_refund" // Wager stake given back
+	TransactionTypeHoldCapture  TransactionType = "hold_capture"  // Coins reserved by a hold taken
 )
 
 // Transaction represents a coin transaction
@@ -29,3 +33,111 @@ func (Transaction) TableName() string {
 	return "coin_transactions"
 }
 
+// EscrowStatus represents the state of a wager stake held in escrow
+type EscrowStatus string
+
+const (
+	EscrowStatusLocked   EscrowStatus = "locked"   // Taken from the emperor, waiting for the battle to end
+	EscrowStatusPaidOut  EscrowStatus = "paid_out" // Part of the pot paid to the winner
+	EscrowStatusRefunded EscrowStatus = "refunded" // Given back after a draw or cancel
+)
+
+// WagerEscrow is an emperor's stake on a battle, held by the coin service until the battle is resolved.
+// There is at most one per battle and emperor.
+type WagerEscrow struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	BattleID   string       `gorm:"type:varchar(64);not null;uniqueIndex:idx_wager_escrow_battle_warrior" json:"battle_id"`
+	WarriorID  uint         `gorm:"not null;uniqueIndex:idx_wager_escrow_battle_warrior" json:"warrior_id"`
+	Amount     int64        `gorm:"not null" json:"amount"`
+	Status     EscrowStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	PaidTo     uint         `json:"paid_to,omitempty"` // Winner the stake went to (paid out only)
+	ResolvedAt *time.Time   `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// TableName specifies the table name for WagerEscrow
+func (WagerEscrow) TableName() string {
+	return "coin_wager_escrows"
+}
+
+// IdempotencyRecord remembers a coin mutation made under an idempotency key, so a replay of the same
+// request returns the original result instead of changing the balance again. Only mutations that
+// succeeded are recorded: a failed one changed nothing and may simply be retried.
+type IdempotencyRecord struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	Key           string    `gorm:"type:varchar(191);not null;uniqueIndex" json:"key"`
+	Operation     string    `gorm:"type:varchar(20);not null" json:"operation"` // deduct, add or transfer
+	WarriorID     uint      `gorm:"not null" json:"warrior_id"`                 // The sender of a transfer
+	ToWarriorID   uint      `json:"to_warrior_id,omitempty"`                    // Transfers only
+	Amount        int64     `gorm:"not null" json:"amount"`
+	BalanceBefore int64     `gorm:"not null" json:"balance_before"`
+	BalanceAfter  int64     `gorm:"not null" json:"balance_after"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for IdempotencyRecord
+func (IdempotencyRecord) TableName() string {
+	return "coin_idempotency_keys"
+}
+
+// LedgerEntry is one movement of coins in the double-entry ledger. Its postings always sum to zero, so
+// every coin taken out of an account goes into another.
+type LedgerEntry struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	Description string          `gorm:"type:text" json:"description"`
+	Postings    []LedgerPosting `gorm:"foreignKey:EntryID" json:"postings,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// TableName specifies the table name for LedgerEntry
+func (LedgerEntry) TableName() string {
+	return "coin_ledger_entries"
+}
+
+// LedgerPosting is one side of a ledger entry. An account's balance is the sum of its postings.
+type LedgerPosting struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	EntryID   uint      `gorm:"not null;index" json:"entry_id"`
+	Account   string    `gorm:"type:varchar(64);not null;index" json:"account"`
+	Amount    int64     `gorm:"not null" json:"amount"` // positive credits the account, negative debits it
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for LedgerPosting
+func (LedgerPosting) TableName() string {
+	return "coin_ledger_postings"
+}
+
+// HoldStatus represents the state of coins reserved by a hold
+type HoldStatus string
+
+const (
+	HoldStatusHeld     HoldStatus = "held"     // Reserved on the warrior's balance
+	HoldStatusCaptured HoldStatus = "captured" // Taken to the hold's account
+	HoldStatusReleased HoldStatus = "released" // Given back to the warrior's available balance
+	HoldStatusExpired  HoldStatus = "expired"  // Neither captured nor released in time; given back
+)
+
+// CoinHold reserves coins on a warrior's balance until a purchase is finished. Held coins stay in the
+// warrior's balance but cannot be spent elsewhere. The caller names each hold with a unique reference.
+// A hold that is neither captured nor released by ExpiresAt stops reserving anything.
+type CoinHold struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	Reference      string     `gorm:"type:varchar(191);not null;uniqueIndex" json:"reference"`
+	WarriorID      uint       `gorm:"not null;index" json:"warrior_id"`
+	Amount         int64      `gorm:"not null" json:"amount"`
+	CapturedAmount int64      `gorm:"not null;default:0" json:"captured_amount"` // Taken on capture; the rest was given back
+	Account        string     `gorm:"type:varchar(64);not null" json:"account"`  // Ledger account the coins go to on capture
+	Reason         string     `gorm:"type:text" json:"reason"`
+	Status         HoldStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	ExpiresAt      time.Time  `gorm:"not null;index" json:"expires_at"`
+	ClosedAt       *time.Time `json:"closed_at,omitempty"` // When the hold was captured, released or expired
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for CoinHold
+func (CoinHold) TableName() string {
+	return "coin_holds"
+}
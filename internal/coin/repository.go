@@ -2,12 +2,27 @@ package coin
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"network-sec-micro/internal/coin/dto"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrWarriorNotFound is returned when a warrior has no coin account
+	ErrWarriorNotFound = errors.New("warrior not found")
+	// ErrEscrowNotFound is returned when a battle holds no stake from a warrior
+	ErrEscrowNotFound = errors.New("wager escrow not found")
+	// ErrIdempotencyKeyNotFound is returned when no mutation was made under an idempotency key
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	// ErrHoldNotFound is returned for a hold that was never placed
+	ErrHoldNotFound = errors.New("hold not found")
 )
 
 // Repository handles database operations with transaction safety
@@ -20,7 +35,8 @@ func NewRepository(db *gorm.DB) *Repository {
 	return &Repository{db: db}
 }
 
-// GetWarriorBalance gets warrior's balance with row lock for safety
+// GetWarriorBalance gets warrior's balance. Inside a transaction that changes it, lock the warrior
+// with LockWarriors first.
 func (r *Repository) GetWarriorBalance(ctx context.Context, warriorID uint) (int64, error) {
 	var balance int64
 	err := r.db.WithContext(ctx).
@@ -30,8 +46,8 @@ func (r *Repository) GetWarriorBalance(ctx context.Context, warriorID uint) (int
 		Row().Scan(&balance)
 	
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return 0, errors.New("warrior not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrWarriorNotFound
 		}
 		return 0, fmt.Errorf("failed to get warrior balance: %w", err)
 	}
@@ -39,6 +55,40 @@ func (r *Repository) GetWarriorBalance(ctx context.Context, warriorID uint) (int
 	return balance, nil
 }
 
+// LockWarriors locks the warriors' rows until the transaction ends (SELECT ... FOR UPDATE) and returns
+// their balances. Rows are locked in ascending ID order, so two transactions locking the same warriors
+// wait for each other instead of deadlocking. It must run on a repository from WithTx.
+func (r *Repository) LockWarriors(ctx context.Context, warriorIDs ...uint) (map[uint]int64, error) {
+	ids := append([]uint(nil), warriorIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var rows []struct {
+		ID          uint
+		CoinBalance int64
+	}
+	err := r.db.WithContext(ctx).
+		Table("warriors").
+		Select("id, coin_balance").
+		Where("id IN ?", ids).
+		Order("id ASC").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock warriors: %w", err)
+	}
+
+	balances := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		balances[row.ID] = row.CoinBalance
+	}
+	for _, id := range ids {
+		if _, ok := balances[id]; !ok {
+			return nil, ErrWarriorNotFound
+		}
+	}
+	return balances, nil
+}
+
 // UpdateWarriorBalance updates warrior's balance with transaction safety
 func (r *Repository) UpdateWarriorBalance(ctx context.Context, warriorID uint, newBalance int64) error {
 	result := r.db.WithContext(ctx).
@@ -51,7 +101,7 @@ func (r *Repository) UpdateWarriorBalance(ctx context.Context, warriorID uint, n
 	}
 	
 	if result.RowsAffected == 0 {
-		return errors.New("warrior not found")
+		return ErrWarriorNotFound
 	}
 	
 	return nil
@@ -90,6 +140,292 @@ func (r *Repository) GetTransactionHistory(ctx context.Context, query dto.GetTra
 	return transactions, count, nil
 }
 
+// GetWagerEscrow gets an emperor's stake on a battle
+func (r *Repository) GetWagerEscrow(ctx context.Context, battleID string, warriorID uint) (*WagerEscrow, error) {
+	var escrow WagerEscrow
+	err := r.db.WithContext(ctx).Where("battle_id = ? AND warrior_id = ?", battleID, warriorID).First(&escrow).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEscrowNotFound
+		}
+		return nil, fmt.Errorf("failed to get wager escrow: %w", err)
+	}
+	return &escrow, nil
+}
+
+// CreateWagerEscrow records a stake taken into escrow
+func (r *Repository) CreateWagerEscrow(ctx context.Context, escrow *WagerEscrow) error {
+	if err := r.db.WithContext(ctx).Create(escrow).Error; err != nil {
+		return fmt.Errorf("failed to create wager escrow: %w", err)
+	}
+	return nil
+}
+
+// ListLockedWagerEscrows lists the stakes on a battle that are still held
+func (r *Repository) ListLockedWagerEscrows(ctx context.Context, battleID string) ([]WagerEscrow, error) {
+	var escrows []WagerEscrow
+	err := r.db.WithContext(ctx).
+		Where("battle_id = ? AND status = ?", battleID, EscrowStatusLocked).
+		Order("id ASC").
+		Find(&escrows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wager escrows: %w", err)
+	}
+	return escrows, nil
+}
+
+// ResolveWagerEscrow moves a held stake to its final status. It returns false if the stake
+// was no longer held, i.e. another caller resolved it first.
+func (r *Repository) ResolveWagerEscrow(ctx context.Context, id uint, status EscrowStatus, paidTo uint, resolvedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&WagerEscrow{}).
+		Where("id = ? AND status = ?", id, EscrowStatusLocked).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"paid_to":     paidTo,
+			"resolved_at": resolvedAt,
+			"updated_at":  resolvedAt,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to resolve wager escrow: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// GetIdempotencyRecord gets the mutation made under an idempotency key
+func (r *Repository) GetIdempotencyRecord(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	var record IdempotencyRecord
+	err := r.db.WithContext(ctx).Where(&IdempotencyRecord{Key: key}).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrIdempotencyKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get idempotency record: %w", err)
+	}
+	return &record, nil
+}
+
+// CreateIdempotencyRecord records a mutation under its idempotency key. The key is unique, so a
+// concurrent request with the same key fails here and its transaction rolls back.
+func (r *Repository) CreateIdempotencyRecord(ctx context.Context, record *IdempotencyRecord) error {
+	if err := r.db.WithContext(ctx).Create(record).Error; err != nil {
+		return fmt.Errorf("failed to create idempotency record: %w", err)
+	}
+	return nil
+}
+
+// CreateLedgerEntry records a ledger entry together with its postings
+func (r *Repository) CreateLedgerEntry(ctx context.Context, entry *LedgerEntry) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create ledger entry: %w", err)
+	}
+	return nil
+}
+
+// HasLedgerPostings reports whether any coins were ever posted to an account
+func (r *Repository) HasLedgerPostings(ctx context.Context, account string) (bool, error) {
+	var ids []uint
+	if err := r.db.WithContext(ctx).Model(&LedgerPosting{}).Where("account = ?", account).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return false, fmt.Errorf("failed to check ledger postings: %w", err)
+	}
+	return len(ids) > 0, nil
+}
+
+// GetLedgerBalance sums the postings to an account
+func (r *Repository) GetLedgerBalance(ctx context.Context, account string) (int64, error) {
+	var balance int64
+	err := r.db.WithContext(ctx).
+		Model(&LedgerPosting{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("account = ?", account).
+		Row().Scan(&balance)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ledger balance: %w", err)
+	}
+	return balance, nil
+}
+
+// AccountBalance is an account's balance as summed from its postings
+type AccountBalance struct {
+	Account string
+	Balance int64
+}
+
+// ListLedgerBalances sums the postings of every account whose name starts with prefix
+func (r *Repository) ListLedgerBalances(ctx context.Context, prefix string) ([]AccountBalance, error) {
+	var balances []AccountBalance
+	err := r.db.WithContext(ctx).
+		Model(&LedgerPosting{}).
+		Select("account, SUM(amount) AS balance").
+		Where("account LIKE ?", prefix+"%").
+		Group("account").
+		Scan(&balances).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ledger balances: %w", err)
+	}
+	return balances, nil
+}
+
+// ListUnbalancedLedgerEntries lists the entries whose postings do not sum to zero
+func (r *Repository) ListUnbalancedLedgerEntries(ctx context.Context) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Model(&LedgerPosting{}).
+		Select("entry_id").
+		Group("entry_id").
+		Having("SUM(amount) <> 0").
+		Scan(&ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unbalanced ledger entries: %w", err)
+	}
+	return ids, nil
+}
+
+// ListWarriorBalances gets every warrior's balance from the warrior table
+func (r *Repository) ListWarriorBalances(ctx context.Context) (map[uint]int64, error) {
+	var rows []struct {
+		ID          uint
+		CoinBalance int64
+	}
+	if err := r.db.WithContext(ctx).Table("warriors").Select("id, coin_balance").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list warrior balances: %w", err)
+	}
+	balances := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		balances[row.ID] = row.CoinBalance
+	}
+	return balances, nil
+}
+
+// SumLockedWagerEscrows sums the stakes still held in escrow
+func (r *Repository) SumLockedWagerEscrows(ctx context.Context) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Model(&WagerEscrow{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("status = ?", EscrowStatusLocked).
+		Row().Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum wager escrows: %w", err)
+	}
+	return total, nil
+}
+
+// GetHold gets a hold by ID
+func (r *Repository) GetHold(ctx context.Context, id uint) (*CoinHold, error) {
+	var hold CoinHold
+	if err := r.db.WithContext(ctx).First(&hold, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrHoldNotFound
+		}
+		return nil, fmt.Errorf("failed to get hold: %w", err)
+	}
+	return &hold, nil
+}
+
+// GetHoldByReference gets the hold placed under a reference
+func (r *Repository) GetHoldByReference(ctx context.Context, reference string) (*CoinHold, error) {
+	var hold CoinHold
+	if err := r.db.WithContext(ctx).Where(&CoinHold{Reference: reference}).First(&hold).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrHoldNotFound
+		}
+		return nil, fmt.Errorf("failed to get hold: %w", err)
+	}
+	return &hold, nil
+}
+
+// CreateHold records a hold. The reference is unique, so a concurrent hold under the same reference
+// fails here and its transaction rolls back.
+func (r *Repository) CreateHold(ctx context.Context, hold *CoinHold) error {
+	if err := r.db.WithContext(ctx).Create(hold).Error; err != nil {
+		return fmt.Errorf("failed to create hold: %w", err)
+	}
+	return nil
+}
+
+// SumActiveHolds sums the coins held on a warrior's balance. Holds past their expiry count for
+// nothing, even before ExpireHolds marks them.
+func (r *Repository) SumActiveHolds(ctx context.Context, warriorID uint) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Model(&CoinHold{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("warrior_id = ? AND status = ? AND expires_at > ?", warriorID, HoldStatusHeld, time.Now()).
+		Row().Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum holds: %w", err)
+	}
+	return total, nil
+}
+
+// CloseHold moves a hold that is still held to its final status. It returns false if the hold was
+// no longer held.
+func (r *Repository) CloseHold(ctx context.Context, id uint, status HoldStatus, capturedAmount int64, closedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&CoinHold{}).
+		Where("id = ? AND status = ?", id, HoldStatusHeld).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"captured_amount": capturedAmount,
+			"closed_at":       closedAt,
+			"updated_at":      closedAt,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to close hold: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ExpireHolds marks the holds still held past their expiry as expired and returns how many it marked
+func (r *Repository) ExpireHolds(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&CoinHold{}).
+		Where("status = ? AND expires_at <= ?", HoldStatusHeld, now).
+		Updates(map[string]interface{}{
+			"status":     HoldStatusExpired,
+			"closed_at":  now,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to expire holds: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ListHolds gets a warrior's holds, newest first, with pagination
+func (r *Repository) ListHolds(ctx context.Context, query dto.ListHoldsQuery) ([]CoinHold, int64, error) {
+	var holds []CoinHold
+	var count int64
+
+	dbQuery := r.db.WithContext(ctx).Model(&CoinHold{}).Where("warrior_id = ?", query.WarriorID)
+	if query.Status != "" {
+		dbQuery = dbQuery.Where("status = ?", query.Status)
+	}
+
+	if err := dbQuery.Count(&count).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count holds: %w", err)
+	}
+
+	if query.Limit > 0 {
+		dbQuery = dbQuery.Limit(query.Limit)
+	}
+	if query.Offset > 0 {
+		dbQuery = dbQuery.Offset(query.Offset)
+	}
+
+	if err := dbQuery.Order("created_at DESC, id DESC").Find(&holds).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch holds: %w", err)
+	}
+
+	return holds, count, nil
+}
+
+// WithTx returns a repository whose operations run inside tx
+func (r *Repository) WithTx(tx *gorm.DB) *Repository {
+	return &Repository{db: tx}
+}
+
 // ExecuteInTransaction executes multiple operations in a single transaction
 func (r *Repository) ExecuteInTransaction(ctx context.Context, fn func(*gorm.DB) error) error {
 	return r.db.WithContext(ctx).Transaction(fn)
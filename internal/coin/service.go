@@ -24,146 +24,256 @@ func NewService() *Service {
 
 // ==================== COMMANDS (WRITE OPERATIONS) ====================
 
+var (
+	// ErrInvalidAmount is returned for a mutation of zero or fewer coins
+	ErrInvalidAmount = errors.New("amount must be positive")
+	// ErrSelfTransfer is returned when a warrior transfers coins to themselves
+	ErrSelfTransfer = errors.New("cannot transfer to self")
+	// ErrInsufficientBalance is returned when a warrior cannot cover a deduction
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrIdempotencyKeyReused is returned when an idempotency key comes back with a different request
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+)
+
+// BalanceChange is the outcome of a coin mutation. For a transfer it is the sender's balance.
+type BalanceChange struct {
+	WarriorID     uint
+	BalanceBefore int64
+	BalanceAfter  int64
+	Replayed      bool // Made by an earlier request with the same idempotency key; nothing changed now
+}
+
 // DeductCoins deducts coins from warrior's balance with transaction safety
 func (s *Service) DeductCoins(ctx context.Context, cmd dto.DeductCoinsCommand) error {
+	_, err := s.Deduct(ctx, cmd)
+	return err
+}
+
+// Deduct deducts coins from warrior's balance and reports the change
+func (s *Service) Deduct(ctx context.Context, cmd dto.DeductCoinsCommand) (*BalanceChange, error) {
 	if cmd.Amount <= 0 {
-		return errors.New("amount must be positive")
+		return nil, ErrInvalidAmount
 	}
 
-	var balanceBefore, balanceAfter int64
-	var transaction *Transaction
-
-	err := s.repo.ExecuteInTransaction(ctx, func(tx *gorm.DB) error {
-		// Get current balance with row lock
-		balance, err := s.repo.GetWarriorBalance(ctx, cmd.WarriorID)
-		if err != nil {
-			return fmt.Errorf("failed to get warrior balance: %w", err)
-		}
-
-		balanceBefore = balance
+	account, err := counterpartyAccount(cmd.Account)
+	if err != nil {
+		return nil, err
+	}
 
-		// Check sufficient balance
-		if balanceBefore < cmd.Amount {
-			return errors.New("insufficient balance")
-		}
+	request := IdempotencyRecord{Key: cmd.IdempotencyKey, Operation: "deduct", WarriorID: cmd.WarriorID, Amount: cmd.Amount}
+	change, err := s.idempotent(ctx, request, func(repo *Repository) (*BalanceChange, error) {
+		return debit(ctx, repo, cmd.WarriorID, cmd.Amount, account, TransactionTypeDeduct, cmd.Reason)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("deduct coins failed: %w", err)
+	}
 
-		// Calculate new balance
-		balanceAfter = balanceBefore - cmd.Amount
+	return change, nil
+}
 
-		// Update warrior balance
-		if err := s.repo.UpdateWarriorBalance(ctx, cmd.WarriorID, balanceAfter); err != nil {
-			return fmt.Errorf("failed to update balance: %w", err)
-		}
+// AddCoins adds coins to warrior's balance with transaction safety
+func (s *Service) AddCoins(ctx context.Context, cmd dto.AddCoinsCommand) error {
+	_, err := s.Add(ctx, cmd)
+	return err
+}
 
-		// Create transaction record
-		transaction = &Transaction{
-			WarriorID:       cmd.WarriorID,
-			Amount:          -cmd.Amount,
-			TransactionType: TransactionTypeDeduct,
-			Reason:          cmd.Reason,
-			BalanceBefore:   balanceBefore,
-			BalanceAfter:    balanceAfter,
-		}
+// Add adds coins to warrior's balance and reports the change
+func (s *Service) Add(ctx context.Context, cmd dto.AddCoinsCommand) (*BalanceChange, error) {
+	if cmd.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
 
-		if err := s.repo.CreateTransaction(ctx, transaction); err != nil {
-			return fmt.Errorf("failed to create transaction record: %w", err)
-		}
+	account, err := counterpartyAccount(cmd.Account)
+	if err != nil {
+		return nil, err
+	}
 
-		return nil
+	request := IdempotencyRecord{Key: cmd.IdempotencyKey, Operation: "add", WarriorID: cmd.WarriorID, Amount: cmd.Amount}
+	change, err := s.idempotent(ctx, request, func(repo *Repository) (*BalanceChange, error) {
+		return credit(ctx, repo, cmd.WarriorID, cmd.Amount, account, TransactionTypeAdd, cmd.Reason)
 	})
-
 	if err != nil {
-		return fmt.Errorf("deduct coins failed: %w", err)
+		return nil, fmt.Errorf("add coins failed: %w", err)
 	}
 
-	return nil
+	return change, nil
 }
 
-// AddCoins adds coins to warrior's balance with transaction safety
-func (s *Service) AddCoins(ctx context.Context, cmd dto.AddCoinsCommand) error {
+// TransferCoins transfers coins between warriors with atomic transaction
+func (s *Service) TransferCoins(ctx context.Context, cmd dto.TransferCoinsCommand) error {
+	_, err := s.Transfer(ctx, cmd)
+	return err
+}
+
+// Transfer transfers coins between warriors and reports the change to the sender's balance
+func (s *Service) Transfer(ctx context.Context, cmd dto.TransferCoinsCommand) (*BalanceChange, error) {
 	if cmd.Amount <= 0 {
-		return errors.New("amount must be positive")
+		return nil, ErrInvalidAmount
 	}
 
-	var balanceBefore, balanceAfter int64
-	var transaction *Transaction
+	if cmd.FromWarriorID == cmd.ToWarriorID {
+		return nil, ErrSelfTransfer
+	}
 
-	err := s.repo.ExecuteInTransaction(ctx, func(tx *gorm.DB) error {
-		// Get current balance
-		balance, err := s.repo.GetWarriorBalance(ctx, cmd.WarriorID)
-		if err != nil {
-			return fmt.Errorf("failed to get warrior balance: %w", err)
+	request := IdempotencyRecord{Key: cmd.IdempotencyKey, Operation: "transfer", WarriorID: cmd.FromWarriorID, ToWarriorID: cmd.ToWarriorID, Amount: cmd.Amount}
+	change, err := s.idempotent(ctx, request, func(repo *Repository) (*BalanceChange, error) {
+		if _, err := repo.LockWarriors(ctx, cmd.FromWarriorID, cmd.ToWarriorID); err != nil {
+			return nil, err
 		}
 
-		balanceBefore = balance
-		balanceAfter = balanceBefore + cmd.Amount
+		// One ledger entry moves the coins from wallet to wallet
+		if err := post(ctx, repo, "transfer: "+cmd.Reason,
+			LedgerPosting{Account: WalletAccount(cmd.FromWarriorID), Amount: -cmd.Amount},
+			LedgerPosting{Account: WalletAccount(cmd.ToWarriorID), Amount: cmd.Amount},
+		); err != nil {
+			return nil, err
+		}
 
-		// Update warrior balance
-		if err := s.repo.UpdateWarriorBalance(ctx, cmd.WarriorID, balanceAfter); err != nil {
-			return fmt.Errorf("failed to update balance: %w", err)
+		// Deduct from sender
+		change, err := adjustBalance(ctx, repo, cmd.FromWarriorID, -cmd.Amount, TransactionTypeDeduct, "transfer_out: "+cmd.Reason)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deduct from sender: %w", err)
 		}
 
-		// Create transaction record
-		transaction = &Transaction{
-			WarriorID:       cmd.WarriorID,
-			Amount:          cmd.Amount,
-			TransactionType: TransactionTypeAdd,
-			Reason:          cmd.Reason,
-			BalanceBefore:   balanceBefore,
-			BalanceAfter:    balanceAfter,
+		// Add to receiver
+		if _, err := adjustBalance(ctx, repo, cmd.ToWarriorID, cmd.Amount, TransactionTypeAdd, "transfer_in: "+cmd.Reason); err != nil {
+			return nil, fmt.Errorf("failed to add to receiver: %w", err)
+		}
+
+		return change, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transfer coins failed: %w", err)
+	}
+
+	return change, nil
+}
+
+// idempotent runs a mutation in one transaction. With an idempotency key the mutation is recorded
+// under the key in the same transaction, and a request whose key was already used gets the recorded
+// result back without anything being changed.
+func (s *Service) idempotent(ctx context.Context, request IdempotencyRecord, mutate func(*Repository) (*BalanceChange, error)) (*BalanceChange, error) {
+	if request.Key != "" {
+		if change, err := s.replay(ctx, request); !errors.Is(err, ErrIdempotencyKeyNotFound) {
+			return change, err
 		}
+	}
+
+	var change *BalanceChange
+	err := s.repo.ExecuteInTransaction(ctx, func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
 
-		if err := s.repo.CreateTransaction(ctx, transaction); err != nil {
-			return fmt.Errorf("failed to create transaction record: %w", err)
+		var err error
+		change, err = mutate(repo)
+		if err != nil || request.Key == "" {
+			return err
 		}
 
-		return nil
+		record := request
+		record.BalanceBefore = change.BalanceBefore
+		record.BalanceAfter = change.BalanceAfter
+		return repo.CreateIdempotencyRecord(ctx, &record)
 	})
+	if err != nil {
+		// A concurrent request with the same key may have committed first, in which case the unique key rolled this one back
+		if request.Key != "" {
+			if change, replayErr := s.replay(ctx, request); replayErr == nil {
+				return change, nil
+			}
+		}
+		return nil, err
+	}
+
+	return change, nil
+}
 
+// replay returns the result recorded under the request's idempotency key
+func (s *Service) replay(ctx context.Context, request IdempotencyRecord) (*BalanceChange, error) {
+	record, err := s.repo.GetIdempotencyRecord(ctx, request.Key)
 	if err != nil {
-		return fmt.Errorf("add coins failed: %w", err)
+		return nil, err
+	}
+	if record.Operation != request.Operation || record.WarriorID != request.WarriorID ||
+		record.ToWarriorID != request.ToWarriorID || record.Amount != request.Amount {
+		return nil, ErrIdempotencyKeyReused
 	}
 
-	return nil
+	return &BalanceChange{
+		WarriorID:     record.WarriorID,
+		BalanceBefore: record.BalanceBefore,
+		BalanceAfter:  record.BalanceAfter,
+		Replayed:      true,
+	}, nil
 }
 
-// TransferCoins transfers coins between warriors with atomic transaction
-func (s *Service) TransferCoins(ctx context.Context, cmd dto.TransferCoinsCommand) error {
-	if cmd.Amount <= 0 {
-		return errors.New("amount must be positive")
+// debit takes coins from a warrior's wallet into the counterparty ledger account. It locks the warrior
+// for the rest of the transaction.
+func debit(ctx context.Context, repo *Repository, warriorID uint, amount int64, counterparty string, txType TransactionType, reason string) (*BalanceChange, error) {
+	if _, err := repo.LockWarriors(ctx, warriorID); err != nil {
+		return nil, err
+	}
+	if err := post(ctx, repo, reason,
+		LedgerPosting{Account: WalletAccount(warriorID), Amount: -amount},
+		LedgerPosting{Account: counterparty, Amount: amount},
+	); err != nil {
+		return nil, err
 	}
+	return adjustBalance(ctx, repo, warriorID, -amount, txType, reason)
+}
 
-	if cmd.FromWarriorID == cmd.ToWarriorID {
-		return errors.New("cannot transfer to self")
+// credit pays coins from the counterparty ledger account into a warrior's wallet. It locks the warrior
+// for the rest of the transaction.
+func credit(ctx context.Context, repo *Repository, warriorID uint, amount int64, counterparty string, txType TransactionType, reason string) (*BalanceChange, error) {
+	if _, err := repo.LockWarriors(ctx, warriorID); err != nil {
+		return nil, err
 	}
+	if err := post(ctx, repo, reason,
+		LedgerPosting{Account: counterparty, Amount: -amount},
+		LedgerPosting{Account: WalletAccount(warriorID), Amount: amount},
+	); err != nil {
+		return nil, err
+	}
+	return adjustBalance(ctx, repo, warriorID, amount, txType, reason)
+}
 
-	err := s.repo.ExecuteInTransaction(ctx, func(tx *gorm.DB) error {
-		// Deduct from sender
-		if err := s.DeductCoins(ctx, dto.DeductCoinsCommand{
-			WarriorID: cmd.FromWarriorID,
-			Amount:    cmd.Amount,
-			Reason:    "transfer_out: " + cmd.Reason,
-		}); err != nil {
-			return fmt.Errorf("failed to deduct from sender: %w", err)
+// adjustBalance changes a warrior's stored balance by delta and records the transaction. The caller
+// must hold the warrior's lock and post the matching ledger entry.
+func adjustBalance(ctx context.Context, repo *Repository, warriorID uint, delta int64, txType TransactionType, reason string) (*BalanceChange, error) {
+	balanceBefore, err := repo.GetWarriorBalance(ctx, warriorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warrior balance: %w", err)
+	}
+	balanceAfter := balanceBefore + delta
+	if balanceAfter < 0 {
+		return nil, ErrInsufficientBalance
+	}
+	if delta < 0 {
+		// Coins reserved by holds cannot be spent
+		held, err := repo.SumActiveHolds(ctx, warriorID)
+		if err != nil {
+			return nil, err
 		}
-
-		// Add to receiver
-		if err := s.AddCoins(ctx, dto.AddCoinsCommand{
-			WarriorID: cmd.ToWarriorID,
-			Amount:    cmd.Amount,
-			Reason:    "transfer_in: " + cmd.Reason,
-		}); err != nil {
-			return fmt.Errorf("failed to add to receiver: %w", err)
+		if balanceAfter < held {
+			return nil, ErrInsufficientBalance
 		}
+	}
 
-		return nil
-	})
+	if err := repo.UpdateWarriorBalance(ctx, warriorID, balanceAfter); err != nil {
+		return nil, fmt.Errorf("failed to update balance: %w", err)
+	}
 
-	if err != nil {
-		return fmt.Errorf("transfer coins failed: %w", err)
+	if err := repo.CreateTransaction(ctx, &Transaction{
+		WarriorID:       warriorID,
+		Amount:          balanceAfter - balanceBefore,
+		TransactionType: txType,
+		Reason:          reason,
+		BalanceBefore:   balanceBefore,
+		BalanceAfter:    balanceAfter,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
 	}
 
-	return nil
+	return &BalanceChange{WarriorID: warriorID, BalanceBefore: balanceBefore, BalanceAfter: balanceAfter}, nil
 }
 
 // CreateTransaction creates a new coin transaction record
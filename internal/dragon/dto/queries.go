@@ -47,9 +47,12 @@ type AttackDragonRequest struct {
 
 // AttackDragonResponse represents HTTP response for dragon attack
 type AttackDragonResponse struct {
-	Success bool   `json:"success"`
-	Dragon  *Dragon `json:"dragon"`
-	Message string `json:"message"`
+	Success  bool    `json:"success"`
+	Dragon   *Dragon `json:"dragon"`
+	Damage   int     `json:"damage"`
+	Element  string  `json:"element,omitempty"`  // Element of the hit; empty for physical
+	Resisted int     `json:"resisted,omitempty"` // Damage absorbed by the dragon's element
+	Message  string  `json:"message"`
 }
 
 // GetDragonResponse represents HTTP response for getting a dragon
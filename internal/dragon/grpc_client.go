@@ -57,6 +57,14 @@ func InitWarriorClient(addr string) error {
 }
 
 func GetWeaponClient() pbWeapon.WeaponServiceClient { return weaponGrpcClient }
+
+// ListWeaponsByOwner fetches weapons for any owner type
+func ListWeaponsByOwner(ctx context.Context, ownerType, ownerID string) ([]*pbWeapon.Weapon, error) {
+	if weaponGrpcClient == nil { return nil, fmt.Errorf("weapon gRPC client not initialized") }
+	resp, err := weaponGrpcClient.ListOwnerWeapons(ctx, &pbWeapon.ListOwnerWeaponsRequest{OwnerType: ownerType, OwnerId: ownerID})
+	if err != nil { return nil, err }
+	return resp.Weapons, nil
+}
 func GetRepairClient() pbRepair.RepairServiceClient { return repairGrpcClient }
 func CloseWeaponClient() { if weaponGrpcConn != nil { weaponGrpcConn.Close() } }
 func CloseRepairClient() { if repairGrpcConn != nil { repairGrpcConn.Close() } }
@@ -121,7 +121,7 @@ func (h *Handler) AttackDragon(c *gin.Context) {
 		AttackerUsername: attackerUsername,
 	}
 
-	dragon, err := h.Service.AttackDragon(cmd)
+	dragon, hit, err := h.Service.AttackDragon(cmd)
 	if err != nil {
 		c.JSON(400, dto.ErrorResponse{
 			Error:   "attack_failed",
@@ -152,9 +152,12 @@ func (h *Handler) AttackDragon(c *gin.Context) {
 	}
 
 	c.JSON(200, dto.AttackDragonResponse{
-		Success: true,
-		Dragon:  dtoDragon,
-		Message: "Dragon attacked successfully",
+		Success:  true,
+		Dragon:   dtoDragon,
+		Damage:   hit.Damage,
+		Element:  hit.Element,
+		Resisted: hit.Resisted,
+		Message:  "Dragon attacked successfully",
 	})
 }
 
@@ -38,6 +38,13 @@ type Dragon struct {
 	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
+// DragonHit describes a single attack on a dragon
+type DragonHit struct {
+	Damage   int    // Damage taken by the dragon
+	Element  string // Element of the hit; empty for physical
+	Resisted int    // Damage the dragon's element absorbed
+}
+
 // CollectionName returns the MongoDB collection name
 func (Dragon) CollectionName() string {
 	return "dragons"
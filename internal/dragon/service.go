@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"network-sec-micro/internal/dragon/dto"
+	"network-sec-micro/pkg/element"
 	"network-sec-micro/pkg/kafka"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -72,8 +73,9 @@ func (s *Service) CreateDragon(cmd dto.CreateDragonCommand) (*Dragon, error) {
 	return dragon, nil
 }
 
-// AttackDragon handles dragon attack by warrior
-func (s *Service) AttackDragon(cmd dto.AttackDragonCommand) (*Dragon, error) {
+// AttackDragon handles dragon attack by warrior.
+// The hit takes the element of the warrior's strongest weapon, which the dragon's own element may weaken or amplify.
+func (s *Service) AttackDragon(cmd dto.AttackDragonCommand) (*Dragon, *DragonHit, error) {
 	ctx := context.Background()
 
 	// Get dragon
@@ -81,30 +83,33 @@ func (s *Service) AttackDragon(cmd dto.AttackDragonCommand) (*Dragon, error) {
 	err := DragonColl.FindOne(ctx, bson.M{"_id": cmd.DragonID}).Decode(&dragon)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("dragon not found")
+			return nil, nil, errors.New("dragon not found")
 		}
-		return nil, fmt.Errorf("failed to get dragon: %w", err)
+		return nil, nil, fmt.Errorf("failed to get dragon: %w", err)
 	}
 
 	// Check if dragon is alive
 	if !dragon.IsAlive {
-		return nil, errors.New("dragon is already dead")
+		return nil, nil, errors.New("dragon is already dead")
 	}
 
 	// Get warrior info via gRPC
 	warrior, err := s.grpcClient.GetWarriorByUsername(ctx, cmd.AttackerUsername)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get warrior info: %w", err)
+		return nil, nil, fmt.Errorf("failed to get warrior info: %w", err)
 	}
 
 	// Check if warrior can kill dragons
 	if !dragon.CanBeKilledBy(warrior.Role) {
-		return nil, errors.New("only light king or light emperor can kill dragons")
+		return nil, nil, errors.New("only light king or light emperor can kill dragons")
 	}
 
 	// Calculate damage (warrior power vs dragon defense)
 	damage := s.calculateDamage(int(warrior.TotalPower), dragon.Defense)
+	hitElement := s.weaponElement(ctx, cmd.AttackerUsername)
+	damage, resisted := element.Apply(damage, hitElement, element.Element(dragon.Type), 0)
 	dragon.TakeDamage(damage)
+	hit := &DragonHit{Damage: damage, Element: string(hitElement), Resisted: resisted}
 
 	// Update dragon
 	updateData := bson.M{
@@ -133,10 +138,30 @@ func (s *Service) AttackDragon(cmd dto.AttackDragonCommand) (*Dragon, error) {
 
 	_, err = DragonColl.UpdateOne(ctx, bson.M{"_id": cmd.DragonID}, bson.M{"$set": updateData})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update dragon: %w", err)
+		return nil, nil, fmt.Errorf("failed to update dragon: %w", err)
 	}
 
-	return &dragon, nil
+	return &dragon, hit, nil
+}
+
+// weaponElement returns the element of the warrior's strongest intact weapon; physical if unarmed or unknown
+func (s *Service) weaponElement(ctx context.Context, username string) element.Element {
+	weapons, err := ListWeaponsByOwner(ctx, "warrior", username)
+	if err != nil {
+		return element.Physical
+	}
+	hitElement := element.Physical
+	maxDamage := 0
+	for _, w := range weapons {
+		if w.IsBroken {
+			continue
+		}
+		if int(w.Damage) > maxDamage {
+			maxDamage = int(w.Damage)
+			hitElement = element.Element(w.Element)
+		}
+	}
+	return hitElement
 }
 
 // ==================== QUERIES (READ OPERATIONS) ====================
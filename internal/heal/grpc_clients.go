@@ -103,16 +103,18 @@ func CloseCoinClient() {
 	}
 }
 
-// DeductCoins deducts coins from warrior's balance via gRPC
-func DeductCoins(ctx context.Context, warriorID uint, amount int64, reason string) error {
+// DeductCoins deducts coins from warrior's balance via gRPC. Retrying with the same idempotency key never charges twice.
+func DeductCoins(ctx context.Context, warriorID uint, amount int64, reason, idempotencyKey string) error {
 	if coinGrpcClient == nil {
 		return fmt.Errorf("coin gRPC client not initialized")
 	}
 
 	req := &pbCoin.DeductCoinsRequest{
-		WarriorId: uint32(warriorID),
-		Amount:    amount,
-		Reason:    reason,
+		WarriorId:      uint32(warriorID),
+		Amount:         amount,
+		Reason:         reason,
+		IdempotencyKey: idempotencyKey,
+		Account:        "service_revenue", // Heals are paid to the coin ledger's service revenue account
 	}
 
 	resp, err := coinGrpcClient.DeductCoins(ctx, req)
@@ -464,14 +466,14 @@ func CheckEnemyHealingState(ctx context.Context, enemyID string) (bool, *time.Ti
 // - Warrior: Deducts from warrior's own balance
 // - Enemy: Deducts from enemy's own balance
 // - Dragon: Deducts from Dark Emperor's (creator's) balance
-func DeductCoinsForParticipant(ctx context.Context, participantID string, participantType string, amount int64, reason string) error {
+func DeductCoinsForParticipant(ctx context.Context, participantID string, participantType string, amount int64, reason, idempotencyKey string) error {
 	switch participantType {
 	case "warrior":
 		warriorID, err := strconv.ParseUint(participantID, 10, 32)
 		if err != nil {
 			return fmt.Errorf("invalid warrior ID: %w", err)
 		}
-		return DeductCoins(ctx, uint(warriorID), amount, reason)
+		return DeductCoins(ctx, uint(warriorID), amount, reason, idempotencyKey)
 
 	case "enemy":
 		// Enemy pays from its own coin balance
@@ -527,7 +529,7 @@ func DeductCoinsForParticipant(ctx context.Context, participantID string, partic
 		log.Printf("Dragon healing paid by Dark Emperor %s (warrior ID: %d) for dragon %s", dragon.CreatedBy, darkEmperorID, participantID)
 
 		// Deduct coins from Dark Emperor's balance
-		return DeductCoins(ctx, uint(darkEmperorID), amount, fmt.Sprintf("dragon_healing_%s_%s", reason, participantID))
+		return DeductCoins(ctx, uint(darkEmperorID), amount, fmt.Sprintf("dragon_healing_%s_%s", reason, participantID), idempotencyKey)
 
 	default:
 		return fmt.Errorf("unsupported participant type: %s", participantType)
@@ -216,13 +216,16 @@ func (s *Service) PurchaseHeal(ctx context.Context, cmd dto.PurchaseHealCommand)
 		return nil, errors.New("no healing needed")
 	}
 
+	// The healing record ID doubles as the payment's idempotency key
+	now := time.Now()
+	recordID := fmt.Sprintf("%s-%s-%d", participantType, participantID, now.Unix())
+
 	// Deduct coins (only for warriors, dragons/enemies are NPCs)
-	if err := DeductCoinsForParticipant(ctx, participantID, participantType, int64(packageInfo.Price), fmt.Sprintf("heal_%s", healType)); err != nil {
+	if err := DeductCoinsForParticipant(ctx, participantID, participantType, int64(packageInfo.Price), fmt.Sprintf("heal_%s", healType), "heal:"+recordID); err != nil {
 		return nil, fmt.Errorf("failed to deduct coins: %w", err)
 	}
 
 	// Calculate healing completion time
-	now := time.Now()
 	completedAt := now.Add(time.Duration(packageInfo.Duration) * time.Second)
 
 	// Set healing state
@@ -251,7 +254,7 @@ func (s *Service) PurchaseHeal(ctx context.Context, cmd dto.PurchaseHealCommand)
 
 	// Create healing record
 	record := &HealingRecord{
-		ID:             fmt.Sprintf("%s-%s-%d", participantType, participantID, now.Unix()),
+		ID:             recordID,
 		ParticipantID:  participantID,
 		ParticipantType: participantType,
 		ParticipantName: participantName,
@@ -0,0 +1,484 @@
+package warrior
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Achievement event kinds that rules can count
+const (
+	AchievementEventDragonKill  = "dragon_kill"
+	AchievementEventEnemyKill   = "enemy_kill"
+	AchievementEventBattleWon   = "battle_won"
+	AchievementEventBattleLost  = "battle_lost"
+	AchievementEventArenaWin    = "arena_win"
+	AchievementEventArenaLoss   = "arena_loss"
+	AchievementEventArenaDraw   = "arena_draw"
+	AchievementEventCoinsEarned = "coins_earned"
+	AchievementEventCoinsSpent  = "coins_spent"
+)
+
+// Rule measures
+const (
+	AchievementMeasureCount  = "count"  // Each matching event adds one
+	AchievementMeasureAmount = "amount" // Each matching event adds its amount (coins)
+)
+
+// AchievementRule is a declarative achievement, e.g. "kill 3 shadow dragons":
+//
+//	{"id": "shadow_hunter", "event": "dragon_kill", "match": {"dragon_type": "shadow"}, "threshold": 3}
+//
+// A rule with reset_on is a streak: progress drops back to zero whenever one of those events happens.
+type AchievementRule struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description"`
+	Event         string            `json:"event"`
+	Match         map[string]string `json:"match,omitempty"` // Event attributes that must all be equal (case-insensitive)
+	Threshold     int               `json:"threshold"`
+	Measure       string            `json:"measure,omitempty"` // count (default) or amount
+	ResetOn       []string          `json:"reset_on,omitempty"`
+	Title         string            `json:"title,omitempty"`          // Granted on unlock
+	TitlePriority int               `json:"title_priority,omitempty"` // A title only replaces one of lower priority
+}
+
+// AchievementRuleSet is the layout of the rules file
+type AchievementRuleSet struct {
+	Rules []AchievementRule `json:"rules"`
+}
+
+// AchievementEvent is a game event normalized for rule evaluation.
+// The warrior is identified by ID, or by username when the source event has no ID.
+type AchievementEvent struct {
+	Kind       string
+	WarriorID  uint
+	Username   string
+	Attributes map[string]string
+	Amount     int
+	Key        string // Optional; an event whose key was already recorded is not counted again
+}
+
+// DefaultAchievementRules are active until a rules file is loaded; they keep the original kill titles
+var DefaultAchievementRules = []AchievementRule{
+	{ID: "dragon_slayer", Name: "Dragon Slayer", Description: "Kill 3 dragons", Event: AchievementEventDragonKill, Threshold: 3, Title: "DragonSlayer", TitlePriority: 10},
+	{ID: "enemy_destroyer", Name: "Enemy Destroyer", Description: "Destroy 100 enemies", Event: AchievementEventEnemyKill, Threshold: 100, Title: "EnemyDestroyer", TitlePriority: 20},
+	{ID: "emperor_of_drags", Name: "Emperor of Drags", Description: "Kill 10 dragons", Event: AchievementEventDragonKill, Threshold: 10, Title: "EmperorOfDrags", TitlePriority: 30},
+}
+
+var (
+	achievementRulesMu sync.RWMutex
+	achievementRules   = DefaultAchievementRules
+)
+
+// GetAchievementRules returns the active rules
+func GetAchievementRules() []AchievementRule {
+	achievementRulesMu.RLock()
+	defer achievementRulesMu.RUnlock()
+	return achievementRules
+}
+
+// SetAchievementRules validates rules and makes them the active set
+func SetAchievementRules(rules []AchievementRule) error {
+	seen := make(map[string]bool, len(rules))
+	for i := range rules {
+		r := &rules[i]
+		if r.ID == "" {
+			return fmt.Errorf("rule %d: id is required", i)
+		}
+		if seen[r.ID] {
+			return fmt.Errorf("rule %s: duplicate id", r.ID)
+		}
+		seen[r.ID] = true
+		if r.Event == "" {
+			return fmt.Errorf("rule %s: event is required", r.ID)
+		}
+		if r.Threshold <= 0 {
+			return fmt.Errorf("rule %s: threshold must be positive", r.ID)
+		}
+		if r.Measure == "" {
+			r.Measure = AchievementMeasureCount
+		}
+		if r.Measure != AchievementMeasureCount && r.Measure != AchievementMeasureAmount {
+			return fmt.Errorf("rule %s: unknown measure %q", r.ID, r.Measure)
+		}
+		if r.Name == "" {
+			r.Name = r.ID
+		}
+	}
+
+	achievementRulesMu.Lock()
+	achievementRules = rules
+	achievementRulesMu.Unlock()
+	return nil
+}
+
+// LoadAchievementRules reads and activates the rules file at path
+func LoadAchievementRules(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read achievement rules: %w", err)
+	}
+	var set AchievementRuleSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("failed to parse achievement rules: %w", err)
+	}
+	if err := SetAchievementRules(set.Rules); err != nil {
+		return fmt.Errorf("invalid achievement rules: %w", err)
+	}
+	log.Printf("warrior: loaded %d achievement rules from %s", len(set.Rules), path)
+	return nil
+}
+
+// WatchAchievementRules loads the rules file and reloads it whenever it changes, so designers can edit
+// rules without a deploy. A broken file is logged and the previous rules stay active. Call stop to end watching.
+func WatchAchievementRules(path string, interval time.Duration) (stop func()) {
+	var modTime time.Time
+	reload := func() {
+		info, err := os.Stat(path)
+		if err != nil {
+			log.Printf("warrior: achievement rules unavailable: %v", err)
+			return
+		}
+		if info.ModTime().Equal(modTime) {
+			return
+		}
+		modTime = info.ModTime()
+		if err := LoadAchievementRules(path); err != nil {
+			log.Printf("warrior: keeping previous achievement rules: %v", err)
+		}
+	}
+	reload()
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				reload()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// matches reports whether ev counts towards the rule
+func (r *AchievementRule) matches(ev *AchievementEvent) bool {
+	if ev.Kind != r.Event {
+		return false
+	}
+	for key, want := range r.Match {
+		if !strings.EqualFold(ev.Attributes[key], want) {
+			return false
+		}
+	}
+	return true
+}
+
+// resetBy reports whether ev breaks the rule's streak
+func (r *AchievementRule) resetBy(ev *AchievementEvent) bool {
+	for _, kind := range r.ResetOn {
+		if kind == ev.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordAchievementEvent advances every active rule the event touches and unlocks the ones that reach
+// their threshold, granting the highest-priority title earned. It returns the newly unlocked achievements.
+func RecordAchievementEvent(ev AchievementEvent) ([]WarriorAchievement, error) {
+	rules := GetAchievementRules()
+	var unlocked []WarriorAchievement
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if ev.Key != "" {
+			receipt := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&AchievementEventReceipt{EventKey: ev.Key})
+			if receipt.Error != nil {
+				return receipt.Error
+			}
+			if receipt.RowsAffected == 0 {
+				return nil // Counted when the message was first delivered
+			}
+		}
+
+		var w Warrior
+		q := tx
+		if ev.WarriorID > 0 {
+			q = q.Where("id = ?", ev.WarriorID)
+		} else if ev.Username != "" {
+			q = q.Where("username = ?", ev.Username)
+		} else {
+			return gorm.ErrRecordNotFound
+		}
+		if err := q.First(&w).Error; err != nil {
+			return err
+		}
+
+		var have []string
+		if err := tx.Model(&WarriorAchievement{}).Where("warrior_id = ?", w.ID).Pluck("achievement_id", &have).Error; err != nil {
+			return err
+		}
+		done := make(map[string]bool, len(have))
+		for _, id := range have {
+			done[id] = true
+		}
+
+		title, titlePriority := w.Title, titlePriorityOf(rules, w.Title)
+		for i := range rules {
+			rule := &rules[i]
+			if done[rule.ID] {
+				continue
+			}
+			counts, resets := rule.matches(&ev), rule.resetBy(&ev)
+			if !counts && !resets {
+				continue
+			}
+
+			progress := AchievementProgress{WarriorID: w.ID, RuleID: rule.ID}
+			if err := tx.Where("warrior_id = ? AND rule_id = ?", w.ID, rule.ID).FirstOrCreate(&progress).Error; err != nil {
+				return err
+			}
+			if resets {
+				progress.Progress = 0
+			}
+			if counts {
+				if rule.Measure == AchievementMeasureAmount {
+					progress.Progress += ev.Amount
+				} else {
+					progress.Progress++
+				}
+			}
+			if err := tx.Model(&progress).Update("progress", progress.Progress).Error; err != nil {
+				return err
+			}
+			if progress.Progress < rule.Threshold {
+				continue
+			}
+
+			achievement := WarriorAchievement{
+				WarriorID:     w.ID,
+				AchievementID: rule.ID,
+				Name:          rule.Name,
+				Description:   rule.Description,
+				Title:         rule.Title,
+				UnlockedAt:    time.Now().UTC(),
+			}
+			if err := tx.Create(&achievement).Error; err != nil {
+				return err
+			}
+			unlocked = append(unlocked, achievement)
+			if rule.Title != "" && (title == "" || rule.TitlePriority > titlePriority) {
+				title, titlePriority = rule.Title, rule.TitlePriority
+			}
+		}
+
+		if title != w.Title {
+			return tx.Model(&Warrior{}).Where("id = ?", w.ID).Update("title", title).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range unlocked {
+		log.Printf("warrior: %d unlocked achievement %s", a.WarriorID, a.AchievementID)
+	}
+	return unlocked, nil
+}
+
+// titlePriorityOf returns the priority of the rule granting title; titles no rule grants rank lowest
+func titlePriorityOf(rules []AchievementRule, title string) int {
+	for _, r := range rules {
+		if r.Title != "" && r.Title == title {
+			return r.TitlePriority
+		}
+	}
+	return 0
+}
+
+// AchievementStatus is a warrior's standing on one active rule
+type AchievementStatus struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Title       string     `json:"title,omitempty"`
+	Progress    int        `json:"progress"`
+	Threshold   int        `json:"threshold"`
+	Unlocked    bool       `json:"unlocked"`
+	UnlockedAt  *time.Time `json:"unlocked_at,omitempty"`
+}
+
+// GetAchievements returns a warrior's unlocked achievements, including those of rules since removed,
+// followed by progress on the active rules not yet unlocked
+func (s *Service) GetAchievements(warriorID uint) ([]AchievementStatus, error) {
+	var unlocked []WarriorAchievement
+	if err := DB.Where("warrior_id = ?", warriorID).Order("unlocked_at ASC").Find(&unlocked).Error; err != nil {
+		return nil, err
+	}
+	var progress []AchievementProgress
+	if err := DB.Where("warrior_id = ?", warriorID).Find(&progress).Error; err != nil {
+		return nil, err
+	}
+	progressByRule := make(map[string]int, len(progress))
+	for _, p := range progress {
+		progressByRule[p.RuleID] = p.Progress
+	}
+
+	rules := GetAchievementRules()
+	thresholds := make(map[string]int, len(rules))
+	for _, r := range rules {
+		thresholds[r.ID] = r.Threshold
+	}
+
+	statuses := make([]AchievementStatus, 0, len(unlocked)+len(rules))
+	done := make(map[string]bool, len(unlocked))
+	for i := range unlocked {
+		a := unlocked[i]
+		done[a.AchievementID] = true
+		threshold, ok := thresholds[a.AchievementID]
+		if !ok {
+			threshold = progressByRule[a.AchievementID]
+		}
+		statuses = append(statuses, AchievementStatus{
+			ID:          a.AchievementID,
+			Name:        a.Name,
+			Description: a.Description,
+			Title:       a.Title,
+			Progress:    threshold,
+			Threshold:   threshold,
+			Unlocked:    true,
+			UnlockedAt:  &a.UnlockedAt,
+		})
+	}
+	for _, r := range rules {
+		if done[r.ID] {
+			continue
+		}
+		statuses = append(statuses, AchievementStatus{
+			ID:          r.ID,
+			Name:        r.Name,
+			Description: r.Description,
+			Title:       r.Title,
+			Progress:    progressByRule[r.ID],
+			Threshold:   r.Threshold,
+		})
+	}
+	return statuses, nil
+}
+
+// achievementEvents turns a raw Kafka event into the achievement events it represents
+func achievementEvents(eventType string, base map[string]interface{}) []AchievementEvent {
+	switch strings.ToLower(eventType) {
+	case "dragon_death":
+		return []AchievementEvent{{
+			Kind:     AchievementEventDragonKill,
+			Username: toString(base["killer_username"]),
+			Attributes: map[string]string{
+				"dragon_type":  toString(base["dragon_type"]),
+				"dragon_name":  toString(base["dragon_name"]),
+				"dragon_level": strconv.Itoa(toInt(base["dragon_level"])),
+			},
+		}}
+	case "enemy_destroyed":
+		return []AchievementEvent{{
+			Kind:      AchievementEventEnemyKill,
+			WarriorID: uint(toInt(base["killer_warrior_id"])),
+			Username:  toString(base["killer_warrior_name"]),
+			Attributes: map[string]string{
+				"enemy_type":  toString(base["enemy_type"]),
+				"enemy_name":  toString(base["enemy_name"]),
+				"enemy_level": strconv.Itoa(toInt(base["enemy_level"])),
+			},
+		}}
+	case "battle_reward":
+		// Rewards only go to the winning side
+		var warriorID uint
+		if _, err := fmt.Sscanf(toString(base["participant_id"]), "%d", &warriorID); err != nil || warriorID == 0 {
+			return nil
+		}
+		attrs := map[string]string{"battle_type": "team", "side": toString(base["side"])}
+		return []AchievementEvent{
+			{Kind: AchievementEventBattleWon, WarriorID: warriorID, Attributes: attrs},
+			{Kind: AchievementEventCoinsEarned, WarriorID: warriorID, Attributes: map[string]string{"source": "battle"}, Amount: toInt(base["coins"])},
+		}
+	case "battle_completed":
+		warriorID := uint(toInt(base["warrior_id"]))
+		if warriorID == 0 {
+			return nil
+		}
+		attrs := map[string]string{"battle_type": toString(base["battle_type"])}
+		switch toString(base["result"]) {
+		case "victory":
+			return []AchievementEvent{{Kind: AchievementEventBattleWon, WarriorID: warriorID, Attributes: attrs}}
+		case "defeat":
+			return []AchievementEvent{{Kind: AchievementEventBattleLost, WarriorID: warriorID, Attributes: attrs}}
+		}
+	case "arena_match_completed":
+		player1, player2 := uint(toInt(base["player1_id"])), uint(toInt(base["player2_id"]))
+		winner := uint(toInt(base["winner_id"]))
+		if winner == 0 {
+			return []AchievementEvent{
+				{Kind: AchievementEventArenaDraw, WarriorID: player1},
+				{Kind: AchievementEventArenaDraw, WarriorID: player2},
+			}
+		}
+		loser := player1
+		if winner == player1 {
+			loser = player2
+		}
+		return []AchievementEvent{
+			{Kind: AchievementEventArenaWin, WarriorID: winner},
+			{Kind: AchievementEventArenaLoss, WarriorID: loser},
+		}
+	case "weapon_purchased":
+		return []AchievementEvent{{
+			Kind:       AchievementEventCoinsSpent,
+			WarriorID:  uint(toInt(base["warrior_id"])),
+			Username:   toString(base["warrior_name"]),
+			Attributes: map[string]string{"item": "weapon"},
+			Amount:     toInt(base["weapon_price"]),
+		}}
+	case "armor_purchased":
+		if toString(base["owner_type"]) != "warrior" {
+			return nil
+		}
+		return []AchievementEvent{{
+			Kind:       AchievementEventCoinsSpent,
+			WarriorID:  uint(toInt(base["buyer_id"])),
+			Username:   toString(base["buyer_name"]),
+			Attributes: map[string]string{"item": "armor"},
+			Amount:     toInt(base["armor_price"]),
+		}}
+	}
+	return nil
+}
+
+// processAchievements evaluates the rules for a raw Kafka event. Failures are logged, not returned,
+// so achievements never block the experience and kill bookkeeping of the same message.
+// Each achievement event is keyed by the message key and its position, so it is counted once.
+func processAchievements(eventType string, base map[string]interface{}, messageKey string) {
+	for i, ev := range achievementEvents(eventType, base) {
+		if (ev.Kind == AchievementEventCoinsEarned || ev.Kind == AchievementEventCoinsSpent) && ev.Amount <= 0 {
+			continue
+		}
+		ev.Key = fmt.Sprintf("%s:%d", messageKey, i)
+		if _, err := RecordAchievementEvent(ev); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("warrior: failed to record %s achievement event: %v", ev.Kind, err)
+		}
+	}
+}
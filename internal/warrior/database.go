@@ -37,7 +37,7 @@ func InitDatabase() error {
 	log.Println("Database connection established")
 
 	// Auto migrate the schema
-	if err := DB.AutoMigrate(&Warrior{}, &KilledMonster{}); err != nil {
+	if err := DB.AutoMigrate(&Warrior{}, &KilledMonster{}, &AchievementProgress{}, &AchievementEventReceipt{}, &ExperienceGrantReceipt{}, &WarriorAchievement{}); err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
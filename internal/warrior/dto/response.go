@@ -15,6 +15,8 @@ type WarriorResponse struct {
 	Email     string    `json:"email"`
 	Role      string    `json:"role"`
     Title     string    `json:"title"`
+	Level     int       `json:"level"`
+	Experience int      `json:"experience"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
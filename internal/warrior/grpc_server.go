@@ -56,6 +56,9 @@ func (s *WarriorServiceServer) GetWarriorByUsername(ctx context.Context, req *pb
 			MaxHp:             int32(maxHP),
 			IsHealing:         w.IsHealing,
 			HealingUntilSeconds: healingUntilSeconds,
+			Experience:        int32(w.Experience),
+			Level:             int32(w.Level),
+			ExperienceToNextLevel: int32(ExperienceToNextLevel(&w)),
 			CreatedAt:         timestamppb.New(w.CreatedAt),
 			UpdatedAt:         timestamppb.New(w.UpdatedAt),
 		},
@@ -93,6 +96,9 @@ func (s *WarriorServiceServer) GetWarriorByID(ctx context.Context, req *pb.GetWa
 			MaxHp:             int32(maxHP),
 			IsHealing:         w.IsHealing,
 			HealingUntilSeconds: healingUntilSeconds,
+			Experience:        int32(w.Experience),
+			Level:             int32(w.Level),
+			ExperienceToNextLevel: int32(ExperienceToNextLevel(&w)),
 			CreatedAt:         timestamppb.New(w.CreatedAt),
 			UpdatedAt:         timestamppb.New(w.UpdatedAt),
 		},
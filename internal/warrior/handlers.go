@@ -63,6 +63,8 @@ func (h *Handler) Login(c *gin.Context) {
 			Email:     response.Warrior.Email,
 			Role:      string(response.Warrior.Role),
 			Title:     response.Warrior.Title,
+			Level:     response.Warrior.Level,
+			Experience: response.Warrior.Experience,
 			CreatedAt: response.Warrior.CreatedAt,
 			UpdatedAt: response.Warrior.UpdatedAt,
 		},
@@ -95,6 +97,8 @@ func (h *Handler) GetProfile(c *gin.Context) {
 		Email:     warrior.Email,
 		Role:      string(warrior.Role),
 		Title:     warrior.Title,
+		Level:     warrior.Level,
+		Experience: warrior.Experience,
 		CreatedAt: warrior.CreatedAt,
 		UpdatedAt: warrior.UpdatedAt,
 	})
@@ -151,6 +155,8 @@ func (h *Handler) GetWarriors(c *gin.Context) {
 			Email:     w.Email,
 			Role:      string(w.Role),
 			Title:     w.Title,
+			Level:     w.Level,
+			Experience: w.Experience,
 			CreatedAt: w.CreatedAt,
 			UpdatedAt: w.UpdatedAt,
 		}
@@ -203,6 +209,8 @@ func (h *Handler) GetKnightWarriors(c *gin.Context) {
 			Email:     k.Email,
 			Role:      string(k.Role),
 			Title:     k.Title,
+			Level:     k.Level,
+			Experience: k.Experience,
 			CreatedAt: k.CreatedAt,
 			UpdatedAt: k.UpdatedAt,
 		}
@@ -256,6 +264,8 @@ func (h *Handler) GetArcherWarriors(c *gin.Context) {
 			Email:     a.Email,
 			Role:      string(a.Role),
 			Title:     a.Title,
+			Level:     a.Level,
+			Experience: a.Experience,
 			CreatedAt: a.CreatedAt,
 			UpdatedAt: a.UpdatedAt,
 		}
@@ -309,6 +319,8 @@ func (h *Handler) GetMageWarriors(c *gin.Context) {
 			Email:     m.Email,
 			Role:      string(m.Role),
 			Title:     m.Title,
+			Level:     m.Level,
+			Experience: m.Experience,
 			CreatedAt: m.CreatedAt,
 			UpdatedAt: m.UpdatedAt,
 		}
@@ -374,4 +386,37 @@ func (h *Handler) GetMyStrongestKill(c *gin.Context) {
         return
     }
     c.JSON(http.StatusOK, gin.H{"strongest": km})
-}
\ No newline at end of file
+}
+// GetMyAchievements godoc
+// @Summary Get warrior's achievements
+// @Description List the authenticated warrior's unlocked achievements and progress on the remaining ones
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "title: string, achievements: []AchievementStatus, unlocked: int"
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /warriors/me/achievements [get]
+func (h *Handler) GetMyAchievements(c *gin.Context) {
+    warrior, err := GetCurrentWarrior(c)
+    if err != nil {
+        c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized", Message: err.Error()})
+        return
+    }
+    achievements, err := h.Service.GetAchievements(warrior.ID)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal_error", Message: err.Error()})
+        return
+    }
+    unlocked := 0
+    for _, a := range achievements {
+        if a.Unlocked {
+            unlocked++
+        }
+    }
+    c.JSON(http.StatusOK, gin.H{
+        "title":        warrior.Title,
+        "achievements": achievements,
+        "unlocked":     unlocked,
+    })
+}
@@ -1,13 +1,19 @@
 package warrior
 
 import (
+    "crypto/sha256"
+    "encoding/hex"
     "encoding/json"
+    "errors"
+    "fmt"
     "log"
     "strings"
     "time"
+
+    "gorm.io/gorm"
 )
 
-// ProcessKafkaMessage handles incoming Kafka messages for warrior achievements
+// ProcessKafkaMessage handles incoming Kafka messages for warrior achievements and experience
 func ProcessKafkaMessage(message []byte) error {
     // Try to detect event type without strict schema dependency
     var base map[string]interface{}
@@ -25,47 +31,61 @@ func ProcessKafkaMessage(message []byte) error {
         source = v
     }
 
+    // Kill counters and experience first, then the achievement rules. A failed message is
+    // redelivered, so its achievements are left for the retry.
+    key := messageKey(message)
+    if err := handleEvent(eventType, source, base, key); err != nil {
+        return err
+    }
+    processAchievements(eventType, base, key)
+    return nil
+}
+
+// messageKey identifies a Kafka message by its content. Events carry their publish timestamp,
+// so a redelivered message has the same key and a new event a different one.
+func messageKey(message []byte) string {
+    sum := sha256.Sum256(message)
+    return hex.EncodeToString(sum[:])
+}
+
+// handleEvent updates kill counters and experience for a message. msgKey identifies the message
+// for grants whose event lacks the IDs to key them.
+func handleEvent(eventType, source string, base map[string]interface{}, msgKey string) error {
+    // Handle battle experience: per-participant team battle rewards, and legacy single battles
+    if strings.EqualFold(eventType, "battle_reward") {
+        return handleBattleReward(base, msgKey)
+    }
+    if strings.EqualFold(eventType, "battle_completed") {
+        return handleBattleCompleted(base, msgKey)
+    }
+
     // Handle dragon death events
     if strings.EqualFold(eventType, "dragon_death") || strings.EqualFold(source, "dragon") {
-        return handleDragonDeath(base)
+        return handleDragonDeath(base, msgKey)
     }
 
     // Handle enemy destroyed events
     if strings.EqualFold(eventType, "enemy_destroyed") || strings.EqualFold(source, "enemy") {
         // Distinguish from enemy attack by presence of fields
         if _, has := base["killer_warrior_id"]; has {
-            return handleEnemyDestroyed(base)
+            return handleEnemyDestroyed(base, msgKey)
         }
     }
 
     return nil
 }
 
-func handleDragonDeath(base map[string]interface{}) error {
+func handleDragonDeath(base map[string]interface{}, msgKey string) error {
     killer, _ := base["killer_username"].(string)
     if killer == "" {
         return nil
     }
 
-    // Increment dragon kill count for killer
     var w Warrior
     if err := DB.Where("username = ?", killer).First(&w).Error; err != nil {
         return nil
     }
 
-    w.DragonKillCount += 1
-    // Title precedence: EmperorOfDrags (>=10) > DragonSlayer (>=3)
-    if w.DragonKillCount >= 10 {
-        w.Title = "EmperorOfDrags"
-    } else if w.DragonKillCount >= 3 && w.Title != "EmperorOfDrags" {
-        w.Title = "DragonSlayer"
-    }
-
-    if err := DB.Save(&w).Error; err != nil {
-        log.Printf("warrior: failed to update dragon kill count: %v", err)
-        return err
-    }
-
     // Persist killed monster details
     km := KilledMonster{
         WarriorID:   w.ID,
@@ -79,13 +99,13 @@ func handleDragonDeath(base map[string]interface{}) error {
         Defense:     toInt(base["dragon_defense"]),
         KilledAt:    timeNowUTC(),
     }
-    if err := DB.Create(&km).Error; err != nil {
-        log.Printf("warrior: failed to record killed dragon: %v", err)
-    }
-    return nil
+
+    // A dragon can be revived, so a kill is the dragon plus the time it died
+    key := grantKey(msgKey, "dragon_kill", km.MonsterID, toString(base["timestamp"]))
+    return recordKill(&w, &km, "dragon_kill_count", DragonKillExperience(km.Level), "dragon_kill", key)
 }
 
-func handleEnemyDestroyed(base map[string]interface{}) error {
+func handleEnemyDestroyed(base map[string]interface{}, msgKey string) error {
     // Prefer warrior ID when available
     var warriorIDFloat float64
     if v, ok := base["killer_warrior_id"].(float64); ok {
@@ -106,20 +126,6 @@ func handleEnemyDestroyed(base map[string]interface{}) error {
         return nil
     }
 
-    w.EnemyKillCount += 1
-    // Set EnemyDestroyer if at least 100, unless a higher title already assigned
-    if w.EnemyKillCount >= 100 && w.Title != "EmperorOfDrags" {
-        // Keep DragonSlayer/EmperorOfDrags precedence
-        if w.DragonKillCount < 10 { // do not override EmperorOfDrags
-            w.Title = "EnemyDestroyer"
-        }
-    }
-
-    if err := DB.Save(&w).Error; err != nil {
-        log.Printf("warrior: failed to update enemy kill count: %v", err)
-        return err
-    }
-
     // Persist killed enemy details
     km := KilledMonster{
         WarriorID:   w.ID,
@@ -133,12 +139,85 @@ func handleEnemyDestroyed(base map[string]interface{}) error {
         Defense:     0,
         KilledAt:    timeNowUTC(),
     }
-    if err := DB.Create(&km).Error; err != nil {
-        log.Printf("warrior: failed to record killed enemy: %v", err)
+
+    key := grantKey(msgKey, "enemy_kill", km.MonsterID)
+    return recordKill(&w, &km, "enemy_kill_count", EnemyKillExperience(km.Level), "enemy_kill", key)
+}
+
+// recordKill counts a kill for w, stores the killed monster and grants the kill experience in one
+// transaction, once per key. Titles are granted by the achievement rules.
+func recordKill(w *Warrior, km *KilledMonster, countColumn string, experience int, source, key string) error {
+    var progress *LevelProgress
+    err := DB.Transaction(func(tx *gorm.DB) error {
+        claimed, err := claimExperienceGrant(tx, key)
+        if err != nil || !claimed {
+            return err
+        }
+        if err := tx.Model(&Warrior{}).Where("id = ?", w.ID).
+            UpdateColumn(countColumn, gorm.Expr(countColumn+" + 1")).Error; err != nil {
+            return fmt.Errorf("failed to update %s: %w", countColumn, err)
+        }
+        if err := tx.Create(km).Error; err != nil {
+            return fmt.Errorf("failed to record killed %s: %w", km.MonsterKind, err)
+        }
+        progress, err = grantExperienceTx(tx, w.ID, experience)
+        return err
+    })
+    if err != nil {
+        log.Printf("warrior: failed to record %s: %v", source, err)
+        return err
+    }
+    if progress != nil {
+        announceProgress(progress, source)
     }
     return nil
 }
 
+func handleBattleReward(base map[string]interface{}, msgKey string) error {
+    var warriorID uint
+    participantID := toString(base["participant_id"])
+    if _, err := fmt.Sscanf(participantID, "%d", &warriorID); err != nil || warriorID == 0 {
+        return nil // not a warrior account
+    }
+    key := grantKey(msgKey, "battle_reward", toString(base["battle_id"]), participantID)
+    return grantBattleExperience(warriorID, toInt(base["experience"]), key)
+}
+
+func handleBattleCompleted(base map[string]interface{}, msgKey string) error {
+    // Team battles report rewards per participant (battle_reward); only legacy battles carry a warrior here
+    warriorID := uint(toInt(base["warrior_id"]))
+    if warriorID == 0 {
+        return nil
+    }
+    key := grantKey(msgKey, "battle_completed", toString(base["battle_id"]), fmt.Sprint(warriorID))
+    return grantBattleExperience(warriorID, toInt(base["experience_gained"]), key)
+}
+
+func grantBattleExperience(warriorID uint, amount int, key string) error {
+    if amount <= 0 {
+        return nil
+    }
+    if _, err := GrantExperienceOnce(warriorID, amount, "battle", key); err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil
+        }
+        log.Printf("warrior: failed to grant battle experience: %v", err)
+        return err
+    }
+    return nil
+}
+
+// grantKey builds the receipt key of an experience grant from the IDs that identify it,
+// falling back to the message key when the event does not carry them all
+func grantKey(msgKey, kind string, ids ...string) string {
+    for _, id := range ids {
+        if id == "" {
+            return kind + ":" + msgKey
+        }
+    }
+    return kind + ":" + strings.Join(ids, ":")
+}
+
 // helper conversions
 func toString(v interface{}) string {
     if v == nil { return "" }
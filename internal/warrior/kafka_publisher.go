@@ -0,0 +1,57 @@
+package warrior
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"network-sec-micro/pkg/kafka"
+)
+
+var (
+	kafkaPublisher     *kafka.Publisher
+	kafkaPublisherOnce sync.Once
+)
+
+// GetKafkaPublisher returns the Kafka publisher, or nil when brokers are unreachable
+func GetKafkaPublisher() *kafka.Publisher {
+	kafkaPublisherOnce.Do(func() {
+		brokers := []string{"localhost:9092"}
+		if v := os.Getenv("KAFKA_BROKERS"); v != "" {
+			brokers = strings.Split(v, ",")
+		}
+		publisher, err := kafka.NewPublisher(brokers)
+		if err != nil {
+			log.Printf("Warning: Failed to initialize Kafka publisher: %v", err)
+			return
+		}
+		kafkaPublisher = publisher
+	})
+	return kafkaPublisher
+}
+
+// CloseKafkaPublisher closes the Kafka publisher
+func CloseKafkaPublisher() {
+	if kafkaPublisher != nil {
+		kafkaPublisher.Close()
+	}
+}
+
+// PublishLevelUpEvent publishes a warrior level-up
+func PublishLevelUpEvent(progress *LevelProgress, source string) error {
+	publisher := GetKafkaPublisher()
+	if publisher == nil {
+		return fmt.Errorf("kafka publisher not initialized")
+	}
+
+	event := kafka.NewWarriorLevelUpEvent(progress.WarriorID, progress.Username, progress.PreviousLevel, progress.Level,
+		progress.Experience, progress.PowerGained, progress.MaxHPGained, source)
+	if err := publisher.Publish(kafka.TopicWarriorLevelUp, event); err != nil {
+		return fmt.Errorf("failed to publish level-up event: %w", err)
+	}
+
+	log.Printf("Published level-up event: %s reached level %d", progress.Username, progress.Level)
+	return nil
+}
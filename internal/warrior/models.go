@@ -40,6 +40,9 @@ type Warrior struct {
     Title            string    `gorm:"type:varchar(50);default:''" json:"title"`
     EnemyKillCount   int       `gorm:"default:0" json:"enemy_kill_count"`
     DragonKillCount  int       `gorm:"default:0" json:"dragon_kill_count"`
+    // Progression (see progression.go)
+    Experience       int       `gorm:"default:0" json:"experience"`
+    Level            int       `gorm:"default:1" json:"level"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -62,6 +65,43 @@ type KilledMonster struct {
     UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// AchievementProgress tracks a warrior's progress towards an achievement rule (see achievements.go)
+type AchievementProgress struct {
+    ID        uint      `gorm:"primaryKey" json:"-"`
+    WarriorID uint      `gorm:"uniqueIndex:idx_achievement_progress;not null" json:"warrior_id"`
+    RuleID    string    `gorm:"type:varchar(60);uniqueIndex:idx_achievement_progress;not null" json:"rule_id"`
+    Progress  int       `gorm:"default:0" json:"progress"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AchievementEventReceipt marks an achievement event as counted, so a redelivered Kafka message
+// does not advance the rules twice
+type AchievementEventReceipt struct {
+    EventKey  string    `gorm:"type:varchar(80);primaryKey" json:"event_key"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// ExperienceGrantReceipt marks an event-driven experience grant (a kill or a battle reward) as applied,
+// so a redelivered Kafka message does not grant experience or count the kill twice
+type ExperienceGrantReceipt struct {
+    GrantKey  string    `gorm:"type:varchar(120);primaryKey" json:"grant_key"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// WarriorAchievement is an achievement a warrior has unlocked.
+// Name, description and title are copied from the rule so they survive rule edits.
+type WarriorAchievement struct {
+    ID            uint      `gorm:"primaryKey" json:"id"`
+    WarriorID     uint      `gorm:"uniqueIndex:idx_warrior_achievement;not null" json:"warrior_id"`
+    AchievementID string    `gorm:"type:varchar(60);uniqueIndex:idx_warrior_achievement;not null" json:"achievement_id"`
+    Name          string    `gorm:"type:varchar(100);not null" json:"name"`
+    Description   string    `gorm:"type:varchar(255)" json:"description"`
+    Title         string    `gorm:"type:varchar(50)" json:"title,omitempty"`
+    UnlockedAt    time.Time `json:"unlocked_at"`
+    CreatedAt     time.Time `json:"created_at"`
+}
+
 // TableName specifies the table name for Warrior
 func (Warrior) TableName() string {
 	return "warriors"
@@ -0,0 +1,267 @@
+package warrior
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LevelCurve defines how much experience each level costs.
+// Reaching level n+1 from level n costs BaseXP * Growth^(n-1).
+type LevelCurve struct {
+	BaseXP   int     // Experience needed to go from level 1 to 2
+	Growth   float64 // Cost multiplier per level
+	MaxLevel int
+}
+
+// DefaultLevelCurve is used unless overridden with SetLevelCurve
+var DefaultLevelCurve = LevelCurve{BaseXP: 100, Growth: 1.5, MaxLevel: 50}
+
+var levelCurve = DefaultLevelCurve
+
+// SetLevelCurve replaces the active level curve
+func SetLevelCurve(c LevelCurve) {
+	if c.BaseXP <= 0 {
+		c.BaseXP = DefaultLevelCurve.BaseXP
+	}
+	if c.Growth < 1 {
+		c.Growth = DefaultLevelCurve.Growth
+	}
+	if c.MaxLevel <= 1 {
+		c.MaxLevel = DefaultLevelCurve.MaxLevel
+	}
+	levelCurve = c
+}
+
+// GetLevelCurve returns the active level curve
+func GetLevelCurve() LevelCurve {
+	return levelCurve
+}
+
+// LevelCurveFromEnv reads WARRIOR_LEVEL_BASE_XP, WARRIOR_LEVEL_GROWTH and WARRIOR_MAX_LEVEL over the defaults
+func LevelCurveFromEnv() LevelCurve {
+	c := DefaultLevelCurve
+	if v, err := strconv.Atoi(os.Getenv("WARRIOR_LEVEL_BASE_XP")); err == nil {
+		c.BaseXP = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("WARRIOR_LEVEL_GROWTH"), 64); err == nil {
+		c.Growth = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("WARRIOR_MAX_LEVEL")); err == nil {
+		c.MaxLevel = v
+	}
+	return c
+}
+
+// XPForLevel returns the total experience needed to reach level
+func (c LevelCurve) XPForLevel(level int) int {
+	total := 0
+	for l := 1; l < level; l++ {
+		total += int(math.Round(float64(c.BaseXP) * math.Pow(c.Growth, float64(l-1))))
+	}
+	return total
+}
+
+// LevelForXP returns the level reached with xp total experience
+func (c LevelCurve) LevelForXP(xp int) int {
+	level := 1
+	for level < c.MaxLevel && xp >= c.XPForLevel(level+1) {
+		level++
+	}
+	return level
+}
+
+// StatGrowth is what a warrior gains on each level-up
+type StatGrowth struct {
+	Power int
+	MaxHP int
+}
+
+// roleGrowth per role: knights are sturdy, mages hit hardest, archers sit in between
+var roleGrowth = map[Role]StatGrowth{
+	RoleKnight: {Power: 8, MaxHP: 60},
+	RoleArcher: {Power: 10, MaxHP: 40},
+	RoleMage:   {Power: 12, MaxHP: 30},
+}
+
+// leaderGrowth applies to kings and emperors
+var leaderGrowth = StatGrowth{Power: 10, MaxHP: 50}
+
+// GrowthForRole returns the per-level stat growth of a role
+func GrowthForRole(role Role) StatGrowth {
+	if g, ok := roleGrowth[role]; ok {
+		return g
+	}
+	return leaderGrowth
+}
+
+// Experience awarded for monster kills, scaled by the monster's level
+func DragonKillExperience(level int) int { return 200 + 50*max(level, 1) }
+func EnemyKillExperience(level int) int  { return 20 + 10*max(level, 1) }
+
+// LevelProgress describes the outcome of an experience grant
+type LevelProgress struct {
+	WarriorID     uint
+	Username      string
+	Gained        int
+	Experience    int
+	PreviousLevel int
+	Level         int
+	PowerGained   int
+	MaxHPGained   int
+}
+
+// LeveledUp reports whether the grant raised the warrior's level
+func (p *LevelProgress) LeveledUp() bool {
+	return p.Level > p.PreviousLevel
+}
+
+// GrantExperience adds experience to a warrior, applying role stat growth for every level gained.
+// A level-up is published as an event.
+func GrantExperience(warriorID uint, amount int, source string) (*LevelProgress, error) {
+	if amount <= 0 {
+		return nil, errors.New("experience amount must be positive")
+	}
+
+	var progress *LevelProgress
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		progress, err = grantExperienceTx(tx, warriorID, amount)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	announceProgress(progress, source)
+	return progress, nil
+}
+
+// GrantExperienceOnce is GrantExperience for grants driven by events that may be redelivered.
+// key is recorded in the same transaction as the grant; if it was already recorded nothing is
+// granted and the returned progress is nil.
+func GrantExperienceOnce(warriorID uint, amount int, source, key string) (*LevelProgress, error) {
+	if amount <= 0 {
+		return nil, errors.New("experience amount must be positive")
+	}
+
+	var progress *LevelProgress
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		claimed, err := claimExperienceGrant(tx, key)
+		if err != nil || !claimed {
+			return err
+		}
+		progress, err = grantExperienceTx(tx, warriorID, amount)
+		return err
+	})
+	if err != nil || progress == nil {
+		return nil, err
+	}
+
+	announceProgress(progress, source)
+	return progress, nil
+}
+
+// claimExperienceGrant records key inside tx. It reports false if the grant was already applied.
+func claimExperienceGrant(tx *gorm.DB, key string) (bool, error) {
+	res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ExperienceGrantReceipt{GrantKey: key})
+	if res.Error != nil {
+		return false, fmt.Errorf("failed to record experience grant: %w", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// grantExperienceTx adds experience to a warrior inside tx
+func grantExperienceTx(tx *gorm.DB, warriorID uint, amount int) (*LevelProgress, error) {
+	var w Warrior
+	if err := tx.First(&w, warriorID).Error; err != nil {
+		return nil, fmt.Errorf("warrior not found: %w", err)
+	}
+
+	progress := applyExperience(&w, amount)
+	updates := map[string]interface{}{
+		"experience":  w.Experience,
+		"level":       w.Level,
+		"total_power": w.TotalPower,
+		"max_hp":      w.MaxHP,
+	}
+	if err := tx.Model(&Warrior{}).Where("id = ?", w.ID).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// announceProgress logs a committed grant and publishes the level-up, if any
+func announceProgress(progress *LevelProgress, source string) {
+	log.Printf("warrior: %s gained %d XP from %s (level %d)", progress.Username, progress.Gained, source, progress.Level)
+	if progress.LeveledUp() {
+		go func() {
+			if err := PublishLevelUpEvent(progress, source); err != nil {
+				log.Printf("warrior: failed to publish level-up: %v", err)
+			}
+		}()
+	}
+}
+
+// applyExperience adds amount to w in memory and grows its stats for every level gained
+func applyExperience(w *Warrior, amount int) *LevelProgress {
+	if w.Level < 1 {
+		w.Level = 1
+	}
+	progress := &LevelProgress{
+		WarriorID:     w.ID,
+		Username:      w.Username,
+		Gained:        amount,
+		PreviousLevel: w.Level,
+	}
+
+	w.Experience += amount
+	newLevel := levelCurve.LevelForXP(w.Experience)
+	if newLevel > w.Level {
+		if w.MaxHP == 0 {
+			w.MaxHP = baseMaxHP(w.TotalPower)
+		}
+		growth := GrowthForRole(w.Role)
+		levels := newLevel - w.Level
+		progress.PowerGained = growth.Power * levels
+		progress.MaxHPGained = growth.MaxHP * levels
+		w.TotalPower += progress.PowerGained
+		w.MaxHP += progress.MaxHPGained
+		w.Level = newLevel
+	}
+
+	progress.Experience = w.Experience
+	progress.Level = w.Level
+	return progress
+}
+
+// ExperienceToNextLevel returns how much more experience w needs to level up; 0 at the level cap
+func ExperienceToNextLevel(w *Warrior) int {
+	level := w.Level
+	if level < 1 {
+		level = 1
+	}
+	if level >= levelCurve.MaxLevel {
+		return 0
+	}
+	remaining := levelCurve.XPForLevel(level+1) - w.Experience
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// baseMaxHP is the max HP of a warrior that has none stored yet, derived from total power
+func baseMaxHP(totalPower int) int {
+	maxHP := totalPower * 10
+	if maxHP < 100 {
+		maxHP = 100
+	}
+	return maxHP
+}
@@ -63,6 +63,7 @@ func SetupRoutes(r *gin.Engine, handler *Handler) {
 			// Admin routes (King only)
 			protected.POST("/warriors", handler.CreateWarrior)
 			protected.GET("/warriors", handler.GetWarriors)
+			protected.GET("/warriors/me/achievements", handler.GetMyAchievements)
 
 			// Individual warrior routes
 			protected.GET("/warriors/:id", handler.GetWarriorById)
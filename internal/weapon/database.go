@@ -8,14 +8,16 @@ import (
 
 	"network-sec-micro/pkg/secrets"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var (
-	Client     *mongo.Client
-	DB         *mongo.Database
-	WeaponColl *mongo.Collection
+	Client       *mongo.Client
+	DB           *mongo.Database
+	WeaponColl   *mongo.Collection
+	PurchaseColl *mongo.Collection
 )
 
 // InitDatabase initializes the MongoDB connection
@@ -39,9 +41,14 @@ func InitDatabase() error {
 
 	DB = Client.Database(dbName)
 	WeaponColl = DB.Collection("weapons")
+	PurchaseColl = DB.Collection("weapon_purchases")
 
 	log.Println("MongoDB connection established")
 
+	if err := createPurchaseIndexes(); err != nil {
+		return fmt.Errorf("failed to create purchase indexes: %w", err)
+	}
+
 	// Seed initial legendary weapons
 	if err := seedDatabase(); err != nil {
 		return fmt.Errorf("failed to seed database: %w", err)
@@ -50,6 +57,20 @@ func InitDatabase() error {
 	return nil
 }
 
+// createPurchaseIndexes lets a buyer run one purchase of a weapon at a time and finds unfinished purchases
+func createPurchaseIndexes() error {
+	_, err := PurchaseColl.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "item_id", Value: 1}, {Key: "buyer_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"active": true}),
+		},
+		{
+			Keys: bson.D{{Key: "active", Value: 1}, {Key: "updated_at", Value: 1}},
+		},
+	})
+	return err
+}
+
 // seedDatabase creates initial legendary weapons
 func seedDatabase() error {
 	ctx := context.Background()
@@ -6,6 +6,7 @@ type CreateWeaponCommand struct {
 	Description string
 	Type        string
 	Damage      int
+	Element     string
 	Price       int
 	CreatedBy   string
 }
@@ -6,6 +6,7 @@ type CreateWeaponRequest struct {
 	Description string `json:"description" binding:"required,max=500"`
 	Type        string `json:"type" binding:"required,oneof=common rare"`
 	Damage      int    `json:"damage" binding:"required,min=1,max=1000"`
+	Element     string `json:"element" binding:"omitempty,oneof=fire ice lightning shadow"`
 	Price       int    `json:"price" binding:"required,min=1"`
 }
 
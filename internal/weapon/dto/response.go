@@ -13,6 +13,7 @@ type WeaponResponse struct {
 	Description string             `json:"description"`
 	Type        string             `json:"type"`
 	Damage      int                `json:"damage"`
+	Element     string             `json:"element,omitempty"`
 	Price       int                `json:"price"`
 	CreatedBy   string             `json:"created_by"`
 	OwnedBy     []string           `json:"owned_by"`
@@ -0,0 +1,107 @@
+package weapon
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	pbCoin "network-sec-micro/api/proto/coin"
+	"network-sec-micro/pkg/purchase"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var coinGrpcClient pbCoin.CoinServiceClient
+var coinGrpcConn *grpc.ClientConn
+
+// InitCoinClient initializes the gRPC client connection to coin service
+func InitCoinClient(addr string) error {
+	if addr == "" {
+		addr = os.Getenv("COIN_GRPC_ADDR")
+		if addr == "" {
+			addr = "localhost:50051"
+		}
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to coin gRPC: %w", err)
+	}
+
+	coinGrpcClient = pbCoin.NewCoinServiceClient(conn)
+	coinGrpcConn = conn
+
+	log.Printf("Connected to Coin gRPC service at %s", addr)
+	return nil
+}
+
+// CloseCoinClient closes the coin gRPC connection
+func CloseCoinClient() {
+	if coinGrpcConn != nil {
+		coinGrpcConn.Close()
+	}
+}
+
+// PlaceCoinHold reserves coins on a warrior's balance for the shop. Placing a hold again under the
+// same reference returns the first hold.
+func PlaceCoinHold(ctx context.Context, warriorID uint, amount int64, reason, reference string) (uint32, error) {
+	if coinGrpcClient == nil {
+		return 0, fmt.Errorf("coin gRPC client not initialized")
+	}
+
+	resp, err := coinGrpcClient.PlaceHold(ctx, &pbCoin.PlaceHoldRequest{
+		WarriorId: uint32(warriorID),
+		Amount:    amount,
+		Reason:    reason,
+		Reference: reference,
+		Account:   "shop_revenue", // Weapon purchases are paid to the coin ledger's shop revenue account
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to place coin hold: %w", err)
+	}
+	if !resp.Success {
+		return 0, fmt.Errorf("%w: %s", purchase.ErrPaymentDeclined, resp.Message)
+	}
+
+	return resp.Hold.Id, nil
+}
+
+// CaptureCoinHold takes the coins a hold reserved. Capturing it again changes nothing.
+func CaptureCoinHold(ctx context.Context, holdID uint32) error {
+	if coinGrpcClient == nil {
+		return fmt.Errorf("coin gRPC client not initialized")
+	}
+
+	resp, err := coinGrpcClient.CaptureHold(ctx, &pbCoin.CaptureHoldRequest{HoldId: holdID})
+	if err != nil {
+		return fmt.Errorf("failed to capture coin hold: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("%w: %s", purchase.ErrPaymentDeclined, resp.Message)
+	}
+
+	log.Printf("Captured coin hold %d. Balance: %d", holdID, resp.BalanceAfter)
+	return nil
+}
+
+// ReleaseCoinHold gives the coins a hold reserved back. Releasing it again changes nothing.
+func ReleaseCoinHold(ctx context.Context, holdID uint32) error {
+	if coinGrpcClient == nil {
+		return fmt.Errorf("coin gRPC client not initialized")
+	}
+
+	resp, err := coinGrpcClient.ReleaseHold(ctx, &pbCoin.ReleaseHoldRequest{HoldId: holdID})
+	if err != nil {
+		return fmt.Errorf("failed to release coin hold: %w", err)
+	}
+	if !resp.Success {
+		if resp.Hold != nil && resp.Hold.Status == "captured" {
+			return purchase.ErrHoldCaptured
+		}
+		return fmt.Errorf("failed to release coin hold: %s", resp.Message)
+	}
+
+	return nil
+}
@@ -44,6 +44,7 @@ func (s *WeaponServiceServer) GetWeapon(ctx context.Context, req *pb.GetWeaponRe
             Durability:  int32(w.Durability),
             MaxDurability: int32(w.MaxDurability),
             IsBroken:    w.IsBroken,
+            Element:     w.Element,
             Owners: func() []*pb.OwnerRef {
                 if len(w.Owners) == 0 { return nil }
                 out := make([]*pb.OwnerRef, 0, len(w.Owners))
@@ -119,16 +120,17 @@ func (s *WeaponServiceServer) ListOwnerWeapons(ctx context.Context, req *pb.List
         if err := cursor.Decode(&w); err != nil { return nil, status.Errorf(codes.Internal, "decode error: %v", err) }
         res = append(res, &pb.Weapon{
             Id: w.ID.Hex(), Name: w.Name, Description: w.Description, Type: string(w.Type), Damage: int32(w.Damage), Price: int32(w.Price),
-            CreatedBy: w.CreatedBy, OwnedBy: w.OwnedBy, Durability: int32(w.Durability), MaxDurability: int32(w.MaxDurability), IsBroken: w.IsBroken,
+            CreatedBy: w.CreatedBy, OwnedBy: w.OwnedBy, Durability: int32(w.Durability), MaxDurability: int32(w.MaxDurability), IsBroken: w.IsBroken, Element: w.Element,
             Owners: func() []*pb.OwnerRef { if len(w.Owners)==0 {return nil}; out:=make([]*pb.OwnerRef,0,len(w.Owners)); for _,o:= range w.Owners { out = append(out, &pb.OwnerRef{OwnerType:o.OwnerType, OwnerId:o.OwnerID})}; return out }(),
         })
     }
     return &pb.ListOwnerWeaponsResponse{Weapons: res}, nil
 }
 
-// ApplyWear reduces durability and sets is_broken when needed
+// ApplyWear reduces durability and sets is_broken when needed. Negative wear gives durability back
+// (up to the maximum), e.g. when a battle is cancelled.
 func (s *WeaponServiceServer) ApplyWear(ctx context.Context, req *pb.ApplyWearRequest) (*pb.ApplyWearResponse, error) {
-    if req.Wear <= 0 { req.Wear = 1 }
+    if req.Wear == 0 { req.Wear = 1 }
     oid, err := primitive.ObjectIDFromHex(req.WeaponId)
     if err != nil { return nil, status.Errorf(codes.InvalidArgument, "invalid weapon id") }
     // Pull current values
@@ -138,6 +140,7 @@ func (s *WeaponServiceServer) ApplyWear(ctx context.Context, req *pb.ApplyWearRe
     }
     newDur := w.Durability - int(req.Wear)
     if newDur < 0 { newDur = 0 }
+    if req.Wear < 0 && newDur > w.MaxDurability && w.MaxDurability > 0 { newDur = w.MaxDurability }
     isBroken := newDur == 0
     update := bson.M{"$set": bson.M{"durability": newDur, "is_broken": isBroken, "updated_at": time.Now()}}
     if _, err := WeaponColl.UpdateByID(ctx, oid, update); err != nil {
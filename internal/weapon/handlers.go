@@ -74,6 +74,7 @@ func (h *Handler) CreateWeapon(c *gin.Context) {
 		Description: req.Description,
 		Type:        req.Type,
 		Damage:      req.Damage,
+		Element:     req.Element,
 		Price:       req.Price,
 		CreatedBy:   user.Username,
 	}
@@ -94,6 +95,7 @@ func (h *Handler) CreateWeapon(c *gin.Context) {
 		Description: weapon.Description,
 		Type:        string(weapon.Type),
 		Damage:      weapon.Damage,
+		Element:     weapon.Element,
 		Price:       weapon.Price,
 		CreatedBy:   weapon.CreatedBy,
 		OwnedBy:     weapon.OwnedBy,
@@ -140,6 +142,7 @@ func (h *Handler) GetWeapons(c *gin.Context) {
 			Description: w.Description,
 			Type:        string(w.Type),
 			Damage:      w.Damage,
+			Element:     w.Element,
 			Price:       w.Price,
 			CreatedBy:   w.CreatedBy,
 			OwnedBy:     w.OwnedBy,
@@ -156,7 +159,7 @@ func (h *Handler) GetWeapons(c *gin.Context) {
 
 // BuyWeapon godoc
 // @Summary Buy weapon
-// @Description Purchase a weapon. Triggers Kafka event for coin deduction via gRPC.
+// @Description Purchase a weapon. The price is held on the buyer's coins via gRPC and captured once the weapon is assigned.
 // @Tags weapons
 // @Accept json
 // @Produce json
@@ -246,6 +249,7 @@ func (h *Handler) GetMyWeapons(c *gin.Context) {
 			Description: w.Description,
 			Type:        string(w.Type),
 			Damage:      w.Damage,
+			Element:     w.Element,
 			Price:       w.Price,
 			CreatedBy:   w.CreatedBy,
 			OwnedBy:     w.OwnedBy,
@@ -29,6 +29,7 @@ type Weapon struct {
     MaxDurability int              `bson:"max_durability" json:"max_durability"`
     IsBroken    bool               `bson:"is_broken" json:"is_broken"`
     Owners      []OwnerRef         `bson:"owners,omitempty" json:"owners,omitempty"` // generalized ownership
+    Element     string             `bson:"element,omitempty" json:"element,omitempty"` // elemental affinity (fire, ice, lightning, shadow); empty = physical
 	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
 }
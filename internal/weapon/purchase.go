@@ -0,0 +1,78 @@
+package weapon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"network-sec-micro/pkg/purchase"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Weapon purchases run through the purchase saga in pkg/purchase; this file is the weapon's side of it
+
+// purchases returns the saga running weapon purchases
+func purchases() *purchase.Saga {
+	return purchase.NewSaga("weapon", PurchaseColl, coinHolds{}, weaponItem{})
+}
+
+// coinHolds places and settles purchase holds through the coin service
+type coinHolds struct{}
+
+func (coinHolds) PlaceHold(ctx context.Context, warriorID uint, amount int64, reason, reference string) (uint32, error) {
+	return PlaceCoinHold(ctx, warriorID, amount, reason, reference)
+}
+
+func (coinHolds) CaptureHold(ctx context.Context, holdID uint32) error {
+	return CaptureCoinHold(ctx, holdID)
+}
+
+func (coinHolds) ReleaseHold(ctx context.Context, holdID uint32) error {
+	return ReleaseCoinHold(ctx, holdID)
+}
+
+// weaponItem assigns a bought weapon by adding the buyer to its owners
+type weaponItem struct{}
+
+func (weaponItem) Assign(ctx context.Context, p *purchase.Purchase) error {
+	result, err := WeaponColl.UpdateOne(ctx,
+		bson.M{"_id": p.ItemID},
+		bson.M{"$addToSet": bson.M{"owned_by": p.BuyerID}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update weapon: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("weapon not found")
+	}
+	return nil
+}
+
+func (weaponItem) Revoke(ctx context.Context, p *purchase.Purchase) error {
+	_, err := WeaponColl.UpdateOne(ctx,
+		bson.M{"_id": p.ItemID},
+		bson.M{"$pull": bson.M{"owned_by": p.BuyerID}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	return err
+}
+
+func (weaponItem) Purchased(ctx context.Context, p *purchase.Purchase) {
+	weapon := &Weapon{ID: p.ItemID, Name: p.ItemName, Price: p.Price}
+	if err := PublishWeaponPurchase(ctx, weapon, p.BuyerUserID, p.BuyerUsername); err != nil {
+		log.Printf("Failed to publish weapon purchase event: %v", err)
+		// Don't fail the purchase if event publishing fails
+	}
+}
+
+// ResumePurchases finishes the weapon purchases that have made no progress for a while
+func (s *Service) ResumePurchases(ctx context.Context) (int, error) {
+	return purchases().Resume(ctx)
+}
+
+// StartPurchaseRecovery resumes unfinished weapon purchases every interval until ctx is done
+func (s *Service) StartPurchaseRecovery(ctx context.Context, interval time.Duration) {
+	purchases().StartRecovery(ctx, interval)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"network-sec-micro/internal/weapon/dto"
+	"network-sec-micro/pkg/purchase"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -31,6 +32,7 @@ func (s *Service) CreateWeapon(ctx context.Context, cmd dto.CreateWeaponCommand)
 		Description: cmd.Description,
 		Type:        weaponType,
 		Damage:      cmd.Damage,
+		Element:     cmd.Element,
 		Price:       cmd.Price,
 		CreatedBy:   cmd.CreatedBy,
 		OwnedBy:     []string{},
@@ -47,7 +49,8 @@ func (s *Service) CreateWeapon(ctx context.Context, cmd dto.CreateWeaponCommand)
 	return &weapon, nil
 }
 
-// BuyWeapon handles weapon purchase
+// BuyWeapon handles weapon purchase. The buyer pays through a coin hold that is captured once the
+// weapon is theirs; see purchase.go.
 func (s *Service) BuyWeapon(ctx context.Context, cmd dto.BuyWeaponCommand) error {
 	weaponID, err := primitive.ObjectIDFromHex(cmd.WeaponID)
 	if err != nil {
@@ -72,25 +75,38 @@ func (s *Service) BuyWeapon(ctx context.Context, cmd dto.BuyWeaponCommand) error
 		}
 	}
 
-	weapon.OwnedBy = append(weapon.OwnedBy, cmd.BuyerID)
-	weapon.UpdatedAt = time.Now()
-
-	_, err = WeaponColl.UpdateOne(ctx,
-		bson.M{"_id": weaponID},
-		bson.M{"$set": bson.M{"owned_by": weapon.OwnedBy, "updated_at": weapon.UpdatedAt}},
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to update weapon: %w", err)
+	now := time.Now()
+	p := &purchase.Purchase{
+		ItemID:        weaponID,
+		ItemName:      weapon.Name,
+		Price:         weapon.Price,
+		BuyerID:       cmd.BuyerID,
+		BuyerUsername: cmd.BuyerUsername,
+		BuyerUserID:   cmd.BuyerUserID,
+		State:         purchase.StateStarted,
+		Active:        true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := purchases().Create(ctx, p); err != nil {
+		return err
 	}
 
-	// Publish weapon purchase event to Kafka
-	if err := PublishWeaponPurchase(ctx, &weapon, cmd.BuyerUserID, cmd.BuyerUsername); err != nil {
-		log.Printf("Failed to publish weapon purchase event: %v", err)
-		// Don't fail the transaction if event publishing fails
+	// With the purchase recorded no other purchase of this weapon by the buyer can run, so ownership
+	// seen from now on was assigned by this one
+	owned, err := WeaponColl.CountDocuments(ctx, bson.M{"_id": weaponID, "owned_by": cmd.BuyerID})
+	if err != nil || owned > 0 {
+		failure := "you already own this weapon"
+		if err != nil {
+			failure = fmt.Sprintf("failed to check ownership: %v", err)
+		}
+		if cancelErr := purchases().Advance(ctx, p, purchase.StateCancelled, failure); cancelErr != nil {
+			log.Printf("Failed to cancel weapon purchase %s: %v", p.ID.Hex(), cancelErr)
+		}
+		return errors.New(failure)
 	}
 
-	return nil
+	return purchases().Run(ctx, p)
 }
 
 // GetWeapons gets all weapons
@@ -0,0 +1,271 @@
+package bracket
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Format is the kind of tournament a bracket is generated for
+type Format string
+
+const (
+	SingleElimination Format = "single_elimination"
+	DoubleElimination Format = "double_elimination"
+	Swiss             Format = "swiss"
+)
+
+// Valid checks if f is a known format
+func (f Format) Valid() bool {
+	return f == SingleElimination || f == DoubleElimination || f == Swiss
+}
+
+// MaxLosses returns how many losses put a player out; zero for Swiss, where nobody is knocked out
+func (f Format) MaxLosses() int {
+	switch f {
+	case SingleElimination:
+		return 1
+	case DoubleElimination:
+		return 2
+	}
+	return 0
+}
+
+// Pool is the part of a bracket a pairing belongs to
+type Pool string
+
+const (
+	PoolWinners Pool = "winners"
+	PoolLosers  Pool = "losers"
+	PoolFinal   Pool = "grand_final"
+	PoolSwiss   Pool = "swiss"
+)
+
+// Pairing is one game of a round. A zero B is a bye: A advances without playing.
+type Pairing struct {
+	Pool Pool
+	Slot int // Position within the pool, in bracket order
+	A, B uint
+}
+
+// IsBye checks if the pairing has no opponent
+func (p Pairing) IsBye() bool { return p.B == 0 }
+
+// SeedPositions returns the seeds (1 = strongest) of a bracket of size players in bracket order,
+// so that consecutive pairs play each other and the top seeds only meet in the last rounds.
+// size must be a power of two.
+func SeedPositions(size int) []int {
+	positions := []int{1}
+	for len(positions) < size {
+		n := len(positions) * 2
+		next := make([]int, 0, n)
+		for _, s := range positions {
+			next = append(next, s, n+1-s)
+		}
+		positions = next
+	}
+	return positions
+}
+
+// FirstRound pairs players given strongest first into the opening round of an elimination bracket.
+// The field is padded to a power of two with byes, which go to the top seeds.
+func FirstRound(seeded []uint) []Pairing {
+	if len(seeded) < 2 {
+		return nil
+	}
+	size := 1 << bits.Len(uint(len(seeded)-1))
+	positions := SeedPositions(size)
+	at := func(seed int) uint {
+		if seed > len(seeded) {
+			return 0
+		}
+		return seeded[seed-1]
+	}
+
+	pairings := make([]Pairing, 0, size/2)
+	for i := 0; i < size; i += 2 {
+		a, b := at(positions[i]), at(positions[i+1])
+		if a == 0 {
+			a, b = b, a
+		}
+		pairings = append(pairings, Pairing{Pool: PoolWinners, Slot: i / 2, A: a, B: b})
+	}
+	return pairings
+}
+
+// PairInOrder pairs players in the order given: first against second, third against fourth and so on.
+// With an odd count the first player gets the bye.
+func PairInOrder(players []uint, pool Pool) []Pairing {
+	var pairings []Pairing
+	if len(players)%2 == 1 {
+		pairings = append(pairings, Pairing{Pool: pool, Slot: 0, A: players[0]})
+		players = players[1:]
+	}
+	for i := 0; i+1 < len(players); i += 2 {
+		pairings = append(pairings, Pairing{Pool: pool, Slot: len(pairings), A: players[i], B: players[i+1]})
+	}
+	return pairings
+}
+
+// EliminationRound pairs the winners of the previous round, given in bracket order
+func EliminationRound(winners []uint) []Pairing {
+	if len(winners) < 2 {
+		return nil
+	}
+	return PairInOrder(winners, PoolWinners)
+}
+
+// DoubleEliminationRound pairs the next round of a double elimination bracket from the players still
+// unbeaten (upper) and those with one loss (lower). When one of each is left they meet in the grand final;
+// if the unbeaten player loses it, both are left with one loss and play once more.
+// No pairings means the bracket is decided.
+func DoubleEliminationRound(upper, lower []uint) []Pairing {
+	switch {
+	case len(upper)+len(lower) < 2:
+		return nil
+	case len(upper) == 1 && len(lower) == 1:
+		return []Pairing{{Pool: PoolFinal, A: upper[0], B: lower[0]}}
+	case len(upper) == 0 && len(lower) == 2:
+		return []Pairing{{Pool: PoolFinal, A: lower[0], B: lower[1]}}
+	}
+
+	var pairings []Pairing
+	if len(upper) >= 2 {
+		pairings = append(pairings, PairInOrder(upper, PoolWinners)...)
+	}
+	if len(lower) >= 2 {
+		pairings = append(pairings, PairInOrder(lower, PoolLosers)...)
+	}
+	return pairings
+}
+
+// SwissRounds returns the number of Swiss rounds needed to separate a clear winner among players
+func SwissRounds(players int) int {
+	if players < 2 {
+		return 0
+	}
+	return bits.Len(uint(players - 1))
+}
+
+// Standing is a player's place in a Swiss event going into a round
+type Standing struct {
+	ID     uint
+	Points int
+	Rating int
+}
+
+// History records who met whom in earlier rounds and who already had a bye
+type History struct {
+	met  map[[2]uint]bool
+	byes map[uint]bool
+}
+
+// NewHistory creates an empty history
+func NewHistory() *History {
+	return &History{met: make(map[[2]uint]bool), byes: make(map[uint]bool)}
+}
+
+// Record adds a played pairing; b is zero for a bye
+func (h *History) Record(a, b uint) {
+	if b == 0 {
+		h.byes[a] = true
+		return
+	}
+	h.met[pairKey(a, b)] = true
+}
+
+// Met checks if a and b already played each other
+func (h *History) Met(a, b uint) bool { return h.met[pairKey(a, b)] }
+
+// HadBye checks if the player already had a bye
+func (h *History) HadBye(id uint) bool { return h.byes[id] }
+
+func pairKey(a, b uint) [2]uint {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]uint{a, b}
+}
+
+// SwissPairings pairs the next Swiss round. Players are ranked by points then rating and each is paired
+// with the next ranked player they have not met yet, falling back to a rematch when nobody else is left.
+// With an odd count the lowest ranked player without a bye so far sits out with a bye.
+func SwissPairings(standings []Standing, history *History) []Pairing {
+	ranked := append([]Standing(nil), standings...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Points != ranked[j].Points {
+			return ranked[i].Points > ranked[j].Points
+		}
+		return ranked[i].Rating > ranked[j].Rating
+	})
+
+	var pairings []Pairing
+	if len(ranked)%2 == 1 {
+		bye := len(ranked) - 1
+		for i := len(ranked) - 1; i >= 0; i-- {
+			if !history.HadBye(ranked[i].ID) {
+				bye = i
+				break
+			}
+		}
+		pairings = append(pairings, Pairing{Pool: PoolSwiss, A: ranked[bye].ID})
+		ranked = append(ranked[:bye], ranked[bye+1:]...)
+	}
+
+	paired := make([]bool, len(ranked))
+	for i := range ranked {
+		if paired[i] {
+			continue
+		}
+		opponent := -1
+		for j := i + 1; j < len(ranked); j++ {
+			if paired[j] {
+				continue
+			}
+			if opponent < 0 {
+				opponent = j
+			}
+			if !history.Met(ranked[i].ID, ranked[j].ID) {
+				opponent = j
+				break
+			}
+		}
+		if opponent < 0 {
+			break
+		}
+		paired[i], paired[opponent] = true, true
+		pairings = append(pairings, Pairing{Pool: PoolSwiss, A: ranked[i].ID, B: ranked[opponent].ID})
+	}
+	for i := range pairings {
+		pairings[i].Slot = i
+	}
+	return pairings
+}
+
+// SplitPrizes divides pool between finishing places. places holds the players of each place, best first;
+// split is the percentage of the pool paid to each position. Players tied on a place share the positions
+// they cover. Whatever is left over, from rounding or from fewer finishers than paid positions, goes to the winner.
+func SplitPrizes(pool int, split []int, places [][]uint) map[uint]int {
+	prizes := make(map[uint]int)
+	if pool <= 0 || len(places) == 0 || len(places[0]) == 0 {
+		return prizes
+	}
+
+	paid, position := 0, 0
+	for _, tied := range places {
+		if position >= len(split) {
+			break
+		}
+		percent := 0
+		for i := position; i < position+len(tied) && i < len(split); i++ {
+			percent += split[i]
+		}
+		share := pool * percent / 100 / len(tied)
+		for _, id := range tied {
+			prizes[id] += share
+			paid += share
+		}
+		position += len(tied)
+	}
+	prizes[places[0][0]] += pool - paid
+	return prizes
+}
@@ -0,0 +1,68 @@
+package element
+
+// Element is the elemental type of a hit, a dragon or a piece of equipment
+type Element string
+
+const (
+	Physical  Element = "" // No element
+	Fire      Element = "fire"
+	Ice       Element = "ice"
+	Lightning Element = "lightning"
+	Shadow    Element = "shadow"
+)
+
+// MaxResistance caps armor resistance to any element, in percent
+const MaxResistance = 75
+
+// opposites take extra damage from each other
+var opposites = map[Element]Element{
+	Fire:      Ice,
+	Ice:       Fire,
+	Lightning: Shadow,
+	Shadow:    Lightning,
+}
+
+// Valid reports whether e is a known element (physical included)
+func Valid(e string) bool {
+	_, ok := opposites[Element(e)]
+	return ok || e == string(Physical)
+}
+
+// AffinityPercent returns the damage percent an attack of element attack does to a defender of element defender:
+// half against its own element, one and a half against the opposite one
+func AffinityPercent(attack, defender Element) int {
+	if attack == Physical || defender == Physical {
+		return 100
+	}
+	if attack == defender {
+		return 50
+	}
+	if opposites[attack] == defender {
+		return 150
+	}
+	return 100
+}
+
+// Apply adjusts damage of element attack for the defender's own element and armor resistance (percent).
+// It returns the damage taken and how much of the original damage was resisted.
+func Apply(damage int, attack, defender Element, resistance int) (taken int, resisted int) {
+	if attack == Physical || damage <= 0 {
+		return damage, 0
+	}
+	if resistance > MaxResistance {
+		resistance = MaxResistance
+	}
+	if resistance < 0 {
+		resistance = 0
+	}
+
+	taken = damage * AffinityPercent(attack, defender) / 100
+	taken = taken * (100 - resistance) / 100
+	if taken < 1 {
+		taken = 1
+	}
+	if taken < damage {
+		resisted = damage - taken
+	}
+	return taken, resisted
+}
@@ -170,6 +170,73 @@ func NewArenaMatchCompletedEvent(matchID string, player1ID uint, player1Name str
 	}
 }
 
+// ArenaMatchFoundEvent represents when the matchmaking queue pairs two warriors
+type ArenaMatchFoundEvent struct {
+	Event
+	MatchID       string `json:"match_id"`
+	Player1ID     uint   `json:"player1_id"`
+	Player1Name   string `json:"player1_name"`
+	Player1Rating int    `json:"player1_rating"`
+	Player2ID     uint   `json:"player2_id"`
+	Player2Name   string `json:"player2_name"`
+	Player2Rating int    `json:"player2_rating"`
+	WaitSeconds   int    `json:"wait_seconds"`
+}
+
+// NewArenaMatchFoundEvent creates a new arena match found event
+func NewArenaMatchFoundEvent(matchID string, player1ID uint, player1Name string, player1Rating int, player2ID uint, player2Name string, player2Rating int, waitSeconds int) *ArenaMatchFoundEvent {
+	return &ArenaMatchFoundEvent{
+		Event: Event{
+			EventType:     "arena_match_found",
+			Timestamp:     time.Now(),
+			SourceService: "arena",
+		},
+		MatchID:       matchID,
+		Player1ID:     player1ID,
+		Player1Name:   player1Name,
+		Player1Rating: player1Rating,
+		Player2ID:     player2ID,
+		Player2Name:   player2Name,
+		Player2Rating: player2Rating,
+		WaitSeconds:   waitSeconds,
+	}
+}
+
+// ArenaTournamentStageEvent represents a tournament moving to a new stage:
+// registration_open, started, round_started, completed or cancelled
+type ArenaTournamentStageEvent struct {
+	Event
+	TournamentID string `json:"tournament_id"`
+	Name         string `json:"name"`
+	Format       string `json:"format"`
+	Stage        string `json:"stage"`
+	Round        int    `json:"round,omitempty"`
+	Players      int    `json:"players"`
+	PrizePool    int    `json:"prize_pool"`
+	WinnerID     *uint  `json:"winner_id,omitempty"`
+	WinnerName   string `json:"winner_name,omitempty"`
+}
+
+// NewArenaTournamentStageEvent creates a new arena tournament stage event
+func NewArenaTournamentStageEvent(tournamentID, name, format, stage string, round, players, prizePool int, winnerID *uint, winnerName string) *ArenaTournamentStageEvent {
+	return &ArenaTournamentStageEvent{
+		Event: Event{
+			EventType:     "arena_tournament_" + stage,
+			Timestamp:     time.Now(),
+			SourceService: "arena",
+		},
+		TournamentID: tournamentID,
+		Name:         name,
+		Format:       format,
+		Stage:        stage,
+		Round:        round,
+		Players:      players,
+		PrizePool:    prizePool,
+		WinnerID:     winnerID,
+		WinnerName:   winnerName,
+	}
+}
+
 // Topic names for arena events
 const (
 	TopicArenaInvitationSent    = "arena.invitation.sent"
@@ -178,6 +245,8 @@ const (
 	TopicArenaInvitationExpired = "arena.invitation.expired"
 	TopicArenaMatchStarted      = "arena.match.started"
 	TopicArenaMatchCompleted    = "arena.match.completed"
+	TopicArenaMatchFound        = "arena.match.found"
+	TopicArenaTournamentStage   = "arena.tournament.stage"
     TopicArenaSpellWindowOpened = "arena.spell.window.opened"
     TopicArenaCrisisWindowOpened = "arena.crisis.window.opened"
 )
@@ -155,9 +155,17 @@ const (
 	TopicBattleStarted  = "battle.started"
 	TopicBattleCompleted = "battle.completed"
     TopicBattleWagerResolved = "battle.wager.resolved"
+    TopicBattleReward = "battle.reward"
+    TopicBattleSurrendered = "battle.surrendered"
+    TopicBattleCancelled = "battle.cancelled"
+    TopicBattleForceCancelled = "battle.force_cancelled"
+    TopicBattleTurn = "battle.turn"
+    TopicBattleSpellCast = "battle.spell_cast"
+    TopicWarriorLevelUp = "warrior.level_up"
 )
 
-// BattleWagerResolvedEvent represents wager payout after a team battle
+// BattleWagerResolvedEvent represents the settlement of a team battle's escrowed wager.
+// The winner side's emperor takes the pot; an empty winner side (draw or cancel) refunds both stakes.
 type BattleWagerResolvedEvent struct {
     Event
     BattleID    string `json:"battle_id"`
@@ -178,3 +186,206 @@ func NewBattleWagerResolvedEvent(battleID, winnerSide string, wagerAmount int, l
     }
 }
 
+// BattleRewardEvent represents the coins and experience one participant earned in a battle
+type BattleRewardEvent struct {
+    Event
+    BattleID        string `json:"battle_id"`
+    ParticipantID   string `json:"participant_id"`
+    ParticipantName string `json:"participant_name"`
+    ParticipantType string `json:"participant_type"`
+    Side            string `json:"side"`
+    DamageDealt     int    `json:"damage_dealt"`
+    Kills           int    `json:"kills"`
+    Coins           int    `json:"coins"`
+    Experience      int    `json:"experience"`
+}
+
+func NewBattleRewardEvent(battleID, participantID, participantName, participantType, side string, damageDealt, kills, coins, experience int) *BattleRewardEvent {
+    return &BattleRewardEvent{
+        Event: Event{ EventType: "battle_reward", Timestamp: time.Now(), SourceService: "battle" },
+        BattleID: battleID,
+        ParticipantID: participantID,
+        ParticipantName: participantName,
+        ParticipantType: participantType,
+        Side: side,
+        DamageDealt: damageDealt,
+        Kills: kills,
+        Coins: coins,
+        Experience: experience,
+    }
+}
+
+// BattleSurrenderedEvent represents a side giving up an in-progress team battle.
+// The other side wins; the wager, if any, goes to its emperor as for any other win.
+type BattleSurrenderedEvent struct {
+    Event
+    BattleID        string `json:"battle_id"`
+    SurrenderedSide string `json:"surrendered_side"`
+    SurrenderedBy   string `json:"surrendered_by"` // ID of the side's leader
+    WinnerSide      string `json:"winner_side"`
+    WagerAmount     int    `json:"wager_amount"`
+    TotalTurns      int    `json:"total_turns"`
+}
+
+func NewBattleSurrenderedEvent(battleID, surrenderedSide, surrenderedBy, winnerSide string, wagerAmount, totalTurns int) *BattleSurrenderedEvent {
+    return &BattleSurrenderedEvent{
+        Event: Event{ EventType: "battle_surrendered", Timestamp: time.Now(), SourceService: "battle" },
+        BattleID: battleID,
+        SurrenderedSide: surrenderedSide,
+        SurrenderedBy: surrenderedBy,
+        WinnerSide: winnerSide,
+        WagerAmount: wagerAmount,
+        TotalTurns: totalTurns,
+    }
+}
+
+// BattleCancelledEvent represents a battle called off by its creator before the first turn.
+// Nobody is rewarded and the wager, if any, is refunded to both emperors.
+type BattleCancelledEvent struct {
+    Event
+    BattleID       string `json:"battle_id"`
+    CancelledBy    string `json:"cancelled_by"`
+    Reason         string `json:"reason,omitempty"`
+    WagerRefunded  int    `json:"wager_refunded"`
+    LightEmperorID string `json:"light_emperor_id,omitempty"`
+    DarkEmperorID  string `json:"dark_emperor_id,omitempty"`
+}
+
+func NewBattleCancelledEvent(battleID, cancelledBy, reason string, wagerRefunded int, lightEmperorID, darkEmperorID string) *BattleCancelledEvent {
+    return &BattleCancelledEvent{
+        Event: Event{ EventType: "battle_cancelled", Timestamp: time.Now(), SourceService: "battle" },
+        BattleID: battleID,
+        CancelledBy: cancelledBy,
+        Reason: reason,
+        WagerRefunded: wagerRefunded,
+        LightEmperorID: lightEmperorID,
+        DarkEmperorID: darkEmperorID,
+    }
+}
+
+// BattleForceCancelledEvent represents an emperor stopping a battle at any point.
+// The wager is refunded, players get partial rewards for the damage they dealt and equipment wear is given back.
+type BattleForceCancelledEvent struct {
+    Event
+    BattleID       string `json:"battle_id"`
+    CancelledBy    string `json:"cancelled_by"`
+    Reason         string `json:"reason,omitempty"`
+    PreviousStatus string `json:"previous_status"`
+    WagerRefunded  int    `json:"wager_refunded"`
+    LightEmperorID string `json:"light_emperor_id,omitempty"`
+    DarkEmperorID  string `json:"dark_emperor_id,omitempty"`
+    RewardedCoins  int    `json:"rewarded_coins"` // Total partial rewards
+    TotalTurns     int    `json:"total_turns"`
+}
+
+func NewBattleForceCancelledEvent(battleID, cancelledBy, reason, previousStatus string, wagerRefunded int, lightEmperorID, darkEmperorID string, rewardedCoins, totalTurns int) *BattleForceCancelledEvent {
+    return &BattleForceCancelledEvent{
+        Event: Event{ EventType: "battle_force_cancelled", Timestamp: time.Now(), SourceService: "battle" },
+        BattleID: battleID,
+        CancelledBy: cancelledBy,
+        Reason: reason,
+        PreviousStatus: previousStatus,
+        WagerRefunded: wagerRefunded,
+        LightEmperorID: lightEmperorID,
+        DarkEmperorID: darkEmperorID,
+        RewardedCoins: rewardedCoins,
+        TotalTurns: totalTurns,
+    }
+}
+
+// BattleTurnEvent represents one hit of a team battle: an attack, or a status effect ticking on a participant
+type BattleTurnEvent struct {
+    Event
+    BattleID       string `json:"battle_id"`
+    TurnNumber     int    `json:"turn_number"`
+    AttackerID     string `json:"attacker_id"`
+    AttackerType   string `json:"attacker_type"`
+    AttackerSide   string `json:"attacker_side"`
+    TargetID       string `json:"target_id"`
+    TargetType     string `json:"target_type"`
+    TargetSide     string `json:"target_side"`
+    DamageDealt    int    `json:"damage_dealt"`
+    CriticalHit    bool   `json:"critical_hit"`
+    TargetDefeated bool   `json:"target_defeated"`
+    EffectType     string `json:"effect_type,omitempty"` // Empty for attacks
+    WeaponID       string `json:"weapon_id,omitempty"`
+    ArmorID        string `json:"armor_id,omitempty"`
+}
+
+func NewBattleTurnEvent(battleID string, turnNumber int, attackerID, attackerType, attackerSide, targetID, targetType, targetSide string, damageDealt int, criticalHit, targetDefeated bool, effectType, weaponID, armorID string) *BattleTurnEvent {
+    return &BattleTurnEvent{
+        Event: Event{ EventType: "battle_turn", Timestamp: time.Now(), SourceService: "battle" },
+        BattleID: battleID,
+        TurnNumber: turnNumber,
+        AttackerID: attackerID,
+        AttackerType: attackerType,
+        AttackerSide: attackerSide,
+        TargetID: targetID,
+        TargetType: targetType,
+        TargetSide: targetSide,
+        DamageDealt: damageDealt,
+        CriticalHit: criticalHit,
+        TargetDefeated: targetDefeated,
+        EffectType: effectType,
+        WeaponID: weaponID,
+        ArmorID: armorID,
+    }
+}
+
+// BattleParticipantSummary is one participant of a finished team battle, carried by its completed event
+type BattleParticipantSummary struct {
+    ParticipantID   string `json:"participant_id"`
+    ParticipantType string `json:"participant_type"`
+    Side            string `json:"side"`
+    Survived        bool   `json:"survived"`
+}
+
+// BattleSpellCastEvent represents a king casting a spell in a battle
+type BattleSpellCastEvent struct {
+    Event
+    BattleID      string `json:"battle_id"`
+    SpellType     string `json:"spell_type"`
+    CasterID      string `json:"caster_id"`
+    CasterRole    string `json:"caster_role"`
+    Side          string `json:"side"` // Side the spell is cast for
+    AffectedCount int    `json:"affected_count"`
+}
+
+func NewBattleSpellCastEvent(battleID, spellType, casterID, casterRole, side string, affectedCount int) *BattleSpellCastEvent {
+    return &BattleSpellCastEvent{
+        Event: Event{ EventType: "battle_spell_cast", Timestamp: time.Now(), SourceService: "battlespell" },
+        BattleID: battleID,
+        SpellType: spellType,
+        CasterID: casterID,
+        CasterRole: casterRole,
+        Side: side,
+        AffectedCount: affectedCount,
+    }
+}
+
+// WarriorLevelUpEvent represents a warrior reaching a new level
+type WarriorLevelUpEvent struct {
+    Event
+    WarriorID     uint   `json:"warrior_id"`
+    Username      string `json:"username"`
+    PreviousLevel int    `json:"previous_level"`
+    Level         int    `json:"level"`
+    Experience    int    `json:"experience"`
+    PowerGained   int    `json:"power_gained"`
+    MaxHPGained   int    `json:"max_hp_gained"`
+    Source        string `json:"source"` // what granted the experience, e.g. "dragon_kill"
+}
+
+func NewWarriorLevelUpEvent(warriorID uint, username string, previousLevel, level, experience, powerGained, maxHPGained int, source string) *WarriorLevelUpEvent {
+    return &WarriorLevelUpEvent{
+        Event: Event{ EventType: "warrior_level_up", Timestamp: time.Now(), SourceService: "warrior" },
+        WarriorID: warriorID,
+        Username: username,
+        PreviousLevel: previousLevel,
+        Level: level,
+        Experience: experience,
+        PowerGained: powerGained,
+        MaxHPGained: maxHPGained,
+        Source: source,
+    }
+}
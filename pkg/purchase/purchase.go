@@ -0,0 +1,65 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrPaymentDeclined is returned when the coin service turns a payment down, e.g. for insufficient balance
+	ErrPaymentDeclined = errors.New("payment declined")
+	// ErrHoldCaptured is returned when releasing a coin hold whose coins were already taken
+	ErrHoldCaptured = errors.New("coin hold was already captured")
+)
+
+// State is how far a purchase has got
+type State string
+
+const (
+	StateStarted      State = "started"      // Recorded, no coins reserved yet
+	StateReserved     State = "reserved"     // Price held on the buyer's coins
+	StateOwned        State = "owned"        // Item assigned, hold not captured yet
+	StateCompensating State = "compensating" // Being rolled back
+	StateCompleted    State = "completed"    // Buyer paid and owns the item
+	StateCancelled    State = "cancelled"    // Rolled back; buyer neither paid nor owns the item
+)
+
+// Purchase is the saved state of a purchase saga. While it is active no other purchase of the same
+// item by the same buyer can start.
+type Purchase struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ItemID        primitive.ObjectID `bson:"item_id" json:"item_id"`
+	ItemName      string             `bson:"item_name" json:"item_name"`
+	Price         int                `bson:"price" json:"price"`
+	BuyerID       string             `bson:"buyer_id" json:"buyer_id"` // username or entity ID, as in the item's owned_by
+	BuyerUsername string             `bson:"buyer_username" json:"buyer_username"`
+	BuyerUserID   uint               `bson:"buyer_user_id" json:"buyer_user_id"` // warrior ID in the coin service
+	OwnerType     string             `bson:"owner_type,omitempty" json:"owner_type,omitempty"`
+	HoldID        uint32             `bson:"hold_id,omitempty" json:"hold_id,omitempty"`
+	State         State              `bson:"state" json:"state"`
+	Active        bool               `bson:"active" json:"active"`                       // Until completed or cancelled
+	Failure       string             `bson:"failure,omitempty" json:"failure,omitempty"` // Why it is being rolled back
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Coins is the coin service's hold API. PlaceHold returns ErrPaymentDeclined when the buyer cannot pay
+// and the existing hold when one was placed under reference before; ReleaseHold returns ErrHoldCaptured
+// when the coins were already taken.
+type Coins interface {
+	PlaceHold(ctx context.Context, warriorID uint, amount int64, reason, reference string) (uint32, error)
+	CaptureHold(ctx context.Context, holdID uint32) error
+	ReleaseHold(ctx context.Context, holdID uint32) error
+}
+
+// Item is the part of a purchase that depends on what is bought. Assigning or revoking twice must
+// change nothing the second time.
+type Item interface {
+	Assign(ctx context.Context, p *Purchase) error
+	Revoke(ctx context.Context, p *Purchase) error
+	// Purchased runs once the purchase is completed, e.g. to publish an event
+	Purchased(ctx context.Context, p *Purchase)
+}
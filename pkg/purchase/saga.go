@@ -0,0 +1,251 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// A purchase is a saga across an item service and the coin service. The price is held on the buyer's
+// coins, the item is assigned to them and only then is the hold captured. When a step fails the ones
+// before it are undone: the item is taken back and the hold released. Each step is saved on the
+// purchase before the next one runs, so a purchase interrupted by a crash is picked up by Resume.
+// Every step can be repeated safely: the hold is placed under the purchase's ID, capturing and
+// releasing it twice changes nothing, and so does assigning or revoking the item.
+
+// RecoveryAge is how long a purchase may go without progress before recovery takes it over, so
+// purchases still running in a request are left alone
+const RecoveryAge = time.Minute
+
+// Saga runs the purchases of one kind of item, saved in coll
+type Saga struct {
+	kind  string
+	coll  *mongo.Collection
+	coins Coins
+	item  Item
+}
+
+// NewSaga creates a saga for purchases of kind (e.g. "weapon"), which names their coin holds and logs
+func NewSaga(kind string, coll *mongo.Collection, coins Coins, item Item) *Saga {
+	return &Saga{kind: kind, coll: coll, coins: coins, item: item}
+}
+
+// holdReference names the coin hold of a purchase
+func (s *Saga) holdReference(p *Purchase) string {
+	return s.kind + "_purchase:" + p.ID.Hex()
+}
+
+// holdReason describes the coin hold of a purchase
+func (s *Saga) holdReason(p *Purchase) string {
+	return s.kind + "_purchase: " + p.ItemName
+}
+
+// Create records a new purchase
+func (s *Saga) Create(ctx context.Context, p *Purchase) error {
+	result, err := s.coll.InsertOne(ctx, p)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("a purchase of this %s is already in progress", s.kind)
+		}
+		return fmt.Errorf("failed to record purchase: %w", err)
+	}
+	p.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Advance saves a purchase's next state. It fails if the purchase is no longer in the state it was
+// loaded in, i.e. another request or recovery moved it on first.
+func (s *Saga) Advance(ctx context.Context, p *Purchase, state State, failure string) error {
+	now := time.Now()
+	set := bson.M{"state": state, "hold_id": p.HoldID, "updated_at": now}
+	if failure != "" {
+		set["failure"] = failure
+	}
+	done := state == StateCompleted || state == StateCancelled
+	if done {
+		set["active"] = false
+	}
+
+	result, err := s.coll.UpdateOne(ctx, bson.M{"_id": p.ID, "state": p.State}, bson.M{"$set": set})
+	if err != nil {
+		return fmt.Errorf("failed to save purchase: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("purchase %s was moved on by another process", p.ID.Hex())
+	}
+
+	p.State, p.UpdatedAt = state, now
+	if failure != "" {
+		p.Failure = failure
+	}
+	if done {
+		p.Active = false
+	}
+	return nil
+}
+
+// Run takes a purchase from its saved state until it is completed or cancelled. It returns nil once
+// the buyer has paid and owns the item. A step that cannot be done now returns its error and leaves
+// the purchase for recovery.
+func (s *Saga) Run(ctx context.Context, p *Purchase) error {
+	for {
+		var err error
+		switch p.State {
+		case StateCompleted:
+			return nil
+		case StateCancelled:
+			return errors.New(p.Failure)
+		case StateStarted:
+			err = s.reserveCoins(ctx, p)
+		case StateReserved:
+			err = s.assignItem(ctx, p)
+		case StateOwned:
+			err = s.capturePayment(ctx, p)
+		case StateCompensating:
+			err = s.compensate(ctx, p)
+		default:
+			return fmt.Errorf("unknown purchase state %q", p.State)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// reserveCoins holds the price on the buyer's coins
+func (s *Saga) reserveCoins(ctx context.Context, p *Purchase) error {
+	if p.Price <= 0 {
+		return s.Advance(ctx, p, StateReserved, "")
+	}
+
+	holdID, err := s.coins.PlaceHold(ctx, p.BuyerUserID, int64(p.Price), s.holdReason(p), s.holdReference(p))
+	if errors.Is(err, ErrPaymentDeclined) {
+		return s.Advance(ctx, p, StateCancelled, err.Error())
+	}
+	if err != nil {
+		// The hold may have been placed before the call failed
+		return s.Advance(ctx, p, StateCompensating, err.Error())
+	}
+
+	p.HoldID = holdID
+	return s.Advance(ctx, p, StateReserved, "")
+}
+
+// assignItem gives the item to the buyer
+func (s *Saga) assignItem(ctx context.Context, p *Purchase) error {
+	if err := s.item.Assign(ctx, p); err != nil {
+		return s.Advance(ctx, p, StateCompensating, err.Error())
+	}
+	return s.Advance(ctx, p, StateOwned, "")
+}
+
+// capturePayment takes the held coins and completes the purchase
+func (s *Saga) capturePayment(ctx context.Context, p *Purchase) error {
+	if p.HoldID != 0 {
+		if err := s.coins.CaptureHold(ctx, p.HoldID); err != nil {
+			// If the capture went through after all, releasing the hold finds out
+			return s.Advance(ctx, p, StateCompensating, err.Error())
+		}
+	}
+	if err := s.Advance(ctx, p, StateCompleted, ""); err != nil {
+		return err
+	}
+
+	s.item.Purchased(ctx, p)
+	return nil
+}
+
+// compensate rolls a purchase back: the item is taken back first, then the hold released. A hold
+// that turns out to be captured means the buyer paid, so the item is assigned again and the purchase
+// completed instead.
+func (s *Saga) compensate(ctx context.Context, p *Purchase) error {
+	if err := s.item.Revoke(ctx, p); err != nil {
+		return fmt.Errorf("failed to revoke %s: %w", s.kind, err)
+	}
+
+	if p.Price > 0 && p.HoldID == 0 {
+		// The call that placed the hold failed, so its ID was never saved. Placing it again under the
+		// same reference returns it; if that is declined, no hold was ever placed.
+		holdID, err := s.coins.PlaceHold(ctx, p.BuyerUserID, int64(p.Price), s.holdReason(p), s.holdReference(p))
+		if err != nil && !errors.Is(err, ErrPaymentDeclined) {
+			return err
+		}
+		p.HoldID = holdID
+	}
+
+	if p.HoldID != 0 {
+		err := s.coins.ReleaseHold(ctx, p.HoldID)
+		if errors.Is(err, ErrHoldCaptured) {
+			return s.Advance(ctx, p, StateReserved, "")
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.Advance(ctx, p, StateCancelled, "")
+}
+
+// Resume finishes the purchases that have made no progress for RecoveryAge, e.g. because the service
+// crashed during them. A purchase whose item was already assigned goes on to capture the payment; one
+// that got no further is rolled back.
+func (s *Saga) Resume(ctx context.Context) (int, error) {
+	cursor, err := s.coll.Find(ctx, bson.M{
+		"active":     true,
+		"updated_at": bson.M{"$lt": time.Now().Add(-RecoveryAge)},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query purchases: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var purchases []Purchase
+	if err := cursor.All(ctx, &purchases); err != nil {
+		return 0, fmt.Errorf("failed to decode purchases: %w", err)
+	}
+
+	for i := range purchases {
+		p := &purchases[i]
+		if p.State == StateStarted || p.State == StateReserved {
+			if err := s.Advance(ctx, p, StateCompensating, "purchase was interrupted"); err != nil {
+				log.Printf("Failed to roll back %s purchase %s: %v", s.kind, p.ID.Hex(), err)
+				continue
+			}
+		}
+		if err := s.Run(ctx, p); err != nil && p.Active {
+			log.Printf("%s purchase %s is still %s: %v", s.kind, p.ID.Hex(), p.State, err)
+			continue
+		}
+		log.Printf("%s purchase %s recovered: %s", s.kind, p.ID.Hex(), p.State)
+	}
+	return len(purchases), nil
+}
+
+// StartRecovery resumes unfinished purchases every interval until ctx is done
+func (s *Saga) StartRecovery(ctx context.Context, interval time.Duration) {
+	check := func() {
+		if _, err := s.Resume(ctx); err != nil {
+			log.Printf("%s purchase recovery failed: %v", s.kind, err)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		check()
+		for {
+			select {
+			case <-ticker.C:
+				check()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,64 @@
+package rating
+
+import "math"
+
+const (
+	DefaultRating    = 1200
+	MinRating        = 100
+	ProvisionalGames = 20 // Games before a rating counts as established and moves more slowly
+
+	provisionalK = 40
+	establishedK = 20
+)
+
+// Outcome is the score of a game from one player's point of view
+type Outcome float64
+
+const (
+	Loss Outcome = 0
+	Draw Outcome = 0.5
+	Win  Outcome = 1
+)
+
+// Player is a rating together with the number of games behind it
+type Player struct {
+	Rating int
+	Games  int
+}
+
+// Expected returns the probability of a player rated rating scoring against opponent
+func Expected(rating, opponent int) float64 {
+	return 1 / (1 + math.Pow(10, float64(opponent-rating)/400))
+}
+
+// KFactor returns how far a single game can move a rating; new players settle faster
+func KFactor(games int) int {
+	if games < ProvisionalGames {
+		return provisionalK
+	}
+	return establishedK
+}
+
+// Update returns the ratings of a and b after a game in which a scored outcome.
+// Each side moves by its own K factor, and no rating drops below MinRating.
+func Update(a, b Player, outcome Outcome) (int, int) {
+	newA := a.Rating + delta(a, b.Rating, float64(outcome))
+	newB := b.Rating + delta(b, a.Rating, 1-float64(outcome))
+	return max(newA, MinRating), max(newB, MinRating)
+}
+
+func delta(p Player, opponent int, score float64) int {
+	return int(math.Round(float64(KFactor(p.Games)) * (score - Expected(p.Rating, opponent))))
+}
+
+// SoftReset pulls a rating towards DefaultRating for a new season, keeping the given fraction (0..1)
+// of its distance from the default
+func SoftReset(r int, keep float64) int {
+	if keep < 0 {
+		keep = 0
+	}
+	if keep > 1 {
+		keep = 1
+	}
+	return DefaultRating + int(math.Round(float64(r-DefaultRating)*keep))
+}
@@ -0,0 +1,180 @@
+package spectate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Event types pushed to spectators
+const (
+	EventSnapshot          = "snapshot" // Current state, sent once when a spectator connects
+	EventTurn              = "turn"
+	EventSpellCast         = "spell_cast"
+	EventParticipantJoined = "participant_joined"
+	EventDragonRevived     = "dragon_revived"
+	EventCompleted         = "completed" // Last event of a stream
+)
+
+// Event is one update pushed to the spectators of a battle or arena match
+type Event struct {
+	Type      string          `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// NewEvent creates an event with data encoded as JSON
+func NewEvent(eventType string, data interface{}) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+	return Event{Type: eventType, Timestamp: time.Now(), Data: raw}, nil
+}
+
+// SpellCast is the data of a spell_cast event
+type SpellCast struct {
+	SpellType     string `json:"spell_type"`
+	CasterID      string `json:"caster_id,omitempty"`
+	CasterName    string `json:"caster_name"`
+	AffectedCount int    `json:"affected_count,omitempty"`
+}
+
+// BattleChannel is the Redis channel carrying a battle's events
+func BattleChannel(battleID string) string { return "spectate:battle:" + battleID }
+
+// ArenaMatchChannel is the Redis channel carrying an arena match's events
+func ArenaMatchChannel(matchID string) string { return "spectate:arena:" + matchID }
+
+// Publish sends an event to every spectator of channel, whichever replica they are connected to
+func Publish(ctx context.Context, rc *redis.Client, channel, eventType string, data interface{}) error {
+	event, err := NewEvent(eventType, data)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return rc.Publish(ctx, channel, payload).Err()
+}
+
+// subscriberBuffer is how many events a spectator may fall behind before being disconnected
+const subscriberBuffer = 64
+
+// Hub shares one Redis subscription per replica between all spectators connected to it,
+// subscribing to a channel while at least one of them watches it.
+type Hub struct {
+	rc   *redis.Client
+	mu   sync.Mutex
+	ps   *redis.PubSub
+	subs map[string]map[*Subscription]struct{}
+}
+
+// NewHub creates a hub on top of rc
+func NewHub(rc *redis.Client) *Hub {
+	return &Hub{rc: rc, subs: make(map[string]map[*Subscription]struct{})}
+}
+
+// Subscription receives the events of one channel. C is closed when the subscription ends,
+// including when the spectator falls too far behind.
+type Subscription struct {
+	C       <-chan Event
+	ch      chan Event
+	hub     *Hub
+	channel string
+}
+
+// Subscribe starts receiving the events of channel; Close must be called when done.
+// The shared Redis subscription outlives any one request, so it is not tied to a request context.
+func (h *Hub) Subscribe(channel string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ctx := context.Background()
+	if h.ps == nil {
+		h.ps = h.rc.Subscribe(ctx)
+		go h.run(h.ps.Channel())
+	}
+	if len(h.subs[channel]) == 0 {
+		if err := h.ps.Subscribe(ctx, channel); err != nil {
+			return nil, fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+		}
+		h.subs[channel] = make(map[*Subscription]struct{})
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, hub: h, channel: channel}
+	h.subs[channel][sub] = struct{}{}
+	return sub, nil
+}
+
+// Close ends the subscription; calling it more than once is safe
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
+
+// remove drops a subscription, unsubscribing from Redis after the last one. Callers hold mu.
+func (h *Hub) remove(s *Subscription) {
+	subs, ok := h.subs[s.channel]
+	if !ok {
+		return
+	}
+	if _, ok := subs[s]; !ok {
+		return
+	}
+	delete(subs, s)
+	close(s.ch)
+	if len(subs) == 0 {
+		delete(h.subs, s.channel)
+		_ = h.ps.Unsubscribe(context.Background(), s.channel)
+	}
+}
+
+func (h *Hub) run(messages <-chan *redis.Message) {
+	for msg := range messages {
+		var event Event
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			continue
+		}
+		h.dispatch(msg.Channel, event)
+	}
+}
+
+// dispatch hands an event to the channel's spectators; a spectator whose buffer is full is
+// disconnected rather than allowed to hold up the others or silently miss turns
+func (h *Hub) dispatch(channel string, event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs[channel] {
+		select {
+		case sub.ch <- event:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+// Close stops the hub and ends every subscription
+func (h *Hub) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subs := range h.subs {
+		for sub := range subs {
+			close(sub.ch)
+		}
+	}
+	h.subs = make(map[string]map[*Subscription]struct{})
+	if h.ps == nil {
+		return nil
+	}
+	err := h.ps.Close()
+	h.ps = nil
+	return err
+}
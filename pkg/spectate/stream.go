@@ -0,0 +1,117 @@
+package spectate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// heartbeatInterval keeps idle SSE connections from being closed by proxies
+const heartbeatInterval = 15 * time.Second
+
+// IsWebSocket checks if r asks for a WebSocket upgrade
+func IsWebSocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// Serve streams initial followed by events to the client, over WebSocket when the request asks
+// for an upgrade and as server-sent events otherwise. Each event is sent as its JSON encoding.
+// The stream ends after a completed event, when events is closed or when the client goes away.
+func Serve(w http.ResponseWriter, r *http.Request, events <-chan Event, initial ...Event) {
+	if IsWebSocket(r) {
+		serveWebSocket(w, r, events, initial)
+		return
+	}
+	serveSSE(w, r, events, initial)
+}
+
+func serveWebSocket(w http.ResponseWriter, r *http.Request, events <-chan Event, initial []Event) {
+	websocket.Server{
+		// Spectators authenticate with a bearer token rather than cookies, so any origin may connect
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			// Spectators only listen; reading tells us when they disconnect
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			go func() {
+				_, _ = io.Copy(io.Discard, ws)
+				cancel()
+			}()
+
+			stream(ctx, events, initial, func(e Event) error {
+				return websocket.JSON.Send(ws, e)
+			}, nil)
+		},
+	}.ServeHTTP(w, r)
+}
+
+func serveSSE(w http.ResponseWriter, r *http.Request, events <-chan Event, initial []Event) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	send := func(e Event) error {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+	heartbeat := func() error {
+		if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+	stream(r.Context(), events, initial, send, heartbeat)
+}
+
+func stream(ctx context.Context, events <-chan Event, initial []Event, send func(Event) error, heartbeat func() error) {
+	for _, e := range initial {
+		if err := send(e); err != nil || e.Type == EventCompleted {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := send(e); err != nil || e.Type == EventCompleted {
+				return
+			}
+		case <-ticker.C:
+			if heartbeat != nil && heartbeat() != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
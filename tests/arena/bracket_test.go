@@ -0,0 +1,98 @@
+package arena_test
+
+import (
+	"testing"
+
+	"network-sec-micro/pkg/bracket"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedPositions(t *testing.T) {
+	assert.Equal(t, []int{1, 2}, bracket.SeedPositions(2))
+	assert.Equal(t, []int{1, 8, 4, 5, 2, 7, 3, 6}, bracket.SeedPositions(8))
+}
+
+func TestFirstRound_ByesGoToTopSeeds(t *testing.T) {
+	// Six players pad to eight: seeds 1 and 2 get byes
+	pairings := bracket.FirstRound([]uint{11, 12, 13, 14, 15, 16})
+	require.Len(t, pairings, 4)
+
+	assert.Equal(t, bracket.Pairing{Pool: bracket.PoolWinners, Slot: 0, A: 11}, pairings[0])
+	assert.Equal(t, bracket.Pairing{Pool: bracket.PoolWinners, Slot: 1, A: 14, B: 15}, pairings[1])
+	assert.Equal(t, bracket.Pairing{Pool: bracket.PoolWinners, Slot: 2, A: 12}, pairings[2])
+	assert.Equal(t, bracket.Pairing{Pool: bracket.PoolWinners, Slot: 3, A: 13, B: 16}, pairings[3])
+
+	// Next round pairs winners in bracket order
+	next := bracket.EliminationRound([]uint{11, 14, 12, 13})
+	require.Len(t, next, 2)
+	assert.Equal(t, uint(11), next[0].A)
+	assert.Equal(t, uint(14), next[0].B)
+	assert.Nil(t, bracket.EliminationRound([]uint{11}))
+}
+
+func TestDoubleEliminationRound(t *testing.T) {
+	// Both pools play among themselves; an odd losers pool gives its first player a bye
+	pairings := bracket.DoubleEliminationRound([]uint{1, 2}, []uint{3, 4, 5})
+	require.Len(t, pairings, 3)
+	assert.Equal(t, bracket.PoolWinners, pairings[0].Pool)
+	assert.True(t, pairings[1].IsBye())
+	assert.Equal(t, uint(3), pairings[1].A)
+	assert.Equal(t, bracket.PoolLosers, pairings[2].Pool)
+
+	// One unbeaten and one once-beaten player meet in the grand final, and again if the final is split
+	final := bracket.DoubleEliminationRound([]uint{1}, []uint{3})
+	require.Len(t, final, 1)
+	assert.Equal(t, bracket.PoolFinal, final[0].Pool)
+	reset := bracket.DoubleEliminationRound(nil, []uint{3, 1})
+	require.Len(t, reset, 1)
+	assert.Equal(t, bracket.PoolFinal, reset[0].Pool)
+
+	assert.Empty(t, bracket.DoubleEliminationRound(nil, []uint{3}))
+}
+
+func TestSwissPairings_AvoidsRematchesAndRepeatByes(t *testing.T) {
+	assert.Equal(t, 3, bracket.SwissRounds(8))
+	assert.Equal(t, 3, bracket.SwissRounds(5))
+
+	history := bracket.NewHistory()
+	history.Record(1, 2)
+	history.Record(5, 0) // 5 already had a bye
+
+	standings := []bracket.Standing{
+		{ID: 1, Points: 3, Rating: 1300},
+		{ID: 2, Points: 3, Rating: 1250},
+		{ID: 3, Points: 0, Rating: 1200},
+		{ID: 4, Points: 0, Rating: 1100},
+		{ID: 5, Points: 0, Rating: 1000},
+	}
+	pairings := bracket.SwissPairings(standings, history)
+	require.Len(t, pairings, 3)
+
+	// Lowest ranked player without a bye sits out
+	assert.Equal(t, bracket.Pairing{Pool: bracket.PoolSwiss, Slot: 0, A: 4}, pairings[0])
+	// 1 and 2 already met, so 1 plays the next ranked player instead
+	assert.Equal(t, uint(1), pairings[1].A)
+	assert.Equal(t, uint(3), pairings[1].B)
+	assert.Equal(t, uint(2), pairings[2].A)
+	assert.Equal(t, uint(5), pairings[2].B)
+}
+
+func TestSplitPrizes(t *testing.T) {
+	split := []int{50, 30, 20}
+
+	prizes := bracket.SplitPrizes(1000, split, [][]uint{{1}, {2}, {3, 4}, {5}})
+	assert.Equal(t, 500, prizes[1])
+	assert.Equal(t, 300, prizes[2])
+	assert.Equal(t, 100, prizes[3]) // 3rd shared by the two semifinal losers
+	assert.Equal(t, 100, prizes[4])
+	assert.Zero(t, prizes[5])
+
+	// Unpaid positions and rounding go to the winner
+	prizes = bracket.SplitPrizes(101, split, [][]uint{{1}, {2}})
+	assert.Equal(t, 71, prizes[1])
+	assert.Equal(t, 30, prizes[2])
+
+	assert.Empty(t, bracket.SplitPrizes(0, split, [][]uint{{1}}))
+}
@@ -0,0 +1,159 @@
+package arena_test
+
+import (
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/arena"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testMatchmaking = arena.MatchmakingConfig{
+	RatingWindow:       100,
+	RatingWindowGrowth: 50,
+	MaxRatingWindow:    300,
+	PowerWindow:        10,
+	PowerWindowGrowth:  5,
+	MaxPowerWindow:     40,
+	WidenEvery:         10 * time.Second,
+}
+
+func TestMatchmakingWindows_WidenWithWaitTime(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		waited     time.Duration
+		wantRating int
+		wantPower  int
+	}{
+		{"just joined", 0, 100, 10},
+		{"under one step", 9 * time.Second, 100, 10},
+		{"one step", 10 * time.Second, 150, 15},
+		{"three steps", 35 * time.Second, 250, 25},
+		{"rating capped", 50 * time.Second, 300, 35},
+		{"both capped", 10 * time.Minute, 300, 40},
+		{"joined in the future", -time.Minute, 100, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket := &arena.QueueTicket{JoinedAt: now.Add(-tt.waited)}
+			ratingWindow, powerWindow := testMatchmaking.Windows(ticket, now)
+			assert.Equal(t, tt.wantRating, ratingWindow)
+			assert.Equal(t, tt.wantPower, powerWindow)
+		})
+	}
+}
+
+func TestMatchmakingCompatible(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		a, b arena.QueueTicket
+		want bool
+	}{
+		{
+			name: "close rating and power",
+			a:    arena.QueueTicket{WarriorID: 1, Rating: 1000, Power: 100, JoinedAt: now},
+			b:    arena.QueueTicket{WarriorID: 2, Rating: 1090, Power: 95, JoinedAt: now},
+			want: true,
+		},
+		{
+			name: "rating too far for new tickets",
+			a:    arena.QueueTicket{WarriorID: 1, Rating: 1000, Power: 100, JoinedAt: now},
+			b:    arena.QueueTicket{WarriorID: 2, Rating: 1200, Power: 100, JoinedAt: now},
+		},
+		{
+			name: "rating gap closed once both waited",
+			a:    arena.QueueTicket{WarriorID: 1, Rating: 1000, Power: 100, JoinedAt: now.Add(-20 * time.Second)},
+			b:    arena.QueueTicket{WarriorID: 2, Rating: 1200, Power: 100, JoinedAt: now.Add(-20 * time.Second)},
+			want: true,
+		},
+		{
+			name: "narrower window of the newer ticket applies",
+			a:    arena.QueueTicket{WarriorID: 1, Rating: 1000, Power: 100, JoinedAt: now.Add(-time.Minute)},
+			b:    arena.QueueTicket{WarriorID: 2, Rating: 1200, Power: 100, JoinedAt: now},
+		},
+		{
+			name: "power too far apart",
+			a:    arena.QueueTicket{WarriorID: 1, Rating: 1000, Power: 100, JoinedAt: now},
+			b:    arena.QueueTicket{WarriorID: 2, Rating: 1000, Power: 80, JoinedAt: now},
+		},
+		{
+			name: "power gap closed once both waited",
+			a:    arena.QueueTicket{WarriorID: 1, Rating: 1000, Power: 100, JoinedAt: now.Add(-time.Minute)},
+			b:    arena.QueueTicket{WarriorID: 2, Rating: 1000, Power: 80, JoinedAt: now.Add(-time.Minute)},
+			want: true,
+		},
+		{
+			name: "no power on either side",
+			a:    arena.QueueTicket{WarriorID: 1, Rating: 1000, JoinedAt: now},
+			b:    arena.QueueTicket{WarriorID: 2, Rating: 1000, JoinedAt: now},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, testMatchmaking.Compatible(&tt.a, &tt.b, now))
+			assert.Equal(t, tt.want, testMatchmaking.Compatible(&tt.b, &tt.a, now), "compatibility is symmetric")
+		})
+	}
+}
+
+func TestPairTickets(t *testing.T) {
+	now := time.Now()
+	healed := now.Add(time.Minute)
+	ticket := func(id uint, rating int, waited time.Duration) *arena.QueueTicket {
+		return &arena.QueueTicket{WarriorID: id, Rating: rating, Power: 100, JoinedAt: now.Add(-waited)}
+	}
+	healing := ticket(9, 1000, time.Minute)
+	healing.HealingUntil = &healed
+
+	tests := []struct {
+		name    string
+		tickets []*arena.QueueTicket
+		want    [][2]uint
+	}{
+		{"empty queue", nil, nil},
+		{"single ticket", []*arena.QueueTicket{ticket(1, 1000, 0)}, nil},
+		{
+			name:    "same warrior listed twice",
+			tickets: []*arena.QueueTicket{ticket(1, 1000, time.Minute), ticket(1, 1000, 0)},
+		},
+		{
+			name:    "odd queue leaves the last ticket waiting",
+			tickets: []*arena.QueueTicket{ticket(1, 1000, 30*time.Second), ticket(2, 1010, 20*time.Second), ticket(3, 1020, 0)},
+			want:    [][2]uint{{1, 2}},
+		},
+		{
+			name: "longest waiting takes the closest rating",
+			tickets: []*arena.QueueTicket{
+				ticket(1, 1000, time.Minute), ticket(2, 1090, 0), ticket(3, 1010, 0), ticket(4, 1080, 0), ticket(5, 1500, 0),
+			},
+			want: [][2]uint{{1, 3}, {2, 4}},
+		},
+		{
+			name:    "healing ticket is skipped",
+			tickets: []*arena.QueueTicket{healing, ticket(1, 1000, 30*time.Second), ticket(2, 1000, 0)},
+			want:    [][2]uint{{1, 2}},
+		},
+		{
+			name:    "wide gap pairs only after waiting",
+			tickets: []*arena.QueueTicket{ticket(1, 1000, time.Minute), ticket(2, 1250, time.Minute)},
+			want:    [][2]uint{{1, 2}},
+		},
+		{
+			name:    "wide gap stays unpaired for new tickets",
+			tickets: []*arena.QueueTicket{ticket(1, 1000, 0), ticket(2, 1250, 0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][2]uint
+			for _, pair := range testMatchmaking.PairTickets(tt.tickets, now) {
+				got = append(got, [2]uint{pair[0].WarriorID, pair[1].WarriorID})
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package arena_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/arena"
+	"network-sec-micro/pkg/rating"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEloUpdate(t *testing.T) {
+	fresh := rating.Player{Rating: rating.DefaultRating}
+
+	// Even players: the winner gains what the loser drops
+	a, b := rating.Update(fresh, fresh, rating.Win)
+	assert.Equal(t, rating.DefaultRating+20, a)
+	assert.Equal(t, rating.DefaultRating-20, b)
+
+	a, b = rating.Update(fresh, fresh, rating.Draw)
+	assert.Equal(t, rating.DefaultRating, a)
+	assert.Equal(t, rating.DefaultRating, b)
+
+	// Established players move half as far as provisional ones
+	veteran := rating.Player{Rating: rating.DefaultRating, Games: rating.ProvisionalGames}
+	a, b = rating.Update(veteran, fresh, rating.Win)
+	assert.Equal(t, rating.DefaultRating+10, a)
+	assert.Equal(t, rating.DefaultRating-20, b)
+
+	// Upsets pay more than expected wins
+	strong := rating.Player{Rating: 1600, Games: 50}
+	weak := rating.Player{Rating: 1200, Games: 50}
+	upsetWeak, _ := rating.Update(weak, strong, rating.Win)
+	_, expectedStrong := rating.Update(weak, strong, rating.Loss)
+	assert.Greater(t, upsetWeak-weak.Rating, expectedStrong-strong.Rating)
+	assert.InDelta(t, 0.909, rating.Expected(1600, 1200), 0.001)
+
+	// Ratings never drop below the floor
+	_, b = rating.Update(rating.Player{Rating: 2000}, rating.Player{Rating: rating.MinRating}, rating.Win)
+	assert.Equal(t, rating.MinRating, b)
+}
+
+func TestSeasonSoftReset(t *testing.T) {
+	assert.Equal(t, 1400, rating.SoftReset(1600, 0.5))
+	assert.Equal(t, 1100, rating.SoftReset(1000, 0.5))
+	assert.Equal(t, rating.DefaultRating, rating.SoftReset(1800, 0))
+	assert.Equal(t, 1800, rating.SoftReset(1800, 1))
+}
+
+func TestRateUnratedMatches_RetriesFailedRating(t *testing.T) {
+	svc, _, db := setupTournaments(t)
+	ctx := context.Background()
+	_, err := svc.CurrentSeason(ctx)
+	require.NoError(t, err)
+
+	// A match that finished while its rating could not be recorded
+	winner := uint(1)
+	now := time.Now()
+	match := &arena.ArenaMatchSQL{
+		Player1ID: 1, Player1Name: "warrior1", Player2ID: 2, Player2Name: "warrior2",
+		Status: string(arena.MatchStatusCompleted), WinnerID: &winner, WinnerName: "warrior1",
+		StartedAt: &now, CompletedAt: &now, CreatedAt: now, UpdatedAt: now,
+	}
+	require.NoError(t, db.Create(match).Error)
+
+	rated, err := svc.RateUnratedMatches(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rated)
+
+	changes, total, err := arena.GetRepository().ListRatingChanges(ctx, 1, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, arena.RatingResultWin, changes[0].Result)
+	assert.Equal(t, rating.DefaultRating+20, changes[0].RatingAfter)
+
+	// Once rated the match is not picked up again
+	rated, err = svc.RateUnratedMatches(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, rated)
+}
@@ -0,0 +1,303 @@
+package arena_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	pbCoin "network-sec-micro/api/proto/coin"
+	pbWarrior "network-sec-micro/api/proto/warrior"
+	"network-sec-micro/internal/arena"
+	"network-sec-micro/internal/arena/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const startingCoins = 1000
+
+// fakeCoins is a coin service that keeps balances in memory and applies each idempotency key once
+type fakeCoins struct {
+	pbCoin.UnimplementedCoinServiceServer
+	mu       sync.Mutex
+	balances map[uint32]int64
+	keys     []string
+	applied  map[string]bool
+}
+
+func (f *fakeCoins) DeductCoins(_ context.Context, req *pbCoin.DeductCoinsRequest) (*pbCoin.DeductCoinsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.keys = append(f.keys, req.IdempotencyKey)
+	if f.applied[req.IdempotencyKey] {
+		return &pbCoin.DeductCoinsResponse{Success: true, WarriorId: req.WarriorId}, nil
+	}
+	if f.balance(req.WarriorId) < req.Amount {
+		return &pbCoin.DeductCoinsResponse{Success: false, Message: "insufficient balance"}, nil
+	}
+	f.balances[req.WarriorId] = f.balance(req.WarriorId) - req.Amount
+	f.applied[req.IdempotencyKey] = true
+	return &pbCoin.DeductCoinsResponse{Success: true, WarriorId: req.WarriorId}, nil
+}
+
+func (f *fakeCoins) AddCoins(_ context.Context, req *pbCoin.AddCoinsRequest) (*pbCoin.AddCoinsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.keys = append(f.keys, req.IdempotencyKey)
+	if !f.applied[req.IdempotencyKey] {
+		f.balances[req.WarriorId] = f.balance(req.WarriorId) + req.Amount
+		f.applied[req.IdempotencyKey] = true
+	}
+	return &pbCoin.AddCoinsResponse{Success: true, WarriorId: req.WarriorId}, nil
+}
+
+func (f *fakeCoins) balance(warriorID uint32) int64 {
+	if b, ok := f.balances[warriorID]; ok {
+		return b
+	}
+	return startingCoins
+}
+
+func (f *fakeCoins) Balance(warriorID uint) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.balance(uint32(warriorID))
+}
+
+// fakeWarriors serves every warrior ID as a fresh warrior named after it
+type fakeWarriors struct {
+	pbWarrior.UnimplementedWarriorServiceServer
+}
+
+func (fakeWarriors) GetWarriorByID(_ context.Context, req *pbWarrior.GetWarriorByIDRequest) (*pbWarrior.GetWarriorByIDResponse, error) {
+	return &pbWarrior.GetWarriorByIDResponse{Warrior: &pbWarrior.Warrior{
+		Id: req.WarriorId, Username: warriorName(uint(req.WarriorId)), TotalPower: 50,
+	}}, nil
+}
+
+func warriorName(id uint) string { return fmt.Sprintf("warrior%d", id) }
+
+// setupTournaments gives the arena service a fresh database and fake warrior and coin services
+func setupTournaments(t *testing.T) (*arena.Service, *fakeCoins, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "arena.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(
+		&arena.ArenaInvitationSQL{}, &arena.ArenaMatchSQL{}, &arena.ArenaSeasonSQL{}, &arena.ArenaRatingSQL{},
+		&arena.ArenaRatingChangeSQL{}, &arena.ArenaSeasonRewardSQL{}, &arena.ArenaTournamentSQL{},
+		&arena.ArenaTournamentEntrySQL{}, &arena.ArenaTournamentMatchSQL{},
+	))
+	arena.SQLDB.Enabled = true
+	arena.SQLDB.DB = db
+
+	coins := &fakeCoins{balances: map[uint32]int64{}, applied: map[string]bool{}}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	pbCoin.RegisterCoinServiceServer(srv, coins)
+	pbWarrior.RegisterWarriorServiceServer(srv, fakeWarriors{})
+	go func() { _ = srv.Serve(lis) }()
+
+	require.NoError(t, arena.InitCoinClient(lis.Addr().String()))
+	require.NoError(t, arena.InitWarriorClient(lis.Addr().String()))
+	t.Cleanup(func() {
+		arena.CloseCoinClient()
+		arena.CloseWarriorClient()
+		srv.Stop()
+	})
+	return arena.NewService(), coins, db
+}
+
+func createTournament(t *testing.T, svc *arena.Service, entryFee, maxPlayers int) *arena.Tournament {
+	tournament, err := svc.CreateTournament(context.Background(), dto.CreateTournamentCommand{
+		OrganizerID:   99,
+		OrganizerName: "emperor",
+		Name:          "Spring Cup",
+		Format:        "single_elimination",
+		EntryFee:      entryFee,
+		MaxPlayers:    maxPlayers,
+		StartsAt:      time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	return tournament
+}
+
+func register(svc *arena.Service, tournamentID string, warriorID uint) error {
+	_, err := svc.RegisterForTournament(context.Background(), dto.TournamentRegistrationCommand{
+		TournamentID: tournamentID, WarriorID: warriorID, WarriorName: warriorName(warriorID),
+	})
+	return err
+}
+
+func withdraw(svc *arena.Service, tournamentID string, warriorID uint) error {
+	return svc.WithdrawFromTournament(context.Background(), dto.TournamentRegistrationCommand{
+		TournamentID: tournamentID, WarriorID: warriorID, WarriorName: warriorName(warriorID),
+	})
+}
+
+// roundMatches returns the bracket slots of one round
+func roundMatches(t *testing.T, svc *arena.Service, tournamentID string, round int) []*arena.TournamentMatch {
+	rounds, err := svc.GetTournamentBracket(context.Background(), tournamentID)
+	require.NoError(t, err)
+	for _, r := range rounds {
+		if r.Round == round {
+			return r.Matches
+		}
+	}
+	return nil
+}
+
+// winMatch completes a slot's arena match for winnerID and reports it to the tournament
+func winMatch(t *testing.T, svc *arena.Service, slot *arena.TournamentMatch, winnerID uint) {
+	ctx := context.Background()
+	repo := arena.GetRepository()
+	now := time.Now()
+	require.NoError(t, repo.UpdateMatchFields(ctx, slot.MatchID, map[string]interface{}{
+		"status": string(arena.MatchStatusCompleted), "winner_id": winnerID, "winner_name": warriorName(winnerID),
+		"completed_at": now, "updated_at": now,
+	}))
+	match, err := repo.GetMatchByID(ctx, slot.MatchID)
+	require.NoError(t, err)
+	require.NoError(t, svc.RecordTournamentResult(ctx, match))
+}
+
+func TestTournament_Registration(t *testing.T) {
+	svc, coins, _ := setupTournaments(t)
+	tournament := createTournament(t, svc, 100, 2)
+
+	require.NoError(t, register(svc, tournament.ID, 1))
+	assert.ErrorIs(t, register(svc, tournament.ID, 1), arena.ErrAlreadyRegistered)
+	require.NoError(t, register(svc, tournament.ID, 2))
+	assert.ErrorIs(t, register(svc, tournament.ID, 3), arena.ErrTournamentFull)
+
+	assert.EqualValues(t, startingCoins-100, coins.Balance(1), "registering twice charges the fee once")
+	assert.EqualValues(t, startingCoins, coins.Balance(3), "a full tournament charges nothing")
+
+	got, entries, err := svc.GetTournament(context.Background(), tournament.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Players)
+	assert.Equal(t, 200, got.PrizePool)
+	assert.Len(t, entries, 2)
+}
+
+func TestTournament_WithdrawRefundsEachRegistration(t *testing.T) {
+	svc, coins, _ := setupTournaments(t)
+	tournament := createTournament(t, svc, 100, 8)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, register(svc, tournament.ID, 1))
+		assert.EqualValues(t, startingCoins-100, coins.Balance(1))
+		require.NoError(t, withdraw(svc, tournament.ID, 1))
+		assert.EqualValues(t, startingCoins, coins.Balance(1))
+	}
+	assert.ErrorIs(t, withdraw(svc, tournament.ID, 1), arena.ErrNotRegistered)
+	assert.EqualValues(t, startingCoins, coins.Balance(1), "withdrawing again refunds nothing")
+
+	assert.Equal(t, []string{
+		"arena_tournament_entry:" + tournament.ID + ":1:1", "arena_tournament_refund:" + tournament.ID + ":1:1",
+		"arena_tournament_entry:" + tournament.ID + ":1:2", "arena_tournament_refund:" + tournament.ID + ":1:2",
+	}, coins.keys)
+
+	got, entries, err := svc.GetTournament(context.Background(), tournament.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.Players)
+	assert.Equal(t, 0, got.PrizePool)
+	assert.Empty(t, entries)
+}
+
+func TestTournament_CancelRefundsEntryFees(t *testing.T) {
+	svc, coins, _ := setupTournaments(t)
+	tournament := createTournament(t, svc, 100, 8)
+	require.NoError(t, register(svc, tournament.ID, 1))
+	require.NoError(t, register(svc, tournament.ID, 2))
+
+	cancelled, err := svc.CancelTournament(context.Background(), tournament.ID)
+	require.NoError(t, err)
+	assert.Equal(t, arena.TournamentStatusCancelled, cancelled.Status)
+	assert.EqualValues(t, startingCoins, coins.Balance(1))
+	assert.EqualValues(t, startingCoins, coins.Balance(2))
+}
+
+func TestTournament_BracketAdvancesToChampion(t *testing.T) {
+	svc, coins, _ := setupTournaments(t)
+	ctx := context.Background()
+	tournament := createTournament(t, svc, 100, 4)
+	for id := uint(1); id <= 4; id++ {
+		require.NoError(t, register(svc, tournament.ID, id))
+	}
+
+	started, err := svc.StartTournament(ctx, tournament.ID)
+	require.NoError(t, err)
+	assert.Equal(t, arena.TournamentStatusInProgress, started.Status)
+
+	semis := roundMatches(t, svc, tournament.ID, 1)
+	require.Len(t, semis, 2)
+	for _, slot := range semis {
+		assert.Equal(t, arena.TournamentMatchInProgress, slot.Status)
+		require.NotEmpty(t, slot.MatchID)
+	}
+
+	winMatch(t, svc, semis[0], semis[0].Player1ID)
+	assert.Empty(t, roundMatches(t, svc, tournament.ID, 2), "the final waits for the other semi-final")
+	winMatch(t, svc, semis[1], semis[1].Player2ID)
+
+	final := roundMatches(t, svc, tournament.ID, 2)
+	require.Len(t, final, 1)
+	assert.ElementsMatch(t, []uint{semis[0].Player1ID, semis[1].Player2ID}, []uint{final[0].Player1ID, final[0].Player2ID})
+
+	champion := final[0].Player1ID
+	winMatch(t, svc, final[0], champion)
+
+	finished, _, err := svc.GetTournament(ctx, tournament.ID)
+	require.NoError(t, err)
+	assert.Equal(t, arena.TournamentStatusCompleted, finished.Status)
+	require.NotNil(t, finished.WinnerID)
+	assert.Equal(t, champion, *finished.WinnerID)
+	assert.EqualValues(t, startingCoins-100+200, coins.Balance(champion), "the champion takes half the pool")
+}
+
+func TestTournament_OverdueMatchIsForfeitedAndRated(t *testing.T) {
+	svc, _, db := setupTournaments(t)
+	ctx := context.Background()
+	tournament := createTournament(t, svc, 0, 4)
+	for id := uint(1); id <= 4; id++ {
+		require.NoError(t, register(svc, tournament.ID, id))
+	}
+	_, err := svc.StartTournament(ctx, tournament.ID)
+	require.NoError(t, err)
+
+	semis := roundMatches(t, svc, tournament.ID, 1)
+	require.Len(t, semis, 2)
+	overdue := semis[1]
+	require.NoError(t, db.Model(&arena.ArenaTournamentMatchSQL{}).Where("id = ?", overdue.ID).
+		Update("deadline", time.Now().Add(-time.Minute)).Error)
+
+	// Reporting the other semi-final moves the tournament on, which forfeits the overdue match
+	winMatch(t, svc, semis[0], semis[0].Player1ID)
+
+	semis = roundMatches(t, svc, tournament.ID, 1)
+	assert.Equal(t, arena.TournamentMatchForfeit, semis[1].Status)
+	require.NotNil(t, semis[1].WinnerID)
+	assert.Equal(t, overdue.Player2ID, *semis[1].WinnerID, "player1 was due to move and loses")
+
+	match, err := arena.GetRepository().GetMatchByID(ctx, overdue.MatchID)
+	require.NoError(t, err)
+	assert.Equal(t, arena.MatchStatusCompleted, match.Status)
+
+	for id, result := range map[uint]string{overdue.Player1ID: arena.RatingResultLoss, overdue.Player2ID: arena.RatingResultWin} {
+		history, total, err := svc.GetRatingHistory(ctx, dto.GetRatingHistoryQuery{WarriorID: id})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, total)
+		assert.Equal(t, overdue.MatchID, history[0].MatchID)
+		assert.Equal(t, result, history[0].Result)
+	}
+
+	assert.Len(t, roundMatches(t, svc, tournament.ID, 2), 1, "the final is paired")
+}
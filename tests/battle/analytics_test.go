@@ -0,0 +1,158 @@
+package battle_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+	"network-sec-micro/pkg/kafka"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var analyticsDay = time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+
+func setupAnalyticsDB(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, db.AutoMigrate(
+		&battle.AnalyticsParticipantDailySQL{}, &battle.AnalyticsVictoryDailySQL{}, &battle.AnalyticsEquipmentDailySQL{},
+		&battle.AnalyticsSpellDailySQL{}, &battle.AnalyticsSpellCastSQL{}, &battle.AnalyticsBattleOutcomeSQL{}, &battle.AnalyticsEventSQL{},
+	))
+	battle.SQLDB.Enabled = true
+	battle.SQLDB.DB = db
+}
+
+// deliver feeds an event to the read model as the consumer would
+func deliver(t *testing.T, event interface{}) {
+	message, err := json.Marshal(event)
+	require.NoError(t, err)
+	require.NoError(t, battle.ProcessAnalyticsMessage(message))
+}
+
+func hit(battleID string, turn int, damage int, crit, kill bool) *kafka.BattleTurnEvent {
+	event := kafka.NewBattleTurnEvent(battleID, turn, "1", "warrior", "light", "goblin", "enemy", "dark", damage, crit, kill, "", "sword-1", "hide-1")
+	event.Timestamp = analyticsDay
+	return event
+}
+
+func spellCast(battleID, spellType, side string, affected int, at time.Time) *kafka.BattleSpellCastEvent {
+	event := kafka.NewBattleSpellCastEvent(battleID, spellType, "7", side+"_king", side, affected)
+	event.Timestamp = at
+	return event
+}
+
+func completed(battleID, winnerSide string, turns int) battle.BattleCompletedEvent {
+	return battle.BattleCompletedEvent{
+		EventType:  "battle_completed",
+		Timestamp:  analyticsDay,
+		BattleID:   battleID,
+		BattleType: "team",
+		Result:     winnerSide + "_victory",
+		TotalTurns: turns,
+		WinnerSide: winnerSide,
+		Participants: []kafka.BattleParticipantSummary{
+			{ParticipantID: "1", ParticipantType: "warrior", Side: "light", Survived: true},
+			{ParticipantID: "2", ParticipantType: "warrior", Side: "light"},
+			{ParticipantID: "goblin", ParticipantType: "enemy", Side: "dark"},
+		},
+	}
+}
+
+func TestBattleAnalytics_AggregatesEvents(t *testing.T) {
+	setupAnalyticsDB(t)
+
+	deliver(t, hit("1", 1, 30, false, false))
+	deliver(t, hit("1", 2, 60, true, true))
+	deliver(t, hit("1", 2, 60, true, true)) // Redelivered
+	tick := kafka.NewBattleTurnEvent("1", 2, "1", "warrior", "light", "1", "warrior", "light", 5, false, false, "poison", "", "")
+	tick.Timestamp = analyticsDay
+	deliver(t, tick)
+	deliver(t, spellCast("1", "resistance", "light", 2, analyticsDay))
+	deliver(t, completed("1", "light", 2))
+	deliver(t, completed("1", "light", 2)) // Redelivered
+
+	// A cast that arrives after its battle ended is decided straight away
+	deliver(t, spellCast("1", "rebirth", "light", 1, analyticsDay.Add(time.Minute)))
+	// Casts in a cancelled battle are counted but never decided
+	deliver(t, spellCast("2", "destroy_the_light", "dark", 3, analyticsDay))
+	deliver(t, kafka.NewBattleCancelledEvent("2", "arthur", "", 0, "", ""))
+
+	analytics, err := battle.NewService().GetBattleAnalytics(dto.BattleAnalyticsQuery{From: "2026-03-01", To: "2026-03-31"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"participant_type", "side"}, analytics.GroupBy)
+
+	require.Len(t, analytics.Participants, 2)
+	goblins, warriors := analytics.Participants[0], analytics.Participants[1]
+	assert.Equal(t, "enemy", goblins.ParticipantType)
+	assert.Equal(t, 1, goblins.Losses)
+	assert.Equal(t, 90, goblins.DamageTaken)
+	assert.Equal(t, "warrior", warriors.ParticipantType)
+	assert.Equal(t, 2, warriors.Battles)
+	assert.Equal(t, 2, warriors.Wins)
+	assert.Equal(t, 1.0, warriors.WinRate)
+	assert.Equal(t, 1, warriors.Survived)
+	assert.Equal(t, 2, warriors.Attacks)
+	assert.Equal(t, 0.5, warriors.CritRate)
+	assert.Equal(t, 1, warriors.Kills)
+	assert.Equal(t, 90, warriors.DamageDealt)
+	assert.Equal(t, 5, warriors.DamageTaken, "status effects count as damage taken")
+
+	require.Len(t, analytics.Victories, 1)
+	assert.Equal(t, dto.VictoryAnalytics{WinnerSide: "light", Victories: 1, AverageTurns: 2}, analytics.Victories[0])
+
+	require.Len(t, analytics.Weapons, 1)
+	assert.Equal(t, dto.EquipmentAnalytics{ItemID: "sword-1", Uses: 2, Damage: 90, AverageDamage: 45}, analytics.Weapons[0])
+	require.Len(t, analytics.Armor, 1)
+	assert.Equal(t, "hide-1", analytics.Armor[0].ItemID)
+
+	spells := map[string]dto.SpellAnalytics{}
+	for _, s := range analytics.Spells {
+		spells[s.SpellType] = s
+	}
+	assert.Equal(t, 1, spells["resistance"].WinningCasts)
+	assert.Equal(t, 1.0, spells["resistance"].WinRate)
+	assert.Equal(t, 2.0, spells["resistance"].AverageAffected)
+	assert.Equal(t, 1, spells["rebirth"].DecidedCasts)
+	assert.Equal(t, 1, spells["destroy_the_light"].Casts)
+	assert.Zero(t, spells["destroy_the_light"].DecidedCasts)
+}
+
+func TestBattleAnalytics_Query(t *testing.T) {
+	setupAnalyticsDB(t)
+	svc := battle.NewService()
+
+	deliver(t, hit("1", 1, 30, false, false))
+	later := hit("3", 1, 40, false, false)
+	later.Timestamp = analyticsDay.AddDate(0, 0, 1)
+	deliver(t, later)
+
+	byDay, err := svc.GetBattleAnalytics(dto.BattleAnalyticsQuery{From: "2026-03-14", To: "2026-03-15", GroupBy: []string{"day", "side"}})
+	require.NoError(t, err)
+	require.Len(t, byDay.Participants, 4)
+	assert.Equal(t, dto.ParticipantAnalytics{Day: "2026-03-14", Side: "dark", DamageTaken: 30}, byDay.Participants[0])
+	assert.Equal(t, "2026-03-15", byDay.Participants[3].Day)
+
+	firstDay, err := svc.GetBattleAnalytics(dto.BattleAnalyticsQuery{From: "2026-03-14", To: "2026-03-14", GroupBy: []string{"side"}})
+	require.NoError(t, err)
+	require.Len(t, firstDay.Participants, 2)
+	assert.Equal(t, 30, firstDay.Participants[1].DamageDealt)
+
+	for name, query := range map[string]dto.BattleAnalyticsQuery{
+		"unknown group": {GroupBy: []string{"weapon"}},
+		"bad date":      {From: "14/03/2026"},
+		"reversed":      {From: "2026-03-15", To: "2026-03-14"},
+		"too long":      {From: "2024-01-01", To: "2026-03-14"},
+	} {
+		_, err := svc.GetBattleAnalytics(query)
+		assert.ErrorIs(t, err, battle.ErrInvalidAnalyticsQuery, name)
+	}
+}
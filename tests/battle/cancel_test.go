@@ -0,0 +1,122 @@
+package battle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculatePartialRewards(t *testing.T) {
+	b := &battle.Battle{ID: "7"}
+	participants := []*battle.BattleParticipant{
+		{ParticipantID: "1", Name: "Knight", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, IsAlive: true},
+		{ParticipantID: "2", Name: "Mage", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, IsAlive: false},
+		{ParticipantID: "20", Name: "Dark King", Type: battle.ParticipantTypeDarkKing, Side: battle.TeamSideDark, IsAlive: true},
+		{ParticipantID: "21", Name: "Goblin", Type: battle.ParticipantTypeEnemy, Side: battle.TeamSideDark, IsAlive: true},
+	}
+	turns := []*battle.BattleTurn{
+		{TurnNumber: 1, AttackerID: "1", TargetID: "20", DamageDealt: 40, TargetDefeated: true},
+		{TurnNumber: 2, AttackerID: "2", TargetID: "21", DamageDealt: 30},
+		{TurnNumber: 3, AttackerID: "20", TargetID: "1", DamageDealt: 50},
+		{TurnNumber: 4, AttackerID: "21", TargetID: "2", DamageDealt: 60},
+	}
+
+	rewards := battle.CalculatePartialRewards(b, participants, turns)
+
+	// Damage share only, for both sides and the fallen, but never for NPCs
+	got := map[string]*battle.BattleReward{}
+	for _, r := range rewards {
+		got[r.ParticipantID] = r
+	}
+	require.Len(t, got, 3)
+	assert.Equal(t, 4, got["1"].Coins)
+	assert.Equal(t, 8, got["1"].Experience)
+	assert.Zero(t, got["1"].Kills)
+	assert.Equal(t, 3, got["2"].Coins)
+	assert.Equal(t, 5, got["20"].Coins)
+}
+
+func TestSurrender_OnlySideLeader(t *testing.T) {
+	battleID := setupEffectBattle(t, 100)
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	require.NoError(t, repo.InsertParticipants(ctx, []*battle.BattleParticipant{
+		{BattleID: battleID, ParticipantID: "2", Name: "Archer", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, HP: 100, MaxHP: 100, AttackPower: 20, IsAlive: true, CreatedAt: time.Now().Add(time.Second)},
+	}))
+	svc := battle.NewService()
+
+	// The knight joined first, so the archer does not lead the light side
+	_, err := svc.Surrender(dto.SurrenderBattleCommand{BattleID: battleID, Side: "light", RequestedBy: "2"})
+	assert.ErrorIs(t, err, battle.ErrNotSideLeader)
+	_, err = svc.Surrender(dto.SurrenderBattleCommand{BattleID: battleID, Side: "dark", RequestedBy: "1"})
+	assert.ErrorIs(t, err, battle.ErrNotSideLeader)
+
+	b, err := svc.Surrender(dto.SurrenderBattleCommand{BattleID: battleID, Side: "light", RequestedBy: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusCompleted, b.Status)
+	assert.Equal(t, battle.BattleResultDarkVictory, b.Result)
+	assert.Equal(t, battle.TeamSideDark, b.WinnerSide)
+
+	_, err = svc.Surrender(dto.SurrenderBattleCommand{BattleID: battleID, Side: "dark", RequestedBy: "20"})
+	assert.Error(t, err, "a finished battle cannot be surrendered")
+}
+
+func TestCancelBattle_CreatorBeforeFirstTurn(t *testing.T) {
+	battleID := setupEffectBattle(t, 100)
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	require.NoError(t, repo.UpdateBattleFields(ctx, battleID, map[string]interface{}{"created_by": "arthur"}))
+	svc := battle.NewService()
+
+	_, err := svc.CancelBattle(dto.CancelBattleCommand{BattleID: battleID, RequestedBy: "morgan"})
+	assert.ErrorIs(t, err, battle.ErrNotBattleCreator)
+
+	b, err := svc.CancelBattle(dto.CancelBattleCommand{BattleID: battleID, RequestedBy: "arthur", Reason: "wrong roster"})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusCancelled, b.Status)
+	assert.NotNil(t, b.CompletedAt)
+	assert.Empty(t, b.CoinsEarned)
+
+	// Once a turn has been played only a force-cancel can stop the battle
+	battleID = setupEffectBattle(t, 100)
+	require.NoError(t, battle.GetRepository().UpdateBattleFields(ctx, battleID, map[string]interface{}{"created_by": "arthur"}))
+	_, _, err = svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "1", TargetID: "20"})
+	require.NoError(t, err)
+	_, err = svc.CancelBattle(dto.CancelBattleCommand{BattleID: battleID, RequestedBy: "arthur"})
+	assert.ErrorContains(t, err, "before the first turn")
+}
+
+func TestForceCancelBattle_PartialRewards(t *testing.T) {
+	battleID := setupEffectBattle(t, 100)
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	require.NoError(t, repo.UpdateBattleFields(ctx, battleID, map[string]interface{}{"wager_amount": 500, "light_emperor_id": "5"}))
+	svc := battle.NewService()
+
+	_, _, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "1", TargetID: "20"})
+	require.NoError(t, err)
+
+	_, err = svc.ForceCancelBattle(dto.ForceCancelBattleCommand{BattleID: battleID, RequestedByID: "1", RequestedByRole: "knight"})
+	assert.ErrorIs(t, err, battle.ErrForceCancelNotAllowed)
+	_, err = svc.ForceCancelBattle(dto.ForceCancelBattleCommand{BattleID: battleID, RequestedByID: "5", RequestedByRole: "light_emperor"})
+	assert.ErrorIs(t, err, battle.ErrForceCancelNotAllowed, "a wager party cannot cancel the battle")
+
+	b, err := svc.ForceCancelBattle(dto.ForceCancelBattleCommand{BattleID: battleID, RequestedBy: "mordred", RequestedByID: "9", RequestedByRole: "dark_emperor"})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusCancelled, b.Status)
+	assert.Empty(t, b.WinnerSide)
+	assert.Greater(t, b.CoinsEarned["1"], 0)
+
+	stored, err := repo.GetBattleByID(ctx, battleID)
+	require.NoError(t, err)
+	assert.Equal(t, b.CoinsEarned, stored.CoinsEarned)
+
+	_, err = svc.ForceCancelBattle(dto.ForceCancelBattleCommand{BattleID: battleID, RequestedByID: "9", RequestedByRole: "dark_emperor"})
+	assert.ErrorContains(t, err, "no longer active")
+}
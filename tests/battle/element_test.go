@@ -0,0 +1,109 @@
+package battle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+	"network-sec-micro/pkg/element"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupElementBattle creates an in-progress knight vs dragon battle with the given innate elements
+func setupElementBattle(t *testing.T, knightElement, dragonElement string) string {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&battle.BattleSQL{}, &battle.BattleParticipantSQL{}, &battle.BattleTurnSQL{}, &battle.BattleRewardSQL{}))
+	battle.SQLDB.Enabled = true
+	battle.SQLDB.DB = db
+
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	now := time.Now()
+	battleID, err := repo.CreateBattle(ctx, &battle.Battle{
+		BattleType: battle.BattleTypeTeam,
+		Status:     battle.BattleStatusInProgress,
+		TurnOrder:  battle.TurnOrderAlternating,
+		MaxTurns:   100,
+		Seed:       5,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertParticipants(ctx, []*battle.BattleParticipant{
+		{BattleID: battleID, ParticipantID: "1", Name: "Knight", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, HP: 1000, MaxHP: 1000, AttackPower: 60, Defense: 5, Element: knightElement, IsAlive: true, CreatedAt: now},
+		{BattleID: battleID, ParticipantID: "30", Name: "Wyrm", Type: battle.ParticipantTypeDragon, Side: battle.TeamSideDark, HP: 1000, MaxHP: 1000, AttackPower: 60, Defense: 5, Element: dragonElement, IsAlive: true, CreatedAt: now},
+	}))
+	return battleID
+}
+
+func TestElementApply(t *testing.T) {
+	// Physical damage ignores elements and resistances
+	taken, resisted := element.Apply(100, element.Physical, element.Fire, 50)
+	assert.Equal(t, 100, taken)
+	assert.Equal(t, 0, resisted)
+
+	// Opposite elements amplify, same elements halve
+	taken, resisted = element.Apply(100, element.Ice, element.Fire, 0)
+	assert.Equal(t, 150, taken)
+	assert.Equal(t, 0, resisted)
+	taken, resisted = element.Apply(100, element.Fire, element.Fire, 0)
+	assert.Equal(t, 50, taken)
+	assert.Equal(t, 50, resisted)
+	taken, _ = element.Apply(100, element.Lightning, element.Fire, 0)
+	assert.Equal(t, 100, taken)
+
+	// Armor resistance is capped and a hit always lands for at least 1
+	taken, resisted = element.Apply(100, element.Shadow, element.Physical, 90)
+	assert.Equal(t, 100-element.MaxResistance, taken)
+	assert.Equal(t, element.MaxResistance, resisted)
+	taken, _ = element.Apply(1, element.Fire, element.Fire, 75)
+	assert.Equal(t, 1, taken)
+
+	assert.True(t, element.Valid("shadow"))
+	assert.True(t, element.Valid(""))
+	assert.False(t, element.Valid("poison"))
+}
+
+func TestElementalAttack_RecordsElementAndResistance(t *testing.T) {
+	svc := battle.NewService()
+
+	// Baseline: physical hit with the same rolls
+	battleID := setupElementBattle(t, "", "")
+	_, physical, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "1", TargetID: "30"})
+	require.NoError(t, err)
+	assert.Empty(t, physical.Element)
+	assert.Zero(t, physical.DamageResisted)
+
+	// Ice against a fire dragon hits harder
+	battleID = setupElementBattle(t, "ice", "fire")
+	_, iceHit, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "1", TargetID: "30"})
+	require.NoError(t, err)
+	assert.Equal(t, "ice", iceHit.Element)
+	assert.Equal(t, physical.DamageDealt*150/100, iceHit.DamageDealt)
+	assert.Zero(t, iceHit.DamageResisted)
+
+	// Fire against a fire dragon is half absorbed, and the record says how much
+	battleID = setupElementBattle(t, "fire", "fire")
+	_, fireHit, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "1", TargetID: "30"})
+	require.NoError(t, err)
+	assert.Equal(t, "fire", fireHit.Element)
+	assert.Equal(t, physical.DamageDealt*50/100, fireHit.DamageDealt)
+	assert.Equal(t, physical.DamageDealt-fireHit.DamageDealt, fireHit.DamageResisted)
+
+	turns, err := battle.GetRepository().ListTurns(context.Background(), battleID, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, turns)
+	assert.Equal(t, "fire", turns[0].Element)
+	assert.Equal(t, fireHit.DamageResisted, turns[0].DamageResisted)
+
+	target, err := battle.GetRepository().GetParticipantByIDs(context.Background(), battleID, "30")
+	require.NoError(t, err)
+	assert.Equal(t, 1000-fireHit.DamageDealt, target.HP)
+}
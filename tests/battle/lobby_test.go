@@ -0,0 +1,128 @@
+package battle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupLobbyDB(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&battle.BattleSQL{}, &battle.BattleParticipantSQL{}, &battle.BattleTurnSQL{}, &battle.BattleRewardSQL{}))
+	battle.SQLDB.Enabled = true
+	battle.SQLDB.DB = db
+}
+
+// createGoblinLobby opens a lobby of lightSlots warriors against a seated goblin
+func createGoblinLobby(t *testing.T, svc *battle.Service, lightSlots int, scheduledStart *time.Time) *battle.Battle {
+	b, participants, err := svc.CreateLobby(dto.CreateLobbyCommand{
+		LightSlots: lightSlots,
+		DarkSlots:  1,
+		DarkParticipants: []dto.ParticipantInfo{
+			{ParticipantID: "goblin", Name: "Goblin", Type: "enemy", Side: "dark", HP: 50, MaxHP: 50, AttackPower: 10},
+		},
+		ScheduledStartAt: scheduledStart,
+		CreatedBy:        "emperor",
+	})
+	require.NoError(t, err)
+	require.Len(t, participants, 1)
+	assert.True(t, participants[0].Ready)
+	assert.True(t, b.IsLobby())
+	return b
+}
+
+func joinWarrior(svc *battle.Service, battleID, id, name string) error {
+	_, _, err := svc.JoinLobby(dto.JoinLobbyCommand{
+		BattleID:    battleID,
+		Participant: dto.ParticipantInfo{ParticipantID: id, Name: name, Type: "warrior", Side: "light", HP: 100, MaxHP: 100, AttackPower: 20},
+	})
+	return err
+}
+
+func TestLobby_StartsWhenFullAndReady(t *testing.T) {
+	setupLobbyDB(t)
+	svc := battle.NewService()
+	lobby := createGoblinLobby(t, svc, 2, nil)
+
+	require.NoError(t, joinWarrior(svc, lobby.ID, "1", "Knight"))
+	require.NoError(t, joinWarrior(svc, lobby.ID, "2", "Archer"))
+
+	b, err := svc.SetLobbyReady(dto.SetLobbyReadyCommand{BattleID: lobby.ID, ParticipantID: "1", Ready: true})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusPending, b.Status, "archer has not answered the ready-check")
+
+	b, err = svc.SetLobbyReady(dto.SetLobbyReadyCommand{BattleID: lobby.ID, ParticipantID: "2", Ready: true})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusInProgress, b.Status)
+	assert.NotNil(t, b.StartedAt)
+
+	_, err = svc.LeaveLobby(dto.LeaveLobbyCommand{BattleID: lobby.ID, ParticipantID: "1"})
+	assert.ErrorContains(t, err, "no longer open")
+}
+
+func TestLobby_JoinRejected(t *testing.T) {
+	setupLobbyDB(t)
+	svc := battle.NewService()
+	lobby := createGoblinLobby(t, svc, 1, nil)
+
+	require.NoError(t, joinWarrior(svc, lobby.ID, "1", "Knight"))
+	assert.ErrorContains(t, joinWarrior(svc, lobby.ID, "1", "Knight"), "already in this lobby")
+	assert.ErrorContains(t, joinWarrior(svc, lobby.ID, "2", "Archer"), "light side is full")
+
+	_, _, err := svc.JoinLobby(dto.JoinLobbyCommand{
+		BattleID:    lobby.ID,
+		Participant: dto.ParticipantInfo{ParticipantID: "3", Name: "Dark King", Type: "dark_king", Side: "dark"},
+	})
+	assert.Error(t, err)
+
+	// Leaving frees the slot
+	_, err = svc.LeaveLobby(dto.LeaveLobbyCommand{BattleID: lobby.ID, ParticipantID: "1"})
+	require.NoError(t, err)
+	assert.NoError(t, joinWarrior(svc, lobby.ID, "2", "Archer"))
+}
+
+func TestLobby_SweepStartsScheduledAndCancelsUnfilled(t *testing.T) {
+	setupLobbyDB(t)
+	svc := battle.NewService()
+	start := time.Now().Add(10 * time.Minute)
+
+	scheduled := createGoblinLobby(t, svc, 1, &start)
+	require.NoError(t, joinWarrior(svc, scheduled.ID, "1", "Knight")) // Full, though nobody said ready
+	unfilled := createGoblinLobby(t, svc, 2, &start)
+	require.NoError(t, joinWarrior(svc, unfilled.ID, "2", "Archer"))
+
+	lobbies, total, err := svc.ListLobbies(dto.ListLobbiesQuery{Limit: 10})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+	assert.Len(t, lobbies, 2)
+
+	// Nothing is due before the scheduled start
+	n, err := svc.SweepDueLobbies(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	n, err = svc.SweepDueLobbies(context.Background(), start.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	b, err := svc.GetLobby(dto.GetBattleQuery{BattleID: scheduled.ID})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusInProgress, b.Status)
+
+	b, err = svc.GetLobby(dto.GetBattleQuery{BattleID: unfilled.ID})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusCancelled, b.Status)
+
+	_, total, err = svc.ListLobbies(dto.ListLobbiesQuery{Limit: 10})
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, total)
+}
@@ -0,0 +1,150 @@
+package battle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupRosterBattle creates a speed-ordered knight vs goblin battle where the knight is due to act
+func setupRosterBattle(t *testing.T, status battle.BattleStatus) string {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&battle.BattleSQL{}, &battle.BattleParticipantSQL{}, &battle.BattleTurnSQL{}, &battle.BattleRewardSQL{}))
+	battle.SQLDB.Enabled = true
+	battle.SQLDB.DB = db
+
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	now := time.Now()
+	battleID, err := repo.CreateBattle(ctx, &battle.Battle{
+		BattleType:              battle.BattleTypeTeam,
+		Status:                  status,
+		TurnOrder:               battle.TurnOrderSpeed,
+		CurrentParticipantIndex: 1, // goblin (speed 7) acts first, then the knight
+		MaxTurns:                100,
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertParticipants(ctx, []*battle.BattleParticipant{
+		{BattleID: battleID, ParticipantID: "1", Name: "Knight", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, HP: 100, MaxHP: 100, AttackPower: 20, Speed: 5, IsAlive: true, CreatedAt: now},
+		{BattleID: battleID, ParticipantID: "goblin", Name: "Goblin", Type: battle.ParticipantTypeEnemy, Side: battle.TeamSideDark, HP: 50, MaxHP: 50, AttackPower: 10, Speed: 7, IsAlive: true, CreatedAt: now},
+	}))
+	return battleID
+}
+
+func TestAddParticipant_KeepsCurrentActor(t *testing.T) {
+	battleID := setupRosterBattle(t, battle.BattleStatusInProgress)
+	svc := battle.NewService()
+
+	// The archer's speed comes from the server (a level 1 warrior is 12), so they move to the
+	// front of the queue but must not steal the knight's turn
+	b, p, err := svc.AddParticipant(dto.AddParticipantCommand{
+		BattleID: battleID,
+		Participant: dto.ParticipantInfo{
+			ParticipantID: "2", Name: "Archer", Type: "warrior", Side: "light", HP: 80, MaxHP: 80,
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, p.IsAlive)
+	assert.Equal(t, 2, b.CurrentParticipantIndex)
+
+	next, _, err := svc.GetNextParticipant(context.Background(), b)
+	require.NoError(t, err)
+	assert.Equal(t, "1", next.ParticipantID)
+}
+
+func TestAddParticipant_IgnoresClientSpeed(t *testing.T) {
+	battleID := setupRosterBattle(t, battle.BattleStatusInProgress)
+	svc := battle.NewService()
+
+	_, p, err := svc.AddParticipant(dto.AddParticipantCommand{
+		BattleID:    battleID,
+		Participant: dto.ParticipantInfo{ParticipantID: "orc", Name: "Orc", Type: "enemy", Side: "dark", HP: 60, MaxHP: 60},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, p.Speed) // enemy base 8 + level 1
+}
+
+func TestRemoveParticipant_RebuildsTurnQueue(t *testing.T) {
+	battleID := setupRosterBattle(t, battle.BattleStatusInProgress)
+	svc := battle.NewService()
+	ctx := context.Background()
+
+	_, _, err := svc.AddParticipant(dto.AddParticipantCommand{
+		BattleID:    battleID,
+		Participant: dto.ParticipantInfo{ParticipantID: "2", Name: "Archer", Type: "warrior", Side: "light", HP: 80, MaxHP: 80},
+	})
+	require.NoError(t, err)
+
+	// Someone else leaving does not move the turn away from the knight
+	b, err := svc.RemoveParticipant(dto.RemoveParticipantCommand{BattleID: battleID, ParticipantID: "2"})
+	require.NoError(t, err)
+	next, _, err := svc.GetNextParticipant(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, "1", next.ParticipantID)
+
+	_, _, err = svc.AddParticipant(dto.AddParticipantCommand{
+		BattleID:    battleID,
+		Participant: dto.ParticipantInfo{ParticipantID: "3", Name: "Mage", Type: "warrior", Side: "light", HP: 80, MaxHP: 80},
+	})
+	require.NoError(t, err)
+
+	// The knight leaving on their own turn passes it on, wrapping to the front of the queue
+	b, err = svc.RemoveParticipant(dto.RemoveParticipantCommand{BattleID: battleID, ParticipantID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusInProgress, b.Status)
+	next, _, err = svc.GetNextParticipant(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, "3", next.ParticipantID)
+}
+
+func TestAddParticipant_Rejected(t *testing.T) {
+	battleID := setupRosterBattle(t, battle.BattleStatusPending)
+	svc := battle.NewService()
+
+	_, _, err := svc.AddParticipant(dto.AddParticipantCommand{
+		BattleID:    battleID,
+		Participant: dto.ParticipantInfo{ParticipantID: "1", Name: "Knight", Type: "warrior", Side: "light"},
+	})
+	assert.ErrorContains(t, err, "already in this battle")
+
+	_, _, err = svc.AddParticipant(dto.AddParticipantCommand{
+		BattleID:    battleID,
+		Participant: dto.ParticipantInfo{ParticipantID: "dk", Name: "Dark King", Type: "dark_king", Side: "dark"},
+	})
+	assert.ErrorContains(t, err, "validation failed")
+}
+
+func TestRemoveParticipant_PendingKeepsBothSides(t *testing.T) {
+	battleID := setupRosterBattle(t, battle.BattleStatusPending)
+	svc := battle.NewService()
+
+	_, err := svc.RemoveParticipant(dto.RemoveParticipantCommand{BattleID: battleID, ParticipantID: "goblin"})
+	assert.ErrorContains(t, err, "at least one participant")
+}
+
+func TestRemoveParticipant_LastFighterForfeits(t *testing.T) {
+	battleID := setupRosterBattle(t, battle.BattleStatusInProgress)
+	svc := battle.NewService()
+
+	b, err := svc.RemoveParticipant(dto.RemoveParticipantCommand{BattleID: battleID, ParticipantID: "goblin"})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusCompleted, b.Status)
+	assert.Equal(t, battle.BattleResultLightVictory, b.Result)
+
+	// Retreated participants stay on record but can no longer be targeted
+	dark, err := battle.GetRepository().FindParticipants(context.Background(), battleID, "dark")
+	require.NoError(t, err)
+	require.Len(t, dark, 1)
+	assert.False(t, dark[0].IsAlive)
+}
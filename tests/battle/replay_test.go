@@ -0,0 +1,121 @@
+package battle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupSQLBattle creates an in-memory SQL store with a two-participant battle
+func setupSQLBattle(t *testing.T) string {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&battle.BattleSQL{}, &battle.BattleParticipantSQL{}, &battle.BattleTurnSQL{}, &battle.BattleRewardSQL{}))
+	battle.SQLDB.Enabled = true
+	battle.SQLDB.DB = db
+
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	now := time.Now()
+	battleID, err := repo.CreateBattle(ctx, &battle.Battle{
+		BattleType: battle.BattleTypeTeam,
+		Status:     battle.BattleStatusInProgress,
+		MaxTurns:   100,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.InsertParticipants(ctx, []*battle.BattleParticipant{
+		{BattleID: battleID, ParticipantID: "1", Name: "Knight", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, HP: 100, MaxHP: 100, IsAlive: true},
+		{BattleID: battleID, ParticipantID: "goblin", Name: "Goblin", Type: battle.ParticipantTypeEnemy, Side: battle.TeamSideDark, HP: 0, MaxHP: 50, IsAlive: false, IsDefeated: true},
+	}))
+
+	turns := []*battle.BattleTurn{
+		{BattleID: battleID, TurnNumber: 1, AttackerID: "1", TargetID: "goblin", DamageDealt: 20, TargetHPBefore: 50, TargetHPAfter: 30},
+		{BattleID: battleID, TurnNumber: 2, AttackerID: "goblin", TargetID: "1", DamageDealt: 15, TargetHPBefore: 100, TargetHPAfter: 85},
+		{BattleID: battleID, TurnNumber: 3, AttackerID: "1", TargetID: "goblin", DamageDealt: 40, TargetHPBefore: 30, TargetHPAfter: 0, TargetDefeated: true},
+	}
+	for i, turn := range turns {
+		turn.CreatedAt = now.Add(time.Duration(i) * time.Second)
+		require.NoError(t, repo.InsertTurn(ctx, turn))
+	}
+	return battleID
+}
+
+func TestReplayBattle_StateAtTurn(t *testing.T) {
+	battleID := setupSQLBattle(t)
+	svc := battle.NewService()
+
+	hp := func(r *battle.BattleReplay, id string) (int, bool) {
+		for _, st := range r.Participants {
+			if st.Participant.ParticipantID == id {
+				return st.HP, st.IsAlive
+			}
+		}
+		t.Fatalf("participant %s missing from replay", id)
+		return 0, false
+	}
+
+	start, err := svc.ReplayBattle(dto.ReplayBattleQuery{BattleID: battleID, Turn: 0})
+	require.NoError(t, err)
+	assert.Equal(t, 3, start.LastTurn)
+	goblinHP, goblinAlive := hp(start, "goblin")
+	assert.Equal(t, 50, goblinHP)
+	assert.True(t, goblinAlive)
+
+	mid, err := svc.ReplayBattle(dto.ReplayBattleQuery{BattleID: battleID, Turn: 2})
+	require.NoError(t, err)
+	goblinHP, _ = hp(mid, "goblin")
+	knightHP, knightAlive := hp(mid, "1")
+	assert.Equal(t, 30, goblinHP)
+	assert.Equal(t, 85, knightHP)
+	assert.True(t, knightAlive)
+
+	end, err := svc.ReplayBattle(dto.ReplayBattleQuery{BattleID: battleID, Turn: -1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, end.Turn)
+	goblinHP, goblinAlive = hp(end, "goblin")
+	assert.Equal(t, 0, goblinHP)
+	assert.False(t, goblinAlive)
+
+	_, err = svc.ReplayBattle(dto.ReplayBattleQuery{BattleID: battleID, Turn: 4})
+	assert.Error(t, err)
+}
+
+func TestReplayBattle_VerifyFlagsMismatch(t *testing.T) {
+	battleID := setupSQLBattle(t)
+	svc := battle.NewService()
+
+	replay, err := svc.ReplayBattle(dto.ReplayBattleQuery{BattleID: battleID, Turn: -1, Verify: true})
+	require.NoError(t, err)
+	assert.True(t, replay.Verified)
+	assert.Empty(t, replay.Mismatches)
+
+	// Tamper with a stored turn: the goblin "lost" more HP than the damage dealt
+	db := battle.SQLDB.DB.(*gorm.DB)
+	require.NoError(t, db.Model(&battle.BattleTurnSQL{}).Where("turn_number = ?", 1).Update("target_hp_after", 20).Error)
+
+	replay, err = svc.ReplayBattle(dto.ReplayBattleQuery{BattleID: battleID, Turn: -1, Verify: true})
+	require.NoError(t, err)
+	require.Len(t, replay.Mismatches, 1)
+	assert.Equal(t, 1, replay.Mismatches[0].TurnNumber)
+	assert.Equal(t, "target_hp_after", replay.Mismatches[0].Field)
+	assert.Equal(t, 20, replay.Mismatches[0].Stored)
+	assert.Equal(t, 30, replay.Mismatches[0].Expected)
+
+	// Replay still reports what was stored
+	for _, st := range replay.Participants {
+		if st.Participant.ParticipantID == "goblin" {
+			assert.Equal(t, 0, st.HP)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package battle_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	pbCoin "network-sec-micro/api/proto/coin"
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// rewardCoins is a coin service that fails AddCoins while down and records the keys it credited
+type rewardCoins struct {
+	pbCoin.UnimplementedCoinServiceServer
+	mu   sync.Mutex
+	down bool
+	keys []string
+}
+
+func (f *rewardCoins) AddCoins(_ context.Context, req *pbCoin.AddCoinsRequest) (*pbCoin.AddCoinsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		return &pbCoin.AddCoinsResponse{Success: false, Message: "coin service unavailable"}, nil
+	}
+	f.keys = append(f.keys, req.IdempotencyKey)
+	return &pbCoin.AddCoinsResponse{Success: true, WarriorId: req.WarriorId}, nil
+}
+
+func (f *rewardCoins) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+func startRewardCoins(t *testing.T) *rewardCoins {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	coins := &rewardCoins{}
+	srv := grpc.NewServer()
+	pbCoin.RegisterCoinServiceServer(srv, coins)
+	go srv.Serve(lis)
+	require.NoError(t, battle.InitCoinClient(lis.Addr().String()))
+	t.Cleanup(func() {
+		battle.CloseCoinClient()
+		srv.Stop()
+	})
+	return coins
+}
+
+func TestCalculateRewards(t *testing.T) {
+	b := &battle.Battle{ID: "7", WinnerSide: battle.TeamSideLight}
+	participants := []*battle.BattleParticipant{
+		{ParticipantID: "1", Name: "Knight", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, IsAlive: true},
+		{ParticipantID: "2", Name: "Archer", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, IsAlive: true},
+		{ParticipantID: "3", Name: "Mage", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, IsAlive: false},
+		{ParticipantID: "20", Name: "Orc", Type: battle.ParticipantTypeEnemy, Side: battle.TeamSideDark, Level: 3},
+		{ParticipantID: "21", Name: "Goblin", Type: battle.ParticipantTypeEnemy, Side: battle.TeamSideDark},
+	}
+	turns := []*battle.BattleTurn{
+		{TurnNumber: 1, AttackerID: "1", TargetID: "20", DamageDealt: 40},
+		{TurnNumber: 2, AttackerID: "3", TargetID: "20", DamageDealt: 30},
+		{TurnNumber: 3, AttackerID: "20", TargetID: "20", DamageDealt: 5, EffectType: battle.StatusEffectPoison},
+		{TurnNumber: 4, AttackerID: "1", TargetID: "20", DamageDealt: 25, TargetDefeated: true},
+		{TurnNumber: 5, AttackerID: "1", TargetID: "21", DamageDealt: 15, TargetDefeated: true},
+	}
+
+	rewards := battle.CalculateRewards(b, participants, turns)
+
+	// Only the surviving knight both fought and survived; the archer dealt nothing, the mage fell
+	require.Len(t, rewards, 1)
+	r := rewards[0]
+	assert.Equal(t, "1", r.ParticipantID)
+	assert.Equal(t, 80, r.DamageDealt) // effect ticks are not credited
+	assert.Equal(t, 2, r.Kills)
+	assert.Equal(t, 25+80/10+(3+1)*15, r.Coins)
+	assert.Equal(t, 50+80/5+(3+1)*20, r.Experience)
+
+	// A draw rewards nobody
+	assert.Empty(t, battle.CalculateRewards(&battle.Battle{ID: "7"}, participants, turns))
+}
+
+func TestCompletedBattle_RecordsRewardsOnce(t *testing.T) {
+	battleID := setupEffectBattle(t, 100)
+	repo := battle.GetRepository()
+	ctx := context.Background()
+	require.NoError(t, repo.UpdateParticipantByIDs(ctx, battleID, "20", map[string]interface{}{"hp": 1}))
+
+	svc := battle.NewService()
+	completed, _, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "1", TargetID: "20"})
+	require.NoError(t, err)
+	require.Equal(t, battle.BattleStatusCompleted, completed.Status)
+	require.Contains(t, completed.CoinsEarned, "1")
+	assert.Greater(t, completed.ExperienceGained["1"], 0)
+
+	// Reloading the battle reads the same rewards back
+	stored, err := repo.GetBattleByID(ctx, battleID)
+	require.NoError(t, err)
+	assert.Equal(t, completed.CoinsEarned, stored.CoinsEarned)
+	assert.Equal(t, completed.ExperienceGained, stored.ExperienceGained)
+
+	// Recording the rewards again changes nothing
+	rewards, err := repo.ListRewards(ctx, battleID)
+	require.NoError(t, err)
+	require.Len(t, rewards, 1)
+	doubled := *rewards[0]
+	doubled.Coins *= 2
+	require.NoError(t, repo.InsertRewards(ctx, []*battle.BattleReward{&doubled}))
+	again, err := repo.ListRewards(ctx, battleID)
+	require.NoError(t, err)
+	require.Len(t, again, 1)
+	assert.Equal(t, rewards[0].Coins, again[0].Coins)
+
+	// The paid flag can only be claimed once
+	first, err := repo.SetRewardPaid(ctx, battleID, "1", true)
+	require.NoError(t, err)
+	second, err := repo.SetRewardPaid(ctx, battleID, "1", true)
+	require.NoError(t, err)
+	assert.False(t, first && second)
+}
+
+func TestSweepUnpaidRewards_RetriesFailedPayout(t *testing.T) {
+	battleID := setupRosterBattle(t, battle.BattleStatusCompleted)
+	repo := battle.GetRepository()
+	ctx := context.Background()
+	coins := startRewardCoins(t)
+	require.NoError(t, repo.InsertRewards(ctx, []*battle.BattleReward{
+		{BattleID: battleID, ParticipantID: "1", ParticipantName: "Knight", ParticipantType: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, DamageDealt: 50, Coins: 30, Experience: 60, CreatedAt: time.Now()},
+	}))
+	svc := battle.NewService()
+
+	// A failed payout releases its claim and stays unpaid
+	coins.setDown(true)
+	paid, err := svc.SweepUnpaidRewards(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, paid)
+	unpaid, err := repo.ListUnpaidRewards(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, unpaid, 1)
+
+	// The next sweep pays it, and later sweeps leave it alone
+	coins.setDown(false)
+	paid, err = svc.SweepUnpaidRewards(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, paid)
+	paid, err = svc.SweepUnpaidRewards(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, paid)
+
+	unpaid, err = repo.ListUnpaidRewards(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, unpaid)
+	assert.Equal(t, []string{"battle_reward:" + battleID + ":1"}, coins.keys)
+}
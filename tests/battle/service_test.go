@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"network-sec-micro/internal/battle"
-	"network-sec-micro/internal/battle/dto"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -73,13 +72,37 @@ func TestCalculateDamage(t *testing.T) {
 	
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			damage := svc.CalculateDamage(tt.attackerPower, tt.targetDefense)
+			damage := svc.CalculateDamage(battle.TurnRNG(42, 1), tt.attackerPower, tt.targetDefense)
 			assert.GreaterOrEqual(t, damage, tt.expectedMin)
 			assert.LessOrEqual(t, damage, tt.expectedMax)
 		})
 	}
 }
 
+func TestTurnRNG_Deterministic(t *testing.T) {
+	svc := battle.NewService()
+
+	// Same seed and turn must replay the same rolls
+	first := svc.CalculateDamage(battle.TurnRNG(1234, 7), 100, 20)
+	second := svc.CalculateDamage(battle.TurnRNG(1234, 7), 100, 20)
+	assert.Equal(t, first, second)
+
+	// Each turn gets its own stream
+	a := battle.TurnRNG(1234, 1).Int63()
+	b := battle.TurnRNG(1234, 2).Int63()
+	assert.NotEqual(t, a, b)
+
+	// Full turn sequence is reproducible from the seed alone
+	seq := func(seed int64) []int {
+		out := make([]int, 0, 10)
+		for turn := 1; turn <= 10; turn++ {
+			out = append(out, svc.CalculateDamage(battle.TurnRNG(seed, turn), 80, 30))
+		}
+		return out
+	}
+	assert.Equal(t, seq(99), seq(99))
+}
+
 func TestBattle_OpponentDefense(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -277,7 +300,7 @@ func TestCalculateOpponentDamage(t *testing.T) {
 	
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			damage := svc.CalculateOpponentDamage(&tt.battle)
+			damage := svc.CalculateOpponentDamage(battle.TurnRNG(42, 1), &tt.battle)
 			assert.GreaterOrEqual(t, damage, tt.expectedMin)
 			assert.LessOrEqual(t, damage, tt.expectedMax)
 		})
@@ -0,0 +1,97 @@
+package battle_test
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"network-sec-micro/pkg/spectate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+func spectatorEvent(t *testing.T, eventType string, data interface{}) spectate.Event {
+	e, err := spectate.NewEvent(eventType, data)
+	require.NoError(t, err)
+	return e
+}
+
+func spectatorServer(t *testing.T, events chan spectate.Event, initial ...spectate.Event) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		spectate.Serve(w, r, events, initial...)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSpectate_SSEStreamsUntilCompleted(t *testing.T) {
+	events := make(chan spectate.Event, 4)
+	srv := spectatorServer(t, events, spectatorEvent(t, spectate.EventSnapshot, map[string]string{"status": "in_progress"}))
+
+	events <- spectatorEvent(t, spectate.EventTurn, map[string]int{"turn_number": 1})
+	events <- spectatorEvent(t, spectate.EventCompleted, map[string]string{"status": "completed"})
+	events <- spectatorEvent(t, spectate.EventTurn, map[string]int{"turn_number": 2}) // Never sent
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The server ends the stream after the completed event, so reading stops at EOF
+	var types []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "event: ") {
+			types = append(types, strings.TrimPrefix(line, "event: "))
+		}
+	}
+	assert.Equal(t, []string{spectate.EventSnapshot, spectate.EventTurn, spectate.EventCompleted}, types)
+}
+
+func TestSpectate_WebSocketStreamsEvents(t *testing.T) {
+	events := make(chan spectate.Event, 4)
+	srv := spectatorServer(t, events, spectatorEvent(t, spectate.EventSnapshot, map[string]string{"status": "in_progress"}))
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), "", srv.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	var got spectate.Event
+	require.NoError(t, websocket.JSON.Receive(ws, &got))
+	assert.Equal(t, spectate.EventSnapshot, got.Type)
+
+	events <- spectatorEvent(t, spectate.EventSpellCast, spectate.SpellCast{SpellType: "rebirth", CasterName: "arthur", AffectedCount: 2})
+	require.NoError(t, websocket.JSON.Receive(ws, &got))
+	assert.Equal(t, spectate.EventSpellCast, got.Type)
+	assert.JSONEq(t, `{"spell_type":"rebirth","caster_name":"arthur","affected_count":2}`, string(got.Data))
+
+	// Closing the feed, as the hub does for a spectator that fell behind, closes the socket
+	close(events)
+	assert.Error(t, websocket.JSON.Receive(ws, &got))
+}
+
+func TestSpectate_FinishedBattleOnlySendsInitialEvents(t *testing.T) {
+	srv := spectatorServer(t, make(chan spectate.Event),
+		spectatorEvent(t, spectate.EventSnapshot, nil),
+		spectatorEvent(t, spectate.EventCompleted, nil),
+	)
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var types []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "event: ") {
+			types = append(types, strings.TrimPrefix(line, "event: "))
+		}
+	}
+	assert.Equal(t, []string{spectate.EventSnapshot, spectate.EventCompleted}, types)
+}
@@ -0,0 +1,172 @@
+package battle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupEffectBattle creates an in-progress knight vs dark king battle; both are player-controlled
+func setupEffectBattle(t *testing.T, knightHP int) string {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&battle.BattleSQL{}, &battle.BattleParticipantSQL{}, &battle.BattleTurnSQL{}, &battle.BattleRewardSQL{}))
+	battle.SQLDB.Enabled = true
+	battle.SQLDB.DB = db
+
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	now := time.Now()
+	battleID, err := repo.CreateBattle(ctx, &battle.Battle{
+		BattleType: battle.BattleTypeTeam,
+		Status:     battle.BattleStatusInProgress,
+		TurnOrder:  battle.TurnOrderAlternating,
+		MaxTurns:   100,
+		Seed:       3,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertParticipants(ctx, []*battle.BattleParticipant{
+		{BattleID: battleID, ParticipantID: "1", Name: "Knight", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, HP: knightHP, MaxHP: 100, AttackPower: 30, Defense: 5, IsAlive: true, CreatedAt: now},
+		{BattleID: battleID, ParticipantID: "20", Name: "Dark King", Type: battle.ParticipantTypeDarkKing, Side: battle.TeamSideDark, HP: 500, MaxHP: 500, AttackPower: 30, Defense: 5, IsAlive: true, CreatedAt: now},
+	}))
+	return battleID
+}
+
+func TestAddStatusEffect_Stacking(t *testing.T) {
+	poison, err := battle.NewStatusEffect("poison", 5, 3, "orc", "", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, battle.EffectTickTurnStart, poison.Tick)
+
+	effects := battle.AddStatusEffect(nil, poison)
+	effects = battle.AddStatusEffect(effects, poison)
+	effects = battle.AddStatusEffect(effects, poison)
+	require.Len(t, effects, 1)
+	assert.Equal(t, 2, effects[0].Stacks) // capped at max stacks
+
+	// Another source is tracked separately
+	other, err := battle.NewStatusEffect("poison", 5, 3, "goblin", "", "", 0)
+	require.NoError(t, err)
+	assert.Len(t, battle.AddStatusEffect(effects, other), 2)
+
+	// Refresh replaces duration and magnitude; ignore keeps the active effect
+	burn, _ := battle.NewStatusEffect("burn", 10, 1, "", "", "", 0)
+	stronger, _ := battle.NewStatusEffect("burn", 20, 3, "", "", "", 0)
+	effects = battle.AddStatusEffect(battle.AddStatusEffect(nil, burn), stronger)
+	assert.Equal(t, 20, effects[0].Magnitude)
+	assert.Equal(t, 3, effects[0].RemainingTurns)
+
+	stun, _ := battle.NewStatusEffect("stun", 0, 1, "", "turn_end", "", 0)
+	assert.Equal(t, battle.EffectTickTurnStart, stun.Tick)
+	longer, _ := battle.NewStatusEffect("stun", 0, 4, "", "", "", 0)
+	effects = battle.AddStatusEffect(battle.AddStatusEffect(nil, stun), longer)
+	assert.Equal(t, 1, effects[0].RemainingTurns)
+
+	_, err = battle.NewStatusEffect("freeze", 0, 1, "", "", "", 0)
+	assert.Error(t, err)
+	_, err = battle.NewStatusEffect("poison", 5, 0, "", "", "", 0)
+	assert.Error(t, err)
+}
+
+func TestModifiedStats(t *testing.T) {
+	weaken, _ := battle.NewStatusEffect("attack_modifier", -25, 2, "", "", "", 0)
+	armor, _ := battle.NewStatusEffect("defense_modifier", 50, 2, "", "", "", 0)
+	p := &battle.BattleParticipant{StatusEffects: []battle.StatusEffect{weaken, weaken, armor}}
+
+	assert.Equal(t, 50, p.ModifiedAttack(100))
+	assert.Equal(t, 30, p.ModifiedDefense(20))
+
+	weaken.Magnitude = -200
+	p.StatusEffects = []battle.StatusEffect{weaken}
+	assert.Equal(t, 0, p.ModifiedAttack(100))
+}
+
+func TestAttack_PoisonTicksAtTurnStart(t *testing.T) {
+	battleID := setupEffectBattle(t, 100)
+	svc := battle.NewService()
+	ctx := context.Background()
+
+	_, err := svc.ApplyStatusEffect(dto.ApplyStatusEffectCommand{BattleID: battleID, ParticipantID: "1", Type: "poison", Magnitude: 10, DurationTurns: 2, SourceID: "20"})
+	require.NoError(t, err)
+
+	_, _, err = svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "1", TargetID: "20"})
+	require.NoError(t, err)
+
+	turns, err := battle.GetRepository().ListTurns(ctx, battleID, 0)
+	require.NoError(t, err)
+	require.Len(t, turns, 2)
+	assert.Equal(t, battle.StatusEffectPoison, turns[0].EffectType)
+	assert.Equal(t, 1, turns[0].TurnNumber)
+	assert.Equal(t, 10, turns[0].DamageDealt)
+	assert.Equal(t, 90, turns[0].TargetHPAfter)
+	assert.Empty(t, turns[1].EffectType)
+	assert.Equal(t, "20", turns[1].TargetID)
+
+	knight, err := battle.GetRepository().GetParticipantByIDs(ctx, battleID, "1")
+	require.NoError(t, err)
+	assert.Equal(t, 90, knight.HP)
+	require.Len(t, knight.StatusEffects, 1)
+	assert.Equal(t, 1, knight.StatusEffects[0].RemainingTurns)
+
+	// Replay re-runs effect ticks without flagging them
+	replay, err := svc.ReplayBattle(dto.ReplayBattleQuery{BattleID: battleID, Turn: -1, Verify: true})
+	require.NoError(t, err)
+	assert.Empty(t, replay.Mismatches)
+}
+
+func TestStun_SkipsTurn(t *testing.T) {
+	battleID := setupEffectBattle(t, 100)
+	svc := battle.NewService()
+	ctx := context.Background()
+
+	_, err := svc.ApplyStatusEffect(dto.ApplyStatusEffectCommand{BattleID: battleID, ParticipantID: "20", Type: "stun", DurationTurns: 1})
+	require.NoError(t, err)
+
+	b, _, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "1", TargetID: "20"})
+	require.NoError(t, err)
+
+	// The dark king's turn was played out, so the knight is up again
+	assert.Equal(t, 2, b.CurrentTurn)
+	next, _, err := svc.GetNextParticipant(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, "1", next.ParticipantID)
+
+	turns, err := battle.GetRepository().ListTurns(ctx, battleID, 0)
+	require.NoError(t, err)
+	require.Len(t, turns, 2)
+	assert.Equal(t, battle.StatusEffectStun, turns[1].EffectType)
+	assert.Equal(t, "20", turns[1].AttackerID)
+
+	king, err := battle.GetRepository().GetParticipantByIDs(ctx, battleID, "20")
+	require.NoError(t, err)
+	assert.Empty(t, king.StatusEffects)
+}
+
+func TestBurn_TicksAtTurnEndAndCanEndBattle(t *testing.T) {
+	battleID := setupEffectBattle(t, 8)
+	svc := battle.NewService()
+
+	_, err := svc.ApplyStatusEffect(dto.ApplyStatusEffectCommand{BattleID: battleID, ParticipantID: "1", Type: "burn", Magnitude: 10, DurationTurns: 3})
+	require.NoError(t, err)
+
+	b, _, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "1", TargetID: "20"})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusCompleted, b.Status)
+	assert.Equal(t, battle.BattleResultDarkVictory, b.Result)
+
+	turns, err := battle.GetRepository().ListTurns(context.Background(), battleID, 0)
+	require.NoError(t, err)
+	require.Len(t, turns, 2)
+	assert.Empty(t, turns[0].EffectType) // the knight still strikes first
+	assert.Equal(t, battle.StatusEffectBurn, turns[1].EffectType)
+	assert.True(t, turns[1].TargetDefeated)
+}
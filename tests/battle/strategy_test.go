@@ -0,0 +1,92 @@
+package battle_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strategyOpponents() []*battle.BattleParticipant {
+	return []*battle.BattleParticipant{
+		{ParticipantID: "knight", HP: 80, AttackPower: 40, IsAlive: true},
+		{ParticipantID: "archer", HP: 30, AttackPower: 25, IsAlive: true},
+		{ParticipantID: "mage", HP: 60, AttackPower: 70, IsAlive: true},
+	}
+}
+
+func TestStrategies_ChooseTarget(t *testing.T) {
+	actor := &battle.BattleParticipant{ParticipantID: "dragon", Type: battle.ParticipantTypeDragon}
+	rng := rand.New(rand.NewSource(1))
+
+	assert.Equal(t, "archer", battle.LowestHPStrategy{}.ChooseTarget(actor, strategyOpponents(), rng).ParticipantID)
+	assert.Equal(t, "mage", battle.HighestThreatStrategy{}.ChooseTarget(actor, strategyOpponents(), rng).ParticipantID)
+
+	// Random picks are reproducible from the same stream
+	a := battle.RandomStrategy{}.ChooseTarget(actor, strategyOpponents(), rand.New(rand.NewSource(5)))
+	b := battle.RandomStrategy{}.ChooseTarget(actor, strategyOpponents(), rand.New(rand.NewSource(5)))
+	assert.Equal(t, a.ParticipantID, b.ParticipantID)
+
+	assert.Nil(t, battle.LowestHPStrategy{}.ChooseTarget(actor, nil, rng))
+}
+
+func TestStrategyFor_ParticipantTypes(t *testing.T) {
+	for _, pType := range []battle.ParticipantType{battle.ParticipantTypeEnemy, battle.ParticipantTypeDragon, battle.ParticipantTypeDarkEmperor} {
+		_, ok := battle.StrategyFor(pType)
+		assert.True(t, ok, "%s should be server-controlled", pType)
+	}
+	_, ok := battle.StrategyFor(battle.ParticipantTypeWarrior)
+	assert.False(t, ok)
+
+	require.NoError(t, battle.SetParticipantStrategy(battle.ParticipantTypeEnemy, "random"))
+	s, _ := battle.StrategyFor(battle.ParticipantTypeEnemy)
+	assert.Equal(t, "random", s.Name())
+	require.NoError(t, battle.SetParticipantStrategy(battle.ParticipantTypeEnemy, "lowest_hp"))
+
+	assert.Error(t, battle.SetParticipantStrategy(battle.ParticipantTypeEnemy, "berserk"))
+}
+
+func TestAttack_EnemyActsAutomatically(t *testing.T) {
+	setupSQLBattle(t)
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	now := time.Now()
+
+	battleID, err := repo.CreateBattle(ctx, &battle.Battle{
+		BattleType: battle.BattleTypeTeam,
+		Status:     battle.BattleStatusInProgress,
+		TurnOrder:  battle.TurnOrderAlternating,
+		MaxTurns:   100,
+		Seed:       11,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertParticipants(ctx, []*battle.BattleParticipant{
+		{BattleID: battleID, ParticipantID: "10", Name: "Knight", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, HP: 500, MaxHP: 500, AttackPower: 40, Defense: 10, IsAlive: true, CreatedAt: now},
+		{BattleID: battleID, ParticipantID: "20", Name: "Goblin", Type: battle.ParticipantTypeEnemy, Side: battle.TeamSideDark, HP: 500, MaxHP: 500, AttackPower: 30, Defense: 5, IsAlive: true, CreatedAt: now},
+	}))
+
+	svc := battle.NewService()
+	b, turn, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "10", TargetID: "20"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, turn.TurnNumber)
+
+	// The goblin answers on its own and hands the turn back to the knight
+	assert.Equal(t, 2, b.CurrentTurn)
+	turns, err := repo.ListTurns(ctx, battleID, 0)
+	require.NoError(t, err)
+	require.Len(t, turns, 2)
+	assert.Equal(t, "20", turns[1].AttackerID)
+	assert.Equal(t, "10", turns[1].TargetID)
+
+	next, _, err := svc.GetNextParticipant(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, "10", next.ParticipantID)
+}
@@ -0,0 +1,119 @@
+package battle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func turnQueueFixture() []*battle.BattleParticipant {
+	now := time.Now()
+	return []*battle.BattleParticipant{
+		{ID: "1", ParticipantID: "knight", Side: battle.TeamSideLight, Speed: 5, IsAlive: true, CreatedAt: now},
+		{ID: "2", ParticipantID: "archer", Side: battle.TeamSideLight, Speed: 9, IsAlive: true, CreatedAt: now},
+		{ID: "3", ParticipantID: "goblin", Side: battle.TeamSideDark, Speed: 7, IsAlive: true, CreatedAt: now},
+	}
+}
+
+func queueIDs(queue []*battle.BattleParticipant) []string {
+	ids := make([]string, len(queue))
+	for i, p := range queue {
+		ids[i] = p.ParticipantID
+	}
+	return ids
+}
+
+func TestBuildTurnQueue_Alternating(t *testing.T) {
+	queue := battle.BuildTurnQueue(turnQueueFixture(), battle.TurnOrderAlternating)
+	assert.Equal(t, []string{"knight", "goblin", "archer"}, queueIDs(queue))
+}
+
+func TestBuildTurnQueue_Speed(t *testing.T) {
+	queue := battle.BuildTurnQueue(turnQueueFixture(), battle.TurnOrderSpeed)
+	assert.Equal(t, []string{"archer", "goblin", "knight"}, queueIDs(queue))
+}
+
+func TestNextInTurnQueue_SkipsDead(t *testing.T) {
+	queue := battle.BuildTurnQueue(turnQueueFixture(), battle.TurnOrderAlternating)
+	queue[1].IsAlive = false // goblin
+
+	next, idx, ok := battle.NextInTurnQueue(queue, 1)
+	require.True(t, ok)
+	assert.Equal(t, "archer", next.ParticipantID)
+	assert.Equal(t, 2, idx)
+
+	// Wraps around to the start of the queue
+	next, idx, ok = battle.NextInTurnQueue(queue, 3)
+	require.True(t, ok)
+	assert.Equal(t, "knight", next.ParticipantID)
+	assert.Equal(t, 0, idx)
+
+	for _, p := range queue {
+		p.IsAlive = false
+	}
+	_, _, ok = battle.NextInTurnQueue(queue, 0)
+	assert.False(t, ok)
+}
+
+func TestParseTurnOrder(t *testing.T) {
+	order, err := battle.ParseTurnOrder("")
+	require.NoError(t, err)
+	assert.Equal(t, battle.TurnOrderAlternating, order)
+
+	order, err = battle.ParseTurnOrder("speed")
+	require.NoError(t, err)
+	assert.Equal(t, battle.TurnOrderSpeed, order)
+
+	_, err = battle.ParseTurnOrder("random")
+	assert.Error(t, err)
+}
+
+func TestAttack_RejectsOutOfTurn(t *testing.T) {
+	setupSQLBattle(t)
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	now := time.Now()
+
+	battleID, err := repo.CreateBattle(ctx, &battle.Battle{
+		BattleType: battle.BattleTypeTeam,
+		Status:     battle.BattleStatusInProgress,
+		TurnOrder:  battle.TurnOrderAlternating,
+		MaxTurns:   100,
+		Seed:       7,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertParticipants(ctx, []*battle.BattleParticipant{
+		{BattleID: battleID, ParticipantID: "10", Name: "Knight", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, HP: 500, MaxHP: 500, AttackPower: 40, Defense: 10, IsAlive: true, CreatedAt: now},
+		{BattleID: battleID, ParticipantID: "20", Name: "Dark King", Type: battle.ParticipantTypeDarkKing, Side: battle.TeamSideDark, HP: 500, MaxHP: 500, AttackPower: 30, Defense: 5, IsAlive: true, CreatedAt: now},
+	}))
+
+	svc := battle.NewService()
+
+	// Dark side tries to open the fight
+	_, _, err = svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "20", TargetID: "10"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, battle.ErrNotYourTurn))
+
+	// Light side goes first, then the turn passes to the dark king
+	_, turn, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "10", TargetID: "20"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, turn.TurnNumber)
+
+	_, _, err = svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "10", TargetID: "20"})
+	assert.True(t, errors.Is(err, battle.ErrNotYourTurn))
+
+	b, err := repo.GetBattleByID(ctx, battleID)
+	require.NoError(t, err)
+	next, _, err := svc.GetNextParticipant(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, "20", next.ParticipantID)
+}
@@ -0,0 +1,120 @@
+package battle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/battle"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTimedBattle stores an in-progress knight vs dark king battle whose current turn is already overdue
+func createTimedBattle(t *testing.T, action battle.TimeoutAction, expiresAt *time.Time) string {
+	t.Helper()
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	now := time.Now()
+	deadline := now.Add(-time.Second).UTC()
+
+	battleID, err := repo.CreateBattle(ctx, &battle.Battle{
+		BattleType:         battle.BattleTypeTeam,
+		Status:             battle.BattleStatusInProgress,
+		TurnOrder:          battle.TurnOrderAlternating,
+		MaxTurns:           100,
+		Seed:               3,
+		TurnTimeoutSeconds: 30,
+		TimeoutAction:      action,
+		TurnDeadline:       &deadline,
+		ExpiresAt:          expiresAt,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertParticipants(ctx, []*battle.BattleParticipant{
+		{BattleID: battleID, ParticipantID: "10", Name: "Knight", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, HP: 500, MaxHP: 500, AttackPower: 40, Defense: 10, IsAlive: true, CreatedAt: now},
+		{BattleID: battleID, ParticipantID: "20", Name: "Dark King", Type: battle.ParticipantTypeDarkKing, Side: battle.TeamSideDark, HP: 300, MaxHP: 500, AttackPower: 30, Defense: 5, IsAlive: true, CreatedAt: now},
+	}))
+	return battleID
+}
+
+func TestSweepOverdueBattles_SkipsIdleParticipant(t *testing.T) {
+	setupSQLBattle(t)
+	ctx := context.Background()
+	battleID := createTimedBattle(t, battle.TimeoutActionSkip, nil)
+	svc := battle.NewService()
+	now := time.Now()
+
+	handled, err := svc.SweepOverdueBattles(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, handled)
+
+	b, err := battle.GetRepository().GetBattleByID(ctx, battleID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, b.CurrentTurn)
+	require.NotNil(t, b.TurnDeadline)
+	assert.True(t, b.TurnDeadline.After(now))
+
+	next, _, err := svc.GetNextParticipant(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, "20", next.ParticipantID)
+
+	// A second sweeper (another replica) finds nothing left to do
+	handled, err = svc.SweepOverdueBattles(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, handled)
+}
+
+func TestSweepOverdueBattles_DefaultAttack(t *testing.T) {
+	setupSQLBattle(t)
+	ctx := context.Background()
+	battleID := createTimedBattle(t, battle.TimeoutActionAttack, nil)
+	svc := battle.NewService()
+
+	handled, err := svc.SweepOverdueBattles(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, handled)
+
+	turns, err := battle.GetRepository().ListTurns(ctx, battleID, 0)
+	require.NoError(t, err)
+	require.Len(t, turns, 1)
+	assert.Equal(t, "10", turns[0].AttackerID)
+	assert.Equal(t, "20", turns[0].TargetID)
+}
+
+func TestSweepOverdueBattles_ExpiresBattle(t *testing.T) {
+	setupSQLBattle(t)
+	ctx := context.Background()
+	expired := time.Now().Add(-time.Minute).UTC()
+	battleID := createTimedBattle(t, battle.TimeoutActionSkip, &expired)
+	svc := battle.NewService()
+
+	handled, err := svc.SweepOverdueBattles(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, handled)
+
+	b, err := battle.GetRepository().GetBattleByID(ctx, battleID)
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusCompleted, b.Status)
+	assert.Equal(t, battle.BattleResultLightVictory, b.Result)
+	assert.Nil(t, b.TurnDeadline)
+
+	handled, err = svc.SweepOverdueBattles(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, handled)
+}
+
+func TestParseTimeoutAction(t *testing.T) {
+	action, err := battle.ParseTimeoutAction("")
+	require.NoError(t, err)
+	assert.Equal(t, battle.TimeoutActionSkip, action)
+
+	action, err = battle.ParseTimeoutAction("attack")
+	require.NoError(t, err)
+	assert.Equal(t, battle.TimeoutActionAttack, action)
+
+	_, err = battle.ParseTimeoutAction("forfeit")
+	assert.Error(t, err)
+}
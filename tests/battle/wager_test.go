@@ -0,0 +1,85 @@
+package battle_test
+
+import (
+	"context"
+	"testing"
+
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startWageredBattle(t *testing.T, svc *battle.Service, wager int) *battle.Battle {
+	b, _, err := svc.StartBattle(dto.StartBattleCommand{
+		LightParticipants: []dto.ParticipantInfo{
+			{ParticipantID: "1", Name: "Knight", Type: "warrior", Side: "light", HP: 100, MaxHP: 100, AttackPower: 20},
+		},
+		DarkParticipants: []dto.ParticipantInfo{
+			{ParticipantID: "goblin", Name: "Goblin", Type: "enemy", Side: "dark", HP: 50, MaxHP: 50, AttackPower: 10},
+		},
+		CreatedBy:      "arthur",
+		WagerAmount:    wager,
+		LightEmperorID: "5",
+		DarkEmperorID:  "6",
+	})
+	require.NoError(t, err)
+	return b
+}
+
+func TestStartBattle_WagerWaitsForBothApprovals(t *testing.T) {
+	setupLobbyDB(t)
+	svc := battle.NewService()
+
+	b := startWageredBattle(t, svc, 500)
+	assert.Equal(t, battle.BattleStatusPending, b.Status)
+	assert.Nil(t, b.StartedAt)
+
+	_, _, err := svc.Attack(dto.AttackCommand{BattleID: b.ID, AttackerID: "1", TargetID: "goblin"})
+	assert.Error(t, err, "the battle cannot be fought before both stakes are locked")
+
+	_, err = svc.ApproveWager(dto.ApproveWagerCommand{BattleID: b.ID, EmperorID: "7"})
+	assert.ErrorIs(t, err, battle.ErrNotWagerEmperor)
+
+	// Without the coin service the stake cannot be locked, so the approval is not recorded
+	_, err = svc.ApproveWager(dto.ApproveWagerCommand{BattleID: b.ID, EmperorID: "5"})
+	require.Error(t, err)
+	stored, err := battle.GetRepository().GetBattleByID(context.Background(), b.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.LightEmperorApproved)
+
+	// Once both stakes are held, approving again does not lock anything and starts the battle
+	require.NoError(t, battle.GetRepository().UpdateBattleFields(context.Background(), b.ID, map[string]interface{}{
+		"light_emperor_approved": true,
+		"dark_emperor_approved":  true,
+	}))
+	started, err := svc.ApproveWager(dto.ApproveWagerCommand{BattleID: b.ID, EmperorID: "6"})
+	require.NoError(t, err)
+	assert.Equal(t, battle.BattleStatusInProgress, started.Status)
+	assert.NotNil(t, started.StartedAt)
+
+	_, err = svc.ApproveWager(dto.ApproveWagerCommand{BattleID: b.ID, EmperorID: "5"})
+	assert.ErrorContains(t, err, "before the battle starts")
+}
+
+func TestStartBattle_WagerValidation(t *testing.T) {
+	setupLobbyDB(t)
+	svc := battle.NewService()
+
+	for name, cmd := range map[string]dto.StartBattleCommand{
+		"negative":     {WagerAmount: -1},
+		"one emperor":  {WagerAmount: 100, LightEmperorID: "5"},
+		"same emperor": {WagerAmount: 100, LightEmperorID: "5", DarkEmperorID: "5"},
+		"non-numeric":  {WagerAmount: 100, LightEmperorID: "5", DarkEmperorID: "mordred"},
+	} {
+		cmd.LightParticipants = []dto.ParticipantInfo{{ParticipantID: "1", Name: "Knight", Type: "warrior", Side: "light"}}
+		cmd.DarkParticipants = []dto.ParticipantInfo{{ParticipantID: "goblin", Name: "Goblin", Type: "enemy", Side: "dark"}}
+		_, _, err := svc.StartBattle(cmd)
+		assert.Error(t, err, name)
+	}
+
+	// Without a wager the battle starts straight away
+	b := startWageredBattle(t, svc, 0)
+	assert.Equal(t, battle.BattleStatusInProgress, b.Status)
+}
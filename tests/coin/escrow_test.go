@@ -0,0 +1,114 @@
+package coin_test
+
+import (
+	"context"
+	"testing"
+
+	"network-sec-micro/internal/coin"
+	"network-sec-micro/internal/coin/dto"
+	"network-sec-micro/internal/warrior"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupEscrowDB creates two emperors with 1000 coins each. The escrow code runs every step of a
+// transaction on the transaction itself, so a single connection keeps the in-memory database shared.
+func setupEscrowDB(t *testing.T) *coin.Service {
+	return newTestService(openEscrowDB(t))
+}
+
+func openEscrowDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, db.AutoMigrate(&warrior.Warrior{}, &coin.Transaction{}, &coin.WagerEscrow{}, &coin.IdempotencyRecord{}, &coin.LedgerEntry{}, &coin.LedgerPosting{}, &coin.CoinHold{}))
+
+	for _, w := range []warrior.Warrior{
+		{ID: 5, Username: "arthur", Email: "arthur@example.com", Password: "password", Role: warrior.RoleLightEmperor, CoinBalance: 1000},
+		{ID: 6, Username: "mordred", Email: "mordred@example.com", Password: "password", Role: warrior.RoleDarkEmperor, CoinBalance: 1000},
+	} {
+		require.NoError(t, db.Create(&w).Error)
+	}
+	return db
+}
+
+func balanceOf(t *testing.T, svc *coin.Service, warriorID uint) int64 {
+	balance, err := svc.GetBalance(context.Background(), dto.GetBalanceQuery{WarriorID: warriorID})
+	require.NoError(t, err)
+	return balance
+}
+
+func TestLockWagerStake_Idempotent(t *testing.T) {
+	svc := setupEscrowDB(t)
+	ctx := context.Background()
+
+	escrow, already, err := svc.LockWagerStake(ctx, dto.LockWagerStakeCommand{BattleID: "42", WarriorID: 5, Amount: 300})
+	require.NoError(t, err)
+	assert.False(t, already)
+	assert.Equal(t, coin.EscrowStatusLocked, escrow.Status)
+	assert.EqualValues(t, 700, balanceOf(t, svc, 5))
+
+	// Locking the same stake again takes nothing
+	_, already, err = svc.LockWagerStake(ctx, dto.LockWagerStakeCommand{BattleID: "42", WarriorID: 5, Amount: 300})
+	require.NoError(t, err)
+	assert.True(t, already)
+	assert.EqualValues(t, 700, balanceOf(t, svc, 5))
+
+	_, _, err = svc.LockWagerStake(ctx, dto.LockWagerStakeCommand{BattleID: "42", WarriorID: 5, Amount: 400})
+	assert.ErrorContains(t, err, "already locked")
+
+	// A stake the emperor cannot cover is not locked at all
+	_, _, err = svc.LockWagerStake(ctx, dto.LockWagerStakeCommand{BattleID: "43", WarriorID: 6, Amount: 5000})
+	assert.ErrorContains(t, err, "insufficient balance")
+	assert.EqualValues(t, 1000, balanceOf(t, svc, 6))
+}
+
+func TestResolveWager_PaysPotOnce(t *testing.T) {
+	svc := setupEscrowDB(t)
+	ctx := context.Background()
+	for _, id := range []uint{5, 6} {
+		_, _, err := svc.LockWagerStake(ctx, dto.LockWagerStakeCommand{BattleID: "42", WarriorID: id, Amount: 300})
+		require.NoError(t, err)
+	}
+
+	resolution, err := svc.ResolveWager(ctx, dto.ResolveWagerCommand{BattleID: "42", WinnerWarriorID: 6})
+	require.NoError(t, err)
+	assert.EqualValues(t, 600, resolution.PaidOut)
+	assert.Zero(t, resolution.Refunded)
+	assert.EqualValues(t, 700, balanceOf(t, svc, 5))
+	assert.EqualValues(t, 1300, balanceOf(t, svc, 6))
+
+	// A redelivered resolution, even a different one, moves nothing
+	again, err := svc.ResolveWager(ctx, dto.ResolveWagerCommand{BattleID: "42"})
+	require.NoError(t, err)
+	assert.Zero(t, again.PaidOut+again.Refunded)
+	assert.EqualValues(t, 1300, balanceOf(t, svc, 6))
+}
+
+func TestResolveWager_RefundsWithoutWinner(t *testing.T) {
+	svc := setupEscrowDB(t)
+	ctx := context.Background()
+
+	// Only the light emperor approved before the battle was cancelled
+	_, _, err := svc.LockWagerStake(ctx, dto.LockWagerStakeCommand{BattleID: "42", WarriorID: 5, Amount: 300})
+	require.NoError(t, err)
+
+	resolution, err := svc.ResolveWager(ctx, dto.ResolveWagerCommand{BattleID: "42"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 300, resolution.Refunded)
+	assert.EqualValues(t, 1000, balanceOf(t, svc, 5))
+	assert.EqualValues(t, 1000, balanceOf(t, svc, 6))
+
+	history, _, err := svc.GetTransactionHistory(ctx, dto.GetTransactionHistoryQuery{WarriorID: 5})
+	require.NoError(t, err)
+	types := []coin.TransactionType{}
+	for _, tx := range history {
+		types = append(types, tx.TransactionType)
+	}
+	assert.ElementsMatch(t, []coin.TransactionType{coin.TransactionTypeEscrowLock, coin.TransactionTypeEscrowRefund}, types)
+}
@@ -0,0 +1,178 @@
+package coin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/coin"
+	"network-sec-micro/internal/coin/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoinHold_ReservesUntilCaptured(t *testing.T) {
+	svc := setupEscrowDB(t)
+	ctx := context.Background()
+
+	hold, err := svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 300, Reason: "weapon_purchase: Excalibur", Reference: "purchase-1", Account: coin.AccountShopRevenue})
+	require.NoError(t, err)
+	assert.Equal(t, coin.HoldStatusHeld, hold.Status)
+	assert.EqualValues(t, 1000, balanceOf(t, svc, 5), "a hold moves no coins")
+
+	// Held coins cannot be spent elsewhere
+	_, err = svc.Deduct(ctx, dto.DeductCoinsCommand{WarriorID: 5, Amount: 800})
+	assert.ErrorIs(t, err, coin.ErrInsufficientBalance)
+	_, err = svc.Transfer(ctx, dto.TransferCoinsCommand{FromWarriorID: 5, ToWarriorID: 6, Amount: 800})
+	assert.ErrorIs(t, err, coin.ErrInsufficientBalance)
+	_, err = svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 800, Reference: "purchase-2"})
+	assert.ErrorIs(t, err, coin.ErrInsufficientBalance)
+
+	captured, err := svc.CaptureHold(ctx, dto.CaptureHoldCommand{HoldID: hold.ID})
+	require.NoError(t, err)
+	assert.Equal(t, coin.HoldStatusCaptured, captured.Status)
+	assert.EqualValues(t, 700, balanceOf(t, svc, 5))
+	assert.EqualValues(t, 300, ledgerBalance(t, svc, coin.AccountShopRevenue))
+
+	// Capturing again takes nothing more, and a captured hold cannot be released
+	_, err = svc.CaptureHold(ctx, dto.CaptureHoldCommand{HoldID: hold.ID})
+	require.NoError(t, err)
+	assert.EqualValues(t, 700, balanceOf(t, svc, 5))
+	released, err := svc.ReleaseHold(ctx, hold.ID)
+	assert.ErrorIs(t, err, coin.ErrHoldCaptured)
+	assert.Equal(t, coin.HoldStatusCaptured, released.Status)
+
+	// The captured coins no longer count against the balance
+	_, err = svc.Deduct(ctx, dto.DeductCoinsCommand{WarriorID: 5, Amount: 700})
+	require.NoError(t, err)
+
+	report, err := svc.ReconcileLedger(ctx)
+	require.NoError(t, err)
+	assert.True(t, report.Clean())
+}
+
+func TestCoinHold_Release(t *testing.T) {
+	svc := setupEscrowDB(t)
+	ctx := context.Background()
+
+	hold, err := svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 6, Amount: 1000, Reference: "purchase-1"})
+	require.NoError(t, err)
+
+	_, err = svc.ReleaseHold(ctx, hold.ID)
+	require.NoError(t, err)
+	_, err = svc.ReleaseHold(ctx, hold.ID)
+	require.NoError(t, err)
+	_, err = svc.CaptureHold(ctx, dto.CaptureHoldCommand{HoldID: hold.ID})
+	assert.ErrorIs(t, err, coin.ErrHoldReleased)
+	assert.EqualValues(t, 1000, balanceOf(t, svc, 6))
+
+	// Released coins can be spent again
+	_, err = svc.Deduct(ctx, dto.DeductCoinsCommand{WarriorID: 6, Amount: 1000})
+	require.NoError(t, err)
+
+	_, err = svc.CaptureHold(ctx, dto.CaptureHoldCommand{HoldID: 99})
+	assert.ErrorIs(t, err, coin.ErrHoldNotFound)
+}
+
+func TestCoinHold_PlacedOncePerReference(t *testing.T) {
+	svc := setupEscrowDB(t)
+	ctx := context.Background()
+
+	first, err := svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 600, Reference: "purchase-1"})
+	require.NoError(t, err)
+	again, err := svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 600, Reference: "purchase-1"})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID, "the same reference returns the first hold")
+
+	// The first hold is returned even once released, rather than a new one being placed
+	_, err = svc.ReleaseHold(ctx, first.ID)
+	require.NoError(t, err)
+	again, err = svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 600, Reference: "purchase-1"})
+	require.NoError(t, err)
+	assert.Equal(t, coin.HoldStatusReleased, again.Status)
+
+	_, err = svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 500, Reference: "purchase-1"})
+	assert.ErrorIs(t, err, coin.ErrIdempotencyKeyReused)
+	_, err = svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 500})
+	assert.ErrorContains(t, err, "reference is required")
+	_, err = svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 0, Reference: "purchase-2"})
+	assert.ErrorIs(t, err, coin.ErrInvalidAmount)
+}
+
+func TestCoinHold_PartialCapture(t *testing.T) {
+	svc := setupEscrowDB(t)
+	ctx := context.Background()
+
+	hold, err := svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 400, Reference: "repair-1", Account: coin.AccountServiceRevenue})
+	require.NoError(t, err)
+
+	_, err = svc.CaptureHold(ctx, dto.CaptureHoldCommand{HoldID: hold.ID, Amount: 500})
+	assert.ErrorIs(t, err, coin.ErrInvalidAmount)
+
+	captured, err := svc.CaptureHold(ctx, dto.CaptureHoldCommand{HoldID: hold.ID, Amount: 150})
+	require.NoError(t, err)
+	assert.EqualValues(t, 150, captured.CapturedAmount)
+	assert.EqualValues(t, 850, balanceOf(t, svc, 5))
+	assert.EqualValues(t, 150, ledgerBalance(t, svc, coin.AccountServiceRevenue))
+
+	// The rest of the hold is given back
+	wallet, err := svc.GetWalletBalance(ctx, dto.GetBalanceQuery{WarriorID: 5})
+	require.NoError(t, err)
+	assert.Equal(t, coin.WalletBalance{WarriorID: 5, Balance: 850, Held: 0, Available: 850}, *wallet)
+
+	// A retry for the same amount changes nothing; a different amount is rejected
+	_, err = svc.CaptureHold(ctx, dto.CaptureHoldCommand{HoldID: hold.ID, Amount: 150})
+	require.NoError(t, err)
+	_, err = svc.CaptureHold(ctx, dto.CaptureHoldCommand{HoldID: hold.ID, Amount: 200})
+	assert.ErrorIs(t, err, coin.ErrHoldCaptured)
+	assert.EqualValues(t, 850, balanceOf(t, svc, 5))
+}
+
+func TestCoinHold_Expires(t *testing.T) {
+	db := openEscrowDB(t)
+	svc := newTestService(db)
+	ctx := context.Background()
+
+	_, err := svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 100, Reference: "heal-1", ExpiresIn: 25 * time.Hour})
+	assert.ErrorIs(t, err, coin.ErrInvalidHoldExpiry)
+
+	hold, err := svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 600, Reference: "heal-1", ExpiresIn: time.Minute})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), hold.ExpiresAt, 5*time.Second)
+	kept, err := svc.PlaceHold(ctx, dto.PlaceHoldCommand{WarriorID: 5, Amount: 100, Reference: "heal-2"})
+	require.NoError(t, err)
+
+	wallet, err := svc.GetWalletBalance(ctx, dto.GetBalanceQuery{WarriorID: 5})
+	require.NoError(t, err)
+	assert.EqualValues(t, 700, wallet.Held)
+	assert.EqualValues(t, 300, wallet.Available)
+
+	require.NoError(t, db.Model(&coin.CoinHold{}).Where("id = ?", hold.ID).Update("expires_at", time.Now().Add(-time.Second)).Error)
+
+	// An expired hold reserves nothing, even before it is marked
+	wallet, err = svc.GetWalletBalance(ctx, dto.GetBalanceQuery{WarriorID: 5})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1000, wallet.Balance)
+	assert.EqualValues(t, 100, wallet.Held)
+	_, err = svc.CaptureHold(ctx, dto.CaptureHoldCommand{HoldID: hold.ID})
+	assert.ErrorIs(t, err, coin.ErrHoldExpired)
+	_, err = svc.ReleaseHold(ctx, hold.ID)
+	require.NoError(t, err)
+
+	expired, err := svc.ExpireHolds(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, expired)
+	holds, total, err := svc.ListHolds(ctx, dto.ListHoldsQuery{WarriorID: 5, Status: string(coin.HoldStatusExpired)})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	require.Len(t, holds, 1)
+	assert.Equal(t, hold.ID, holds[0].ID)
+
+	holds, total, err = svc.ListHolds(ctx, dto.ListHoldsQuery{WarriorID: 5})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+	require.Len(t, holds, 2)
+	assert.Equal(t, kept.ID, holds[0].ID, "newest first")
+	assert.EqualValues(t, 1000, balanceOf(t, svc, 5))
+}
@@ -0,0 +1,74 @@
+package coin_test
+
+import (
+	"context"
+	"testing"
+
+	"network-sec-micro/internal/coin"
+	"network-sec-micro/internal/coin/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduct_ReplaysIdempotencyKey(t *testing.T) {
+	svc := setupEscrowDB(t)
+	ctx := context.Background()
+	cmd := dto.DeductCoinsCommand{WarriorID: 5, Amount: 200, Reason: "weapon_purchase: Excalibur", IdempotencyKey: "weapon_purchase:sword-1:5"}
+
+	first, err := svc.Deduct(ctx, cmd)
+	require.NoError(t, err)
+	assert.False(t, first.Replayed)
+	assert.EqualValues(t, 800, first.BalanceAfter)
+
+	// A redelivered purchase returns the original result without charging again
+	again, err := svc.Deduct(ctx, cmd)
+	require.NoError(t, err)
+	assert.True(t, again.Replayed)
+	assert.Equal(t, first.BalanceBefore, again.BalanceBefore)
+	assert.Equal(t, first.BalanceAfter, again.BalanceAfter)
+	assert.EqualValues(t, 800, balanceOf(t, svc, 5))
+
+	cmd.Amount = 300
+	_, err = svc.Deduct(ctx, cmd)
+	assert.ErrorIs(t, err, coin.ErrIdempotencyKeyReused)
+	_, err = svc.Add(ctx, dto.AddCoinsCommand{WarriorID: 5, Amount: 200, IdempotencyKey: "weapon_purchase:sword-1:5"})
+	assert.ErrorIs(t, err, coin.ErrIdempotencyKeyReused, "a key belongs to one operation")
+	assert.EqualValues(t, 800, balanceOf(t, svc, 5))
+}
+
+func TestDeduct_FailureDoesNotUseKey(t *testing.T) {
+	svc := setupEscrowDB(t)
+	ctx := context.Background()
+	cmd := dto.DeductCoinsCommand{WarriorID: 6, Amount: 1500, IdempotencyKey: "heal:warrior-6-1"}
+
+	_, err := svc.Deduct(ctx, cmd)
+	assert.ErrorIs(t, err, coin.ErrInsufficientBalance)
+
+	// Once the warrior can afford it, the same key goes through
+	_, err = svc.Add(ctx, dto.AddCoinsCommand{WarriorID: 6, Amount: 500})
+	require.NoError(t, err)
+	change, err := svc.Deduct(ctx, cmd)
+	require.NoError(t, err)
+	assert.False(t, change.Replayed)
+	assert.Zero(t, balanceOf(t, svc, 6))
+}
+
+func TestTransfer_ReplaysIdempotencyKey(t *testing.T) {
+	svc := setupEscrowDB(t)
+	ctx := context.Background()
+	cmd := dto.TransferCoinsCommand{FromWarriorID: 5, ToWarriorID: 6, Amount: 250, IdempotencyKey: "gift:1"}
+
+	for i := 0; i < 2; i++ {
+		change, err := svc.Transfer(ctx, cmd)
+		require.NoError(t, err)
+		assert.Equal(t, i > 0, change.Replayed)
+		assert.EqualValues(t, 750, change.BalanceAfter)
+	}
+	assert.EqualValues(t, 750, balanceOf(t, svc, 5))
+	assert.EqualValues(t, 1250, balanceOf(t, svc, 6))
+
+	cmd.ToWarriorID = 7
+	_, err := svc.Transfer(ctx, cmd)
+	assert.ErrorIs(t, err, coin.ErrIdempotencyKeyReused)
+}
@@ -0,0 +1,85 @@
+package coin_test
+
+import (
+	"context"
+	"testing"
+
+	"network-sec-micro/internal/coin"
+	"network-sec-micro/internal/coin/dto"
+	"network-sec-micro/internal/warrior"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ledgerBalance(t *testing.T, svc *coin.Service, account string) int64 {
+	balance, err := svc.GetLedgerBalance(context.Background(), account)
+	require.NoError(t, err)
+	return balance
+}
+
+func TestLedger_PostsEveryMovement(t *testing.T) {
+	db := openEscrowDB(t)
+	require.NoError(t, db.Create(&warrior.Warrior{ID: 7, Username: "gawain", Email: "gawain@example.com", Password: "password", Role: warrior.RoleKnight, CoinBalance: 50}).Error)
+	svc := newTestService(db)
+	ctx := context.Background()
+
+	_, err := svc.Deduct(ctx, dto.DeductCoinsCommand{WarriorID: 5, Amount: 200, Reason: "weapon_purchase: Excalibur", Account: coin.AccountShopRevenue})
+	require.NoError(t, err)
+	_, err = svc.Add(ctx, dto.AddCoinsCommand{WarriorID: 6, Amount: 100, Reason: "battle_reward"})
+	require.NoError(t, err)
+	_, err = svc.Transfer(ctx, dto.TransferCoinsCommand{FromWarriorID: 6, ToWarriorID: 5, Amount: 150})
+	require.NoError(t, err)
+	_, err = svc.Deduct(ctx, dto.DeductCoinsCommand{WarriorID: 5, Amount: 30, Reason: "goblin_attack", Account: coin.EnemyAccount("goblin")})
+	require.NoError(t, err)
+	for _, id := range []uint{5, 6} {
+		_, _, err := svc.LockWagerStake(ctx, dto.LockWagerStakeCommand{BattleID: "42", WarriorID: id, Amount: 100})
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 200, ledgerBalance(t, svc, coin.AccountWagerEscrow))
+	_, err = svc.ResolveWager(ctx, dto.ResolveWagerCommand{BattleID: "42", WinnerWarriorID: 5})
+	require.NoError(t, err)
+
+	// Rejected movements post nothing
+	_, err = svc.Deduct(ctx, dto.DeductCoinsCommand{WarriorID: 6, Amount: 5000, Account: coin.AccountShopRevenue})
+	assert.ErrorIs(t, err, coin.ErrInsufficientBalance)
+	_, err = svc.Deduct(ctx, dto.DeductCoinsCommand{WarriorID: 6, Amount: 10, Account: coin.AccountWagerEscrow})
+	assert.ErrorIs(t, err, coin.ErrInvalidAccount)
+
+	assert.EqualValues(t, 1020, ledgerBalance(t, svc, coin.WalletAccount(5)))
+	assert.EqualValues(t, balanceOf(t, svc, 5), ledgerBalance(t, svc, coin.WalletAccount(5)))
+	assert.EqualValues(t, balanceOf(t, svc, 6), ledgerBalance(t, svc, coin.WalletAccount(6)))
+	assert.EqualValues(t, 200, ledgerBalance(t, svc, coin.AccountShopRevenue))
+	assert.EqualValues(t, 30, ledgerBalance(t, svc, coin.EnemyAccount("goblin")))
+	assert.Zero(t, ledgerBalance(t, svc, coin.AccountWagerEscrow))
+	assert.EqualValues(t, -2100, ledgerBalance(t, svc, coin.AccountTreasury), "two opening balances and a reward")
+
+	report, err := svc.ReconcileLedger(ctx)
+	require.NoError(t, err)
+	assert.True(t, report.Clean())
+	assert.Equal(t, 2, report.CheckedWallets)
+	assert.Equal(t, 1, report.UnopenedWallets)
+}
+
+func TestReconcileLedger_ReportsDrift(t *testing.T) {
+	db := openEscrowDB(t)
+	svc := newTestService(db)
+	ctx := context.Background()
+
+	_, _, err := svc.LockWagerStake(ctx, dto.LockWagerStakeCommand{BattleID: "42", WarriorID: 5, Amount: 300})
+	require.NoError(t, err)
+	_, err = svc.Add(ctx, dto.AddCoinsCommand{WarriorID: 6, Amount: 100})
+	require.NoError(t, err)
+
+	// Balances written around the coin service drift from the ledger
+	require.NoError(t, db.Table("warriors").Where("id = ?", 6).Update("coin_balance", 2000).Error)
+	require.NoError(t, db.Model(&coin.WagerEscrow{}).Where("battle_id = ?", "42").Update("status", coin.EscrowStatusRefunded).Error)
+
+	report, err := svc.ReconcileLedger(ctx)
+	require.NoError(t, err)
+	assert.False(t, report.Clean())
+	assert.Equal(t, []coin.WalletDrift{{WarriorID: 6, Balance: 2000, LedgerBalance: 1100}}, report.Drifts)
+	assert.Zero(t, report.EscrowHeld)
+	assert.EqualValues(t, 300, report.EscrowLedger)
+	assert.Empty(t, report.UnbalancedEntries)
+}
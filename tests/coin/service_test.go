@@ -33,7 +33,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	require.NoError(t, err)
 	
 	// Auto migrate - warriors table has coin_balance column
-	err = db.AutoMigrate(&warrior.Warrior{}, &coin.Transaction{})
+	err = db.AutoMigrate(&warrior.Warrior{}, &coin.Transaction{}, &coin.LedgerEntry{}, &coin.LedgerPosting{}, &coin.CoinHold{})
 	require.NoError(t, err)
 	
 	// Set global DB
@@ -0,0 +1,199 @@
+package race_conditions_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "network-sec-micro/api/proto/battle"
+	"network-sec-micro/internal/battle"
+	"network-sec-micro/internal/battle/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupBattleDB creates an in-progress knight vs dark king battle in a shared in-memory database
+func setupBattleDB(t *testing.T) string {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) // one connection keeps the in-memory database shared between goroutines
+	require.NoError(t, db.AutoMigrate(&battle.BattleSQL{}, &battle.BattleParticipantSQL{}, &battle.BattleTurnSQL{}, &battle.BattleRewardSQL{}))
+	battle.SQLDB.Enabled = true
+	battle.SQLDB.DB = db
+
+	ctx := context.Background()
+	repo := battle.GetRepository()
+	now := time.Now()
+	battleID, err := repo.CreateBattle(ctx, &battle.Battle{
+		BattleType: battle.BattleTypeTeam,
+		Status:     battle.BattleStatusInProgress,
+		TurnOrder:  battle.TurnOrderAlternating,
+		MaxTurns:   100,
+		Seed:       9,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertParticipants(ctx, []*battle.BattleParticipant{
+		{BattleID: battleID, ParticipantID: "10", Name: "Knight", Type: battle.ParticipantTypeWarrior, Side: battle.TeamSideLight, HP: 500, MaxHP: 500, AttackPower: 40, Defense: 10, IsAlive: true, CreatedAt: now},
+		{BattleID: battleID, ParticipantID: "20", Name: "Dark King", Type: battle.ParticipantTypeDarkKing, Side: battle.TeamSideDark, HP: 500, MaxHP: 500, AttackPower: 30, Defense: 5, IsAlive: true, CreatedAt: now},
+	}))
+	return battleID
+}
+
+// TestBattleAttack_RaceCondition fires the same attack concurrently: only one may take the turn
+func TestBattleAttack_RaceCondition(t *testing.T) {
+	battleID := setupBattleDB(t)
+	svc := battle.NewService()
+	ctx := context.Background()
+
+	concurrency := 10
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	successCount := 0
+	damage := 0
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, turn, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "10", TargetID: "20"})
+			if err != nil {
+				assert.True(t, errors.Is(err, battle.ErrNotYourTurn), "unexpected error: %v", err)
+				return
+			}
+			mu.Lock()
+			successCount++
+			damage = turn.DamageDealt
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, successCount)
+
+	turns, err := battle.GetRepository().ListTurns(ctx, battleID, 0)
+	require.NoError(t, err)
+	assert.Len(t, turns, 1)
+
+	target, err := battle.GetRepository().GetParticipantByIDs(ctx, battleID, "20")
+	require.NoError(t, err)
+	assert.Equal(t, 500-damage, target.HP)
+}
+
+// TestBattleAttackAndSpell_RaceCondition races an attack with spell stat updates on the same target:
+// neither the damage nor any buff may be lost
+func TestBattleAttackAndSpell_RaceCondition(t *testing.T) {
+	battleID := setupBattleDB(t)
+	svc := battle.NewService()
+	server := battle.NewBattleServiceServer(svc)
+	ctx := context.Background()
+
+	spells := 10
+	var wg sync.WaitGroup
+	var turn *battle.BattleTurn
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var err error
+		_, turn, err = svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "10", TargetID: "20"})
+		assert.NoError(t, err)
+	}()
+
+	for i := 0; i < spells; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Same read-modify-write as battlespell's ModifyParticipantStats: +1 attack power per spell
+			for {
+				resp, err := server.GetBattleParticipants(ctx, &pb.GetBattleParticipantsRequest{BattleId: battleID, Side: "dark"})
+				if !assert.NoError(t, err) {
+					return
+				}
+				p := resp.Participants[0]
+				_, err = server.UpdateParticipantStats(ctx, &pb.UpdateParticipantStatsRequest{
+					BattleId:        battleID,
+					ParticipantId:   p.ParticipantId,
+					Hp:              p.Hp,
+					MaxHp:           p.MaxHp,
+					AttackPower:     p.AttackPower + 1,
+					Defense:         p.Defense,
+					IsAlive:         p.IsAlive,
+					ExpectedVersion: p.Version,
+				})
+				if status.Code(err) == codes.Aborted {
+					continue
+				}
+				assert.NoError(t, err)
+				return
+			}
+		}()
+	}
+
+	wg.Wait()
+	require.NotNil(t, turn)
+
+	target, err := battle.GetRepository().GetParticipantByIDs(ctx, battleID, "20")
+	require.NoError(t, err)
+	assert.Equal(t, 30+spells, target.AttackPower)
+	assert.Equal(t, 500-turn.DamageDealt, target.HP)
+	assert.Equal(t, turn.TargetHPBefore-turn.DamageDealt, turn.TargetHPAfter)
+}
+
+// TestParticipantVersion_RejectsStaleWrite checks the conditional update itself
+func TestParticipantVersion_RejectsStaleWrite(t *testing.T) {
+	battleID := setupBattleDB(t)
+	repo := battle.GetRepository()
+	ctx := context.Background()
+
+	p, err := repo.GetParticipantByIDs(ctx, battleID, "20")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.UpdateParticipantIfVersion(ctx, battleID, "20", p.Version, map[string]interface{}{"hp": 400}))
+	err = repo.UpdateParticipantIfVersion(ctx, battleID, "20", p.Version, map[string]interface{}{"hp": 450})
+	assert.True(t, errors.Is(err, battle.ErrVersionConflict))
+
+	fresh, err := repo.GetParticipantByIDs(ctx, battleID, "20")
+	require.NoError(t, err)
+	assert.Equal(t, 400, fresh.HP)
+	assert.Equal(t, p.Version+1, fresh.Version)
+}
+
+// TestBattleAttack_FailedWriteKeepsTurn checks that an attack whose turn record cannot be written
+// rolls back its turn claim and damage, so the same attacker can play the turn again
+func TestBattleAttack_FailedWriteKeepsTurn(t *testing.T) {
+	battleID := setupBattleDB(t)
+	svc := battle.NewService()
+	ctx := context.Background()
+	db := battle.SQLDB.DB.(*gorm.DB)
+
+	require.NoError(t, db.Migrator().DropTable(&battle.BattleTurnSQL{}))
+	_, _, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "10", TargetID: "20"})
+	require.Error(t, err)
+
+	b, err := battle.GetRepository().GetBattleByID(ctx, battleID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, b.CurrentTurn)
+	target, err := battle.GetRepository().GetParticipantByIDs(ctx, battleID, "20")
+	require.NoError(t, err)
+	assert.Equal(t, 500, target.HP)
+
+	require.NoError(t, db.AutoMigrate(&battle.BattleTurnSQL{}))
+	_, turn, err := svc.Attack(dto.AttackCommand{BattleID: battleID, AttackerID: "10", TargetID: "20"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, turn.TurnNumber)
+}
@@ -2,10 +2,12 @@ package transactions_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"network-sec-micro/internal/coin"
 	"network-sec-micro/internal/coin/dto"
+	"network-sec-micro/internal/warrior"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,23 +15,38 @@ import (
 	"gorm.io/gorm"
 )
 
-// TestCoinTransfer_Atomicity tests that coin transfers are atomic
-func TestCoinTransfer_Atomicity(t *testing.T) {
+// setupCoinDB creates warriors with the given balances in an in-memory database
+func setupCoinDB(t *testing.T, balances map[uint]int) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	require.NoError(t, err)
-	
-	err = db.AutoMigrate(&coin.WarriorBalance{}, &coin.Transaction{})
+	sqlDB, err := db.DB()
 	require.NoError(t, err)
-	
+	sqlDB.SetMaxOpenConns(1) // Every connection to :memory: opens a database of its own
+	require.NoError(t, db.AutoMigrate(&warrior.Warrior{}, &coin.Transaction{}, &coin.IdempotencyRecord{}, &coin.LedgerEntry{}, &coin.LedgerPosting{}, &coin.CoinHold{}))
+
+	for id, balance := range balances {
+		require.NoError(t, db.Create(&warrior.Warrior{
+			ID:          id,
+			Username:    fmt.Sprintf("warrior%d", id),
+			Email:       fmt.Sprintf("warrior%d@example.com", id),
+			Password:    "password",
+			Role:        warrior.RoleKnight,
+			CoinBalance: balance,
+		}).Error)
+	}
 	coin.DB = db
-	
-	// Create warriors with balances
-	balance1 := coin.WarriorBalance{WarriorID: 1, Balance: 1000}
-	balance2 := coin.WarriorBalance{WarriorID: 2, Balance: 500}
-	err = db.Create(&balance1).Error
-	require.NoError(t, err)
-	err = db.Create(&balance2).Error
-	require.NoError(t, err)
+	return db
+}
+
+func coinBalance(t *testing.T, db *gorm.DB, warriorID uint) int64 {
+	var w warrior.Warrior
+	require.NoError(t, db.First(&w, warriorID).Error)
+	return int64(w.CoinBalance)
+}
+
+// TestCoinTransfer_Atomicity tests that coin transfers are atomic
+func TestCoinTransfer_Atomicity(t *testing.T) {
+	db := setupCoinDB(t, map[uint]int{1: 1000, 2: 500})
 	
 	svc := coin.NewService()
 	ctx := context.Background()
@@ -44,19 +61,18 @@ func TestCoinTransfer_Atomicity(t *testing.T) {
 		Reason:        "atomicity_test",
 	}
 	
-	err = svc.TransferCoins(ctx, cmd)
+	err := svc.TransferCoins(ctx, cmd)
 	require.NoError(t, err)
 	
 	// Verify balances
-	var finalBalance1, finalBalance2 coin.WarriorBalance
-	db.First(&finalBalance1, 1)
-	db.First(&finalBalance2, 2)
+	finalBalance1 := coinBalance(t, db, 1)
+	finalBalance2 := coinBalance(t, db, 2)
 	
-	assert.Equal(t, int64(700), finalBalance1.Balance) // 1000 - 300
-	assert.Equal(t, int64(800), finalBalance2.Balance) // 500 + 300
+	assert.Equal(t, int64(700), finalBalance1) // 1000 - 300
+	assert.Equal(t, int64(800), finalBalance2) // 500 + 300
 	
 	// Verify total balance preserved
-	totalBalance := finalBalance1.Balance + finalBalance2.Balance
+	totalBalance := finalBalance1 + finalBalance2
 	assert.Equal(t, int64(1500), totalBalance)
 	
 	// Verify transaction records created
@@ -69,17 +85,7 @@ func TestCoinTransfer_Atomicity(t *testing.T) {
 
 // TestCoinDeduction_Atomicity tests that coin deduction is atomic
 func TestCoinDeduction_Atomicity(t *testing.T) {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	require.NoError(t, err)
-	
-	err = db.AutoMigrate(&coin.WarriorBalance{}, &coin.Transaction{})
-	require.NoError(t, err)
-	
-	coin.DB = db
-	
-	balance := coin.WarriorBalance{WarriorID: 1, Balance: 1000}
-	err = db.Create(&balance).Error
-	require.NoError(t, err)
+	db := setupCoinDB(t, map[uint]int{1: 1000})
 	
 	svc := coin.NewService()
 	ctx := context.Background()
@@ -93,14 +99,13 @@ func TestCoinDeduction_Atomicity(t *testing.T) {
 		Reason:    "atomicity_test",
 	}
 	
-	err = svc.DeductCoins(ctx, cmd)
+	err := svc.DeductCoins(ctx, cmd)
 	require.NoError(t, err)
 	
 	// Verify balance updated
-	var finalBalance coin.WarriorBalance
-	db.First(&finalBalance, 1)
+	finalBalance := coinBalance(t, db, 1)
 	
-	assert.Equal(t, int64(700), finalBalance.Balance) // 1000 - 300
+	assert.Equal(t, int64(700), finalBalance) // 1000 - 300
 	
 	// Verify transaction record created
 	var transaction coin.Transaction
@@ -115,17 +120,7 @@ func TestCoinDeduction_Atomicity(t *testing.T) {
 
 // TestCoinAddition_Atomicity tests that coin addition is atomic
 func TestCoinAddition_Atomicity(t *testing.T) {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	require.NoError(t, err)
-	
-	err = db.AutoMigrate(&coin.WarriorBalance{}, &coin.Transaction{})
-	require.NoError(t, err)
-	
-	coin.DB = db
-	
-	balance := coin.WarriorBalance{WarriorID: 1, Balance: 1000}
-	err = db.Create(&balance).Error
-	require.NoError(t, err)
+	db := setupCoinDB(t, map[uint]int{1: 1000})
 	
 	svc := coin.NewService()
 	ctx := context.Background()
@@ -139,14 +134,13 @@ func TestCoinAddition_Atomicity(t *testing.T) {
 		Reason:    "atomicity_test",
 	}
 	
-	err = svc.AddCoins(ctx, cmd)
+	err := svc.AddCoins(ctx, cmd)
 	require.NoError(t, err)
 	
 	// Verify balance updated
-	var finalBalance coin.WarriorBalance
-	db.First(&finalBalance, 1)
+	finalBalance := coinBalance(t, db, 1)
 	
-	assert.Equal(t, int64(1500), finalBalance.Balance) // 1000 + 500
+	assert.Equal(t, int64(1500), finalBalance) // 1000 + 500
 	
 	// Verify transaction record created
 	var transaction coin.Transaction
@@ -161,17 +155,7 @@ func TestCoinAddition_Atomicity(t *testing.T) {
 
 // TestTransactionRollback_OnError tests transaction rollback on error
 func TestTransactionRollback_OnError(t *testing.T) {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	require.NoError(t, err)
-	
-	err = db.AutoMigrate(&coin.WarriorBalance{}, &coin.Transaction{})
-	require.NoError(t, err)
-	
-	coin.DB = db
-	
-	balance := coin.WarriorBalance{WarriorID: 1, Balance: 1000}
-	err = db.Create(&balance).Error
-	require.NoError(t, err)
+	db := setupCoinDB(t, map[uint]int{1: 1000})
 	
 	svc := coin.NewService()
 	ctx := context.Background()
@@ -183,14 +167,13 @@ func TestTransactionRollback_OnError(t *testing.T) {
 		Reason:    "rollback_test",
 	}
 	
-	err = svc.DeductCoins(ctx, cmd)
+	err := svc.DeductCoins(ctx, cmd)
 	require.Error(t, err)
 	
 	// Verify balance unchanged
-	var finalBalance coin.WarriorBalance
-	db.First(&finalBalance, 1)
+	finalBalance := coinBalance(t, db, 1)
 	
-	assert.Equal(t, int64(1000), finalBalance.Balance) // Should remain unchanged
+	assert.Equal(t, int64(1000), finalBalance) // Should remain unchanged
 	
 	// Verify no transaction record created
 	var transaction coin.Transaction
@@ -200,24 +183,7 @@ func TestTransactionRollback_OnError(t *testing.T) {
 
 // TestMultiOperation_Atomicity tests multiple operations in single transaction
 func TestMultiOperation_Atomicity(t *testing.T) {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	require.NoError(t, err)
-	
-	err = db.AutoMigrate(&coin.WarriorBalance{}, &coin.Transaction{})
-	require.NoError(t, err)
-	
-	coin.DB = db
-	
-	// Create warriors
-	balance1 := coin.WarriorBalance{WarriorID: 1, Balance: 1000}
-	balance2 := coin.WarriorBalance{WarriorID: 2, Balance: 500}
-	balance3 := coin.WarriorBalance{WarriorID: 3, Balance: 200}
-	err = db.Create(&balance1).Error
-	require.NoError(t, err)
-	err = db.Create(&balance2).Error
-	require.NoError(t, err)
-	err = db.Create(&balance3).Error
-	require.NoError(t, err)
+	db := setupCoinDB(t, map[uint]int{1: 1000, 2: 500, 3: 200})
 	
 	svc := coin.NewService()
 	ctx := context.Background()
@@ -232,7 +198,7 @@ func TestMultiOperation_Atomicity(t *testing.T) {
 		Amount:        200,
 		Reason:        "multi_op_test",
 	}
-	err = svc.TransferCoins(ctx, cmd1)
+	err := svc.TransferCoins(ctx, cmd1)
 	require.NoError(t, err)
 	
 	// Second transfer
@@ -246,17 +212,16 @@ func TestMultiOperation_Atomicity(t *testing.T) {
 	require.NoError(t, err)
 	
 	// Verify final balances
-	var finalBalance1, finalBalance2, finalBalance3 coin.WarriorBalance
-	db.First(&finalBalance1, 1)
-	db.First(&finalBalance2, 2)
-	db.First(&finalBalance3, 3)
+	finalBalance1 := coinBalance(t, db, 1)
+	finalBalance2 := coinBalance(t, db, 2)
+	finalBalance3 := coinBalance(t, db, 3)
 	
-	assert.Equal(t, int64(800), finalBalance1.Balance)  // 1000 - 200
-	assert.Equal(t, int64(600), finalBalance2.Balance)   // 500 + 200 - 100
-	assert.Equal(t, int64(300), finalBalance3.Balance)   // 200 + 100
+	assert.Equal(t, int64(800), finalBalance1)  // 1000 - 200
+	assert.Equal(t, int64(600), finalBalance2)   // 500 + 200 - 100
+	assert.Equal(t, int64(300), finalBalance3)   // 200 + 100
 	
 	// Verify total preserved
-	totalBalance := finalBalance1.Balance + finalBalance2.Balance + finalBalance3.Balance
+	totalBalance := finalBalance1 + finalBalance2 + finalBalance3
 	assert.Equal(t, int64(1700), totalBalance)
 }
 
@@ -0,0 +1,165 @@
+package warrior_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"network-sec-micro/internal/warrior"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAchievementRules_ShadowDragonsAndTitles(t *testing.T) {
+	warrior.DB = setupTestDB(t)
+	rules := append([]warrior.AchievementRule{
+		{ID: "shadow_hunter", Name: "Shadow Hunter", Event: warrior.AchievementEventDragonKill, Match: map[string]string{"dragon_type": "shadow"}, Threshold: 3, Title: "ShadowHunter", TitlePriority: 15},
+	}, warrior.DefaultAchievementRules...)
+	require.NoError(t, warrior.SetAchievementRules(rules))
+	defer warrior.SetAchievementRules(warrior.DefaultAchievementRules)
+
+	w := createProgressionWarrior(t, "hunter", warrior.RoleKnight)
+	killDragon := func(dragonType string) {
+		msg, err := json.Marshal(map[string]interface{}{
+			"event_type": "dragon_death", "source_service": "dragon", "timestamp": time.Now(),
+			"killer_username": "hunter", "dragon_id": "d", "dragon_name": "Wyrm", "dragon_type": dragonType, "dragon_level": 1,
+		})
+		require.NoError(t, err)
+		require.NoError(t, warrior.ProcessKafkaMessage(msg))
+	}
+
+	// Three dragons, only two of them shadow: the generic rule unlocks, the shadow one does not
+	killDragon("shadow")
+	killDragon("fire")
+	killDragon("Shadow")
+
+	var stored warrior.Warrior
+	require.NoError(t, warrior.DB.First(&stored, w.ID).Error)
+	assert.Equal(t, "DragonSlayer", stored.Title)
+
+	// The third shadow dragon unlocks a higher title
+	killDragon("shadow")
+	require.NoError(t, warrior.DB.First(&stored, w.ID).Error)
+	assert.Equal(t, "ShadowHunter", stored.Title)
+
+	statuses, err := warrior.NewService().GetAchievements(w.ID)
+	require.NoError(t, err)
+	byID := make(map[string]warrior.AchievementStatus)
+	for _, s := range statuses {
+		byID[s.ID] = s
+	}
+	assert.True(t, byID["dragon_slayer"].Unlocked)
+	assert.True(t, byID["shadow_hunter"].Unlocked)
+	assert.False(t, byID["emperor_of_drags"].Unlocked)
+	assert.Equal(t, 4, byID["emperor_of_drags"].Progress)
+	assert.Equal(t, 10, byID["emperor_of_drags"].Threshold)
+
+	// Unlocking happens once
+	var count int64
+	require.NoError(t, warrior.DB.Model(&warrior.WarriorAchievement{}).Where("warrior_id = ?", w.ID).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestAchievementRules_ArenaStreakResets(t *testing.T) {
+	warrior.DB = setupTestDB(t)
+	require.NoError(t, warrior.SetAchievementRules([]warrior.AchievementRule{
+		{ID: "arena_streak", Event: warrior.AchievementEventArenaWin, ResetOn: []string{warrior.AchievementEventArenaLoss}, Threshold: 3, Title: "ArenaChampion"},
+	}))
+	defer warrior.SetAchievementRules(warrior.DefaultAchievementRules)
+
+	a := createProgressionWarrior(t, "gladiator", warrior.RoleKnight)
+	b := createProgressionWarrior(t, "rival", warrior.RoleArcher)
+	match := func(winner *warrior.Warrior) {
+		msg, err := json.Marshal(map[string]interface{}{
+			"event_type": "arena_match_completed", "source_service": "arena", "timestamp": time.Now(),
+			"player1_id": a.ID, "player2_id": b.ID, "winner_id": winner.ID,
+		})
+		require.NoError(t, err)
+		require.NoError(t, warrior.ProcessKafkaMessage(msg))
+	}
+
+	match(a)
+	match(a)
+	match(b) // streak broken
+	match(a)
+	match(a)
+
+	var stored warrior.Warrior
+	require.NoError(t, warrior.DB.First(&stored, a.ID).Error)
+	assert.Empty(t, stored.Title)
+
+	match(a)
+	require.NoError(t, warrior.DB.First(&stored, a.ID).Error)
+	assert.Equal(t, "ArenaChampion", stored.Title)
+}
+
+func TestLoadAchievementRules(t *testing.T) {
+	defer warrior.SetAchievementRules(warrior.DefaultAchievementRules)
+	path := filepath.Join(t.TempDir(), "achievements.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": [
+		{"id": "big_spender", "event": "coins_spent", "measure": "amount", "threshold": 500}
+	]}`), 0o644))
+	require.NoError(t, warrior.LoadAchievementRules(path))
+	rules := warrior.GetAchievementRules()
+	require.Len(t, rules, 1)
+	assert.Equal(t, "big_spender", rules[0].Name)
+
+	// Invalid files are rejected and leave the active rules alone
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": [{"id": "broken", "event": "dragon_kill"}]}`), 0o644))
+	assert.Error(t, warrior.LoadAchievementRules(path))
+	assert.Equal(t, "big_spender", warrior.GetAchievementRules()[0].ID)
+
+	// Spending accumulates the amount
+	warrior.DB = setupTestDB(t)
+	w := createProgressionWarrior(t, "spender", warrior.RoleMage)
+	for _, price := range []int{200, 300} {
+		msg, err := json.Marshal(map[string]interface{}{
+			"event_type": "weapon_purchased", "source_service": "weapon", "timestamp": time.Now(), "warrior_id": w.ID, "weapon_price": price,
+		})
+		require.NoError(t, err)
+		require.NoError(t, warrior.ProcessKafkaMessage(msg))
+	}
+	statuses, err := warrior.NewService().GetAchievements(w.ID)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Unlocked)
+}
+
+func TestAchievementRules_RedeliveryCountsOnce(t *testing.T) {
+	warrior.DB = setupTestDB(t)
+	require.NoError(t, warrior.SetAchievementRules([]warrior.AchievementRule{
+		{ID: "veteran", Event: warrior.AchievementEventBattleWon, Threshold: 3},
+	}))
+	defer warrior.SetAchievementRules(warrior.DefaultAchievementRules)
+
+	w := createProgressionWarrior(t, "veteran", warrior.RoleKnight)
+	msg, err := json.Marshal(map[string]interface{}{
+		"event_type": "battle_reward", "source_service": "battle", "timestamp": time.Now(),
+		"battle_id": "3", "participant_id": "1", "experience": 40, "coins": 10,
+	})
+	require.NoError(t, err)
+	progress := func() int {
+		statuses, err := warrior.NewService().GetAchievements(w.ID)
+		require.NoError(t, err)
+		return statuses[0].Progress
+	}
+
+	// Experience cannot be written: the message fails and counts nothing
+	failUpdates := func(db *gorm.DB) { db.AddError(errors.New("database unavailable")) }
+	require.NoError(t, warrior.DB.Callback().Update().Before("gorm:update").Register("test:fail_updates", failUpdates))
+	assert.Error(t, warrior.ProcessKafkaMessage(msg))
+	assert.Equal(t, 0, progress())
+
+	// The redelivery succeeds and counts, later redeliveries do not
+	require.NoError(t, warrior.DB.Callback().Update().Remove("test:fail_updates"))
+	require.NoError(t, warrior.ProcessKafkaMessage(msg))
+	assert.Equal(t, 1, progress())
+	require.NoError(t, warrior.ProcessKafkaMessage(msg))
+	assert.Equal(t, 1, progress())
+}
@@ -0,0 +1,156 @@
+package warrior_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"network-sec-micro/internal/warrior"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createProgressionWarrior(t *testing.T, username string, role warrior.Role) *warrior.Warrior {
+	w := &warrior.Warrior{Username: username, Email: username + "@example.com", Password: "x", Role: role}
+	require.NoError(t, warrior.DB.Create(w).Error)
+	return w
+}
+
+func TestLevelCurve(t *testing.T) {
+	curve := warrior.LevelCurve{BaseXP: 100, Growth: 2, MaxLevel: 5}
+
+	assert.Equal(t, 0, curve.XPForLevel(1))
+	assert.Equal(t, 100, curve.XPForLevel(2))
+	assert.Equal(t, 300, curve.XPForLevel(3))
+	assert.Equal(t, 700, curve.XPForLevel(4))
+
+	assert.Equal(t, 1, curve.LevelForXP(99))
+	assert.Equal(t, 2, curve.LevelForXP(100))
+	assert.Equal(t, 3, curve.LevelForXP(699))
+	assert.Equal(t, 5, curve.LevelForXP(1000000)) // capped
+}
+
+func TestGrantExperience_LevelsUpWithRoleGrowth(t *testing.T) {
+	warrior.DB = setupTestDB(t)
+	warrior.SetLevelCurve(warrior.LevelCurve{BaseXP: 100, Growth: 2, MaxLevel: 10})
+	defer warrior.SetLevelCurve(warrior.DefaultLevelCurve)
+
+	knight := createProgressionWarrior(t, "knight1", warrior.RoleKnight)
+	mage := createProgressionWarrior(t, "mage1", warrior.RoleMage)
+	assert.Equal(t, 1, knight.Level)
+
+	// Not enough for a level
+	progress, err := warrior.GrantExperience(knight.ID, 50, "test")
+	require.NoError(t, err)
+	assert.False(t, progress.LeveledUp())
+
+	// Two levels at once: 350 total reaches level 3
+	progress, err = warrior.GrantExperience(knight.ID, 300, "test")
+	require.NoError(t, err)
+	assert.True(t, progress.LeveledUp())
+	assert.Equal(t, 1, progress.PreviousLevel)
+	assert.Equal(t, 3, progress.Level)
+
+	knightGrowth := warrior.GrowthForRole(warrior.RoleKnight)
+	var stored warrior.Warrior
+	require.NoError(t, warrior.DB.First(&stored, knight.ID).Error)
+	assert.Equal(t, 350, stored.Experience)
+	assert.Equal(t, 3, stored.Level)
+	assert.Equal(t, 100+2*knightGrowth.Power, stored.TotalPower)
+	assert.Equal(t, 1000+2*knightGrowth.MaxHP, stored.MaxHP) // derived max HP (power x10) plus growth
+	assert.Equal(t, 700-350, warrior.ExperienceToNextLevel(&stored))
+
+	// Mages grow power faster than knights
+	_, err = warrior.GrantExperience(mage.ID, 100, "test")
+	require.NoError(t, err)
+	var storedMage warrior.Warrior
+	require.NoError(t, warrior.DB.First(&storedMage, mage.ID).Error)
+	assert.Equal(t, 100+warrior.GrowthForRole(warrior.RoleMage).Power, storedMage.TotalPower)
+	assert.Greater(t, warrior.GrowthForRole(warrior.RoleMage).Power, knightGrowth.Power)
+
+	_, err = warrior.GrantExperience(knight.ID, 0, "test")
+	assert.Error(t, err)
+}
+
+func TestProcessKafkaMessage_GrantsExperience(t *testing.T) {
+	warrior.DB = setupTestDB(t)
+	w := createProgressionWarrior(t, "slayer", warrior.RoleArcher)
+
+	publish := func(event map[string]interface{}) {
+		msg, err := json.Marshal(event)
+		require.NoError(t, err)
+		require.NoError(t, warrior.ProcessKafkaMessage(msg))
+	}
+
+	publish(map[string]interface{}{
+		"event_type": "battle_reward", "source_service": "battle",
+		"battle_id": "3", "participant_id": "1", "experience": 40,
+	})
+	publish(map[string]interface{}{
+		"event_type": "dragon_death", "source_service": "dragon",
+		"killer_username": "slayer", "dragon_id": "d1", "dragon_name": "Smaug", "dragon_level": 2,
+	})
+	publish(map[string]interface{}{
+		"event_type": "enemy_destroyed", "source_service": "enemy",
+		"killer_warrior_id": float64(w.ID), "enemy_id": "e1", "enemy_name": "Orc", "enemy_level": 1,
+	})
+	// Team battle completion carries no single warrior and grants nothing itself
+	publish(map[string]interface{}{
+		"event_type": "battle_completed", "source_service": "battle", "warrior_id": 0, "experience_gained": 500,
+	})
+
+	var stored warrior.Warrior
+	require.NoError(t, warrior.DB.First(&stored, w.ID).Error)
+	assert.Equal(t, 40+warrior.DragonKillExperience(2)+warrior.EnemyKillExperience(1), stored.Experience)
+	assert.Equal(t, warrior.GetLevelCurve().LevelForXP(stored.Experience), stored.Level)
+	assert.Equal(t, 1, stored.DragonKillCount)
+	assert.Equal(t, 1, stored.EnemyKillCount)
+}
+
+func TestProcessKafkaMessage_RedeliveryGrantsOnce(t *testing.T) {
+	warrior.DB = setupTestDB(t)
+	w := createProgressionWarrior(t, "veteran", warrior.RoleKnight)
+
+	deliver := func(event map[string]interface{}) {
+		msg, err := json.Marshal(event)
+		require.NoError(t, err)
+		require.NoError(t, warrior.ProcessKafkaMessage(msg))
+	}
+	reward := map[string]interface{}{
+		"event_type": "battle_reward", "source_service": "battle",
+		"battle_id": "7", "participant_id": "1", "experience": 40,
+	}
+	dragonDeath := map[string]interface{}{
+		"event_type": "dragon_death", "source_service": "dragon", "timestamp": "2026-01-02T10:00:00Z",
+		"killer_username": "veteran", "dragon_id": "d1", "dragon_name": "Smaug", "dragon_level": 2,
+	}
+	enemyDestroyed := map[string]interface{}{
+		"event_type": "enemy_destroyed", "source_service": "enemy",
+		"killer_warrior_id": float64(w.ID), "enemy_id": "e1", "enemy_name": "Orc", "enemy_level": 1,
+	}
+	for i := 0; i < 2; i++ {
+		deliver(reward)
+		deliver(dragonDeath)
+		deliver(enemyDestroyed)
+	}
+
+	// The reward republished with a new timestamp is still the same grant
+	reward["timestamp"] = "2026-01-02T10:05:00Z"
+	deliver(reward)
+
+	var stored warrior.Warrior
+	require.NoError(t, warrior.DB.First(&stored, w.ID).Error)
+	assert.Equal(t, 40+warrior.DragonKillExperience(2)+warrior.EnemyKillExperience(1), stored.Experience)
+	assert.Equal(t, 1, stored.DragonKillCount)
+	assert.Equal(t, 1, stored.EnemyKillCount)
+
+	var kills int64
+	require.NoError(t, warrior.DB.Model(&warrior.KilledMonster{}).Where("warrior_id = ?", w.ID).Count(&kills).Error)
+	assert.Equal(t, int64(2), kills)
+
+	// The same dragon revived and killed again is a new kill
+	dragonDeath["timestamp"] = "2026-01-03T10:00:00Z"
+	deliver(dragonDeath)
+	require.NoError(t, warrior.DB.First(&stored, w.ID).Error)
+	assert.Equal(t, 2, stored.DragonKillCount)
+}
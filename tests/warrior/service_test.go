@@ -19,7 +19,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	require.NoError(t, err)
 	
 	// Auto migrate
-	err = db.AutoMigrate(&warrior.Warrior{}, &warrior.KilledMonster{})
+	err = db.AutoMigrate(&warrior.Warrior{}, &warrior.KilledMonster{}, &warrior.AchievementProgress{}, &warrior.AchievementEventReceipt{}, &warrior.ExperienceGrantReceipt{}, &warrior.WarriorAchievement{})
 	require.NoError(t, err)
 	
 	return db